@@ -17,12 +17,14 @@ package backend
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 
 	survey "github.com/AlecAivazis/survey/v2"
 	surveycore "github.com/AlecAivazis/survey/v2/core"
+	"github.com/dustin/go-humanize/english"
 
 	"github.com/pulumi/pulumi/pkg/v3/backend/display"
 	"github.com/pulumi/pulumi/pkg/v3/engine"
@@ -77,10 +79,19 @@ const (
 	yes     response = "yes"
 	no      response = "no"
 	details response = "details"
+	choose  response = "select"
 )
 
 func PreviewThenPrompt(ctx context.Context, kind apitype.UpdateKind, stack Stack,
 	op UpdateOperation, apply Applier,
+) (*deploy.Plan, sdkDisplay.ResourceChanges, result.Result) {
+	return previewThenPrompt(ctx, kind, stack, &op, apply, false /*canSelect*/)
+}
+
+// previewThenPrompt previews an operation and then asks the user whether to proceed. If canSelect is true, the user
+// may also select a subset of the changes to apply, in which case the operation's options are updated to target them.
+func previewThenPrompt(ctx context.Context, kind apitype.UpdateKind, stack Stack,
+	op *UpdateOperation, apply Applier, canSelect bool,
 ) (*deploy.Plan, sdkDisplay.ResourceChanges, result.Result) {
 	// create a channel to hear about the update events from the engine. this will be used so that
 	// we can build up the diff display in case the user asks to see the details of the diff
@@ -113,7 +124,7 @@ func PreviewThenPrompt(ctx context.Context, kind apitype.UpdateKind, stack Stack
 		ShowLink: true,
 	}
 
-	plan, changes, res := apply(ctx, kind, stack, op, opts, eventsChannel)
+	plan, changes, res := apply(ctx, kind, stack, *op, opts, eventsChannel)
 	if res != nil {
 		close(eventsChannel)
 		return plan, changes, res
@@ -158,14 +169,16 @@ func PreviewThenPrompt(ctx context.Context, kind apitype.UpdateKind, stack Stack
 	}
 
 	// Otherwise, ensure the user wants to proceed.
-	res, plan = confirmBeforeUpdating(kind, stack, events, plan, op.Opts)
+	res, plan = confirmBeforeUpdating(kind, stack, events, plan, &op.Opts, canSelect)
 	close(eventsChannel)
 	return plan, changes, res
 }
 
-// confirmBeforeUpdating asks the user whether to proceed. A nil error means yes.
+// confirmBeforeUpdating asks the user whether to proceed. A nil error means yes. If canSelect is true and the user
+// selects a subset of the changes to apply, the options are updated to target the selected resources and their
+// dependents.
 func confirmBeforeUpdating(kind apitype.UpdateKind, stack Stack,
-	events []engine.Event, plan *deploy.Plan, opts UpdateOptions,
+	events []engine.Event, plan *deploy.Plan, opts *UpdateOptions, canSelect bool,
 ) (result.Result, *deploy.Plan) {
 	for {
 		var response string
@@ -183,6 +196,13 @@ func confirmBeforeUpdating(kind apitype.UpdateKind, stack Stack,
 			choices = append(choices, string(details))
 		}
 
+		// For interactive updates, we can also offer to select the changes to apply, unless they are already
+		// constrained by a plan.
+		if canSelect && kind == apitype.UpdateUpdate && !opts.SkipPreview && opts.Display.IsInteractive &&
+			opts.Engine.Plan == nil {
+			choices = append(choices, string(choose))
+		}
+
 		var previewWarning string
 		if opts.SkipPreview {
 			previewWarning = colors.SpecWarning + " without a preview" + colors.Bold
@@ -227,6 +247,28 @@ func confirmBeforeUpdating(kind apitype.UpdateKind, stack Stack,
 			contract.IgnoreError(err)
 			continue
 		}
+
+		if response == string(choose) {
+			targets, err := display.SelectChanges(events, opts.Display)
+			if errors.Is(err, display.ErrSelectionCancelled) {
+				continue
+			}
+			if err != nil {
+				return result.FromError(err), nil
+			}
+			if len(targets) == 0 {
+				infoPrefix := "\b" + opts.Display.Color.Colorize(colors.SpecWarning+"info: "+colors.Reset)
+				fmt.Print(infoPrefix, "There are no changes to select.\n\n")
+				continue
+			}
+
+			// Apply exactly the selected changes. The plan of the preview covers all of its changes, so it is
+			// discarded.
+			opts.Engine.Targets = deploy.NewUrnTargetsFromUrns(targets)
+			opts.Engine.TargetDependents = true
+			fmt.Printf("applying the selected changes to %s\n", english.Plural(len(targets), "resource", ""))
+			return nil, nil
+		}
 	}
 }
 
@@ -245,7 +287,7 @@ func PreviewThenPromptThenExecute(ctx context.Context, kind apitype.UpdateKind,
 			originalPlan = op.Opts.Engine.Plan.Clone()
 		}
 
-		plan, changes, res := PreviewThenPrompt(ctx, kind, stack, op, apply)
+		plan, changes, res := previewThenPrompt(ctx, kind, stack, &op, apply, true /*canSelect*/)
 		if res != nil || kind == apitype.PreviewUpdate {
 			return changes, res
 		}
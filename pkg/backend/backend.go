@@ -229,6 +229,16 @@ type SpecificDeploymentExporter interface {
 	ExportDeploymentForVersion(ctx context.Context, stack Stack, version string) (*apitype.UntypedDeployment, error)
 }
 
+// UpdateEventsExporter is an interface defining an additional capability of a Backend, specifically the ability to
+// export the engine events of a specific update from a stack's history. This isn't a requirement for all backends and
+// should be checked for dynamically.
+type UpdateEventsExporter interface {
+	// ExportUpdateEvents exports the engine events of the update with the given version from the history of a stack,
+	// in the order in which they were emitted. As with ExportDeploymentForVersion, the meaning of version is
+	// backend-specific.
+	ExportUpdateEvents(ctx context.Context, stack Stack, version string) ([]apitype.EngineEvent, error)
+}
+
 // UpdateOperation is a complete stack update operation (preview, update, import, refresh, or destroy).
 type UpdateOperation struct {
 	Proj               *workspace.Project
@@ -257,6 +267,10 @@ type QueryOperation struct {
 type StackConfiguration struct {
 	Config    config.Map
 	Decrypter config.Decrypter
+	// Environment is the environment variables that are set for the stack's language host and resource providers.
+	Environment map[string]string
+	// EventSinks are the destinations that the engine events of each update of the stack are streamed to.
+	EventSinks []workspace.EventSink
 }
 
 // UpdateOptions is the full set of update options, including backend and engine options.
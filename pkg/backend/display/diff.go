@@ -21,6 +21,7 @@ import (
 	"math"
 	"os"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/dustin/go-humanize/english"
@@ -119,6 +120,8 @@ func RenderDiffEvent(event engine.Event, seen map[resource.URN]engine.StepEventM
 		return renderDiffDiagEvent(event.Payload().(engine.DiagEventPayload), opts)
 	case engine.PolicyViolationEvent:
 		return renderDiffPolicyViolationEvent(event.Payload().(engine.PolicyViolationEventPayload), opts)
+	case engine.ResourceAnnotationEvent:
+		return renderDiffResourceAnnotationEvent(event.Payload().(engine.ResourceAnnotationEventPayload), opts)
 
 	default:
 		contract.Failf("unknown event type '%s'", event.Type)
@@ -137,6 +140,29 @@ func renderDiffPolicyViolationEvent(payload engine.PolicyViolationEventPayload,
 	return opts.Color.Colorize(payload.Prefix + payload.Message)
 }
 
+func renderDiffResourceAnnotationEvent(payload engine.ResourceAnnotationEventPayload, opts Options) string {
+	value := payload.Text
+	if payload.Number != nil {
+		value = formatAnnotationNumber(*payload.Number, payload.Unit)
+	}
+	return opts.Color.Colorize(fmt.Sprintf("%s%s (%s): %s%s: %s\n",
+		colors.SpecInfo, payload.ResourceURN.Name(), payload.ResourceURN.Type().DisplayName(), colors.Reset,
+		payload.Label, value))
+}
+
+// formatAnnotationNumber formats the numeric value of an annotation, which is usually a change in something like the
+// cost of a resource, with an explicit sign, rounded to two decimal places, and followed by its unit.
+func formatAnnotationNumber(number float64, unit string) string {
+	s := strconv.FormatFloat(math.Round(number*100)/100, 'f', -1, 64)
+	if number > 0 {
+		s = "+" + s
+	}
+	if unit != "" {
+		s += " " + unit
+	}
+	return s
+}
+
 func renderStdoutColorEvent(payload engine.StdoutEventPayload, opts Options) string {
 	return opts.Color.Colorize(payload.Message)
 }
@@ -220,6 +246,11 @@ func renderSummaryEvent(event engine.SummaryEventPayload, wroteDiagnosticHeader
 		fprintfIgnoreError(out, "\n")
 	}
 
+	// Print the totals of the numeric annotations of the changes, like their cost.
+	for _, t := range event.AnnotationTotals {
+		fprintfIgnoreError(out, "    %s: %s\n", t.Label, formatAnnotationNumber(t.Total, t.Unit))
+	}
+
 	// Print policy packs loaded. Data is rendered as a table of {policy-pack-name, version}.
 	renderPolicyPacks(out, event.PolicyPacks, opts)
 
@@ -308,27 +339,29 @@ func renderDiff(
 ) {
 	indent := getIndent(metadata, seen)
 	summary := getResourcePropertiesSummary(metadata, indent)
-
-	var details string
-	if metadata.DetailedDiff != nil {
-		var buf bytes.Buffer
-		if diff := engine.TranslateDetailedDiff(&metadata); diff != nil {
-			PrintObjectDiff(&buf, *diff, nil /*include*/, planning, indent+1, opts.SummaryDiff, opts.TruncateOutput, debug)
-		} else {
-			PrintObject(
-				&buf, metadata.Old.Inputs, planning, indent+1, deploy.OpSame, true /*prefix*/, opts.TruncateOutput, debug)
-		}
-		details = buf.String()
-	} else {
-		details = getResourcePropertiesDetails(
-			metadata, indent, planning, opts.SummaryDiff, opts.TruncateOutput, debug)
-	}
+	details := getDiffDetails(metadata, indent, planning, debug, opts)
 
 	fprintIgnoreError(out, opts.Color.Colorize(summary))
 	fprintIgnoreError(out, opts.Color.Colorize(details))
 	fprintIgnoreError(out, opts.Color.Colorize(colors.Reset))
 }
 
+// getDiffDetails returns the uncolorized property diff of a step, indented by the given amount.
+func getDiffDetails(metadata engine.StepEventMetadata, indent int, planning, debug bool, opts Options) string {
+	if metadata.DetailedDiff == nil {
+		return getResourcePropertiesDetails(metadata, indent, planning, opts.SummaryDiff, opts.TruncateOutput, debug)
+	}
+
+	var buf bytes.Buffer
+	if diff := engine.TranslateDetailedDiff(&metadata); diff != nil {
+		PrintObjectDiff(&buf, *diff, nil /*include*/, planning, indent+1, opts.SummaryDiff, opts.TruncateOutput, debug)
+	} else {
+		PrintObject(
+			&buf, metadata.Old.Inputs, planning, indent+1, deploy.OpSame, true /*prefix*/, opts.TruncateOutput, debug)
+	}
+	return buf.String()
+}
+
 func renderDiffResourcePreEvent(
 	payload engine.ResourcePreEventPayload,
 	seen map[resource.URN]engine.StepEventMetadata,
@@ -63,9 +63,25 @@ func ShowEvents(
 	op string, action apitype.UpdateKind, stack tokens.Name, proj tokens.PackageName,
 	permalink string, events <-chan engine.Event, done chan<- bool, opts Options, isPreview bool,
 ) {
+	update := eventSinkUpdate{
+		project: proj,
+		stack:   stack,
+		kind:    action,
+		preview: isPreview,
+	}
 	if opts.EventLogPath != "" {
 		events, done = startEventLogger(events, done, opts)
 	}
+	if len(opts.EventSinks) > 0 {
+		events, done = startEventSinks(events, done, opts, update)
+	}
+	if opts.ReportJUnitPath != "" || opts.ReportSARIFPath != "" {
+		events, done = startReports(events, done, opts, update)
+	}
+
+	if opts.HTMLReportPath != "" {
+		events, done = startHTMLReport(events, done, opts, update)
+	}
 
 	streamPreview := cmdutil.IsTruthy(os.Getenv("PULUMI_ENABLE_STREAMING_JSON_PREVIEW"))
 
@@ -78,7 +94,9 @@ func ShowEvents(
 		return
 	}
 
-	if opts.Type != DisplayProgress {
+	// The progress display prints the permalink itself, the markdown display includes it in its document, and the
+	// summary display doesn't print anything.
+	if opts.Type != DisplayProgress && opts.Type != DisplayMarkdown && opts.Type != DisplaySummary {
 		printPermalinkNonInteractive(os.Stdout, opts, permalink)
 	}
 
@@ -92,16 +110,29 @@ func ShowEvents(
 			"directly instead of through ShowEvents")
 	case DisplayWatch:
 		ShowWatchEvents(op, events, done, opts)
+	case DisplayMarkdown:
+		ShowMarkdownEvents(op, stack, proj, permalink, events, done, opts)
+	case DisplaySummary:
+		ShowSummaryEvents(events, done, opts)
 	default:
 		contract.Failf("Unknown display type %d", opts.Type)
 	}
 }
 
 func logJSONEvent(encoder *json.Encoder, event engine.Event, opts Options, seq int) error {
-	apiEvent, err := ConvertEngineEvent(event, false /* showSecrets */)
+	apiEvent, err := convertLoggedEvent(event, opts, seq)
 	if err != nil {
 		return err
 	}
+	return encoder.Encode(apiEvent)
+}
+
+// convertLoggedEvent converts an engine event to the JSON form written to the event log, without secrets.
+func convertLoggedEvent(event engine.Event, opts Options, seq int) (apitype.EngineEvent, error) {
+	apiEvent, err := ConvertEngineEvent(event, false /* showSecrets */)
+	if err != nil {
+		return apitype.EngineEvent{}, err
+	}
 
 	apiEvent.Sequence = seq
 	apiEvent.Timestamp = int(time.Now().Unix())
@@ -122,7 +153,7 @@ func logJSONEvent(encoder *json.Encoder, event engine.Event, opts Options, seq i
 		}
 	}
 
-	return encoder.Encode(apiEvent)
+	return apiEvent, nil
 }
 
 func startEventLogger(events <-chan engine.Event, done chan<- bool, opts Options) (<-chan engine.Event, chan<- bool) {
@@ -195,7 +226,8 @@ func shouldShow(step engine.StepEventMetadata, opts Options) bool {
 
 	// For logical replacement operations, only show them during progress-style updates (since this is integrated
 	// into the resource status update), or if it is requested explicitly (for diffs and JSON outputs).
-	if (opts.Type == DisplayDiff || opts.JSONDisplay) && !step.Logical && !opts.ShowReplacementSteps {
+	diffLike := opts.Type == DisplayDiff || opts.Type == DisplayMarkdown || opts.JSONDisplay
+	if diffLike && !step.Logical && !opts.ShowReplacementSteps {
 		return false
 	}
 
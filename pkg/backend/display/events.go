@@ -81,6 +81,20 @@ func ConvertEngineEvent(e engine.Event, showSecrets bool) (apitype.EngineEvent,
 			EnforcementLevel:     string(p.EnforcementLevel),
 		}
 
+	case engine.ResourceAnnotationEvent:
+		p, ok := e.Payload().(engine.ResourceAnnotationEventPayload)
+		if !ok {
+			return apiEvent, eventTypePayloadMismatch
+		}
+		apiEvent.ResourceAnnotationEvent = &apitype.ResourceAnnotationEvent{
+			ResourceURN:    string(p.ResourceURN),
+			Label:          p.Label,
+			Number:         p.Number,
+			Text:           p.Text,
+			Unit:           p.Unit,
+			PolicyPackName: p.PolicyPackName,
+		}
+
 	case engine.PreludeEvent:
 		p, ok := e.Payload().(engine.PreludeEventPayload)
 		if !ok {
@@ -111,6 +125,10 @@ func ConvertEngineEvent(e engine.Event, showSecrets bool) (apitype.EngineEvent,
 			ResourceChanges: changes,
 			PolicyPacks:     p.PolicyPacks,
 		}
+		for _, t := range p.AnnotationTotals {
+			apiEvent.SummaryEvent.AnnotationTotals = append(apiEvent.SummaryEvent.AnnotationTotals,
+				apitype.AnnotationTotal{Label: t.Label, Unit: t.Unit, Total: t.Total})
+		}
 
 	case engine.ResourcePreEvent:
 		p, ok := e.Payload().(engine.ResourcePreEventPayload)
@@ -198,6 +216,7 @@ func convertStepEventMetadata(md engine.StepEventMetadata, showSecrets bool) api
 		Keys:         keys,
 		Diffs:        diffs,
 		DetailedDiff: detailedDiff,
+		Patches:      propertyPatches(md),
 		Logical:      md.Logical,
 		Provider:     md.Provider,
 	}
@@ -222,6 +241,13 @@ func convertStepEventStateMetadata(md *engine.StepEventStateMetadata,
 	outputs, err := stack.SerializeProperties(md.Outputs, encrypter, showSecrets)
 	contract.IgnoreError(err)
 
+	var dependencies []string
+	if md.State != nil {
+		for _, dep := range md.State.Dependencies {
+			dependencies = append(dependencies, string(dep))
+		}
+	}
+
 	return &apitype.StepEventStateMetadata{
 		Type: string(md.Type),
 		URN:  string(md.URN),
@@ -234,6 +260,8 @@ func convertStepEventStateMetadata(md *engine.StepEventStateMetadata,
 		Inputs:     inputs,
 		Outputs:    outputs,
 		InitErrors: md.InitErrors,
+
+		Dependencies: dependencies,
 	}
 }
 
@@ -280,6 +308,17 @@ func ConvertJSONEvent(apiEvent apitype.EngineEvent) (engine.Event, error) {
 			EnforcementLevel:  apitype.EnforcementLevel(p.EnforcementLevel),
 		})
 
+	case apiEvent.ResourceAnnotationEvent != nil:
+		p := apiEvent.ResourceAnnotationEvent
+		event = engine.NewEvent(engine.ResourceAnnotationEvent, engine.ResourceAnnotationEventPayload{
+			ResourceURN:    resource.URN(p.ResourceURN),
+			Label:          p.Label,
+			Number:         p.Number,
+			Text:           p.Text,
+			Unit:           p.Unit,
+			PolicyPackName: p.PolicyPackName,
+		})
+
 	case apiEvent.PreludeEvent != nil:
 		p := apiEvent.PreludeEvent
 
@@ -295,11 +334,16 @@ func ConvertJSONEvent(apiEvent apitype.EngineEvent) (engine.Event, error) {
 		for op, count := range p.ResourceChanges {
 			changes[display.StepOp(op)] = count
 		}
+		var totals []engine.AnnotationTotal
+		for _, t := range p.AnnotationTotals {
+			totals = append(totals, engine.AnnotationTotal{Label: t.Label, Unit: t.Unit, Total: t.Total})
+		}
 		event = engine.NewEvent(engine.SummaryEvent, engine.SummaryEventPayload{
-			MaybeCorrupt:    p.MaybeCorrupt,
-			Duration:        time.Duration(p.DurationSeconds) * time.Second,
-			ResourceChanges: changes,
-			PolicyPacks:     p.PolicyPacks,
+			MaybeCorrupt:     p.MaybeCorrupt,
+			Duration:         time.Duration(p.DurationSeconds) * time.Second,
+			ResourceChanges:  changes,
+			PolicyPacks:      p.PolicyPacks,
+			AnnotationTotals: totals,
 		})
 
 	case apiEvent.ResourcePreEvent != nil:
@@ -0,0 +1,722 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/display"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+)
+
+// HTMLReport describes an update to render as a self-contained HTML report.
+type HTMLReport struct {
+	Project tokens.PackageName
+	Stack   tokens.Name
+	Kind    apitype.UpdateKind
+	Preview bool
+	// Version is the version of the update in the stack's history, or 0 if it is not known.
+	Version int
+	Message string
+	// Result is the result of the update. If it is empty, it is derived from the events.
+	Result string
+	// StartTime and EndTime bound the update. If they are zero, they are derived from the timestamps of the events.
+	StartTime time.Time
+	EndTime   time.Time
+	// Events are the engine events of the update, as written to an event log.
+	Events []apitype.EngineEvent
+}
+
+// htmlResource is a resource in an HTML report.
+type htmlResource struct {
+	ID           string
+	URN          resource.URN
+	Type         tokens.Type
+	Name         string
+	Op           display.StepOp
+	Failed       bool
+	Planned      bool
+	Diff         []htmlDiffLine
+	Diagnostics  []htmlDiagnostic
+	Children     []*htmlResource
+	Duration     time.Duration
+	parent       resource.URN
+	dependencies []resource.URN
+	start, end   int
+}
+
+type htmlDiffLine struct {
+	Class string
+	Text  string
+}
+
+type htmlDiagnostic struct {
+	Severity string
+	URN      resource.URN
+	Message  string
+}
+
+type htmlPolicyResult struct {
+	PolicyPack        string
+	PolicyPackVersion string
+	Policy            string
+	EnforcementLevel  string
+	URN               resource.URN
+	Message           string
+}
+
+type htmlChange struct {
+	Op    display.StepOp
+	Count int
+}
+
+type htmlTiming struct {
+	Resource *htmlResource
+	Percent  int
+}
+
+type htmlGraph struct {
+	Width  int
+	Height int
+	Nodes  []htmlGraphNode
+	Edges  []htmlGraphEdge
+}
+
+type htmlGraphNode struct {
+	X, Y     int
+	Resource *htmlResource
+	Label    string
+}
+
+type htmlGraphEdge struct {
+	X1, Y1, X2, Y2 int
+}
+
+// htmlDocument is the model of an HTML report that is rendered by its template.
+type htmlDocument struct {
+	Title       string
+	Report      HTMLReport
+	Operation   string
+	Result      string
+	Started     string
+	Duration    time.Duration
+	Changes     []htmlChange
+	Unchanged   int
+	Roots       []*htmlResource
+	Resources   []*htmlResource
+	Diagnostics []htmlDiagnostic
+	Policies    []htmlPolicyResult
+	Timings     []htmlTiming
+	Graph       htmlGraph
+
+	byURN        map[resource.URN]*htmlResource
+	summary      *apitype.SummaryEvent
+	first, last  int
+	errorsLogged bool
+}
+
+func newHTMLDocument(report HTMLReport) *htmlDocument {
+	d := &htmlDocument{Report: report, byURN: map[resource.URN]*htmlResource{}}
+	for _, e := range report.Events {
+		d.add(e)
+	}
+	d.finish()
+	return d
+}
+
+func (d *htmlDocument) resource(urn resource.URN) *htmlResource {
+	res, ok := d.byURN[urn]
+	if !ok {
+		res = &htmlResource{
+			ID:   fmt.Sprintf("resource-%d", len(d.Resources)),
+			URN:  urn,
+			Type: urn.Type(),
+			Name: urn.Name().String(),
+			Op:   deploy.OpSame,
+		}
+		d.byURN[urn] = res
+		d.Resources = append(d.Resources, res)
+	}
+	return res
+}
+
+func (d *htmlDocument) add(apiEvent apitype.EngineEvent) {
+	if apiEvent.Timestamp != 0 {
+		if d.first == 0 || apiEvent.Timestamp < d.first {
+			d.first = apiEvent.Timestamp
+		}
+		if apiEvent.Timestamp > d.last {
+			d.last = apiEvent.Timestamp
+		}
+	}
+
+	switch {
+	case apiEvent.ResourcePreEvent != nil:
+		e, err := ConvertJSONEvent(apiEvent)
+		if err != nil {
+			logging.V(7).Infof("could not convert event for the HTML report: %v", err)
+			return
+		}
+		p := e.Payload().(engine.ResourcePreEventPayload)
+		m := p.Metadata
+
+		_, seen := d.byURN[m.URN]
+		res := d.resource(m.URN)
+		// The steps of a replacement share a URN, so the resource is reported as replaced rather than as the first of
+		// them.
+		if seen && m.Op != deploy.OpReplace {
+			return
+		}
+		res.Op, res.Planned, res.start = m.Op, p.Planning, apiEvent.Timestamp
+		if m.Op != deploy.OpSame && m.Op != deploy.OpRead && m.Op != deploy.OpRefresh {
+			details := getDiffDetails(m, 0 /*indent*/, p.Planning, false /*debug*/, Options{})
+			res.Diff = htmlDiff(markdownDiff(m.Op, details))
+		}
+
+		state := apiEvent.ResourcePreEvent.Metadata.New
+		if state == nil {
+			state = apiEvent.ResourcePreEvent.Metadata.Old
+		}
+		if state != nil {
+			res.parent = resource.URN(state.Parent)
+			res.dependencies = nil
+			for _, dep := range state.Dependencies {
+				res.dependencies = append(res.dependencies, resource.URN(dep))
+			}
+		}
+	case apiEvent.ResOutputsEvent != nil:
+		res := d.resource(resource.URN(apiEvent.ResOutputsEvent.Metadata.URN))
+		res.end = apiEvent.Timestamp
+	case apiEvent.ResOpFailedEvent != nil:
+		res := d.resource(resource.URN(apiEvent.ResOpFailedEvent.Metadata.URN))
+		res.Failed, res.end = true, apiEvent.Timestamp
+	case apiEvent.DiagnosticEvent != nil:
+		p := apiEvent.DiagnosticEvent
+		if p.Ephemeral || p.Severity == string(diag.Debug) {
+			return
+		}
+		diagnostic := htmlDiagnostic{
+			Severity: p.Severity,
+			URN:      resource.URN(p.URN),
+			Message:  strings.TrimRight(colors.Never.Colorize(p.Prefix+p.Message), "\n"),
+		}
+		d.Diagnostics = append(d.Diagnostics, diagnostic)
+		if p.Severity == string(diag.Error) {
+			d.errorsLogged = true
+		}
+		if diagnostic.URN != "" {
+			res := d.resource(diagnostic.URN)
+			res.Diagnostics = append(res.Diagnostics, diagnostic)
+			if p.Severity == string(diag.Error) {
+				res.Failed = true
+			}
+		}
+	case apiEvent.StdoutEvent != nil:
+		d.Diagnostics = append(d.Diagnostics, htmlDiagnostic{
+			Severity: string(diag.Info),
+			Message:  strings.TrimRight(colors.Never.Colorize(apiEvent.StdoutEvent.Message), "\n"),
+		})
+	case apiEvent.PolicyEvent != nil:
+		p := apiEvent.PolicyEvent
+		d.Policies = append(d.Policies, htmlPolicyResult{
+			PolicyPack:        p.PolicyPackName,
+			PolicyPackVersion: p.PolicyPackVersion,
+			Policy:            p.PolicyName,
+			EnforcementLevel:  p.EnforcementLevel,
+			URN:               resource.URN(p.ResourceURN),
+			Message:           strings.TrimRight(colors.Never.Colorize(p.Message), "\n"),
+		})
+	case apiEvent.SummaryEvent != nil:
+		d.summary = apiEvent.SummaryEvent
+	}
+}
+
+// htmlDiff splits a diff as rendered for the markdown display into lines that are styled by their operation.
+func htmlDiff(diff string) []htmlDiffLine {
+	if strings.TrimSpace(diff) == "" {
+		return nil
+	}
+
+	var lines []htmlDiffLine
+	for _, line := range strings.Split(diff, "\n") {
+		class := ""
+		if line != "" {
+			switch line[0] {
+			case '+':
+				class = "create"
+			case '-':
+				class = "delete"
+			case '~':
+				class = "update"
+			}
+		}
+		lines = append(lines, htmlDiffLine{Class: class, Text: line})
+	}
+	return lines
+}
+
+// finish computes the summary, resource tree, timings and dependency graph of the report once all of its events have
+// been added.
+func (d *htmlDocument) finish() {
+	r := d.Report
+
+	d.Operation = string(r.Kind)
+	if r.Preview {
+		d.Operation = "preview"
+	}
+	d.Title = fmt.Sprintf("Pulumi %s of %s/%s", d.Operation, r.Project, r.Stack)
+	if r.Version != 0 {
+		d.Title += fmt.Sprintf(" (version %d)", r.Version)
+	}
+
+	d.Result = r.Result
+	if d.Result == "" {
+		d.Result = string(apitype.SucceededResult)
+		if d.errorsLogged {
+			d.Result = string(apitype.FailedResult)
+		}
+		for _, res := range d.Resources {
+			if res.Failed {
+				d.Result = string(apitype.FailedResult)
+			}
+		}
+	}
+
+	start, end := r.StartTime, r.EndTime
+	if start.IsZero() && d.first != 0 {
+		start = time.Unix(int64(d.first), 0)
+	}
+	if end.IsZero() && d.last != 0 {
+		end = time.Unix(int64(d.last), 0)
+	}
+	if !start.IsZero() {
+		d.Started = start.UTC().Format(time.RFC1123)
+	}
+	switch {
+	case d.summary != nil && d.summary.DurationSeconds > 0:
+		d.Duration = time.Duration(d.summary.DurationSeconds) * time.Second
+	case !start.IsZero() && !end.IsZero():
+		d.Duration = end.Sub(start)
+	}
+
+	changes := display.ResourceChanges{}
+	if d.summary != nil {
+		for op, c := range d.summary.ResourceChanges {
+			changes[display.StepOp(op)] = c
+		}
+	} else {
+		for _, res := range d.Resources {
+			if !isRootURN(res.URN) {
+				changes[res.Op]++
+			}
+		}
+	}
+	for _, op := range deploy.StepOps {
+		if op == deploy.OpSame {
+			d.Unchanged = changes[op]
+		} else if c := changes[op]; c > 0 {
+			d.Changes = append(d.Changes, htmlChange{Op: op, Count: c})
+		}
+	}
+
+	// Build the resource tree from the resources' parents.
+	for _, res := range d.Resources {
+		if res.end != 0 && res.start != 0 {
+			res.Duration = time.Duration(res.end-res.start) * time.Second
+		}
+		if parent, ok := d.byURN[res.parent]; ok && parent != res {
+			parent.Children = append(parent.Children, res)
+		} else {
+			d.Roots = append(d.Roots, res)
+		}
+	}
+
+	// Show the slowest resources first.
+	var slowest time.Duration
+	for _, res := range d.Resources {
+		if res.Duration > 0 {
+			d.Timings = append(d.Timings, htmlTiming{Resource: res})
+			if res.Duration > slowest {
+				slowest = res.Duration
+			}
+		}
+	}
+	sort.SliceStable(d.Timings, func(i, j int) bool {
+		return d.Timings[i].Resource.Duration > d.Timings[j].Resource.Duration
+	})
+	for i := range d.Timings {
+		d.Timings[i].Percent = int(d.Timings[i].Resource.Duration * 100 / slowest)
+	}
+
+	d.Graph = d.graph()
+}
+
+const (
+	htmlGraphNodeWidth  = 220
+	htmlGraphNodeHeight = 28
+	htmlGraphColumnGap  = 60
+	htmlGraphRowGap     = 12
+	htmlGraphMaxLabel   = 28
+)
+
+// graph lays out the dependency graph of the report's resources in columns, such that each resource is to the right
+// of all of the resources that it depends on.
+func (d *htmlDocument) graph() htmlGraph {
+	columns := map[resource.URN]int{}
+	var column func(res *htmlResource, visiting map[resource.URN]bool) int
+	column = func(res *htmlResource, visiting map[resource.URN]bool) int {
+		if c, ok := columns[res.URN]; ok {
+			return c
+		}
+		visiting[res.URN] = true
+		c := 0
+		for _, dep := range res.dependencies {
+			if depRes, ok := d.byURN[dep]; ok && !visiting[dep] {
+				if depColumn := column(depRes, visiting) + 1; depColumn > c {
+					c = depColumn
+				}
+			}
+		}
+		delete(visiting, res.URN)
+		columns[res.URN] = c
+		return c
+	}
+
+	var g htmlGraph
+	positions := map[resource.URN]htmlGraphNode{}
+	rows := map[int]int{}
+	for _, res := range d.Resources {
+		if isRootURN(res.URN) {
+			continue
+		}
+		c := column(res, map[resource.URN]bool{})
+		label := res.Name
+		if len(label) > htmlGraphMaxLabel {
+			label = label[:htmlGraphMaxLabel-3] + "..."
+		}
+		node := htmlGraphNode{
+			X:        c * (htmlGraphNodeWidth + htmlGraphColumnGap),
+			Y:        rows[c] * (htmlGraphNodeHeight + htmlGraphRowGap),
+			Resource: res,
+			Label:    label,
+		}
+		rows[c]++
+		positions[res.URN] = node
+		g.Nodes = append(g.Nodes, node)
+
+		if right := node.X + htmlGraphNodeWidth; right > g.Width {
+			g.Width = right
+		}
+		if bottom := node.Y + htmlGraphNodeHeight; bottom > g.Height {
+			g.Height = bottom
+		}
+	}
+
+	for _, node := range g.Nodes {
+		for _, dep := range node.Resource.dependencies {
+			if from, ok := positions[dep]; ok {
+				g.Edges = append(g.Edges, htmlGraphEdge{
+					X1: from.X + htmlGraphNodeWidth, Y1: from.Y + htmlGraphNodeHeight/2,
+					X2: node.X, Y2: node.Y + htmlGraphNodeHeight/2,
+				})
+			}
+		}
+	}
+	return g
+}
+
+// htmlOpClass returns the style of an operation in an HTML report.
+func htmlOpClass(op display.StepOp) string {
+	switch op {
+	case deploy.OpCreate, deploy.OpCreateReplacement, deploy.OpImport, deploy.OpImportReplacement:
+		return "create"
+	case deploy.OpDelete, deploy.OpDeleteReplaced, deploy.OpDiscardReplaced, deploy.OpReadDiscard:
+		return "delete"
+	case deploy.OpUpdate, deploy.OpRefresh:
+		return "update"
+	case deploy.OpReplace:
+		return "replace"
+	default:
+		return ""
+	}
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"opClass": htmlOpClass,
+	"status": func(res *htmlResource) string {
+		switch {
+		case res.Failed:
+			return "failed"
+		case res.Planned:
+			return "planned"
+		case res.end != 0:
+			return "succeeded"
+		default:
+			return ""
+		}
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body {
+  font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif;
+  margin: 2em;
+  color: #1f2328;
+}
+h1 { font-size: 1.6em; }
+h2 { font-size: 1.3em; border-bottom: 1px solid #d0d7de; padding-bottom: .3em; margin-top: 2em; }
+h3 { font-size: 1.1em; margin-top: 1.5em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #d0d7de; padding: 4px 8px; text-align: left; vertical-align: top; }
+code, pre { font-family: SFMono-Regular, Consolas, "Liberation Mono", Menlo, monospace; font-size: 12px; }
+pre { background: #f6f8fa; padding: 8px; overflow-x: auto; margin: 4px 0; }
+ul.tree { list-style: none; padding-left: 1.2em; }
+.create { color: #1a7f37; }
+.delete { color: #cf222e; }
+.update { color: #9a6700; }
+.replace { color: #8250df; }
+.failed, .error, .mandatory { color: #cf222e; font-weight: bold; }
+.warning, .advisory { color: #9a6700; }
+.succeeded { color: #1a7f37; }
+.bar { background: #54aeff; height: 10px; min-width: 1px; }
+svg rect { fill: #f6f8fa; stroke: #8c959f; }
+svg rect.create { stroke: #1a7f37; }
+svg rect.delete, svg rect.failed { stroke: #cf222e; }
+svg rect.update { stroke: #9a6700; }
+svg rect.replace { stroke: #8250df; }
+svg text { font-size: 12px; fill: #1f2328; }
+svg line { stroke: #8c959f; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+
+<h2 id="summary">Summary</h2>
+<table>
+<tr><th>Project</th><td>{{.Report.Project}}</td></tr>
+<tr><th>Stack</th><td>{{.Report.Stack}}</td></tr>
+<tr><th>Operation</th><td>{{.Operation}}</td></tr>
+{{- if .Report.Version}}
+<tr><th>Version</th><td>{{.Report.Version}}</td></tr>
+{{- end}}
+{{- if .Report.Message}}
+<tr><th>Message</th><td>{{.Report.Message}}</td></tr>
+{{- end}}
+<tr><th>Result</th><td class="{{.Result}}">{{.Result}}</td></tr>
+{{- if .Started}}
+<tr><th>Started</th><td>{{.Started}}</td></tr>
+{{- end}}
+{{- if .Duration}}
+<tr><th>Duration</th><td>{{.Duration}}</td></tr>
+{{- end}}
+</table>
+<h3>Changes</h3>
+{{- if .Changes}}
+<table>
+<tr><th>Operation</th><th>Resources</th></tr>
+{{- range .Changes}}
+<tr><td class="{{opClass .Op}}">{{.Op}}</td><td>{{.Count}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No changes.</p>
+{{- end}}
+{{- if .Unchanged}}
+<p>{{.Unchanged}} unchanged</p>
+{{- end}}
+
+{{- define "node"}}
+<li><a href="#{{.ID}}">{{.Name}}</a> <code>{{.Type}}</code> <span class="{{opClass .Op}}">{{.Op}}</span>
+{{- with status .}} <span class="{{.}}">{{.}}</span>{{end}}
+{{- if .Children}}
+<ul class="tree">
+{{- range .Children}}{{template "node" .}}{{end}}
+</ul>
+{{- end}}
+</li>
+{{- end}}
+
+<h2 id="resources">Resources</h2>
+{{- if .Roots}}
+<ul class="tree">
+{{- range .Roots}}{{template "node" .}}{{end}}
+</ul>
+{{- else}}
+<p>No resources.</p>
+{{- end}}
+{{- range .Resources}}
+<h3 id="{{.ID}}">{{.Name}} <span class="{{opClass .Op}}">{{.Op}}</span></h3>
+<p><code>{{.URN}}</code>
+{{- with status .}} <span class="{{.}}">{{.}}</span>{{end}}
+{{- if .Duration}} in {{.Duration}}{{end}}</p>
+{{- if .Diff}}
+<pre>{{range .Diff}}<span class="{{.Class}}">{{.Text}}</span>
+{{end}}</pre>
+{{- end}}
+{{- range .Diagnostics}}
+<p class="{{.Severity}}">{{.Severity}}</p>
+<pre>{{.Message}}</pre>
+{{- end}}
+{{- end}}
+
+<h2 id="diagnostics">Diagnostics</h2>
+{{- if .Diagnostics}}
+<table>
+<tr><th>Severity</th><th>Resource</th><th>Message</th></tr>
+{{- range .Diagnostics}}
+<tr>
+<td class="{{.Severity}}">{{.Severity}}</td>
+<td>{{if .URN}}<code>{{.URN}}</code>{{end}}</td>
+<td><pre>{{.Message}}</pre></td>
+</tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No diagnostics.</p>
+{{- end}}
+
+<h2 id="policies">Policies</h2>
+{{- if .Policies}}
+<table>
+<tr><th>Policy pack</th><th>Version</th><th>Policy</th><th>Enforcement</th><th>Resource</th><th>Message</th></tr>
+{{- range .Policies}}
+<tr>
+<td>{{.PolicyPack}}</td>
+<td>{{.PolicyPackVersion}}</td>
+<td>{{.Policy}}</td>
+<td class="{{.EnforcementLevel}}">{{.EnforcementLevel}}</td>
+<td>{{if .URN}}<code>{{.URN}}</code>{{end}}</td>
+<td><pre>{{.Message}}</pre></td>
+</tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No policy violations.</p>
+{{- end}}
+
+<h2 id="timings">Timings</h2>
+{{- if .Timings}}
+<table>
+<tr><th>Resource</th><th>Operation</th><th>Duration</th><th></th></tr>
+{{- range .Timings}}
+<tr>
+<td><a href="#{{.Resource.ID}}">{{.Resource.Name}}</a></td>
+<td class="{{opClass .Resource.Op}}">{{.Resource.Op}}</td>
+<td>{{.Resource.Duration}}</td>
+<td style="width: 300px"><div class="bar" style="width: {{.Percent}}%"></div></td>
+</tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No timings were recorded.</p>
+{{- end}}
+
+<h2 id="graph">Dependency graph</h2>
+{{- if .Graph.Nodes}}
+<svg xmlns="http://www.w3.org/2000/svg" width="{{.Graph.Width}}" height="{{.Graph.Height}}"
+  viewBox="-1 -1 {{.Graph.Width}} {{.Graph.Height}}" overflow="visible">
+<defs>
+<marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="6" markerHeight="6" orient="auto-start-reverse">
+<path d="M 0 0 L 10 5 L 0 10 z" fill="#8c959f"/>
+</marker>
+</defs>
+{{- range .Graph.Edges}}
+<line x1="{{.X1}}" y1="{{.Y1}}" x2="{{.X2}}" y2="{{.Y2}}" marker-end="url(#arrow)"/>
+{{- end}}
+{{- range .Graph.Nodes}}
+<a href="#{{.Resource.ID}}">
+<rect class="{{if .Resource.Failed}}failed{{else}}{{opClass .Resource.Op}}{{end}}" x="{{.X}}" y="{{.Y}}"
+  width="220" height="28" rx="4"><title>{{.Resource.URN}}</title></rect>
+<text x="{{.X}}" y="{{.Y}}" dx="8" dy="18">{{.Label}}</text>
+</a>
+{{- end}}
+</svg>
+{{- else}}
+<p>No resources.</p>
+{{- end}}
+</body>
+</html>
+`))
+
+// RenderHTMLReport renders a self-contained HTML report of an update from its engine events, with a summary, the tree
+// of its resources and their diffs, its diagnostics, policy violations and timings, and the dependency graph of its
+// resources. The report doesn't load any external resources, so that it can be viewed offline.
+func RenderHTMLReport(w io.Writer, report HTMLReport) error {
+	return htmlReportTemplate.Execute(w, newHTMLDocument(report))
+}
+
+// startHTMLReport records the events of an update for the HTML report requested in the options, and passes every event
+// through to the returned channel. Events are converted to their JSON form without secrets, as for the event log, and
+// the report is written once the display is done.
+func startHTMLReport(events <-chan engine.Event, done chan<- bool, opts Options,
+	update eventSinkUpdate,
+) (<-chan engine.Event, chan<- bool) {
+	report := HTMLReport{
+		Project:   update.project,
+		Stack:     update.stack,
+		Kind:      update.kind,
+		Preview:   update.preview,
+		StartTime: time.Now(),
+	}
+
+	outEvents, outDone := make(chan engine.Event), make(chan bool)
+	go func() {
+		defer close(done)
+
+		sequence := 0
+		for e := range events {
+			apiEvent, err := convertLoggedEvent(e, Options{Color: colors.Never}, sequence)
+			if err != nil {
+				logging.V(7).Infof("failed to convert event for the HTML report: %v", err)
+			} else {
+				report.Events = append(report.Events, apiEvent)
+			}
+			sequence++
+
+			outEvents <- e
+
+			if e.Type == engine.CancelEvent {
+				break
+			}
+		}
+		report.EndTime = time.Now()
+
+		<-outDone
+
+		if err := writeReportFile(opts.HTMLReportPath, func(w io.Writer) error {
+			return RenderHTMLReport(w, report)
+		}); err != nil {
+			cmdutil.Diag().Warningf(diag.RawMessage("", fmt.Sprintf("could not write HTML report: %v", err)))
+		}
+	}()
+
+	return outEvents, outDone
+}
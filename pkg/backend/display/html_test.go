@@ -0,0 +1,127 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+)
+
+func TestRenderHTMLReport(t *testing.T) {
+	t.Parallel()
+
+	testEvents, siteURN, logsURN := reportTestEvents()
+	var events []apitype.EngineEvent
+	for i, e := range testEvents {
+		if e.Type == engine.CancelEvent {
+			continue
+		}
+		apiEvent, err := convertLoggedEvent(e, Options{Color: colors.Never}, i)
+		require.NoError(t, err)
+		apiEvent.Timestamp = 1000 + i
+		if pre := apiEvent.ResourcePreEvent; pre != nil && pre.Metadata.URN == string(logsURN) {
+			pre.Metadata.New.Dependencies = []string{string(siteURN)}
+		}
+		events = append(events, apiEvent)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderHTMLReport(&buf, HTMLReport{
+		Project: "proj",
+		Stack:   "dev",
+		Kind:    apitype.UpdateUpdate,
+		Version: 3,
+		Message: "<script>alert(1)</script>",
+		Events:  events,
+	}))
+	html := buf.String()
+
+	assert.Contains(t, html, "<title>Pulumi update of proj/dev (version 3)</title>")
+	for _, section := range []string{"summary", "resources", "diagnostics", "policies", "timings", "graph"} {
+		assert.Contains(t, html, `id="`+section+`"`)
+	}
+
+	// The report is self-contained and escapes the values that it shows.
+	assert.NotContains(t, html, "<script")
+	assert.NotContains(t, html, "src=")
+	assert.Contains(t, html, "&lt;script&gt;alert(1)&lt;/script&gt;")
+
+	// The update failed because one of its resources did.
+	assert.Contains(t, html, `<td class="failed">failed</td>`)
+	assert.Contains(t, html, `<span class="failed">failed</span>`)
+	assert.Contains(t, html, "updating failed: access denied")
+
+	// Diffs are shown per resource.
+	assert.Contains(t, html, `<span class="create">&#43; acl: &#34;public-read&#34;</span>`)
+	assert.Contains(t, html, `<span class="update">~ acl: &#34;private&#34; =&gt; &#34;log-delivery-write&#34;</span>`)
+
+	// Policy violations are shown without their color directives.
+	assert.Contains(t, html, "<td>s3-no-public-read</td>")
+	assert.Contains(t, html, "<pre>Buckets must not be public.</pre>")
+	assert.NotContains(t, html, "{%")
+
+	// logs depends on site, so it is drawn to its right with an edge between them.
+	assert.Contains(t, html, "<svg")
+	assert.Contains(t, html, `<rect class="create" x="0" y="0"`)
+	assert.Contains(t, html, `<rect class="failed" x="280" y="0"`)
+	assert.Contains(t, html, `<line x1="220" y1="14" x2="280" y2="14"`)
+}
+
+func TestHTMLReport(t *testing.T) {
+	t.Parallel()
+
+	opts := Options{HTMLReportPath: filepath.Join(t.TempDir(), "report.html")}
+
+	events, done := make(chan engine.Event), make(chan bool)
+	out, outDone := startHTMLReport(events, done, opts, eventSinkUpdate{
+		project: "proj",
+		stack:   "dev",
+		kind:    apitype.UpdateUpdate,
+		preview: true,
+	})
+	go func() {
+		for e := range out {
+			if e.Type == engine.CancelEvent {
+				close(outDone)
+				return
+			}
+		}
+	}()
+	testEvents, siteURN, _ := reportTestEvents()
+	for _, e := range testEvents {
+		events <- e
+	}
+	select {
+	case <-done:
+	case <-time.After(30 * time.Second):
+		t.Fatal("timed out waiting for the report")
+	}
+
+	b, err := os.ReadFile(opts.HTMLReportPath)
+	require.NoError(t, err)
+	html := string(b)
+	assert.Contains(t, html, "<title>Pulumi preview of proj/dev</title>")
+	assert.Contains(t, html, "<code>"+string(siteURN)+"</code>")
+}
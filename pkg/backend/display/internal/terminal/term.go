@@ -259,8 +259,11 @@ const (
 	KeyCtrlC    = "ctrl+c"
 	KeyCtrlO    = "ctrl+o"
 	KeyDown     = "down"
+	KeyEnter    = "enter"
+	KeyLeft     = "left"
 	KeyPageDown = "page-down"
 	KeyPageUp   = "page-up"
+	KeyRight    = "right"
 	KeyUp       = "up"
 )
 
@@ -290,6 +293,8 @@ func (t *terminal) ReadKey() (string, error) {
 		switch d.final {
 		case 3: // ETX
 			return KeyCtrlC, nil
+		case 13: // CR
+			return KeyEnter, nil
 		case 15: // SI
 			return KeyCtrlO, nil
 		}
@@ -304,6 +309,12 @@ func (t *terminal) ReadKey() (string, error) {
 		case 'B':
 			// CUD - Cursor Down: CSI (Pn) B
 			return KeyDown, nil
+		case 'C':
+			// CUF - Cursor Forward: CSI (Pn) C
+			return KeyRight, nil
+		case 'D':
+			// CUB - Cursor Backward: CSI (Pn) D
+			return KeyLeft, nil
 		case '~':
 			// DECFNK - Function Key: CSI Ps1 (; Ps2) ~
 			switch string(d.params) {
@@ -172,6 +172,7 @@ func ShowPreviewDigest(events <-chan engine.Event, done chan<- bool, opts Option
 					DiffReasons:    m.Diffs,
 					ReplaceReasons: m.Keys,
 					DetailedDiff:   detailedDiff,
+					Patches:        propertyPatches(m),
 				}
 
 				if m.Old != nil {
@@ -200,8 +201,8 @@ func ShowPreviewDigest(events <-chan engine.Event, done chan<- bool, opts Option
 		// resolving or operations failing.
 
 		// Events occurring late:
-		case engine.PolicyViolationEvent:
-			// At this point in time, we don't handle policy events in JSON serialization
+		case engine.PolicyViolationEvent, engine.ResourceAnnotationEvent:
+			// At this point in time, we don't handle policy or annotation events in JSON serialization
 			continue
 		case engine.SummaryEvent:
 			// At the end of the preview, a summary event indicates the final conclusions.
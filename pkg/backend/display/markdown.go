@@ -0,0 +1,383 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"strings"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/display"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+// maxMarkdownLength is the size budget of the markdown display, which is the maximum length of a GitHub comment.
+const maxMarkdownLength = 65536
+
+// markdownFooterReserve is the part of the size budget that is kept for the footer of the markdown display.
+const markdownFooterReserve = 512
+
+// ShowMarkdownEvents renders the engine events of an operation as a GitHub-flavored markdown document that is suitable
+// for a pull request comment. The document is written once all of the events have been received, and is truncated to
+// fit in the size of a comment.
+func ShowMarkdownEvents(op string, stack tokens.Name, proj tokens.PackageName, permalink string,
+	events <-chan engine.Event, done chan<- bool, opts Options,
+) {
+	// Ensure we close the done channel before exiting.
+	defer func() { close(done) }()
+
+	stdout := opts.Stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+
+	doc := newMarkdownDocument(op, stack, proj, permalink, opts)
+	for e := range events {
+		doc.add(e)
+		if e.Type == engine.CancelEvent {
+			break
+		}
+	}
+	fprintIgnoreError(stdout, doc.render(maxMarkdownLength))
+}
+
+// markdownResource is a resource that is shown in the markdown display, with its property diff, policy violations and
+// diagnostics.
+type markdownResource struct {
+	op          display.StepOp
+	urn         resource.URN
+	diff        string
+	violations  []engine.PolicyViolationEventPayload
+	diagnostics []engine.DiagEventPayload
+}
+
+// markdownDocument accumulates the events of an operation for the markdown display.
+type markdownDocument struct {
+	op        string
+	stack     tokens.Name
+	proj      tokens.PackageName
+	permalink string
+	opts      Options
+
+	resources   []*markdownResource
+	byURN       map[resource.URN]*markdownResource
+	violations  []engine.PolicyViolationEventPayload
+	diagnostics []engine.DiagEventPayload
+	summary     *engine.SummaryEventPayload
+}
+
+func newMarkdownDocument(op string, stack tokens.Name, proj tokens.PackageName, permalink string,
+	opts Options,
+) *markdownDocument {
+	return &markdownDocument{
+		op:        op,
+		stack:     stack,
+		proj:      proj,
+		permalink: permalink,
+		opts:      opts,
+		byURN:     make(map[resource.URN]*markdownResource),
+	}
+}
+
+func (d *markdownDocument) add(e engine.Event) {
+	switch e.Type {
+	case engine.ResourcePreEvent:
+		p := e.Payload().(engine.ResourcePreEventPayload)
+		m := p.Metadata
+		if m.Op == deploy.OpRefresh || m.Op == deploy.OpImport || !shouldShow(m, d.opts) {
+			return
+		}
+		res := &markdownResource{
+			op:   m.Op,
+			urn:  m.URN,
+			diff: markdownDiff(m.Op, getDiffDetails(m, 0 /*indent*/, p.Planning, p.Debug, d.opts)),
+		}
+		d.resources = append(d.resources, res)
+		d.byURN[m.URN] = res
+	case engine.PolicyViolationEvent:
+		p := e.Payload().(engine.PolicyViolationEventPayload)
+		if res, has := d.byURN[p.ResourceURN]; has {
+			res.violations = append(res.violations, p)
+		} else {
+			d.violations = append(d.violations, p)
+		}
+	case engine.DiagEvent:
+		p := e.Payload().(engine.DiagEventPayload)
+		if p.Ephemeral || (p.Severity == diag.Debug && !d.opts.Debug) {
+			return
+		}
+		if res, has := d.byURN[p.URN]; has {
+			res.diagnostics = append(res.diagnostics, p)
+		} else {
+			d.diagnostics = append(d.diagnostics, p)
+		}
+	case engine.StdoutColorEvent:
+		p := e.Payload().(engine.StdoutEventPayload)
+		d.diagnostics = append(d.diagnostics, engine.DiagEventPayload{Message: p.Message, Severity: diag.Info})
+	case engine.SummaryEvent:
+		p := e.Payload().(engine.SummaryEventPayload)
+		d.summary = &p
+	}
+}
+
+// markdownDiff converts an uncolorized property diff into the body of a `diff` code block, with each line's
+// operation prefix moved to the first column so that GitHub highlights it. The properties of created and deleted
+// resources are printed without a prefix, so they are given the prefix of the step's operation.
+func markdownDiff(op display.StepOp, details string) string {
+	var unmarked byte
+	switch op {
+	case deploy.OpCreate:
+		unmarked = '+'
+	case deploy.OpDelete:
+		unmarked = '-'
+	}
+
+	lines := strings.Split(strings.TrimRight(colors.Never.Colorize(details), "\n"), "\n")
+	for i, line := range lines {
+		// Every line is indented by at least the two columns of a property's prefix.
+		line = strings.TrimPrefix(line, "  ")
+		j := len(line) - len(strings.TrimLeft(line, " "))
+		switch {
+		case j < len(line) && strings.ContainsRune("+-~", rune(line[j])):
+			if j > 0 {
+				line = line[j:j+1] + line[:j-1] + " " + line[j+1:]
+			}
+		case unmarked != 0 && j > 0:
+			line = string(unmarked) + line[1:]
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// render renders the document, keeping it within the given number of bytes. The headline, change summary and the
+// diagnostics that are not associated with a resource always come first; resources whose details don't fit are
+// collapsed to a single line, and resources that don't fit at all are counted in the footer.
+func (d *markdownDocument) render(budget int) string {
+	var b strings.Builder
+	d.renderHeader(&b)
+
+	limit := budget - markdownFooterReserve
+	if len(d.violations) > 0 || len(d.diagnostics) > 0 {
+		var section strings.Builder
+		section.WriteString("#### Diagnostics\n\n")
+		renderMarkdownViolations(&section, d.violations)
+		renderMarkdownDiagnostics(&section, d.diagnostics)
+		writeWithinBudget(&b, section.String(), limit)
+	}
+
+	if len(d.resources) > 0 {
+		b.WriteString("#### Resources\n\n")
+	}
+	collapsed, omitted := 0, 0
+	for _, res := range d.resources {
+		switch {
+		case omitted > 0:
+			omitted++
+		case b.Len()+len(res.details()) <= limit:
+			b.WriteString(res.details())
+		case b.Len()+len(res.line()) <= limit:
+			b.WriteString(res.line())
+			collapsed++
+		default:
+			omitted++
+		}
+	}
+
+	if collapsed > 0 {
+		fmt.Fprintf(&b, "\n_The details of %s were omitted to fit in the size of a comment._\n",
+			english.Plural(collapsed, "resource", ""))
+	}
+	if omitted > 0 {
+		fmt.Fprintf(&b, "\n_%d more %s %s not shown to fit in the size of a comment._\n",
+			omitted, english.PluralWord(omitted, "resource", ""), english.PluralWord(omitted, "is", "are"))
+	}
+	if d.permalink != "" && !d.opts.SuppressPermalink {
+		fmt.Fprintf(&b, "\n[View Live](%s)\n", d.permalink)
+	}
+	return b.String()
+}
+
+func (d *markdownDocument) renderHeader(b *strings.Builder) {
+	title := d.op
+	if title != "" {
+		title = strings.ToUpper(title[:1]) + title[1:]
+	}
+	fmt.Fprintf(b, "### %s (`%s/%s`)\n\n", title, d.proj, d.stack)
+
+	changes := d.changes()
+	changeCount := 0
+	var rows strings.Builder
+	for _, op := range deploy.StepOps {
+		if op == deploy.OpSame || op == deploy.OpRead || op == deploy.OpReadDiscard || op == deploy.OpReadReplacement {
+			continue
+		}
+		if c := changes[op]; c > 0 {
+			changeCount += c
+			fmt.Fprintf(&rows, "| `%s` %s | %d |\n", markdownPrefix(op), op, c)
+		}
+	}
+
+	if changeCount == 0 {
+		b.WriteString("No changes.")
+	} else {
+		b.WriteString("| Operation | Resources |\n| --- | ---: |\n")
+		b.WriteString(rows.String())
+		fmt.Fprintf(b, "\n**%s**", english.Plural(changeCount, "change", ""))
+	}
+	if same := changes[deploy.OpSame]; same > 0 {
+		fmt.Fprintf(b, ", %d unchanged", same)
+	}
+	b.WriteString("\n\n")
+}
+
+// changes returns the number of resources by operation, from the summary of the operation if it finished.
+func (d *markdownDocument) changes() display.ResourceChanges {
+	if d.summary != nil {
+		return d.summary.ResourceChanges
+	}
+	changes := make(display.ResourceChanges)
+	for _, res := range d.resources {
+		changes[res.op]++
+	}
+	return changes
+}
+
+// markdownPrefix returns the prefix of an operation, e.g. `+` for creates, or an empty string for sames.
+func markdownPrefix(op display.StepOp) string {
+	return strings.TrimSpace(deploy.RawPrefix(op))
+}
+
+// summary returns the HTML summary line of a resource.
+func (r *markdownResource) summary() string {
+	var prefix string
+	if p := markdownPrefix(r.op); p != "" {
+		prefix = "<code>" + html.EscapeString(p) + "</code> "
+	}
+	return fmt.Sprintf("%s%s <b>%s</b> (%s)", prefix,
+		html.EscapeString(string(r.urn.Type())), html.EscapeString(r.urn.Name().String()), r.op)
+}
+
+// line returns the collapsed form of a resource, without its details.
+func (r *markdownResource) line() string {
+	return "- " + r.summary() + "\n"
+}
+
+// details returns the collapsible form of a resource, with its property diff, policy violations and diagnostics.
+func (r *markdownResource) details() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<details>\n<summary>%s</summary>\n\n", r.summary())
+	if r.diff != "" {
+		writeMarkdownCodeBlock(&b, "diff", r.diff)
+	}
+	renderMarkdownViolations(&b, r.violations)
+	renderMarkdownDiagnostics(&b, r.diagnostics)
+	b.WriteString("</details>\n\n")
+	return b.String()
+}
+
+func renderMarkdownViolations(b *strings.Builder, violations []engine.PolicyViolationEventPayload) {
+	for _, v := range violations {
+		level := "advisory"
+		if v.EnforcementLevel == apitype.Mandatory {
+			level = "mandatory"
+		}
+		fmt.Fprintf(b, "**Policy violation** (%s): `%s@v%s` / `%s`\n\n", level,
+			v.PolicyPackName, v.PolicyPackVersion, v.PolicyName)
+		writeMarkdownCodeBlock(b, "", colors.Never.Colorize(v.Message))
+	}
+}
+
+func renderMarkdownDiagnostics(b *strings.Builder, diagnostics []engine.DiagEventPayload) {
+	for _, p := range diagnostics {
+		fmt.Fprintf(b, "**%s**", p.Severity)
+		if p.URN != "" {
+			fmt.Fprintf(b, " `%s`", p.URN)
+		}
+		b.WriteString("\n\n")
+		writeMarkdownCodeBlock(b, "", colors.Never.Colorize(p.Message))
+	}
+}
+
+// writeMarkdownCodeBlock writes a fenced code block whose fence is longer than any run of backticks in the content.
+func writeMarkdownCodeBlock(b *strings.Builder, lang, content string) {
+	fence := "```"
+	for strings.Contains(content, fence) {
+		fence += "`"
+	}
+	fmt.Fprintf(b, "%s%s\n%s\n%s\n\n", fence, lang, strings.TrimRight(content, "\n"), fence)
+}
+
+// writeWithinBudget writes s if it fits in the given number of bytes, or as much of it as fits, closing any code block
+// that it truncates with the same fence that opened it.
+func writeWithinBudget(b *strings.Builder, s string, limit int) {
+	if b.Len()+len(s) <= limit {
+		b.WriteString(s)
+		return
+	}
+
+	const truncated = "\n\n_Truncated to fit in the size of a comment._\n\n"
+	// Leave room to close the longest fence that the cut might fall within.
+	longest := 0
+	for _, line := range strings.Split(s, "\n") {
+		if fence := markdownFence(line); len(fence) > longest {
+			longest = len(fence)
+		}
+	}
+	n := limit - b.Len() - len(truncated) - len("\n") - longest
+	if n <= 0 {
+		return
+	}
+	// Cut at a line boundary so that a code fence is never split.
+	s = s[:n]
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	b.WriteString(s)
+
+	open := ""
+	for _, line := range strings.Split(s, "\n") {
+		fence := markdownFence(line)
+		switch {
+		case fence == "":
+		case open == "":
+			open = fence
+		case len(fence) >= len(open) && line == fence:
+			open = ""
+		}
+	}
+	if open != "" {
+		b.WriteString("\n" + open)
+	}
+	b.WriteString(truncated)
+}
+
+// markdownFence returns the code fence that begins line, or "" if line doesn't begin with one.
+func markdownFence(line string) string {
+	fence := line[:len(line)-len(strings.TrimLeft(line, "`"))]
+	if len(fence) < 3 {
+		return ""
+	}
+	return fence
+}
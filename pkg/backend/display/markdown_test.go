@@ -0,0 +1,160 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/display"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+func markdownTestStep(op display.StepOp, name string, old, new resource.PropertyMap) engine.Event {
+	urn := resource.NewURN("dev", "proj", "", "aws:s3/bucket:Bucket", tokens.QName(name))
+	m := engine.StepEventMetadata{Op: op, URN: urn, Type: urn.Type(), Logical: true}
+	if old != nil {
+		m.Old = &engine.StepEventStateMetadata{URN: urn, Type: urn.Type(), Inputs: old, Outputs: old}
+	}
+	if new != nil {
+		m.New = &engine.StepEventStateMetadata{URN: urn, Type: urn.Type(), Inputs: new}
+	}
+	if old != nil && new != nil {
+		m.Diffs = old.Diff(new).ChangedKeys()
+	}
+	return engine.NewEvent(engine.ResourcePreEvent, engine.ResourcePreEventPayload{Metadata: m, Planning: true})
+}
+
+func TestMarkdownEvents(t *testing.T) {
+	t.Parallel()
+
+	created := markdownTestStep(deploy.OpCreate, "logs", nil, resource.PropertyMap{
+		"acl":      resource.NewStringProperty("private"),
+		"password": resource.MakeSecret(resource.NewStringProperty("hunter2")),
+	})
+	updated := markdownTestStep(deploy.OpUpdate, "site", resource.PropertyMap{
+		"acl": resource.NewStringProperty("private"),
+	}, resource.PropertyMap{
+		"acl": resource.NewStringProperty("public-read"),
+	})
+	urn := updated.Payload().(engine.ResourcePreEventPayload).Metadata.URN
+
+	events := []engine.Event{
+		created,
+		updated,
+		engine.NewEvent(engine.PolicyViolationEvent, engine.PolicyViolationEventPayload{
+			ResourceURN:       urn,
+			Message:           "Buckets must not be public.",
+			PolicyName:        "no-public-buckets",
+			PolicyPackName:    "security",
+			PolicyPackVersion: "1.0.0",
+			EnforcementLevel:  apitype.Mandatory,
+		}),
+		engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{
+			Message:  "deprecated config key",
+			Severity: diag.Warning,
+		}),
+		engine.NewEvent(engine.SummaryEvent, engine.SummaryEventPayload{
+			IsPreview: true,
+			ResourceChanges: display.ResourceChanges{
+				deploy.OpCreate: 1,
+				deploy.OpUpdate: 1,
+				deploy.OpSame:   3,
+			},
+		}),
+		engine.NewEvent(engine.CancelEvent, nil),
+	}
+
+	eventChannel, doneChannel := make(chan engine.Event), make(chan bool)
+	var stdout bytes.Buffer
+	go ShowMarkdownEvents("previewing update", "dev", "proj", "https://example.com/update",
+		eventChannel, doneChannel, Options{Stdout: &stdout})
+	for _, e := range events {
+		eventChannel <- e
+	}
+	<-doneChannel
+
+	out := stdout.String()
+	assert.Contains(t, out, "### Previewing update (`proj/dev`)\n")
+	assert.Contains(t, out, "| `+` create | 1 |\n| `~` update | 1 |\n\n**2 changes**, 3 unchanged\n")
+	assert.Contains(t, out, "#### Diagnostics\n\n**warning**\n\n```\ndeprecated config key\n```\n")
+	assert.Contains(t, out,
+		"<details>\n<summary><code>~</code> aws:s3/bucket:Bucket <b>site</b> (update)</summary>\n\n"+
+			"```diff\n~ acl: \"private\" => \"public-read\"\n```\n\n"+
+			"**Policy violation** (mandatory): `security@v1.0.0` / `no-public-buckets`\n\n"+
+			"```\nBuckets must not be public.\n```\n\n</details>\n")
+	assert.Contains(t, out, "```diff\n+ acl     : \"private\"\n+ password: [secret]\n```\n")
+	assert.NotContains(t, out, "hunter2")
+	assert.True(t, strings.HasSuffix(out, "\n[View Live](https://example.com/update)\n"))
+}
+
+func TestMarkdownBudget(t *testing.T) {
+	t.Parallel()
+
+	doc := newMarkdownDocument("previewing update", "dev", "proj", "", Options{})
+	for i := 0; i < 100; i++ {
+		doc.add(markdownTestStep(deploy.OpCreate, fmt.Sprintf("bucket-%d", i), nil, resource.PropertyMap{
+			"policy": resource.NewStringProperty(strings.Repeat("x", 200)),
+		}))
+	}
+
+	const budget = 4096
+	out := doc.render(budget)
+	assert.LessOrEqual(t, len(out), budget)
+	assert.Contains(t, out, "| `+` create | 100 |")
+	assert.Contains(t, out, "<details>\n<summary><code>+</code> aws:s3/bucket:Bucket <b>bucket-0</b> (create)")
+	assert.Contains(t, out, "- <code>+</code> aws:s3/bucket:Bucket <b>bucket-")
+	assert.Regexp(t, `_The details of \d+ resources were omitted to fit in the size of a comment._`, out)
+	assert.Regexp(t, `_\d+ more resources are not shown to fit in the size of a comment._`, out)
+
+	// A long diagnostic is truncated without leaving its code block open.
+	doc = newMarkdownDocument("previewing update", "dev", "proj", "", Options{})
+	doc.add(engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{
+		Message:  strings.Repeat("error detail\n", 1000),
+		Severity: diag.Error,
+	}))
+	out = doc.render(budget)
+	assert.LessOrEqual(t, len(out), budget)
+	assert.Contains(t, out, "error detail\n```\n\n_Truncated to fit in the size of a comment._\n")
+
+	// A diagnostic that contains a code fence is closed with the longer fence that opened its code block.
+	doc = newMarkdownDocument("previewing update", "dev", "proj", "", Options{})
+	doc.add(engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{
+		Message:  "```\n" + strings.Repeat("error detail\n", 1000),
+		Severity: diag.Error,
+	}))
+	out = doc.render(budget)
+	assert.LessOrEqual(t, len(out), budget)
+	assert.Contains(t, out, "````\n```\nerror detail\n")
+	assert.Contains(t, out, "error detail\n````\n\n_Truncated to fit in the size of a comment._\n")
+}
+
+func TestMarkdownDiff(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "+ a: 1\n~ b: {\n-         c: 2\n    }\n  d: 3",
+		markdownDiff(deploy.OpUpdate, "  + a: 1\n  ~ b: {\n          - c: 2\n      }\n    d: 3\n"))
+	assert.Equal(t, "+ a: {\n+     b: 1\n+ }",
+		markdownDiff(deploy.OpCreate, "    a: {\n        b: 1\n    }\n"))
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/engine"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/display"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
@@ -1059,17 +1060,15 @@ func (p *propertyPrinter) printTextDiff(old, new string) {
 		return
 	}
 
-	differ := diffmatchpatch.New()
-	differ.DiffTimeout = 0
-
 	singleLine := !strings.ContainsRune(old, '\n') && !strings.ContainsRune(new, '\n')
 	if singleLine {
+		differ := diffmatchpatch.New()
+		differ.DiffTimeout = 0
+
 		diff := differ.DiffMain(old, new, false)
 		p.printCharacterDiff(differ.DiffCleanupEfficiency(diff))
 	} else {
-		hashed1, hashed2, lineArray := differ.DiffLinesToChars(old, new)
-		diffs := differ.DiffMain(hashed1, hashed2, false)
-		p.indented(1).printLineDiff(differ.DiffCharsToLines(diffs, lineArray))
+		p.indented(1).printUnifiedDiff(unifiedDiff(old, new, diffContextLines))
 	}
 }
 
@@ -1101,71 +1100,26 @@ func (p *propertyPrinter) printCharacterDiff(diffs []diffmatchpatch.Diff) {
 	p.writeVerbatim("\"\n")
 }
 
-// printLineDiff takes the full diff produed by diffmatchpatch and condenses it into something
-// useful we can print to the console. Specifically, while it includes any adds/removes in
-// green/red, it will also show portions of the unchanged text to help give surrounding context to
-// those add/removes. Because the unchanged portions may be very large, it only included around 3
-// lines before/after the change.
-func (p *propertyPrinter) printLineDiff(diffs []diffmatchpatch.Diff) {
+// printUnifiedDiff prints the hunks of a unified diff. Each hunk starts with a header that gives the lines of the old
+// and new text that it covers, followed by its added and deleted lines in green and red amongst a few unchanged lines
+// of context.
+func (p *propertyPrinter) printUnifiedDiff(hunks []apitype.PatchHunk) {
 	p.writeVerbatim("\n")
 
-	writeDiff := func(op display.StepOp, text string) {
-		prefix := op == deploy.OpCreate || op == deploy.OpDelete
-		p.withOp(op).withPrefix(prefix).writeWithIndent("%s", text)
-	}
-
-	for index, diff := range diffs {
-		text := diff.Text
-		lines := strings.Split(text, "\n")
-		printLines := func(op display.StepOp, startInclusive int, endExclusive int) {
-			for i := startInclusive; i < endExclusive; i++ {
-				if strings.TrimSpace(lines[i]) != "" {
-					writeDiff(op, lines[i])
-					p.writeString("\n")
-				}
-			}
-		}
-
-		switch diff.Type {
-		case diffmatchpatch.DiffInsert:
-			printLines(deploy.OpCreate, 0, len(lines))
-		case diffmatchpatch.DiffDelete:
-			printLines(deploy.OpDelete, 0, len(lines))
-		case diffmatchpatch.DiffEqual:
-			var trimmedLines []string
-			for _, line := range lines {
-				if strings.TrimSpace(line) != "" {
-					trimmedLines = append(trimmedLines, line)
-				}
-			}
-			lines = trimmedLines
-
-			const contextLines = 2
-
-			// Show the unchanged text in white.
-			if index == 0 {
-				// First chunk of the file.
-				if len(lines) > contextLines+1 {
-					writeDiff(deploy.OpSame, "...\n")
-					printLines(deploy.OpSame, len(lines)-contextLines, len(lines))
-					continue
-				}
-			} else if index == len(diffs)-1 {
-				if len(lines) > contextLines+1 {
-					printLines(deploy.OpSame, 0, contextLines)
-					writeDiff(deploy.OpSame, "...\n")
-					continue
-				}
-			} else {
-				if len(lines) > (2*contextLines + 1) {
-					printLines(deploy.OpSame, 0, contextLines)
-					writeDiff(deploy.OpSame, "...\n")
-					printLines(deploy.OpSame, len(lines)-contextLines, len(lines))
-					continue
-				}
+	for _, h := range hunks {
+		p.withOp(deploy.OpSame).withPrefix(false).writeWithIndent(
+			"@@ -%d,%d +%d,%d @@\n", h.OldStart, h.OldLines, h.NewStart, h.NewLines)
+
+		for _, line := range h.Lines {
+			op := deploy.OpSame
+			switch line[0] {
+			case '-':
+				op = deploy.OpDelete
+			case '+':
+				op = deploy.OpCreate
 			}
-
-			printLines(deploy.OpSame, 0, len(lines))
+			p.withOp(op).withPrefix(op != deploy.OpSame).writeWithIndent("%s", line[1:])
+			p.writeString("\n")
 		}
 	}
 }
@@ -1181,16 +1135,19 @@ func (p *propertyPrinter) printEncodedValueDiff(old, new string) bool {
 		return false
 	}
 
+	diff := oldValue.Diff(newValue, resource.IsInternalPropertyKey)
+	if diff == nil && old != new {
+		// The documents only differ in their formatting, e.g. the order of their keys, so show the change to their
+		// text instead, as in the property's patch.
+		return false
+	}
+
 	if oldKind != newKind {
 		p.write("(%s => %s) ", oldKind, newKind)
-	} else if old != new {
-		p.printTextDiff(strconv.Quote(old), strconv.Quote(new))
-		return true
 	} else {
 		p.write("(%s) ", oldKind)
 	}
 
-	diff := oldValue.Diff(newValue, resource.IsInternalPropertyKey)
 	if diff == nil {
 		p.withOp(deploy.OpSame).printPropertyValue(oldValue)
 		return true
@@ -15,8 +15,15 @@
 package display
 
 import (
+	"bytes"
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -91,3 +98,150 @@ func Test_decodeValue(t *testing.T) {
 		})
 	}
 }
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Parallel()
+
+	lines := func(from, to int) []string {
+		var ls []string
+		for i := from; i <= to; i++ {
+			ls = append(ls, fmt.Sprintf("line %d", i))
+		}
+		return ls
+	}
+	old := lines(1, 20)
+	new := append(append(append([]string{}, old[:1]...), "inserted"), old[1:]...)
+	new[6] = "changed 6"
+	new = append(new[:15], new[16:]...)
+
+	hunks := unifiedDiff(strings.Join(old, "\n")+"\n", strings.Join(new, "\n")+"\n", 3)
+	require.Len(t, hunks, 2)
+
+	// The insertion after line 1 and the change of line 6 are close enough to share a hunk.
+	assert.Equal(t, apitype.PatchHunk{
+		OldStart: 1, OldLines: 9,
+		NewStart: 1, NewLines: 10,
+		Lines: []string{
+			" line 1", "+inserted", " line 2", " line 3", " line 4", " line 5", "-line 6", "+changed 6",
+			" line 7", " line 8", " line 9",
+		},
+	}, hunks[0])
+	assert.Equal(t, apitype.PatchHunk{
+		OldStart: 12, OldLines: 7,
+		NewStart: 13, NewLines: 6,
+		Lines: []string{" line 12", " line 13", " line 14", "-line 15", " line 16", " line 17", " line 18"},
+	}, hunks[1])
+
+	assert.Empty(t, unifiedDiff("a\nb\n", "a\nb\n", 3))
+	assert.Equal(t, []apitype.PatchHunk{{
+		OldStart: 0, OldLines: 0,
+		NewStart: 1, NewLines: 2,
+		Lines: []string{"+a", "+b"},
+	}}, unifiedDiff("", "a\nb\n", 3))
+}
+
+func TestPrintTextDiff(t *testing.T) {
+	t.Parallel()
+
+	print := func(old, new string) string {
+		var buf bytes.Buffer
+		p := propertyPrinter{dest: &buf, op: deploy.OpUpdate, prefix: true, indent: 1}
+		p.printTextDiff(old, new)
+		return colors.Never.Colorize(buf.String())
+	}
+
+	script := "#!/bin/bash\nset -e\n\necho a\necho b\necho c\necho d\necho e\n"
+	assert.Equal(t, "\n"+
+		"        @@ -2,7 +2,7 @@\n"+
+		"        set -e\n"+
+		"        \n"+
+		"        echo a\n"+
+		"      - echo b\n"+
+		"      + echo B\n"+
+		"        echo c\n"+
+		"        echo d\n"+
+		"        echo e\n",
+		print(script, strings.Replace(script, "echo b", "echo B", 1)))
+
+	// Documents are diffed structurally, even if they only span a single line.
+	assert.Equal(t, "(json) {\n      ~ a: 1 => 2\n        b: true\n    }\n",
+		print(`{"a": 1, "b": true}`, `{"b": true, "a": 2}`))
+
+	// Documents that only differ in their formatting are diffed as text, as in their patches.
+	oldDoc, newDoc := "{\n  \"a\": 1,\n  \"b\": true\n}\n", "{\n  \"b\": true,\n  \"a\": 1\n}\n"
+	assert.Equal(t, "\n"+
+		"        @@ -1,4 +1,4 @@\n"+
+		"        {\n"+
+		"      -   \"a\": 1,\n"+
+		"      -   \"b\": true\n"+
+		"      +   \"b\": true,\n"+
+		"      +   \"a\": 1\n"+
+		"        }\n",
+		print(oldDoc, newDoc))
+	patch, ok := newPropertyPatch(oldDoc, newDoc)
+	require.True(t, ok)
+	assert.Equal(t, "text", patch.Format)
+}
+
+func TestPropertyPatches(t *testing.T) {
+	t.Parallel()
+
+	old := resource.PropertyMap{
+		"userData": resource.NewStringProperty("#!/bin/bash\necho hello\n"),
+		"policy":   resource.NewStringProperty(`{"Statement": [{"Effect": "Allow"}], "Version": "2008-10-17"}`),
+		"manifest": resource.NewStringProperty("kind: Pod\nmetadata:\n  labels:\n    app: web\n"),
+		"name":     resource.NewStringProperty("site"),
+		"password": resource.MakeSecret(resource.NewStringProperty("a\nb")),
+		"tags": resource.NewObjectProperty(resource.PropertyMap{
+			"notes": resource.NewStringProperty("a\nb"),
+		}),
+	}
+	new := resource.PropertyMap{
+		"userData": resource.NewStringProperty("#!/bin/bash\necho world\n"),
+		"policy":   resource.NewStringProperty(`{"Statement": [{"Effect": "Deny"}, {"Effect": "Allow"}]}`),
+		"manifest": resource.NewStringProperty("kind: Pod\nmetadata:\n  labels:\n    app: web\n    tier/name: db\n"),
+		"name":     resource.NewStringProperty("site-2"),
+		"password": resource.MakeSecret(resource.NewStringProperty("a\nc")),
+		"tags": resource.NewObjectProperty(resource.PropertyMap{
+			"notes": resource.NewStringProperty("a\nc"),
+		}),
+	}
+	m := markdownTestStep(deploy.OpUpdate, "site", old, new).Payload().(engine.ResourcePreEventPayload).Metadata
+
+	patches := propertyPatches(m)
+	assert.Equal(t, map[string]apitype.PropertyPatch{
+		"userData": {
+			Format: "text",
+			Hunks: []apitype.PatchHunk{{
+				OldStart: 1, OldLines: 2,
+				NewStart: 1, NewLines: 2,
+				Lines: []string{" #!/bin/bash", "-echo hello", "+echo world"},
+			}},
+		},
+		"policy": {
+			Format: "json",
+			Operations: []apitype.PatchOperation{
+				{Op: "replace", Path: "/Statement/0/Effect", Value: "Deny"},
+				{Op: "add", Path: "/Statement/1", Value: map[string]interface{}{"Effect": "Allow"}},
+				{Op: "remove", Path: "/Version"},
+			},
+		},
+		"manifest": {
+			Format: "yaml",
+			Operations: []apitype.PatchOperation{
+				{Op: "add", Path: "/metadata/labels/tier~1name", Value: "db"},
+			},
+		},
+		"tags.notes": {
+			Format: "text",
+			Hunks: []apitype.PatchHunk{{
+				OldStart: 1, OldLines: 2,
+				NewStart: 1, NewLines: 2,
+				Lines: []string{" a", "-b", "+c"},
+			}},
+		},
+	}, patches)
+
+	// The patches are included in the JSON engine events.
+	assert.Equal(t, patches, convertStepEventMetadata(m, false).Patches)
+}
@@ -18,7 +18,9 @@ import (
 	"io"
 
 	"github.com/pulumi/pulumi/pkg/v3/backend/display/internal/terminal"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 )
 
 // Type of output to display.
@@ -33,6 +35,11 @@ const (
 	DisplayQuery
 	// DisplayWatch displays watch output.
 	DisplayWatch
+	// DisplayMarkdown displays a markdown summary of an update, e.g. for a pull request comment.
+	DisplayMarkdown
+	// DisplaySummary displays nothing while an update runs, but records its errors for a summary that is written
+	// once it completes, e.g. for `--output json`.
+	DisplaySummary
 )
 
 // Options controls how the output of events are rendered
@@ -47,7 +54,7 @@ type Options struct {
 	SuppressPermalink    bool                // true to suppress state permalink
 	SummaryDiff          bool                // true if diff display should be summarized.
 	IsInteractive        bool                // true if we should display things interactively.
-	Type                 Type                // type of display (rich diff, progress, or query).
+	Type                 Type                // type of display (rich diff, progress, markdown, or query).
 	JSONDisplay          bool                // true if we should emit the entire diff as JSON.
 	EventLogPath         string              // the path to the file to use for logging events, if any.
 	Debug                bool                // true to enable debug output.
@@ -56,6 +63,31 @@ type Options struct {
 	Stderr               io.Writer           // the writer to use for stderr. Defaults to os.Stderr if unset.
 	SuppressTimings      bool                // true to suppress displaying timings of resource actions
 
+	// EventSinks are the destinations that the engine events of the update are streamed to, if any.
+	EventSinks []workspace.EventSink
+
+	// ReportJUnitPath and ReportSARIFPath are the paths of the files to write JUnit and SARIF reports of the resource
+	// failures and policy violations of the update to, if any.
+	ReportJUnitPath string
+	ReportSARIFPath string
+
+	// HTMLReportPath is the path of the file to write a self-contained HTML report of the update to, if any.
+	HTMLReportPath string
+
+	// DiagnosticSeverity is the least severe diagnostic shown by the progress display, if any. Debug diagnostics are
+	// only ever shown if Debug is also set.
+	DiagnosticSeverity diag.Severity
+	// DiagnosticFilters are the URNs or types of the resources whose diagnostics are shown by the progress display,
+	// which may contain globs as for --target. If there are none, the diagnostics of all resources are shown.
+	DiagnosticFilters []string
+	// CollapseDiagnostics shows repeated identical diagnostics of a resource once, with the number of repetitions.
+	CollapseDiagnostics bool
+	// StreamErrors shows errors as soon as they are reported, rather than only in the final diagnostics.
+	StreamErrors bool
+
+	// SummaryErrors records the errors of an update that is displayed as a summary.
+	SummaryErrors *SummaryErrors
+
 	// testing-only options
 	term                terminal.Terminal
 	deterministicOutput bool
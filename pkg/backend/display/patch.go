@@ -0,0 +1,241 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// diffContextLines is the number of unchanged lines shown around each change in a unified diff.
+const diffContextLines = 3
+
+// diffLine is a single line of a line-level diff.
+type diffLine struct {
+	op   diffmatchpatch.Operation
+	text string
+}
+
+// diffLines computes the line-level differences between two strings.
+func diffLines(old, new string) []diffLine {
+	differ := diffmatchpatch.New()
+	differ.DiffTimeout = 0
+
+	oldRunes, newRunes, lineArray := differ.DiffLinesToRunes(old, new)
+	diffs := differ.DiffCharsToLines(differ.DiffMainRunes(oldRunes, newRunes, false), lineArray)
+
+	var lines []diffLine
+	for _, d := range diffs {
+		text := strings.TrimSuffix(d.Text, "\n")
+		for _, line := range strings.Split(text, "\n") {
+			lines = append(lines, diffLine{op: d.Type, text: line})
+		}
+	}
+	return lines
+}
+
+// unifiedDiff computes the hunks of a unified diff between two strings, with the given number of unchanged lines of
+// context around each change. Changes that are separated by no more than twice that many lines share a hunk.
+func unifiedDiff(old, new string, context int) []apitype.PatchHunk {
+	lines := diffLines(old, new)
+
+	// Record the old and new line numbers of each line.
+	oldLines, newLines := make([]int, len(lines)), make([]int, len(lines))
+	oldLine, newLine := 1, 1
+	for i, l := range lines {
+		oldLines[i], newLines[i] = oldLine, newLine
+		if l.op != diffmatchpatch.DiffInsert {
+			oldLine++
+		}
+		if l.op != diffmatchpatch.DiffDelete {
+			newLine++
+		}
+	}
+
+	var hunks []apitype.PatchHunk
+	for i := 0; i < len(lines); {
+		if lines[i].op == diffmatchpatch.DiffEqual {
+			i++
+			continue
+		}
+
+		// Find the end of the last change that is close enough to this one to share its hunk.
+		end := i
+		for {
+			for end < len(lines) && lines[end].op != diffmatchpatch.DiffEqual {
+				end++
+			}
+			next := end
+			for next < len(lines) && lines[next].op == diffmatchpatch.DiffEqual {
+				next++
+			}
+			if next == len(lines) || next-end > 2*context {
+				break
+			}
+			end = next
+		}
+
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		stop := end + context
+		if stop > len(lines) {
+			stop = len(lines)
+		}
+
+		hunk := apitype.PatchHunk{OldStart: oldLines[start], NewStart: newLines[start]}
+		for _, l := range lines[start:stop] {
+			switch l.op {
+			case diffmatchpatch.DiffDelete:
+				hunk.OldLines++
+				hunk.Lines = append(hunk.Lines, "-"+l.text)
+			case diffmatchpatch.DiffInsert:
+				hunk.NewLines++
+				hunk.Lines = append(hunk.Lines, "+"+l.text)
+			default:
+				hunk.OldLines++
+				hunk.NewLines++
+				hunk.Lines = append(hunk.Lines, " "+l.text)
+			}
+		}
+		// As with diff -u, an empty range starts at the line before it.
+		if hunk.OldLines == 0 {
+			hunk.OldStart--
+		}
+		if hunk.NewLines == 0 {
+			hunk.NewStart--
+		}
+		hunks = append(hunks, hunk)
+
+		i = stop
+	}
+	return hunks
+}
+
+// newPropertyPatch returns a patch describing the changes between two string property values, if either spans multiple
+// lines or both hold JSON or YAML documents.
+func newPropertyPatch(old, new string) (apitype.PropertyPatch, bool) {
+	var p propertyPrinter
+	if oldValue, oldKind, ok := p.decodeValue(old); ok {
+		if newValue, newKind, ok := p.decodeValue(new); ok && oldKind == newKind {
+			// If the documents only differ in their formatting, fall back to a text diff.
+			if diff := oldValue.Diff(newValue, resource.IsInternalPropertyKey); diff != nil {
+				return apitype.PropertyPatch{Format: oldKind, Operations: jsonPatchOperations("", *diff)}, true
+			}
+		}
+	}
+
+	if !strings.ContainsRune(old, '\n') && !strings.ContainsRune(new, '\n') {
+		return apitype.PropertyPatch{}, false
+	}
+	return apitype.PropertyPatch{Format: "text", Hunks: unifiedDiff(old, new, diffContextLines)}, true
+}
+
+// jsonPatchOperations returns the JSON Patch operations that apply the given diff to the value at the given pointer.
+func jsonPatchOperations(pointer string, diff resource.ValueDiff) []apitype.PatchOperation {
+	var ops []apitype.PatchOperation
+	switch {
+	case diff.Object != nil:
+		o := diff.Object
+		for _, k := range o.ChangedKeys() {
+			path := pointer + "/" + escapeJSONPointer(string(k))
+			if add, isadd := o.Adds[k]; isadd {
+				ops = append(ops, apitype.PatchOperation{Op: "add", Path: path, Value: add.Mappable()})
+			} else if _, isdelete := o.Deletes[k]; isdelete {
+				ops = append(ops, apitype.PatchOperation{Op: "remove", Path: path})
+			} else {
+				ops = append(ops, jsonPatchOperations(path, o.Updates[k])...)
+			}
+		}
+	case diff.Array != nil:
+		// Elements are compared by index, so any additions or deletions are at the end of the array. Deletions are
+		// made from the last element backwards so that each pointer remains valid.
+		a := diff.Array
+		var deletes []apitype.PatchOperation
+		for i := 0; i < a.Len(); i++ {
+			path := pointer + "/" + strconv.Itoa(i)
+			if add, isadd := a.Adds[i]; isadd {
+				ops = append(ops, apitype.PatchOperation{Op: "add", Path: path, Value: add.Mappable()})
+			} else if _, isdelete := a.Deletes[i]; isdelete {
+				deletes = append([]apitype.PatchOperation{{Op: "remove", Path: path}}, deletes...)
+			} else if update, isupdate := a.Updates[i]; isupdate {
+				ops = append(ops, jsonPatchOperations(path, update)...)
+			}
+		}
+		ops = append(ops, deletes...)
+	default:
+		ops = append(ops, apitype.PatchOperation{Op: "replace", Path: pointer, Value: diff.New.Mappable()})
+	}
+	return ops
+}
+
+// escapeJSONPointer escapes a key for use as a JSON pointer reference token.
+func escapeJSONPointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// propertyPatches returns the patches for the changed multi-line and JSON or YAML string properties of a step, keyed by
+// property path. Secret values are never patched.
+func propertyPatches(step engine.StepEventMetadata) map[string]apitype.PropertyPatch {
+	if step.Old == nil || step.New == nil {
+		return nil
+	}
+
+	var diff *resource.ObjectDiff
+	if step.DetailedDiff != nil {
+		diff = engine.TranslateDetailedDiff(&step)
+	} else if step.Old.Inputs != nil && step.New.Inputs != nil {
+		diff = step.Old.Inputs.Diff(step.New.Inputs)
+	}
+	if diff == nil {
+		return nil
+	}
+
+	patches := map[string]apitype.PropertyPatch{}
+	for k, update := range diff.Updates {
+		addPropertyPatches(patches, resource.PropertyPath{string(k)}, update)
+	}
+	if len(patches) == 0 {
+		return nil
+	}
+	return patches
+}
+
+func addPropertyPatches(patches map[string]apitype.PropertyPatch, path resource.PropertyPath, diff resource.ValueDiff) {
+	child := func(key interface{}) resource.PropertyPath {
+		return append(append(resource.PropertyPath{}, path...), key)
+	}
+
+	switch {
+	case diff.Object != nil:
+		for k, update := range diff.Object.Updates {
+			addPropertyPatches(patches, child(string(k)), update)
+		}
+	case diff.Array != nil:
+		for i, update := range diff.Array.Updates {
+			addPropertyPatches(patches, child(i), update)
+		}
+	case diff.Old.IsString() && diff.New.IsString():
+		if patch, ok := newPropertyPatch(diff.Old.StringValue(), diff.New.StringValue()); ok {
+			patches[path.String()] = patch
+		}
+	}
+}
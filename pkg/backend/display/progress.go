@@ -116,6 +116,10 @@ type ProgressDisplay struct {
 	headerRow    Row
 	resourceRows []ResourceRow
 
+	// The labels of the annotations of changes to resources, in the order in which they were first seen. Each label
+	// is displayed as a column before the info column.
+	annotationLabels []string
+
 	// A mapping from each resource URN we are told about to its current status.
 	eventUrnToResourceRow map[resource.URN]ResourceRow
 
@@ -138,6 +142,9 @@ type ProgressDisplay struct {
 
 	// Structure that tracks the time taken to perform an action on a resource.
 	opStopwatch opStopwatch
+
+	// The resources whose diagnostics are shown, from opts.DiagnosticFilters.
+	diagnosticFilters deploy.UrnTargets
 }
 
 type opStopwatch struct {
@@ -173,6 +180,8 @@ func getEventUrnAndMetadata(event engine.Event) (resource.URN, *engine.StepEvent
 		return event.Payload().(engine.DiagEventPayload).URN, nil
 	case engine.PolicyViolationEvent:
 		return event.Payload().(engine.PolicyViolationEventPayload).ResourceURN, nil
+	case engine.ResourceAnnotationEvent:
+		return event.Payload().(engine.ResourceAnnotationEventPayload).ResourceURN, nil
 	default:
 		return "", nil
 	}
@@ -231,6 +240,7 @@ func ShowProgressEvents(op string, action apitype.UpdateKind, stack tokens.Name,
 		urnToID:               make(map[resource.URN]string),
 		displayOrderCounter:   1,
 		opStopwatch:           newOpStopwatch(),
+		diagnosticFilters:     deploy.NewUrnTargets(opts.DiagnosticFilters),
 	}
 
 	ticker := time.NewTicker(1 * time.Second)
@@ -496,9 +506,14 @@ func (display *ProgressDisplay) printDiagnostics() bool {
 				payloads = []engine.DiagEventPayload{p}
 			}
 
+			var counts []int
+			if display.opts.CollapseDiagnostics {
+				payloads, counts = collapseDiagnostics(payloads)
+			}
+
 			// Did we write any diagnostic information for the resource x stream?
 			wrote := false
-			for _, v := range payloads {
+			for i, v := range payloads {
 				if v.Ephemeral {
 					continue
 				}
@@ -509,6 +524,9 @@ func (display *ProgressDisplay) printDiagnostics() bool {
 				if len(lines) == 0 {
 					continue
 				}
+				if counts != nil && counts[i] > 1 {
+					lines[len(lines)-1] += fmt.Sprintf(" (repeated %d times)", counts[i])
+				}
 
 				// If we haven't printed the Diagnostics header, do so now.
 				if !wroteDiagnosticHeader {
@@ -540,6 +558,79 @@ func (display *ProgressDisplay) printDiagnostics() bool {
 	return wroteDiagnosticHeader
 }
 
+// collapseDiagnostics collapses identical diagnostics into the first of them, returning the remaining diagnostics
+// and the number of times that each was reported.
+func collapseDiagnostics(payloads []engine.DiagEventPayload) ([]engine.DiagEventPayload, []int) {
+	type diagKey struct {
+		severity        diag.Severity
+		prefix, message string
+		ephemeral       bool
+	}
+
+	var collapsed []engine.DiagEventPayload
+	var counts []int
+	indices := map[diagKey]int{}
+	for _, p := range payloads {
+		key := diagKey{p.Severity, p.Prefix, p.Message, p.Ephemeral}
+		if i, has := indices[key]; has {
+			counts[i]++
+			continue
+		}
+		indices[key] = len(collapsed)
+		collapsed = append(collapsed, p)
+		counts = append(counts, 1)
+	}
+	return collapsed, counts
+}
+
+// diagnosticSeverityLevels orders the severities of diagnostics from the least to the most severe.
+var diagnosticSeverityLevels = map[diag.Severity]int{
+	diag.Debug:   0,
+	diag.Info:    1,
+	diag.Infoerr: 1,
+	diag.Warning: 2,
+	diag.Error:   3,
+}
+
+// shouldShowDiagnostic returns true if a diagnostic is at least as severe as the least severe diagnostic that the
+// display shows, and was reported by one of the resources whose diagnostics the display shows.
+func (display *ProgressDisplay) shouldShowDiagnostic(payload engine.DiagEventPayload) bool {
+	if diagnosticSeverityLevels[payload.Severity] < diagnosticSeverityLevels[display.opts.DiagnosticSeverity] {
+		return false
+	}
+	if !display.diagnosticFilters.IsConstrained() {
+		return true
+	}
+
+	// Diagnostics that aren't associated with a resource are associated with the stack.
+	urn := payload.URN
+	if urn == "" {
+		urn = display.stackUrn
+	}
+	if urn == "" {
+		return false
+	}
+	// Types are matched as if they were URNs, so that filters may name a type rather than spelling out a glob of the
+	// URNs of its resources.
+	return display.diagnosticFilters.Contains(urn) || display.diagnosticFilters.Contains(resource.URN(urn.Type()))
+}
+
+// streamError shows an error reported by a resource as a system message, so that it is seen as soon as it is
+// reported rather than only in the diagnostics at the end of the update.
+func (display *ProgressDisplay) streamError(row ResourceRow, payload engine.DiagEventPayload) {
+	lines := splitIntoDisplayableLines(display.renderProgressDiagEvent(payload, true /*includePrefix:*/))
+	if len(lines) == 0 {
+		return
+	}
+
+	columns := row.ColorizedColumns()
+	header := colors.BrightBlue + columns[typeColumn] + " (" + columns[nameColumn] + "):" + colors.Reset
+	display.handleSystemEvent(engine.StdoutEventPayload{
+		Message: header + "\n  " + strings.Join(lines, "\n  ") + "\n",
+		Color:   display.opts.Color,
+	})
+}
+
 // printPolicyViolations prints a new "Policy Violation:" section with all of the violations
 // grouped by policy pack. If no policy violations were encountered, prints nothing.
 func (display *ProgressDisplay) printPolicyViolations() bool {
@@ -815,6 +906,8 @@ func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
 	// registration, so if we don't show the row, the violation gets attributed to the stack
 	// resource rather than the resources whose policy failed.
 	hideRowIfUnnecessary = hideRowIfUnnecessary || event.Type == engine.PolicyViolationEvent
+	// Annotations are made before the resource is changed, so leave it to the step that follows to show the row.
+	hideRowIfUnnecessary = hideRowIfUnnecessary || event.Type == engine.ResourceAnnotationEvent
 	if !hideRowIfUnnecessary {
 		row.SetHideRowIfUnnecessary(false)
 	}
@@ -858,9 +951,21 @@ func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
 	} else if event.Type == engine.DiagEvent {
 		// also record this diagnostic so we print it at the end.
 		row.RecordDiagEvent(event)
+
+		// If requested, show errors straight away rather than waiting for the end of the update.
+		payload := event.Payload().(engine.DiagEventPayload)
+		if display.opts.StreamErrors && payload.Severity == diag.Error && !payload.Ephemeral {
+			display.streamError(row, payload)
+		}
 	} else if event.Type == engine.PolicyViolationEvent {
 		// also record this policy violation so we print it at the end.
 		row.RecordPolicyViolationEvent(event)
+	} else if event.Type == engine.ResourceAnnotationEvent {
+		display.recordAnnotationLabel(event.Payload().(engine.ResourceAnnotationEventPayload).Label)
+		row.RecordResourceAnnotationEvent(event)
+
+		// The annotation will be displayed along with the step that follows it.
+		return
 	} else {
 		contract.Failf("Unhandled event type '%s'", event.Type)
 	}
@@ -868,6 +973,31 @@ func (display *ProgressDisplay) processNormalEvent(event engine.Event) {
 	display.renderer.rowUpdated(display, row)
 }
 
+// recordAnnotationLabel adds a column for annotations with the given label, if there isn't one already.
+func (display *ProgressDisplay) recordAnnotationLabel(label string) {
+	for _, l := range display.annotationLabels {
+		if l == label {
+			return
+		}
+	}
+	display.annotationLabels = append(display.annotationLabels, label)
+}
+
+// insertAnnotationColumns inserts a column for each annotation label before the info column of a row, using the given
+// function to render the value of each column.
+func (display *ProgressDisplay) insertAnnotationColumns(columns []string, value func(label string) string) []string {
+	if len(display.annotationLabels) == 0 {
+		return columns
+	}
+
+	result := make([]string, 0, len(columns)+len(display.annotationLabels))
+	result = append(result, columns[:infoColumn]...)
+	for _, label := range display.annotationLabels {
+		result = append(result, value(label))
+	}
+	return append(result, columns[infoColumn:]...)
+}
+
 func (display *ProgressDisplay) handleSystemEvent(payload engine.StdoutEventPayload) {
 	// Make sure we have a header to display
 	display.ensureHeaderAndStackRows()
@@ -922,6 +1052,10 @@ func (display *ProgressDisplay) processEvents(ticker *time.Ticker, events <-chan
 				return
 			}
 
+			if event.Type == engine.DiagEvent && !display.shouldShowDiagnostic(event.Payload().(engine.DiagEventPayload)) {
+				continue
+			}
+
 			display.processNormalEvent(event)
 		}
 	}
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/pulumi/pulumi/pkg/v3/backend/display/internal/terminal"
 	"github.com/pulumi/pulumi/pkg/v3/engine"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/display"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
@@ -191,3 +193,169 @@ func TestStatusDisplayFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestProgressDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	site := markdownTestStep(deploy.OpCreate, "site", nil, resource.PropertyMap{})
+	logs := markdownTestStep(deploy.OpCreate, "logs", nil, resource.PropertyMap{})
+	siteURN := site.Payload().(engine.ResourcePreEventPayload).Metadata.URN
+	logsURN := logs.Payload().(engine.ResourcePreEventPayload).Metadata.URN
+	diagEvent := func(urn resource.URN, severity diag.Severity, message string) engine.Event {
+		return engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{
+			URN:      urn,
+			Prefix:   string(severity) + ": ",
+			Message:  message,
+			Severity: severity,
+		})
+	}
+	events := []engine.Event{
+		site,
+		logs,
+		diagEvent(siteURN, diag.Info, "waiting for the bucket to be created"),
+		diagEvent(siteURN, diag.Info, "waiting for the bucket to be created"),
+		diagEvent(siteURN, diag.Info, "waiting for the bucket to be created"),
+		diagEvent(siteURN, diag.Warning, "the bucket is public"),
+		diagEvent(logsURN, diag.Error, "creating failed: access denied"),
+		engine.NewEvent(engine.ResourceOperationFailed, engine.ResourceOperationFailedPayload{
+			Metadata: logs.Payload().(engine.ResourcePreEventPayload).Metadata,
+		}),
+		diagEvent(siteURN, diag.Info, "the bucket was created"),
+		engine.NewEvent(engine.CancelEvent, nil),
+	}
+
+	show := func(opts Options) string {
+		var stdout bytes.Buffer
+		opts.Color = colors.Never
+		opts.Stdout = &stdout
+		opts.deterministicOutput = true
+
+		eventChannel, doneChannel := make(chan engine.Event), make(chan bool)
+		go ShowProgressEvents("test", "update", "stack", "project", "", eventChannel, doneChannel, opts, false)
+		for _, e := range events {
+			eventChannel <- e
+		}
+		<-doneChannel
+
+		// Only return the diagnostics that were printed at the end of the update.
+		out := stdout.String()
+		if i := strings.Index(out, "Diagnostics:"); i != -1 {
+			return out[i:]
+		}
+		return ""
+	}
+
+	t.Run("severity", func(t *testing.T) {
+		t.Parallel()
+
+		out := show(Options{DiagnosticSeverity: diag.Warning})
+		assert.Contains(t, out, "warning: the bucket is public")
+		assert.Contains(t, out, "error: creating failed: access denied")
+		assert.NotContains(t, out, "info:")
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		t.Parallel()
+
+		out := show(Options{DiagnosticFilters: []string{"**::logs"}})
+		assert.Contains(t, out, "error: creating failed: access denied")
+		assert.NotContains(t, out, "the bucket")
+
+		out = show(Options{DiagnosticFilters: []string{"aws:s3/bucket:Bucket"}})
+		assert.Contains(t, out, "error: creating failed: access denied")
+		assert.Contains(t, out, "warning: the bucket is public")
+
+		out = show(Options{DiagnosticFilters: []string{"aws:ec2/**"}})
+		assert.NotContains(t, out, "error:")
+	})
+
+	t.Run("collapse", func(t *testing.T) {
+		t.Parallel()
+
+		out := show(Options{})
+		assert.Equal(t, 3, strings.Count(out, "info: waiting for the bucket to be created\n"))
+
+		out = show(Options{CollapseDiagnostics: true})
+		assert.Equal(t, 1, strings.Count(out, "info: waiting for the bucket to be created"))
+		assert.Contains(t, out, "info: waiting for the bucket to be created (repeated 3 times)\n")
+		assert.Contains(t, out, "info: the bucket was created\n")
+	})
+
+	t.Run("stream errors", func(t *testing.T) {
+		t.Parallel()
+
+		var stdout bytes.Buffer
+		eventChannel, doneChannel := make(chan engine.Event), make(chan bool)
+		go ShowProgressEvents("test", "update", "stack", "project", "", eventChannel, doneChannel, Options{
+			Color:               colors.Never,
+			Stdout:              &stdout,
+			StreamErrors:        true,
+			deterministicOutput: true,
+		}, false)
+		for _, e := range events {
+			eventChannel <- e
+		}
+		<-doneChannel
+
+		// The error is shown as soon as it is reported, before the diagnostics that follow it.
+		out := stdout.String()
+		streamed := strings.Index(out, "aws:s3:Bucket (logs):\n  error: creating failed: access denied\n")
+		require.NotEqual(t, -1, streamed)
+		assert.Less(t, streamed, strings.Index(out, "info: the bucket was created"))
+
+		// The error is also shown with the other diagnostics.
+		assert.Contains(t, out[strings.Index(out, "Diagnostics:"):], "error: creating failed: access denied")
+	})
+}
+
+func TestProgressAnnotations(t *testing.T) {
+	t.Parallel()
+
+	site := markdownTestStep(deploy.OpCreate, "site", nil, resource.PropertyMap{})
+	logs := markdownTestStep(deploy.OpCreate, "logs", nil, resource.PropertyMap{})
+	annotation := func(step engine.Event, label string, number *float64, text string) engine.Event {
+		return engine.NewEvent(engine.ResourceAnnotationEvent, engine.ResourceAnnotationEventPayload{
+			ResourceURN: step.Payload().(engine.ResourcePreEventPayload).Metadata.URN,
+			Label:       label,
+			Number:      number,
+			Text:        text,
+			Unit:        "USD",
+		})
+	}
+	siteCost, logsCost := 12.5, 0.333
+	events := []engine.Event{
+		annotation(site, "Monthly cost", &siteCost, ""),
+		site,
+		annotation(logs, "Monthly cost", &logsCost, ""),
+		annotation(logs, "Complexity", nil, "high"),
+		logs,
+		engine.NewEvent(engine.SummaryEvent, engine.SummaryEventPayload{
+			IsPreview:        true,
+			ResourceChanges:  display.ResourceChanges{deploy.OpCreate: 2},
+			AnnotationTotals: []engine.AnnotationTotal{{Label: "Monthly cost", Unit: "USD", Total: 12.833}},
+		}),
+		engine.NewEvent(engine.CancelEvent, nil),
+	}
+
+	var stdout bytes.Buffer
+	eventChannel, doneChannel := make(chan engine.Event), make(chan bool)
+	go ShowProgressEvents("test", "preview", "stack", "project", "", eventChannel, doneChannel, Options{
+		IsInteractive:       true,
+		Color:               colors.Never,
+		Stdout:              &stdout,
+		term:                terminal.NewMockTerminal(&stdout, 200, 20, true),
+		deterministicOutput: true,
+	}, true)
+	for _, e := range events {
+		eventChannel <- e
+	}
+	<-doneChannel
+
+	out := stdout.String()
+	assert.Contains(t, out, "Monthly cost")
+	assert.Contains(t, out, "Complexity")
+	assert.Contains(t, out, "+12.5 USD")
+	assert.Contains(t, out, "+0.33 USD")
+	assert.Contains(t, out, "high")
+	assert.Contains(t, out, "    Monthly cost: +12.83 USD\n")
+}
@@ -0,0 +1,416 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/version"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/display"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// reportResource records the outcome of the steps for a single resource.
+type reportResource struct {
+	urn    resource.URN
+	typ    tokens.Type
+	op     display.StepOp
+	failed bool
+	errors []string
+}
+
+// updateReport accumulates the resource outcomes and policy violations of an update, to be written as a test report
+// that CI systems can render.
+type updateReport struct {
+	update     eventSinkUpdate
+	resources  []*reportResource
+	byURN      map[resource.URN]*reportResource
+	violations []engine.PolicyViolationEventPayload
+}
+
+func newUpdateReport(update eventSinkUpdate) *updateReport {
+	return &updateReport{update: update, byURN: map[resource.URN]*reportResource{}}
+}
+
+func (r *updateReport) resource(urn resource.URN) *reportResource {
+	res, ok := r.byURN[urn]
+	if !ok {
+		res = &reportResource{urn: urn, typ: urn.Type(), op: deploy.OpSame}
+		r.byURN[urn] = res
+		r.resources = append(r.resources, res)
+	}
+	return res
+}
+
+// record adds an engine event to the report.
+func (r *updateReport) record(e engine.Event) {
+	switch e.Type {
+	case engine.ResourcePreEvent:
+		step := e.Payload().(engine.ResourcePreEventPayload).Metadata
+		_, seen := r.byURN[step.URN]
+		res := r.resource(step.URN)
+		// The steps of a replacement share a URN, so the resource is reported as replaced rather than as the first of
+		// them.
+		if !seen || step.Op == deploy.OpReplace {
+			res.op = step.Op
+		}
+	case engine.ResourceOperationFailed:
+		r.resource(e.Payload().(engine.ResourceOperationFailedPayload).Metadata.URN).failed = true
+	case engine.DiagEvent:
+		p := e.Payload().(engine.DiagEventPayload)
+		if p.Severity == diag.Error && p.URN != "" && !p.Ephemeral {
+			res := r.resource(p.URN)
+			res.failed = true
+			res.errors = append(res.errors, strings.TrimSpace(colors.Never.Colorize(p.Prefix+p.Message)))
+		}
+	case engine.PolicyViolationEvent:
+		p := e.Payload().(engine.PolicyViolationEventPayload)
+		p.Message = strings.TrimSpace(colors.Never.Colorize(p.Message))
+		r.violations = append(r.violations, p)
+	}
+}
+
+func (r *updateReport) name() string {
+	kind := string(r.update.kind)
+	if r.update.preview {
+		kind = "preview"
+	}
+	return fmt.Sprintf("pulumi %s %s/%s", kind, r.update.project, r.update.stack)
+}
+
+// policyName returns the name of a policy qualified by its policy pack.
+func policyName(v engine.PolicyViolationEventPayload) string {
+	return v.PolicyPackName + "/" + v.PolicyName
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string          `xml:"name,attr"`
+	ClassName  string          `xml:"classname,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Failure    *junitFailure   `xml:"failure,omitempty"`
+	SystemOut  string          `xml:"system-out,omitempty"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (s *junitTestSuite) add(c junitTestCase) {
+	s.Tests++
+	if c.Failure != nil {
+		s.Failures++
+	}
+	s.Cases = append(s.Cases, c)
+}
+
+// writeJUnit writes the report as JUnit XML, with a test case for each resource and each policy violation. Resources
+// fail if any of their steps failed, and policy violations fail if they are mandatory.
+func (r *updateReport) writeJUnit(w io.Writer) error {
+	resources := junitTestSuite{Name: "resources"}
+	for _, res := range r.resources {
+		c := junitTestCase{
+			Name:      string(res.urn),
+			ClassName: string(res.typ),
+			Properties: []junitProperty{
+				{Name: "urn", Value: string(res.urn)},
+				{Name: "operation", Value: string(res.op)},
+			},
+		}
+		if res.failed {
+			message := fmt.Sprintf("%s of %s failed", res.op, res.urn)
+			if len(res.errors) > 0 {
+				message = strings.SplitN(res.errors[0], "\n", 2)[0]
+			}
+			c.Failure = &junitFailure{Message: message, Type: "error", Text: strings.Join(res.errors, "\n")}
+		}
+		resources.add(c)
+	}
+
+	policies := junitTestSuite{Name: "policies"}
+	for _, v := range r.violations {
+		c := junitTestCase{
+			Name:      fmt.Sprintf("%s: %s", v.PolicyName, v.ResourceURN),
+			ClassName: v.PolicyPackName,
+			Properties: []junitProperty{
+				{Name: "severity", Value: string(v.EnforcementLevel)},
+				{Name: "policyPack", Value: v.PolicyPackName},
+				{Name: "policyPackVersion", Value: v.PolicyPackVersion},
+				{Name: "urn", Value: string(v.ResourceURN)},
+			},
+		}
+		if v.EnforcementLevel == apitype.Mandatory {
+			c.Failure = &junitFailure{
+				Message: strings.SplitN(v.Message, "\n", 2)[0],
+				Type:    string(v.EnforcementLevel),
+				Text:    v.Message,
+			}
+		} else {
+			c.SystemOut = v.Message
+		}
+		policies.add(c)
+	}
+
+	suites := junitTestSuites{
+		Name:     r.name(),
+		Tests:    resources.Tests + policies.Tests,
+		Failures: resources.Failures + policies.Failures,
+		Suites:   []junitTestSuite{resources, policies},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+
+	// sarifResourceRule is the ID of the rule that the results for resources are reported against.
+	sarifResourceRule = "pulumi/resource-operation"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string                 `json:"id"`
+	Name             string                 `json:"name"`
+	ShortDescription sarifMessage           `json:"shortDescription"`
+	Properties       map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string                 `json:"ruleId"`
+	RuleIndex  int                    `json:"ruleIndex"`
+	Kind       string                 `json:"kind"`
+	Level      string                 `json:"level"`
+	Message    sarifMessage           `json:"message"`
+	Locations  []sarifLocation        `json:"locations"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+func sarifResourceLocations(urn resource.URN) []sarifLocation {
+	return []sarifLocation{{LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: string(urn), Kind: "resource"}}}}
+}
+
+// writeSARIF writes the report as a SARIF log, with a result for each resource and each policy violation. Resources
+// that succeeded are reported as passing, and each policy is a rule.
+func (r *updateReport) writeSARIF(w io.Writer) error {
+	rules := []sarifRule{{
+		ID:               sarifResourceRule,
+		Name:             "ResourceOperation",
+		ShortDescription: sarifMessage{Text: "The operations on a resource must succeed."},
+	}}
+	ruleIndices := map[string]int{}
+
+	results := []sarifResult{}
+	for _, res := range r.resources {
+		result := sarifResult{
+			RuleID:     sarifResourceRule,
+			Kind:       "pass",
+			Level:      "none",
+			Message:    sarifMessage{Text: fmt.Sprintf("%s of %s succeeded", res.op, res.urn)},
+			Locations:  sarifResourceLocations(res.urn),
+			Properties: map[string]interface{}{"operation": string(res.op)},
+		}
+		if res.failed {
+			result.Kind, result.Level = "fail", "error"
+			result.Message.Text = fmt.Sprintf("%s of %s failed", res.op, res.urn)
+			if len(res.errors) > 0 {
+				result.Message.Text = strings.Join(res.errors, "\n")
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, v := range r.violations {
+		id := policyName(v)
+		index, ok := ruleIndices[id]
+		if !ok {
+			index = len(rules)
+			ruleIndices[id] = index
+			rules = append(rules, sarifRule{
+				ID:               id,
+				Name:             v.PolicyName,
+				ShortDescription: sarifMessage{Text: fmt.Sprintf("Policy %s of policy pack %s", v.PolicyName, v.PolicyPackName)},
+				Properties: map[string]interface{}{
+					"policyPack":        v.PolicyPackName,
+					"policyPackVersion": v.PolicyPackVersion,
+				},
+			})
+		}
+
+		level := "warning"
+		if v.EnforcementLevel == apitype.Mandatory {
+			level = "error"
+		}
+		results = append(results, sarifResult{
+			RuleID:    id,
+			RuleIndex: index,
+			Kind:      "fail",
+			Level:     level,
+			Message:   sarifMessage{Text: v.Message},
+			Locations: sarifResourceLocations(v.ResourceURN),
+			Properties: map[string]interface{}{
+				"severity":          string(v.EnforcementLevel),
+				"policyPack":        v.PolicyPackName,
+				"policyPackVersion": v.PolicyPackVersion,
+			},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "pulumi",
+				Version:        version.Version,
+				InformationURI: "https://www.pulumi.com",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+func writeReportFile(path string, write func(w io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		contract.IgnoreClose(f)
+		return err
+	}
+	return f.Close()
+}
+
+// startReports records the events of an update for the JUnit and SARIF reports requested in the options, and passes
+// every event through to the returned channel. The reports are written once the display is done.
+func startReports(events <-chan engine.Event, done chan<- bool, opts Options,
+	update eventSinkUpdate,
+) (<-chan engine.Event, chan<- bool) {
+	report := newUpdateReport(update)
+
+	outEvents, outDone := make(chan engine.Event), make(chan bool)
+	go func() {
+		defer close(done)
+
+		for e := range events {
+			report.record(e)
+
+			outEvents <- e
+
+			if e.Type == engine.CancelEvent {
+				break
+			}
+		}
+
+		<-outDone
+
+		write := func(kind, path string, write func(w io.Writer) error) {
+			if path == "" {
+				return
+			}
+			if err := writeReportFile(path, write); err != nil {
+				cmdutil.Diag().Warningf(diag.RawMessage("", fmt.Sprintf("could not write %s report: %v", kind, err)))
+			}
+		}
+		write("JUnit", opts.ReportJUnitPath, report.writeJUnit)
+		write("SARIF", opts.ReportSARIFPath, report.writeSARIF)
+	}()
+
+	return outEvents, outDone
+}
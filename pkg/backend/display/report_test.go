@@ -0,0 +1,206 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+func reportTestEvents() ([]engine.Event, resource.URN, resource.URN) {
+	site := markdownTestStep(deploy.OpCreate, "site", nil, resource.PropertyMap{
+		"acl": resource.NewStringProperty("public-read"),
+	})
+	logs := markdownTestStep(deploy.OpUpdate, "logs", resource.PropertyMap{
+		"acl": resource.NewStringProperty("private"),
+	}, resource.PropertyMap{
+		"acl": resource.NewStringProperty("log-delivery-write"),
+	})
+	siteURN := site.Payload().(engine.ResourcePreEventPayload).Metadata.URN
+	logsURN := logs.Payload().(engine.ResourcePreEventPayload).Metadata.URN
+
+	return []engine.Event{
+		engine.NewEvent(engine.PreludeEvent, engine.PreludeEventPayload{Config: map[string]string{}}),
+		site,
+		engine.NewEvent(engine.PolicyViolationEvent, engine.PolicyViolationEventPayload{
+			ResourceURN:       siteURN,
+			Message:           "<{%fg 1%}>Buckets must not be public.<{%reset%}>\n",
+			PolicyName:        "s3-no-public-read",
+			PolicyPackName:    "aws-security",
+			PolicyPackVersion: "1.2.0",
+			EnforcementLevel:  apitype.Mandatory,
+		}),
+		engine.NewEvent(engine.PolicyViolationEvent, engine.PolicyViolationEventPayload{
+			ResourceURN:       siteURN,
+			Message:           "Buckets should be tagged.",
+			PolicyName:        "s3-tags",
+			PolicyPackName:    "aws-security",
+			PolicyPackVersion: "1.2.0",
+			EnforcementLevel:  apitype.Advisory,
+		}),
+		logs,
+		engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{
+			URN:      logsURN,
+			Message:  "updating failed: access denied\n",
+			Severity: diag.Error,
+		}),
+		engine.NewEvent(engine.ResourceOperationFailed, engine.ResourceOperationFailedPayload{
+			Metadata: logs.Payload().(engine.ResourcePreEventPayload).Metadata,
+		}),
+		engine.NewEvent(engine.CancelEvent, nil),
+	}, siteURN, logsURN
+}
+
+func TestReports(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	opts := Options{
+		ReportJUnitPath: filepath.Join(dir, "report.xml"),
+		ReportSARIFPath: filepath.Join(dir, "report.sarif"),
+	}
+
+	events, done := make(chan engine.Event), make(chan bool)
+	out, outDone := startReports(events, done, opts, eventSinkUpdate{
+		project: "proj",
+		stack:   "dev",
+		kind:    apitype.UpdateUpdate,
+	})
+	go func() {
+		for e := range out {
+			if e.Type == engine.CancelEvent {
+				close(outDone)
+				return
+			}
+		}
+	}()
+	testEvents, siteURN, logsURN := reportTestEvents()
+	for _, e := range testEvents {
+		events <- e
+	}
+	select {
+	case <-done:
+	case <-time.After(30 * time.Second):
+		t.Fatal("timed out waiting for the reports")
+	}
+
+	t.Run("junit", func(t *testing.T) {
+		t.Parallel()
+
+		b, err := os.ReadFile(opts.ReportJUnitPath)
+		require.NoError(t, err)
+
+		var suites junitTestSuites
+		require.NoError(t, xml.Unmarshal(b, &suites))
+		assert.Equal(t, "pulumi update proj/dev", suites.Name)
+		assert.Equal(t, 4, suites.Tests)
+		assert.Equal(t, 2, suites.Failures)
+		require.Len(t, suites.Suites, 2)
+
+		resources := suites.Suites[0]
+		assert.Equal(t, "resources", resources.Name)
+		require.Len(t, resources.Cases, 2)
+		assert.Equal(t, string(siteURN), resources.Cases[0].Name)
+		assert.Equal(t, "aws:s3/bucket:Bucket", resources.Cases[0].ClassName)
+		assert.Contains(t, resources.Cases[0].Properties, junitProperty{Name: "operation", Value: "create"})
+		assert.Nil(t, resources.Cases[0].Failure)
+		assert.Equal(t, &junitFailure{
+			Message: "updating failed: access denied",
+			Type:    "error",
+			Text:    "updating failed: access denied",
+		}, resources.Cases[1].Failure)
+
+		policies := suites.Suites[1]
+		assert.Equal(t, "policies", policies.Name)
+		assert.Equal(t, 2, policies.Tests)
+		assert.Equal(t, 1, policies.Failures)
+		mandatory := policies.Cases[0]
+		assert.Equal(t, "s3-no-public-read: "+string(siteURN), mandatory.Name)
+		assert.Equal(t, "aws-security", mandatory.ClassName)
+		assert.Equal(t, []junitProperty{
+			{Name: "severity", Value: "mandatory"},
+			{Name: "policyPack", Value: "aws-security"},
+			{Name: "policyPackVersion", Value: "1.2.0"},
+			{Name: "urn", Value: string(siteURN)},
+		}, mandatory.Properties)
+		assert.Equal(t, &junitFailure{
+			Message: "Buckets must not be public.",
+			Type:    "mandatory",
+			Text:    "Buckets must not be public.",
+		}, mandatory.Failure)
+		assert.Nil(t, policies.Cases[1].Failure)
+		assert.Equal(t, "Buckets should be tagged.", policies.Cases[1].SystemOut)
+	})
+
+	t.Run("sarif", func(t *testing.T) {
+		t.Parallel()
+
+		b, err := os.ReadFile(opts.ReportSARIFPath)
+		require.NoError(t, err)
+
+		var log sarifLog
+		require.NoError(t, json.Unmarshal(b, &log))
+		assert.Equal(t, "2.1.0", log.Version)
+		require.Len(t, log.Runs, 1)
+
+		run := log.Runs[0]
+		assert.Equal(t, "pulumi", run.Tool.Driver.Name)
+		var rules []string
+		for _, r := range run.Tool.Driver.Rules {
+			rules = append(rules, r.ID)
+		}
+		assert.Equal(t, []string{sarifResourceRule, "aws-security/s3-no-public-read", "aws-security/s3-tags"}, rules)
+
+		type result struct {
+			rule, kind, level, message string
+			urn                        resource.URN
+		}
+		var results []result
+		for _, r := range run.Results {
+			require.Equal(t, rules[r.RuleIndex], r.RuleID)
+			results = append(results, result{
+				rule:    r.RuleID,
+				kind:    r.Kind,
+				level:   r.Level,
+				message: r.Message.Text,
+				urn:     resource.URN(r.Locations[0].LogicalLocations[0].FullyQualifiedName),
+			})
+		}
+		assert.Equal(t, []result{
+			{sarifResourceRule, "pass", "none", "create of " + string(siteURN) + " succeeded", siteURN},
+			{sarifResourceRule, "fail", "error", "updating failed: access denied", logsURN},
+			{"aws-security/s3-no-public-read", "fail", "error", "Buckets must not be public.", siteURN},
+			{"aws-security/s3-tags", "fail", "warning", "Buckets should be tagged.", siteURN},
+		}, results)
+		assert.Equal(t, map[string]interface{}{
+			"severity":          "mandatory",
+			"policyPack":        "aws-security",
+			"policyPackVersion": "1.2.0",
+		}, run.Results[2].Properties)
+	})
+}
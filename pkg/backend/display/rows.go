@@ -62,6 +62,7 @@ type ResourceRow interface {
 
 	RecordDiagEvent(diagEvent engine.Event)
 	RecordPolicyViolationEvent(diagEvent engine.Event)
+	RecordResourceAnnotationEvent(annotationEvent engine.Event)
 }
 
 // Implementation of a Row, used for the header of the grid.
@@ -87,7 +88,8 @@ func (data *headerRowData) SetDisplayOrderIndex(time int) {
 }
 
 func (data *headerRowData) ColorizedColumns() []string {
-	if len(data.columns) == 0 {
+	// Rebuild the columns if an annotation with a new label has been seen since they were last built.
+	if len(data.columns) != int(infoColumn)+1+len(data.display.annotationLabels) {
 		header := func(msg string) string {
 			return columnHeader(msg)
 		}
@@ -99,6 +101,7 @@ func (data *headerRowData) ColorizedColumns() []string {
 			statusColumn = header("Status")
 		}
 		data.columns = []string{"", header("Type"), header("Name"), statusColumn, header("Info")}
+		data.columns = data.display.insertAnnotationColumns(data.columns, header)
 	}
 
 	return data.columns
@@ -128,6 +131,9 @@ type resourceRowData struct {
 	diagInfo       *DiagInfo
 	policyPayloads []engine.PolicyViolationEventPayload
 
+	// The rendered values of the annotations of the change to the resource, by their label.
+	annotations map[string][]string
+
 	// If this row should be hidden by default.  We will hide unless we have any child nodes
 	// we need to show.
 	hideRowIfUnnecessary bool
@@ -229,6 +235,20 @@ func (data *resourceRowData) RecordPolicyViolationEvent(event engine.Event) {
 	data.policyPayloads = append(data.policyPayloads, pePayload)
 }
 
+// RecordResourceAnnotationEvent records an annotation of the change to the resource with the resourceRowData.
+func (data *resourceRowData) RecordResourceAnnotationEvent(event engine.Event) {
+	payload := event.Payload().(engine.ResourceAnnotationEventPayload)
+
+	value := payload.Text
+	if payload.Number != nil {
+		value = formatAnnotationNumber(*payload.Number, payload.Unit)
+	}
+	if data.annotations == nil {
+		data.annotations = make(map[string][]string)
+	}
+	data.annotations[payload.Label] = append(data.annotations[payload.Label], value)
+}
+
 type column int
 
 const (
@@ -309,7 +329,9 @@ func (data *resourceRowData) ColorizedColumns() []string {
 
 	columns[statusColumn] = data.display.getStepStatus(step, done, failed)
 	columns[infoColumn] = data.getInfoColumn()
-	return columns
+	return data.display.insertAnnotationColumns(columns, func(label string) string {
+		return strings.Join(data.annotations[label], ", ")
+	})
 }
 
 // addRetainStatusFlag adds a "[retain]" suffix to the input string if the resource is marked as
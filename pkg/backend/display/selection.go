@@ -0,0 +1,574 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend/display/internal/terminal"
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/display"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// ErrSelectionCancelled is returned by SelectChanges if the user cancelled the selection.
+var ErrSelectionCancelled = errors.New("selection cancelled")
+
+// selectionFilters are the operation filters that the change selector cycles through. The empty filter shows all
+// changes.
+var selectionFilters = []display.StepOp{"", deploy.OpCreate, deploy.OpUpdate, deploy.OpReplace, deploy.OpDelete}
+
+// SelectChanges shows the changes of a preview in a full-screen resource tree, in which the user can expand the
+// property diff of each resource and mark each change to include or skip. It returns the URNs of the resources whose
+// changes were selected, along with the resources that depend on them, in tree order. The changes of resources that
+// depend on a selected resource are always included, as they may be affected by its change.
+func SelectChanges(events []engine.Event, opts Options) ([]resource.URN, error) {
+	term := opts.term
+	if term == nil {
+		stdin := opts.Stdin
+		if stdin == nil {
+			stdin = os.Stdin
+		}
+		stdout := opts.Stdout
+		if stdout == nil {
+			stdout = os.Stdout
+		}
+
+		raw := runtime.GOOS != "windows"
+		t, err := terminal.Open(stdin, stdout, raw)
+		if err != nil {
+			return nil, fmt.Errorf("selecting changes requires an interactive terminal: %w", err)
+		}
+		defer contract.IgnoreClose(t)
+		term = t
+	}
+	if !term.IsRaw() {
+		return nil, errors.New("selecting changes requires an interactive terminal")
+	}
+
+	s := newChangeSelector(events, term, opts)
+	if len(s.nodes) == 0 {
+		return nil, nil
+	}
+
+	term.HideCursor()
+	defer term.ShowCursor()
+	return s.run()
+}
+
+// selectorNode is a resource row of the change selector.
+type selectorNode struct {
+	urn        resource.URN
+	op         display.StepOp
+	row        string        // the rendered row of the resource tree
+	parent     *selectorNode // the parent row, if any
+	diff       []string      // the lines of the resource's property diff
+	changeable bool          // true if the resource has a change that can be selected
+}
+
+// changeSelector is the state of the full-screen change selector.
+type changeSelector struct {
+	opts Options
+	term terminal.Terminal
+
+	header string
+	nodes  []*selectorNode
+
+	// dependents maps each resource to the resources that depend on it.
+	dependents map[resource.URN][]resource.URN
+
+	selected map[resource.URN]bool
+	expanded map[resource.URN]bool
+	filter   int // the index of the current filter in selectionFilters
+	cursor   int // the index of the node under the cursor in the visible nodes
+	offset   int // the scroll offset into the lines of the body
+	status   string
+	rewind   int // the number of lines to rewind to redraw the display
+}
+
+// nopProgressRenderer is a progress renderer that renders nothing. The change selector uses it to build the resource
+// tree of a ProgressDisplay, which it renders itself.
+type nopProgressRenderer struct{}
+
+func (nopProgressRenderer) Close() error                                              { return nil }
+func (nopProgressRenderer) tick(*ProgressDisplay)                                     {}
+func (nopProgressRenderer) rowUpdated(*ProgressDisplay, Row)                          {}
+func (nopProgressRenderer) systemMessage(*ProgressDisplay, engine.StdoutEventPayload) {}
+func (nopProgressRenderer) done(*ProgressDisplay)                                     {}
+func (nopProgressRenderer) println(*ProgressDisplay, string)                          {}
+
+func newChangeSelector(events []engine.Event, term terminal.Terminal, opts Options) *changeSelector {
+	// Build the resource tree of the preview in the same way as the progress display does.
+	pd := &ProgressDisplay{
+		action:                apitype.UpdateUpdate,
+		isPreview:             true,
+		isTerminal:            true,
+		opts:                  opts,
+		renderer:              nopProgressRenderer{},
+		eventUrnToResourceRow: make(map[resource.URN]ResourceRow),
+		suffixColumn:          int(statusColumn),
+		suffixesArray:         []string{""},
+		urnToID:               make(map[resource.URN]string),
+		displayOrderCounter:   1,
+		opStopwatch:           newOpStopwatch(),
+	}
+
+	s := &changeSelector{
+		opts:       opts,
+		term:       term,
+		dependents: make(map[resource.URN][]resource.URN),
+		selected:   make(map[resource.URN]bool),
+		expanded:   make(map[resource.URN]bool),
+	}
+
+	diffs := make(map[resource.URN][]string)
+	for _, e := range events {
+		switch e.Type {
+		case engine.ResourcePreEvent:
+			p := e.Payload().(engine.ResourcePreEventPayload)
+			s.addDependencies(p.Metadata)
+			if shouldShow(p.Metadata, opts) {
+				details := getDiffDetails(p.Metadata, 0 /*indent*/, p.Planning, p.Debug, opts)
+				diffs[p.Metadata.URN] = splitIntoDisplayableLines(strings.TrimRight(details, "\n"))
+			}
+		case engine.ResourceOutputsEvent, engine.ResourceOperationFailed, engine.DiagEvent,
+			engine.PolicyViolationEvent, engine.ResourceAnnotationEvent:
+		default:
+			continue
+		}
+		pd.processNormalEvent(e)
+	}
+	if pd.headerRow == nil {
+		return s
+	}
+	pd.done = true
+
+	// Order the rows of the tree by the order in which the engine reported the resources.
+	for i, row := range pd.resourceRows {
+		row.SetDisplayOrderIndex(i + 1)
+	}
+
+	rootNodes := pd.generateTreeNodes()
+	rootNodes = pd.filterOutUnnecessaryNodesAndSetDisplayTimes(rootNodes)
+	sortNodes(rootNodes)
+	pd.addIndentations(rootNodes, true /*isRoot*/, "")
+
+	var rows [][]string
+	var maxColumnLengths []int
+	pd.convertNodesToRows(rootNodes, 0 /*maxSuffixLength*/, &rows, &maxColumnLengths)
+	removeInfoColumnIfUnneeded(rows)
+
+	// The rows are in the same order as a pre-order traversal of the tree, starting with the header.
+	s.header = renderRow(rows[0], maxColumnLengths)
+	var walk func(nodes []*treeNode, parent *selectorNode)
+	walk = func(nodes []*treeNode, parent *selectorNode) {
+		for _, node := range nodes {
+			row, ok := node.row.(ResourceRow)
+			if !ok {
+				walk(node.childNodes, parent)
+				continue
+			}
+			step := row.Step()
+			op := pd.getStepOp(step)
+			n := &selectorNode{
+				urn:        step.URN,
+				op:         op,
+				row:        renderRow(rows[len(s.nodes)+1], maxColumnLengths),
+				parent:     parent,
+				diff:       diffs[step.URN],
+				changeable: isSelectableOp(op) && !isRootURN(step.URN),
+			}
+			s.nodes = append(s.nodes, n)
+			if n.changeable {
+				s.selected[n.urn] = true
+			}
+			walk(node.childNodes, n)
+		}
+	}
+	walk(rootNodes, nil)
+
+	return s
+}
+
+// isSelectableOp returns true if a change with the given operation can be selected.
+func isSelectableOp(op display.StepOp) bool {
+	switch op {
+	case deploy.OpSame, deploy.OpRead, deploy.OpReadDiscard, deploy.OpReadReplacement, deploy.OpRefresh:
+		return false
+	default:
+		return true
+	}
+}
+
+// matchesFilter returns true if a change with the given operation is shown by the given filter.
+func matchesFilter(op, filter display.StepOp) bool {
+	switch filter {
+	case "":
+		return true
+	case deploy.OpReplace:
+		return op == deploy.OpReplace || op == deploy.OpCreateReplacement || op == deploy.OpDeleteReplaced
+	case deploy.OpDelete:
+		return op == deploy.OpDelete || op == deploy.OpDiscardReplaced
+	default:
+		return op == filter
+	}
+}
+
+// addDependencies records the resources that the resource of a step depends on.
+func (s *changeSelector) addDependencies(step engine.StepEventMetadata) {
+	state := step.New
+	if state == nil {
+		state = step.Old
+	}
+	if state == nil {
+		return
+	}
+
+	var deps []resource.URN
+	if state.Parent != "" {
+		deps = append(deps, state.Parent)
+	}
+	if state.Provider != "" {
+		if ref, err := providers.ParseReference(state.Provider); err == nil {
+			deps = append(deps, ref.URN())
+		}
+	}
+	if state.State != nil {
+		deps = append(deps, state.State.Dependencies...)
+		for _, propDeps := range state.State.PropertyDependencies {
+			deps = append(deps, propDeps...)
+		}
+		if state.State.DeletedWith != "" {
+			deps = append(deps, state.State.DeletedWith)
+		}
+	}
+
+	for _, dep := range deps {
+		s.dependents[dep] = append(s.dependents[dep], step.URN)
+	}
+}
+
+// included returns the resources whose changes will be applied: the selected resources and their dependents.
+func (s *changeSelector) included() map[resource.URN]bool {
+	included := make(map[resource.URN]bool)
+	var include func(urn resource.URN)
+	include = func(urn resource.URN) {
+		if included[urn] {
+			return
+		}
+		included[urn] = true
+		for _, dependent := range s.dependents[urn] {
+			include(dependent)
+		}
+	}
+	for urn, selected := range s.selected {
+		if selected {
+			include(urn)
+		}
+	}
+	return included
+}
+
+// targets returns the URNs of the changed resources whose changes will be applied, in tree order.
+func (s *changeSelector) targets() []resource.URN {
+	included := s.included()
+	targets := []resource.URN{}
+	for _, n := range s.nodes {
+		if n.changeable && included[n.urn] {
+			targets = append(targets, n.urn)
+		}
+	}
+	return targets
+}
+
+// visibleNodes returns the nodes that match the current filter, along with their ancestors.
+func (s *changeSelector) visibleNodes() []*selectorNode {
+	filter := selectionFilters[s.filter]
+	if filter == "" {
+		return s.nodes
+	}
+
+	visible := make(map[*selectorNode]bool)
+	for _, n := range s.nodes {
+		if n.changeable && matchesFilter(n.op, filter) {
+			for p := n; p != nil && !visible[p]; p = p.parent {
+				visible[p] = true
+			}
+		}
+	}
+	var nodes []*selectorNode
+	for _, n := range s.nodes {
+		if visible[n] {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// run handles keypresses until the user applies or cancels the selection.
+func (s *changeSelector) run() ([]resource.URN, error) {
+	for {
+		s.frame(false)
+
+		key, err := s.term.ReadKey()
+		if err != nil {
+			s.frame(true)
+			if errors.Is(err, io.EOF) {
+				return nil, ErrSelectionCancelled
+			}
+			return nil, err
+		}
+
+		nodes := s.visibleNodes()
+		var current *selectorNode
+		if s.cursor < len(nodes) {
+			current = nodes[s.cursor]
+		}
+
+		s.status = ""
+		switch key {
+		case terminal.KeyUp, "k":
+			if s.cursor > 0 {
+				s.cursor--
+			}
+		case terminal.KeyDown, "j":
+			if s.cursor < len(nodes)-1 {
+				s.cursor++
+			}
+		case terminal.KeyPageUp:
+			s.cursor -= s.pageSize()
+			if s.cursor < 0 {
+				s.cursor = 0
+			}
+		case terminal.KeyPageDown:
+			s.cursor += s.pageSize()
+			if s.cursor > len(nodes)-1 {
+				s.cursor = len(nodes) - 1
+			}
+		case " ":
+			s.toggle(current)
+		case terminal.KeyEnter, terminal.KeyRight, terminal.KeyLeft, "l", "h":
+			if current != nil && len(current.diff) > 0 {
+				s.expanded[current.urn] = !s.expanded[current.urn]
+			}
+		case "a":
+			for _, n := range nodes {
+				if n.changeable {
+					s.selected[n.urn] = true
+				}
+			}
+		case "n":
+			for _, n := range nodes {
+				s.selected[n.urn] = false
+			}
+		case "f":
+			s.filter = (s.filter + 1) % len(selectionFilters)
+			s.cursor, s.offset = 0, 0
+			if len(s.visibleNodes()) == 0 {
+				s.status = fmt.Sprintf("no %s changes", selectionFilters[s.filter])
+			}
+		case "y":
+			targets := s.targets()
+			if len(targets) == 0 {
+				s.status = "no changes are selected"
+				continue
+			}
+			s.frame(true)
+			return targets, nil
+		case "q", terminal.KeyCtrlC:
+			s.frame(true)
+			return nil, ErrSelectionCancelled
+		}
+	}
+}
+
+// toggle selects or deselects the change of a node.
+func (s *changeSelector) toggle(n *selectorNode) {
+	if n == nil || !n.changeable {
+		return
+	}
+	s.selected[n.urn] = !s.selected[n.urn]
+	if !s.selected[n.urn] && s.included()[n.urn] {
+		s.status = fmt.Sprintf("%s is still included because it depends on a selected resource", n.urn.Name())
+	}
+}
+
+// pageSize returns the number of body lines that fit on the screen.
+func (s *changeSelector) pageSize() int {
+	_, height, err := s.term.Size()
+	contract.IgnoreError(err)
+
+	// Leave room for the title, the tree header, the footer and the final newline.
+	if size := height - 4; size > 0 {
+		return size
+	}
+	return 1
+}
+
+// lines returns the lines of the display, and the index of the line of the node under the cursor in the body.
+func (s *changeSelector) lines() (title, header string, body []string, cursorLine int, footer string) {
+	nodes := s.visibleNodes()
+	if s.cursor >= len(nodes) {
+		s.cursor = len(nodes) - 1
+	}
+	if s.cursor < 0 {
+		s.cursor = 0
+	}
+
+	filter := "all"
+	if f := selectionFilters[s.filter]; f != "" {
+		filter = string(f)
+	}
+	title = colors.SpecHeadline + "Select the changes to apply" + colors.Reset +
+		colors.SpecUnimportant + fmt.Sprintf(" (showing %s changes)", filter) + colors.Reset
+
+	header = "      " + s.header
+
+	included := s.included()
+	for i, n := range nodes {
+		var mark string
+		switch {
+		case !n.changeable:
+			mark = "   "
+		case s.selected[n.urn]:
+			mark = "[x]"
+		case included[n.urn]:
+			mark = "[+]"
+		default:
+			mark = "[ ]"
+		}
+
+		cursor := "  "
+		if i == s.cursor {
+			cursor = colors.BrightGreen + "> " + colors.Reset
+			cursorLine = len(body)
+		}
+
+		expand := " "
+		if len(n.diff) > 0 {
+			if s.expanded[n.urn] {
+				expand = "▾"
+			} else {
+				expand = "▸"
+			}
+		}
+
+		row := n.row
+		if n.changeable && !included[n.urn] {
+			row = colors.SpecUnimportant + colors.Never.Colorize(row) + colors.Reset
+		}
+		body = append(body, cursor+mark+expand+row)
+
+		if s.expanded[n.urn] {
+			for _, line := range n.diff {
+				body = append(body, "          "+line)
+			}
+		}
+	}
+
+	targets := s.targets()
+	changes := 0
+	for _, n := range s.nodes {
+		if n.changeable {
+			changes++
+		}
+	}
+	footer = fmt.Sprintf("%d of %s selected", len(targets), english.Plural(changes, "change", "")) +
+		colors.SpecUnimportant +
+		"  ↑↓ move  space toggle  enter diff  a all  n none  f filter  y apply  q cancel" + colors.Reset
+	if s.status != "" {
+		footer = colors.SpecWarning + s.status + colors.Reset
+	}
+	return title, header, body, cursorLine, footer
+}
+
+// frame redraws the display. If done is true, the display is cleared instead.
+func (s *changeSelector) frame(done bool) {
+	width, height, err := s.term.Size()
+	contract.IgnoreError(err)
+
+	var lines []string
+	if !done {
+		title, header, body, cursorLine, footer := s.lines()
+
+		// Scroll the body so that the line under the cursor is visible.
+		pageSize := s.pageSize()
+		if cursorLine < s.offset {
+			s.offset = cursorLine
+		} else if cursorLine >= s.offset+pageSize {
+			s.offset = cursorLine - pageSize + 1
+		}
+		if max := len(body) - pageSize; s.offset > max {
+			s.offset = max
+		}
+		if s.offset < 0 {
+			s.offset = 0
+		}
+		end := s.offset + pageSize
+		if end > len(body) {
+			end = len(body)
+		}
+
+		lines = append(lines, title, header)
+		lines = append(lines, body[s.offset:end]...)
+		lines = append(lines, footer)
+		if len(lines) > height-1 && height > 1 {
+			lines = lines[:height-1]
+		}
+	}
+
+	// Re-home the cursor, clearing any lines that we won't overwrite.
+	s.print("\r")
+	for ; s.rewind > 0; s.rewind-- {
+		if s.rewind > len(lines)-1 {
+			s.term.ClearEnd()
+		}
+		s.term.CursorUp(1)
+	}
+	if done {
+		s.term.ClearEnd()
+		return
+	}
+	s.rewind = len(lines) - 1
+
+	for i, line := range lines {
+		maxWidth := width - 1
+		if maxWidth < 0 {
+			maxWidth = 0
+		}
+		s.print(colors.TrimColorizedString(line, maxWidth))
+		s.term.ClearEnd()
+		if i < len(lines)-1 {
+			s.print("\n")
+		}
+	}
+}
+
+func (s *changeSelector) print(text string) {
+	_, err := s.term.Write([]byte(s.opts.Color.Colorize(text)))
+	contract.IgnoreError(err)
+}
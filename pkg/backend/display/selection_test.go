@@ -0,0 +1,180 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend/display/internal/terminal"
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/display"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+func selectionTestEvents() ([]engine.Event, []resource.URN) {
+	stack := resource.NewURN("dev", "proj", "", resource.RootStackType, "proj-dev")
+	bucket := resource.NewURN("dev", "proj", "", "aws:s3/bucket:Bucket", "bucket")
+	object := resource.NewURN("dev", "proj", "", "aws:s3/bucketObject:BucketObject", "index")
+	queue := resource.NewURN("dev", "proj", "", "aws:sqs/queue:Queue", "queue")
+
+	step := func(op display.StepOp, urn resource.URN, deps []resource.URN,
+		old, new resource.PropertyMap,
+	) engine.Event {
+		m := engine.StepEventMetadata{Op: op, URN: urn, Type: urn.Type(), Logical: true}
+		if old != nil {
+			m.Old = &engine.StepEventStateMetadata{URN: urn, Type: urn.Type(), Parent: stack, Inputs: old}
+		}
+		if new != nil {
+			m.New = &engine.StepEventStateMetadata{
+				URN: urn, Type: urn.Type(), Parent: stack, Inputs: new,
+				State: &resource.State{URN: urn, Type: urn.Type(), Dependencies: deps},
+			}
+		}
+		if old != nil && new != nil {
+			m.Diffs = old.Diff(new).ChangedKeys()
+		}
+		m.Res = m.New
+		if m.Res == nil {
+			m.Res = m.Old
+		}
+		return engine.NewEvent(engine.ResourcePreEvent, engine.ResourcePreEventPayload{Metadata: m, Planning: true})
+	}
+
+	events := []engine.Event{
+		step(deploy.OpSame, stack, nil, resource.PropertyMap{}, resource.PropertyMap{}),
+		step(deploy.OpCreate, bucket, nil, nil, resource.PropertyMap{
+			"acl": resource.NewStringProperty("private"),
+		}),
+		step(deploy.OpCreate, object, []resource.URN{bucket}, nil, resource.PropertyMap{
+			"key": resource.NewStringProperty("index.html"),
+		}),
+		step(deploy.OpUpdate, queue, nil, resource.PropertyMap{
+			"delay": resource.NewNumberProperty(0),
+		}, resource.PropertyMap{
+			"delay": resource.NewNumberProperty(5),
+		}),
+	}
+	return events, []resource.URN{bucket, object, queue}
+}
+
+func runSelection(t *testing.T, keys ...string) ([]resource.URN, error, string) {
+	events, _ := selectionTestEvents()
+
+	var stdout bytes.Buffer
+	term := terminal.NewMockTerminal(&stdout, 120, 40, true)
+	type selection struct {
+		urns []resource.URN
+		err  error
+	}
+	result := make(chan selection)
+	go func() {
+		urns, err := SelectChanges(events, Options{Color: colors.Never, term: term})
+		result <- selection{urns, err}
+	}()
+	for _, key := range keys {
+		term.SendKey(key)
+	}
+	r := <-result
+	return r.urns, r.err, stdout.String()
+}
+
+func TestSelectChanges(t *testing.T) {
+	t.Parallel()
+
+	_, urns := selectionTestEvents()
+	bucket, object, queue := urns[0], urns[1], urns[2]
+
+	t.Run("all", func(t *testing.T) {
+		t.Parallel()
+
+		selected, err, out := runSelection(t, "y")
+		assert.NoError(t, err)
+		assert.Equal(t, []resource.URN{bucket, object, queue}, selected)
+		assert.Contains(t, out, "3 of 3 changes selected")
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		t.Parallel()
+
+		selected, err, out := runSelection(t, terminal.KeyDown, terminal.KeyDown, terminal.KeyDown, " ", "y")
+		assert.NoError(t, err)
+		assert.Equal(t, []resource.URN{bucket, object}, selected)
+		assert.Contains(t, out, "2 of 3 changes selected")
+	})
+
+	t.Run("dependents", func(t *testing.T) {
+		t.Parallel()
+
+		// The object depends on the bucket, so it stays included while the bucket is selected.
+		selected, err, out := runSelection(t, "j", "j", " ", "y")
+		assert.NoError(t, err)
+		assert.Equal(t, []resource.URN{bucket, object, queue}, selected)
+		assert.Contains(t, out, "index is still included because it depends on a selected resource")
+
+		// Skipping the bucket only skips the bucket.
+		selected, err, _ = runSelection(t, "j", " ", "y")
+		assert.NoError(t, err)
+		assert.Equal(t, []resource.URN{object, queue}, selected)
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		t.Parallel()
+
+		// Show only creates and skip them all.
+		selected, err, out := runSelection(t, "f", "n", "y")
+		assert.NoError(t, err)
+		assert.Equal(t, []resource.URN{queue}, selected)
+		assert.Contains(t, out, "(showing create changes)")
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		t.Parallel()
+
+		_, err, out := runSelection(t, "j", terminal.KeyEnter, "q")
+		assert.ErrorIs(t, err, ErrSelectionCancelled)
+		assert.Contains(t, out, `acl: "private"`)
+	})
+
+	t.Run("nothing selected", func(t *testing.T) {
+		t.Parallel()
+
+		selected, err, out := runSelection(t, "n", "y", "a", "y")
+		assert.NoError(t, err)
+		assert.Equal(t, []resource.URN{bucket, object, queue}, selected)
+		assert.Contains(t, out, "no changes are selected")
+	})
+}
+
+func TestSelectChangesDependents(t *testing.T) {
+	t.Parallel()
+
+	urn := func(name string) resource.URN {
+		return resource.NewURN("dev", "proj", "", "test:index:Resource", tokens.QName(name))
+	}
+	s := &changeSelector{
+		dependents: map[resource.URN][]resource.URN{
+			urn("a"): {urn("b")},
+			urn("b"): {urn("c")},
+		},
+		selected: map[resource.URN]bool{urn("a"): true, urn("d"): false},
+	}
+	assert.Equal(t, map[resource.URN]bool{urn("a"): true, urn("b"): true, urn("c"): true}, s.included())
+}
@@ -0,0 +1,510 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+const (
+	defaultEventSinkBatchSize     = 100
+	defaultEventSinkFlushInterval = time.Second
+	defaultEventSinkRetries       = 3
+	eventSinkTimeout              = 10 * time.Second
+
+	// eventSinkQueueBatches is the number of batches of events that are queued for a sink before its events are
+	// dropped, so that a slow sink never holds up the update.
+	eventSinkQueueBatches = 10
+)
+
+// eventSinkRetryDelay is the delay before the first retry of a batch. It doubles with each retry.
+var eventSinkRetryDelay = 500 * time.Millisecond
+
+// eventSinkTypes are the types of events that event sinks can filter on, named after the fields of
+// apitype.EngineEvent.
+var eventSinkTypes = []string{
+	"cancelEvent",
+	"stdoutEvent",
+	"diagnosticEvent",
+	"preludeEvent",
+	"summaryEvent",
+	"resourcePreEvent",
+	"resOutputsEvent",
+	"resOpFailedEvent",
+	"policyEvent",
+	"resourceAnnotationEvent",
+}
+
+// engineEventType returns the type of an event as it is named in event sink filters.
+func engineEventType(e apitype.EngineEvent) string {
+	switch {
+	case e.CancelEvent != nil:
+		return "cancelEvent"
+	case e.StdoutEvent != nil:
+		return "stdoutEvent"
+	case e.DiagnosticEvent != nil:
+		return "diagnosticEvent"
+	case e.PreludeEvent != nil:
+		return "preludeEvent"
+	case e.SummaryEvent != nil:
+		return "summaryEvent"
+	case e.ResourcePreEvent != nil:
+		return "resourcePreEvent"
+	case e.ResOutputsEvent != nil:
+		return "resOutputsEvent"
+	case e.ResOpFailedEvent != nil:
+		return "resOpFailedEvent"
+	case e.PolicyEvent != nil:
+		return "policyEvent"
+	case e.ResourceAnnotationEvent != nil:
+		return "resourceAnnotationEvent"
+	default:
+		return ""
+	}
+}
+
+// ValidateEventSinks returns an error if any of the given event sinks is misconfigured, or can't be used with the
+// display of an update with the given options.
+func ValidateEventSinks(sinks []workspace.EventSink, opts Options, isPreview bool) error {
+	for _, s := range sinks {
+		if _, err := newDisplayEventSink(s, eventSinkUpdate{preview: isPreview}, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// displayWritesToStdout returns true if the display of an update with the given options writes to stdout while the
+// update runs. Displays that only write once the update is done are shown after the event sinks are flushed.
+func displayWritesToStdout(opts Options, isPreview bool) bool {
+	if opts.JSONDisplay {
+		return !isPreview || cmdutil.IsTruthy(os.Getenv("PULUMI_ENABLE_STREAMING_JSON_PREVIEW"))
+	}
+	return opts.Type != DisplayMarkdown && opts.Type != DisplaySummary
+}
+
+// newDisplayEventSink creates a sink for the display of an update with the given options. A sink can only write to
+// stdout if the display doesn't, since their output would otherwise be interleaved.
+func newDisplayEventSink(cfg workspace.EventSink, update eventSinkUpdate, opts Options) (*eventSink, error) {
+	s, err := newEventSink(cfg, update, opts.Stdout)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Stdout && displayWritesToStdout(opts, update.preview) {
+		return nil, fmt.Errorf("event sink %q writes to stdout, which is used by the display; use `--output json` "+
+			"to only write a summary once the update is done, or stream the events to a socket or webhook instead",
+			s.name)
+	}
+	return s, nil
+}
+
+// eventSinkUpdate describes the update whose events are sent to a sink.
+type eventSinkUpdate struct {
+	project tokens.PackageName
+	stack   tokens.Name
+	kind    apitype.UpdateKind
+	preview bool
+}
+
+// eventSinkTransport delivers batches of events to a sink's destination.
+type eventSinkTransport interface {
+	send(events []apitype.EngineEvent) error
+	Close() error
+}
+
+// eventSink batches the events of an update that pass its filter and delivers them from a background goroutine,
+// retrying batches that fail.
+type eventSink struct {
+	name          string
+	filter        map[string]bool
+	batchSize     int
+	flushInterval time.Duration
+	retries       int
+	transport     eventSinkTransport
+
+	events chan apitype.EngineEvent
+	done   chan struct{}
+
+	dropped    int   // the number of events that could not be delivered.
+	err        error // the last error that caused events to be dropped.
+	overflowed int   // the number of events that were dropped because the sink's queue was full.
+}
+
+// newEventSink creates a sink from its configuration. The sink's transport writes NDJSON to stdout, if it is
+// configured to use stdout.
+func newEventSink(cfg workspace.EventSink, update eventSinkUpdate, stdout io.Writer) (*eventSink, error) {
+	s := &eventSink{
+		name:          cfg.Name,
+		batchSize:     defaultEventSinkBatchSize,
+		flushInterval: defaultEventSinkFlushInterval,
+		retries:       defaultEventSinkRetries,
+	}
+
+	destinations := 0
+	switch {
+	case cfg.Webhook != "":
+		destinations++
+		u, err := url.Parse(os.ExpandEnv(cfg.Webhook))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("event sink %q: webhook must be an http or https URL", cfg.Webhook)
+		}
+		if s.name == "" {
+			// Don't name the sink after its full URL, which may contain a token.
+			s.name = "webhook " + u.Host
+		}
+		headers := make(http.Header)
+		for k, v := range cfg.Headers {
+			headers.Set(k, os.ExpandEnv(v))
+		}
+		headers.Set("Content-Type", "application/json")
+		headers.Set("X-Pulumi-Project", string(update.project))
+		headers.Set("X-Pulumi-Stack", string(update.stack))
+		headers.Set("X-Pulumi-Update-Kind", string(update.kind))
+		headers.Set("X-Pulumi-Preview", strconv.FormatBool(update.preview))
+		s.transport = &webhookTransport{
+			client:  &http.Client{Timeout: eventSinkTimeout},
+			url:     u.String(),
+			headers: headers,
+		}
+	}
+	if cfg.Socket != "" {
+		destinations++
+		if s.name == "" {
+			s.name = "socket " + cfg.Socket
+		}
+		s.transport = &socketTransport{path: cfg.Socket}
+	}
+	if cfg.Stdout {
+		destinations++
+		if s.name == "" {
+			s.name = "stdout"
+		}
+		if stdout == nil {
+			stdout = os.Stdout
+		}
+		s.transport = &ndjsonTransport{w: stdout}
+	}
+	if destinations != 1 {
+		return nil, errors.New("event sinks must set exactly one of webhook, socket and stdout")
+	}
+	if len(cfg.Headers) > 0 && cfg.Webhook == "" {
+		return nil, fmt.Errorf("event sink %q: headers are only supported by webhooks", s.name)
+	}
+
+	if len(cfg.Events) > 0 {
+		s.filter = make(map[string]bool)
+		for _, t := range cfg.Events {
+			if !contains(eventSinkTypes, t) {
+				return nil, fmt.Errorf("event sink %q: unknown event type %q; expected one of %s",
+					s.name, t, strings.Join(eventSinkTypes, ", "))
+			}
+			s.filter[t] = true
+		}
+	}
+	if cfg.BatchSize < 0 {
+		return nil, fmt.Errorf("event sink %q: batchSize must be positive", s.name)
+	} else if cfg.BatchSize > 0 {
+		s.batchSize = cfg.BatchSize
+	}
+	if cfg.FlushInterval != "" {
+		d, err := time.ParseDuration(cfg.FlushInterval)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("event sink %q: flushInterval must be a positive duration such as 500ms", s.name)
+		}
+		s.flushInterval = d
+	}
+	if cfg.Retries != nil {
+		if *cfg.Retries < 0 {
+			return nil, fmt.Errorf("event sink %q: retries must not be negative", s.name)
+		}
+		s.retries = *cfg.Retries
+	}
+
+	return s, nil
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *eventSink) accepts(e apitype.EngineEvent) bool {
+	return s.filter == nil || s.filter[engineEventType(e)]
+}
+
+func (s *eventSink) start() {
+	s.events = make(chan apitype.EngineEvent, s.batchSize*eventSinkQueueBatches)
+	s.done = make(chan struct{})
+	go s.run()
+}
+
+func (s *eventSink) run() {
+	defer close(s.done)
+	defer contract.IgnoreClose(s.transport)
+
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	var batch []apitype.EngineEvent
+	flush := func() {
+		if len(batch) > 0 {
+			s.deliver(batch)
+			batch = nil
+		}
+	}
+
+	for {
+		select {
+		case e, ok := <-s.events:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, e)
+			if len(batch) >= s.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// deliver sends a batch, retrying with exponential backoff. A batch that still fails is dropped.
+func (s *eventSink) deliver(batch []apitype.EngineEvent) {
+	delay := eventSinkRetryDelay
+	for attempt := 0; ; attempt++ {
+		err := s.transport.send(batch)
+		if err == nil {
+			return
+		}
+		logging.V(7).Infof("event sink %s: sending %d events (attempt %d): %v", s.name, len(batch), attempt+1, err)
+		if attempt >= s.retries {
+			s.dropped += len(batch)
+			s.err = err
+			return
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// webhookTransport posts each batch to an HTTP endpoint as an apitype.EngineEventBatch.
+type webhookTransport struct {
+	client  *http.Client
+	url     string
+	headers http.Header
+}
+
+func (t *webhookTransport) send(events []apitype.EngineEvent) error {
+	body, err := json.Marshal(apitype.EngineEventBatch{Events: events})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, t.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header = t.headers.Clone()
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		// The error includes the URL, which may contain a token.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return urlErr.Err
+		}
+		return err
+	}
+	defer contract.IgnoreClose(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %v", resp.Status)
+	}
+	return nil
+}
+
+func (t *webhookTransport) Close() error {
+	t.client.CloseIdleConnections()
+	return nil
+}
+
+// writeNDJSON writes events as newline-delimited JSON, in the same format as the event log.
+func writeNDJSON(w io.Writer, events []apitype.EngineEvent) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	for _, e := range events {
+		if err := encoder.Encode(e); err != nil {
+			return err
+		}
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// ndjsonTransport writes batches to a writer as newline-delimited JSON.
+type ndjsonTransport struct {
+	w io.Writer
+}
+
+func (t *ndjsonTransport) send(events []apitype.EngineEvent) error {
+	return writeNDJSON(t.w, events)
+}
+
+func (t *ndjsonTransport) Close() error {
+	return nil
+}
+
+// socketTransport writes batches to a Unix domain socket as newline-delimited JSON. The socket is connected when the
+// first batch is sent, and reconnected when a batch is retried after a failure.
+type socketTransport struct {
+	path string
+	conn net.Conn
+}
+
+func (t *socketTransport) send(events []apitype.EngineEvent) error {
+	if t.conn == nil {
+		conn, err := net.DialTimeout("unix", t.path, eventSinkTimeout)
+		if err != nil {
+			return err
+		}
+		t.conn = conn
+	}
+
+	contract.IgnoreError(t.conn.SetWriteDeadline(time.Now().Add(eventSinkTimeout)))
+	if err := writeNDJSON(t.conn, events); err != nil {
+		contract.IgnoreClose(t.conn)
+		t.conn = nil
+		return err
+	}
+	return nil
+}
+
+func (t *socketTransport) Close() error {
+	if t.conn == nil {
+		return nil
+	}
+	return t.conn.Close()
+}
+
+// startEventSinks streams the events of an update to the event sinks configured in the options, and passes every event
+// through to the returned channel. Events are converted to their JSON form without secrets, as for the event log.
+// Events are queued for each sink without blocking the update, and are dropped if a sink falls too far behind. The
+// sinks are flushed before the final cancellation event is passed on, so that they are done writing before the display
+// is, and a warning is printed for each sink that dropped events once the display is done.
+func startEventSinks(events <-chan engine.Event, done chan<- bool, opts Options,
+	update eventSinkUpdate,
+) (<-chan engine.Event, chan<- bool) {
+	var sinks []*eventSink
+	for _, cfg := range opts.EventSinks {
+		s, err := newDisplayEventSink(cfg, update, opts)
+		if err != nil {
+			// The sinks are validated before the update starts, so this is unexpected.
+			logging.V(7).Infof("could not create event sink: %v", err)
+			continue
+		}
+		s.start()
+		sinks = append(sinks, s)
+	}
+	if len(sinks) == 0 {
+		return events, done
+	}
+
+	outEvents, outDone := make(chan engine.Event), make(chan bool)
+	go func() {
+		defer close(done)
+
+		sequence := 0
+		var cancel *engine.Event
+		for e := range events {
+			apiEvent, err := convertLoggedEvent(e, opts, sequence)
+			if err != nil {
+				logging.V(7).Infof("failed to convert event for event sinks: %v", err)
+			} else {
+				for _, s := range sinks {
+					if s.accepts(apiEvent) {
+						select {
+						case s.events <- apiEvent:
+						default:
+							s.overflowed++
+						}
+					}
+				}
+			}
+			sequence++
+
+			if e.Type == engine.CancelEvent {
+				cancel = &e
+				break
+			}
+			outEvents <- e
+		}
+
+		var wg sync.WaitGroup
+		for _, s := range sinks {
+			close(s.events)
+			wg.Add(1)
+			go func(s *eventSink) {
+				defer wg.Done()
+				<-s.done
+			}(s)
+		}
+		wg.Wait()
+
+		if cancel != nil {
+			outEvents <- *cancel
+		}
+		<-outDone
+
+		// Report failures once the display is done, so that they aren't interleaved with it.
+		for _, s := range sinks {
+			if s.dropped > 0 {
+				cmdutil.Diag().Warningf(diag.RawMessage("", fmt.Sprintf(
+					"event sink %s dropped %d events: %v", s.name, s.dropped, s.err)))
+			}
+			if s.overflowed > 0 {
+				cmdutil.Diag().Warningf(diag.RawMessage("", fmt.Sprintf(
+					"event sink %s dropped %d events because it could not keep up with the update", s.name, s.overflowed)))
+			}
+		}
+	}()
+
+	return outEvents, outDone
+}
@@ -0,0 +1,365 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/display"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+func sinkTestEvents() []engine.Event {
+	return []engine.Event{
+		engine.NewEvent(engine.PreludeEvent, engine.PreludeEventPayload{Config: map[string]string{}}),
+		markdownTestStep(deploy.OpCreate, "site", nil, resource.PropertyMap{
+			"acl": resource.NewStringProperty("private"),
+		}),
+		engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{Message: "deprecated", Severity: diag.Warning}),
+		markdownTestStep(deploy.OpCreate, "logs", nil, resource.PropertyMap{
+			"password": resource.MakeSecret(resource.NewStringProperty("hunter2")),
+		}),
+		engine.NewEvent(engine.SummaryEvent, engine.SummaryEventPayload{
+			ResourceChanges: display.ResourceChanges{deploy.OpCreate: 2},
+		}),
+		engine.NewEvent(engine.CancelEvent, nil),
+	}
+}
+
+// runEventSinks streams the test events through the given sinks and waits for them to be flushed.
+func runEventSinks(t *testing.T, sinks []workspace.EventSink, opts Options) {
+	opts.EventSinks, opts.Color = sinks, colors.Never
+
+	events, done := make(chan engine.Event), make(chan bool)
+	out, outDone := startEventSinks(events, done, opts, eventSinkUpdate{
+		project: "proj",
+		stack:   "dev",
+		kind:    apitype.UpdateUpdate,
+	})
+	go func() {
+		for e := range out {
+			if e.Type == engine.CancelEvent {
+				close(outDone)
+				return
+			}
+		}
+	}()
+	for _, e := range sinkTestEvents() {
+		events <- e
+	}
+
+	select {
+	case <-done:
+	case <-time.After(30 * time.Second):
+		t.Fatal("timed out waiting for the event sinks")
+	}
+}
+
+func eventTypes(events []apitype.EngineEvent) []string {
+	var types []string
+	for _, e := range events {
+		types = append(types, engineEventType(e))
+	}
+	return types
+}
+
+func TestValidateEventSinks(t *testing.T) {
+	t.Parallel()
+
+	negative := -1
+	cases := []struct {
+		sink workspace.EventSink
+		err  string
+	}{
+		{workspace.EventSink{}, "event sinks must set exactly one of webhook, socket and stdout"},
+		{
+			workspace.EventSink{Socket: "/tmp/events.sock", Stdout: true},
+			"event sinks must set exactly one of webhook, socket and stdout",
+		},
+		{workspace.EventSink{Webhook: "ftp://example.com"}, `event sink "ftp://example.com": webhook must be`},
+		{workspace.EventSink{Stdout: true, Events: []string{"resourceEvent"}}, `unknown event type "resourceEvent"`},
+		{workspace.EventSink{Stdout: true, FlushInterval: "soon"}, "flushInterval must be a positive duration"},
+		{workspace.EventSink{Stdout: true, BatchSize: -1}, "batchSize must be positive"},
+		{workspace.EventSink{Stdout: true, Retries: &negative}, "retries must not be negative"},
+		{
+			workspace.EventSink{Socket: "/tmp/events.sock", Headers: map[string]string{"a": "b"}},
+			`event sink "socket /tmp/events.sock": headers are only supported by webhooks`,
+		},
+	}
+	for _, c := range cases {
+		err := ValidateEventSinks([]workspace.EventSink{c.sink}, Options{Type: DisplaySummary}, false)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), c.err)
+		}
+	}
+
+	assert.NoError(t, ValidateEventSinks([]workspace.EventSink{
+		{Webhook: "https://hooks.example.com/T000/B000/token", Events: []string{"summaryEvent"}},
+		{Socket: "/tmp/events.sock", BatchSize: 10, FlushInterval: "100ms"},
+		{Stdout: true},
+	}, Options{Type: DisplaySummary}, false))
+
+	// A webhook's default name doesn't include its path, which may contain a token.
+	s, err := newEventSink(workspace.EventSink{Webhook: "https://hooks.example.com/T000/B000/token"},
+		eventSinkUpdate{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "webhook hooks.example.com", s.name)
+}
+
+//nolint:paralleltest // sets environment variables
+func TestEventSinkWebhook(t *testing.T) {
+	t.Setenv("EVENT_SINK_TEST_TOKEN", "abc")
+
+	var m sync.Mutex
+	var batches [][]apitype.EngineEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer abc", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "dev", r.Header.Get("X-Pulumi-Stack"))
+		assert.Equal(t, "proj", r.Header.Get("X-Pulumi-Project"))
+		assert.Equal(t, "update", r.Header.Get("X-Pulumi-Update-Kind"))
+
+		var batch apitype.EngineEventBatch
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+		m.Lock()
+		batches = append(batches, batch.Events)
+		m.Unlock()
+	}))
+	defer server.Close()
+
+	runEventSinks(t, []workspace.EventSink{{
+		Webhook:   server.URL,
+		Headers:   map[string]string{"Authorization": "Bearer ${EVENT_SINK_TEST_TOKEN}"},
+		Events:    []string{"resourcePreEvent", "diagnosticEvent", "summaryEvent"},
+		BatchSize: 2,
+	}}, Options{})
+
+	var events []apitype.EngineEvent
+	for _, b := range batches {
+		assert.LessOrEqual(t, len(b), 2)
+		events = append(events, b...)
+	}
+	assert.Equal(t, []string{"resourcePreEvent", "diagnosticEvent", "resourcePreEvent", "summaryEvent"},
+		eventTypes(events))
+	for i, e := range events {
+		if i > 0 {
+			assert.Greater(t, e.Sequence, events[i-1].Sequence)
+		}
+	}
+
+	b, err := json.Marshal(batches)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "hunter2")
+}
+
+//nolint:paralleltest // changes the retry delay
+func TestEventSinkRetries(t *testing.T) {
+	delay := eventSinkRetryDelay
+	eventSinkRetryDelay = time.Millisecond
+	defer func() { eventSinkRetryDelay = delay }()
+
+	var m sync.Mutex
+	requests, received := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.Lock()
+		defer m.Unlock()
+		requests++
+		if requests%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var batch apitype.EngineEventBatch
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+		received += len(batch.Events)
+	}))
+	defer server.Close()
+
+	// Every batch fails once and then succeeds.
+	runEventSinks(t, []workspace.EventSink{{Webhook: server.URL, BatchSize: 100}}, Options{})
+	assert.Equal(t, len(sinkTestEvents()), received)
+
+	// Without retries, the failed batches are dropped.
+	none := 0
+	s, err := newEventSink(workspace.EventSink{Webhook: server.URL, Retries: &none}, eventSinkUpdate{}, nil)
+	require.NoError(t, err)
+	m.Lock()
+	requests = 0
+	m.Unlock()
+	s.deliver([]apitype.EngineEvent{{Sequence: 1}})
+	assert.Equal(t, 1, s.dropped)
+	assert.EqualError(t, s.err, "webhook returned 503 Service Unavailable")
+}
+
+func TestEventSinkOverflow(t *testing.T) {
+	t.Parallel()
+
+	// The webhook blocks until every event has been sent to the sinks.
+	release := make(chan struct{})
+	var m sync.Mutex
+	received := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		var batch apitype.EngineEventBatch
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+		m.Lock()
+		defer m.Unlock()
+		received += len(batch.Events)
+	}))
+	defer server.Close()
+
+	events, done := make(chan engine.Event), make(chan bool)
+	out, outDone := startEventSinks(events, done, Options{
+		Color:      colors.Never,
+		EventSinks: []workspace.EventSink{{Webhook: server.URL, BatchSize: 1}},
+	}, eventSinkUpdate{})
+	go func() {
+		for e := range out {
+			if e.Type == engine.CancelEvent {
+				close(outDone)
+				return
+			}
+		}
+	}()
+
+	// A slow sink doesn't hold up the update: the events that don't fit in its queue are dropped.
+	total := 5 * eventSinkQueueBatches
+	for i := 0; i < total-1; i++ {
+		events <- engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{Message: "event", Severity: diag.Info})
+	}
+	events <- engine.NewEvent(engine.CancelEvent, nil)
+	close(release)
+
+	select {
+	case <-done:
+	case <-time.After(30 * time.Second):
+		t.Fatal("timed out waiting for the event sinks")
+	}
+	m.Lock()
+	defer m.Unlock()
+	assert.Greater(t, received, 0)
+	assert.Less(t, received, total)
+}
+
+func TestEventSinkStdoutDisplays(t *testing.T) {
+	t.Parallel()
+
+	// Events can only be written to stdout if the display doesn't write to it while the update runs.
+	sinks := []workspace.EventSink{{Stdout: true}}
+	cases := []struct {
+		opts      Options
+		isPreview bool
+		ok        bool
+	}{
+		{Options{Type: DisplayProgress}, false, false},
+		{Options{Type: DisplayProgress, IsInteractive: true}, false, false},
+		{Options{Type: DisplayDiff}, true, false},
+		{Options{JSONDisplay: true}, false, false},
+		{Options{JSONDisplay: true}, true, true},
+		{Options{Type: DisplayMarkdown}, true, true},
+		{Options{Type: DisplaySummary}, false, true},
+	}
+	for _, c := range cases {
+		err := ValidateEventSinks(sinks, c.opts, c.isPreview)
+		if c.ok {
+			assert.NoError(t, err)
+		} else {
+			assert.ErrorContains(t, err, `event sink "stdout" writes to stdout, which is used by the display`)
+		}
+	}
+
+	// A non-interactive progress display writes to stdout, so the sink is skipped.
+	var stdout bytes.Buffer
+	runEventSinks(t, sinks, Options{Stdout: &stdout, Type: DisplayProgress})
+	assert.Empty(t, stdout.String())
+}
+
+func TestEventSinkSocket(t *testing.T) {
+	t.Parallel()
+
+	// Unix socket paths are limited to around 100 characters, which t.TempDir can exceed.
+	dir, err := os.MkdirTemp("", "sink")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.sock")
+
+	l, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer l.Close()
+
+	lines := make(chan []string, 1)
+	go func() {
+		conn, err := l.Accept()
+		if !assert.NoError(t, err) {
+			lines <- nil
+			return
+		}
+		defer conn.Close()
+
+		var ls []string
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			ls = append(ls, scanner.Text())
+		}
+		lines <- ls
+	}()
+
+	runEventSinks(t, []workspace.EventSink{{Socket: path, Events: []string{"preludeEvent", "cancelEvent"}}}, Options{})
+
+	var types []string
+	for _, line := range <-lines {
+		var e apitype.EngineEvent
+		require.NoError(t, json.Unmarshal([]byte(line), &e))
+		types = append(types, engineEventType(e))
+	}
+	assert.Equal(t, []string{"preludeEvent", "cancelEvent"}, types)
+}
+
+func TestEventSinkStdout(t *testing.T) {
+	t.Parallel()
+
+	var stdout bytes.Buffer
+	runEventSinks(t, []workspace.EventSink{{Stdout: true}}, Options{Stdout: &stdout, Type: DisplaySummary})
+
+	var events []apitype.EngineEvent
+	decoder := json.NewDecoder(&stdout)
+	for decoder.More() {
+		var e apitype.EngineEvent
+		require.NoError(t, decoder.Decode(&e))
+		events = append(events, e)
+	}
+	assert.Equal(t, []string{
+		"preludeEvent", "resourcePreEvent", "diagnosticEvent", "resourcePreEvent", "summaryEvent", "cancelEvent",
+	}, eventTypes(events))
+	assert.NotContains(t, stdout.String(), "hunter2")
+}
@@ -0,0 +1,75 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+)
+
+// SummaryErrors records the errors that are reported while an update is displayed as a summary. It is safe for
+// concurrent use.
+type SummaryErrors struct {
+	m      sync.Mutex
+	errors []apitype.OperationError
+}
+
+// Add records an error that wasn't reported by the update itself, e.g. the error that the update failed with.
+func (s *SummaryErrors) Add(err apitype.OperationError) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.errors = append(s.errors, err)
+}
+
+// Errors returns the errors that have been recorded, in the order in which they were reported.
+func (s *SummaryErrors) Errors() []apitype.OperationError {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return append([]apitype.OperationError(nil), s.errors...)
+}
+
+// ShowSummaryEvents reads events from the `events` channel until it is closed or a cancel event is received, without
+// displaying anything. The errors that are reported are recorded in opts.SummaryErrors, if it is set, so that they
+// can be included in a summary of the update once it completes.
+func ShowSummaryEvents(events <-chan engine.Event, done chan<- bool, opts Options) {
+	defer close(done)
+
+	for e := range events {
+		if e.Type == engine.CancelEvent {
+			return
+		}
+		if e.Type != engine.DiagEvent || opts.SummaryErrors == nil {
+			continue
+		}
+
+		payload := e.Payload().(engine.DiagEventPayload)
+		if payload.Severity != diag.Error || payload.Ephemeral {
+			continue
+		}
+		message := strings.TrimRightFunc(colors.Never.Colorize(payload.Message), unicode.IsSpace)
+		if message == "" {
+			continue
+		}
+		opts.SummaryErrors.Add(apitype.OperationError{URN: string(payload.URN), Message: message})
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package display
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+func TestShowSummaryEvents(t *testing.T) {
+	t.Parallel()
+
+	urn := resource.URN("urn:pulumi:dev::proj::aws:s3/bucket:Bucket::b")
+	diagEvent := func(urn resource.URN, severity diag.Severity, message string, ephemeral bool) engine.Event {
+		return engine.NewEvent(engine.DiagEvent, engine.DiagEventPayload{
+			URN:       urn,
+			Message:   message,
+			Severity:  severity,
+			Ephemeral: ephemeral,
+		})
+	}
+
+	events := make(chan engine.Event, 8)
+	events <- diagEvent(urn, diag.Info, "creating bucket\n", false)
+	events <- diagEvent(urn, diag.Error, colors.Red+"access denied"+colors.Reset+"\n", false)
+	events <- diagEvent(urn, diag.Error, "retrying\n", true)
+	events <- diagEvent("", diag.Error, "update failed\n", false)
+	events <- engine.NewEvent(engine.CancelEvent, nil)
+	close(events)
+
+	opts := Options{Type: DisplaySummary, SummaryErrors: &SummaryErrors{}}
+	done := make(chan bool)
+	go ShowSummaryEvents(events, done, opts)
+	<-done
+
+	assert.Equal(t, []apitype.OperationError{
+		{URN: string(urn), Message: "access denied"},
+		{Message: "update failed"},
+	}, opts.SummaryErrors.Errors())
+}
@@ -49,8 +49,8 @@ func ShowWatchEvents(op string, events <-chan engine.Event, done chan<- bool, op
 		case engine.PreludeEvent, engine.SummaryEvent, engine.StdoutColorEvent:
 			// Ignore it
 			continue
-		case engine.PolicyViolationEvent:
-			// At this point in time, we don't handle policy events as part of pulumi watch
+		case engine.PolicyViolationEvent, engine.ResourceAnnotationEvent:
+			// At this point in time, we don't handle policy or annotation events as part of pulumi watch
 			continue
 		case engine.DiagEvent:
 			// Skip any ephemeral or debug messages, and elide all colorization.
@@ -23,6 +23,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -87,6 +88,11 @@ var (
 	//
 	// This opt-out is intended to be removed in a future release.
 	PulumiFilestateLegacyLayoutEnvVar = env.SelfManagedStateLegacyLayout.Var().Name()
+
+	// PulumiFilestateRecordEventsEnvVar is an env var that must be truthy
+	// to store the engine events of each update alongside its history,
+	// which makes the events available to `pulumi stack history report`.
+	PulumiFilestateRecordEventsEnvVar = env.SelfManagedStateRecordEvents.Var().Name()
 )
 
 // Backend extends the base backend interface with specific information about local backends.
@@ -114,6 +120,9 @@ type localBackend struct {
 
 	gzip bool
 
+	// recordEvents is true if the engine events of each update are stored in the stack's history.
+	recordEvents bool
+
 	Getenv func(string) string // == os.Getenv
 
 	// The current project, if any.
@@ -276,13 +285,14 @@ func newLocalBackend(
 	bucket = nil // prevent accidental use of unwrapped bucket
 
 	backend := &localBackend{
-		d:           d,
-		originalURL: originalURL,
-		url:         u,
-		bucket:      wbucket,
-		lockID:      lockID.String(),
-		gzip:        gzipCompression,
-		Getenv:      opts.Getenv,
+		d:            d,
+		originalURL:  originalURL,
+		url:          u,
+		bucket:       wbucket,
+		lockID:       lockID.String(),
+		gzip:         gzipCompression,
+		recordEvents: cmdutil.IsTruthy(opts.Getenv(PulumiFilestateRecordEventsEnvVar)),
+		Getenv:       opts.Getenv,
 	}
 	backend.currentProject.Store(project)
 
@@ -899,7 +909,13 @@ func (b *localBackend) apply(
 	stackName := stackRef.FullyQualifiedName()
 	actionLabel := backend.ActionLabel(kind, opts.DryRun)
 
-	if !(op.Opts.Display.JSONDisplay || op.Opts.Display.Type == display.DisplayWatch) {
+	err = display.ValidateEventSinks(op.StackConfiguration.EventSinks, op.Opts.Display, opts.DryRun)
+	if err != nil {
+		return nil, nil, result.FromError(err)
+	}
+
+	if !(op.Opts.Display.JSONDisplay || op.Opts.Display.Type == display.DisplayWatch ||
+		op.Opts.Display.Type == display.DisplayMarkdown || op.Opts.Display.Type == display.DisplaySummary) {
 		// Print a banner so it's clear this is a local deployment.
 		fmt.Printf(op.Opts.Display.Color.Colorize(
 			colors.SpecHeadline+"%s (%s):"+colors.Reset+"\n"), actionLabel, stackRef)
@@ -911,23 +927,36 @@ func (b *localBackend) apply(
 		return nil, nil, result.FromError(err)
 	}
 
-	// Spawn a display loop to show events on the CLI.
+	// Spawn a display loop to show events on the CLI, which also streams them to the event sinks declared by the
+	// project and stack.
+	displayOpts := op.Opts.Display
+	displayOpts.EventSinks = op.StackConfiguration.EventSinks
 	displayEvents := make(chan engine.Event)
 	displayDone := make(chan bool)
 	go display.ShowEvents(
 		strings.ToLower(actionLabel), kind, stackName.Name(), op.Proj.Name, "",
-		displayEvents, displayDone, op.Opts.Display, opts.DryRun)
+		displayEvents, displayDone, displayOpts, opts.DryRun)
 
 	// Create a separate event channel for engine events that we'll pipe to both listening streams.
 	engineEvents := make(chan engine.Event)
 
 	scope := op.Scopes.NewScope(engineEvents, opts.DryRun)
 	eventsDone := make(chan bool)
+	var historyEvents []apitype.EngineEvent
 	go func() {
 		// Pull in all events from the engine and send them to the two listeners.
 		for e := range engineEvents {
 			displayEvents <- e
 
+			// Record the events of updates for their history, without secrets, if that is enabled.
+			if b.recordEvents && !opts.DryRun {
+				if apiEvent, err := display.ConvertEngineEvent(e, false /* showSecrets */); err == nil {
+					apiEvent.Sequence = len(historyEvents)
+					apiEvent.Timestamp = int(time.Now().Unix())
+					historyEvents = append(historyEvents, apiEvent)
+				}
+			}
+
 			// If the caller also wants to see the events, stream them there also.
 			if events != nil {
 				events <- e
@@ -1000,7 +1029,7 @@ func (b *localBackend) apply(
 	var saveErr error
 	var backupErr error
 	if !opts.DryRun {
-		saveErr = b.addToHistory(ctx, localStackRef, info)
+		saveErr = b.addToHistory(ctx, localStackRef, info, historyEvents)
 		backupErr = b.backupStack(ctx, localStackRef)
 	}
 
@@ -1019,7 +1048,8 @@ func (b *localBackend) apply(
 	}
 
 	// Make sure to print a link to the stack's checkpoint before exiting.
-	if !op.Opts.Display.SuppressPermalink && opts.ShowLink && !op.Opts.Display.JSONDisplay {
+	if !op.Opts.Display.SuppressPermalink && opts.ShowLink && !op.Opts.Display.JSONDisplay &&
+		op.Opts.Display.Type != display.DisplaySummary {
 		// Note we get a real signed link for aws/azure/gcp links.  But no such option exists for
 		// file:// links so we manually create the link ourselves.
 		var link string
@@ -1078,6 +1108,22 @@ func (b *localBackend) GetHistory(
 	return updates, nil
 }
 
+func (b *localBackend) ExportUpdateEvents(
+	ctx context.Context,
+	stack backend.Stack,
+	version string,
+) ([]apitype.EngineEvent, error) {
+	localStackRef, err := b.getReference(stack.Ref())
+	if err != nil {
+		return nil, err
+	}
+	v, err := strconv.Atoi(version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version %q: %w", version, err)
+	}
+	return b.getUpdateEvents(ctx, localStackRef, v)
+}
+
 func (b *localBackend) GetLogs(ctx context.Context,
 	secretsProvider secrets.Provider, stack backend.Stack, cfg backend.StackConfiguration,
 	query operations.LogQuery,
@@ -235,7 +235,7 @@ func TestRenameWorks_legacy(t *testing.T) {
 	assert.True(t, stackFileExists)
 
 	// Fake up some history
-	err = lb.addToHistory(ctx, aStackRef, backend.UpdateInfo{Kind: apitype.DestroyUpdate})
+	err = lb.addToHistory(ctx, aStackRef, backend.UpdateInfo{Kind: apitype.DestroyUpdate}, nil)
 	assert.NoError(t, err)
 	// And pollute the history folder
 	err = lb.bucket.WriteAll(ctx, path.Join(aStackRef.HistoryDir(), "randomfile.txt"), []byte{0, 13}, nil)
@@ -369,7 +369,7 @@ func TestRenameWorks(t *testing.T) {
 	assert.True(t, stackFileExists)
 
 	// Fake up some history
-	err = lb.addToHistory(ctx, aStackRef, backend.UpdateInfo{Kind: apitype.DestroyUpdate})
+	err = lb.addToHistory(ctx, aStackRef, backend.UpdateInfo{Kind: apitype.DestroyUpdate}, nil)
 	assert.NoError(t, err)
 	// And pollute the history folder
 	err = lb.bucket.WriteAll(ctx, path.Join(aStackRef.HistoryDir(), "randomfile.txt"), []byte{0, 13}, nil)
@@ -439,7 +439,7 @@ func TestRenameProjectWorks(t *testing.T) {
 	assert.True(t, stackFileExists)
 
 	// Fake up some history
-	err = lb.addToHistory(ctx, aStackRef, backend.UpdateInfo{Kind: apitype.DestroyUpdate})
+	err = lb.addToHistory(ctx, aStackRef, backend.UpdateInfo{Kind: apitype.DestroyUpdate}, nil)
 	assert.NoError(t, err)
 	// And pollute the history folder
 	err = lb.bucket.WriteAll(ctx, path.Join(aStackRef.HistoryDir(), "randomfile.txt"), []byte{0, 13}, nil)
@@ -466,6 +466,90 @@ func TestRenameProjectWorks(t *testing.T) {
 	assert.Equal(t, apitype.DestroyUpdate, history[0].Kind)
 }
 
+func TestUpdateEventsHistory(t *testing.T) {
+	t.Parallel()
+
+	// Login to a temp dir filestate backend
+	tmpDir := t.TempDir()
+	ctx := context.Background()
+	b, err := New(ctx, diagtest.LogSink(t), "file://"+filepath.ToSlash(tmpDir), nil)
+	require.NoError(t, err)
+	lb, ok := b.(*localBackend)
+	require.True(t, ok)
+
+	// Create a new stack
+	aStackRef, err := lb.parseStackReference("organization/project/a")
+	require.NoError(t, err)
+	aStack, err := b.CreateStack(ctx, aStackRef, "", nil)
+	require.NoError(t, err)
+
+	// Fake up some history, with events recorded for the last two updates, the last of them compressed.
+	message := func(text string) []apitype.EngineEvent {
+		return []apitype.EngineEvent{
+			{Sequence: 0, StdoutEvent: &apitype.StdoutEngineEvent{Message: text}},
+			{Sequence: 1, CancelEvent: &apitype.CancelEvent{}},
+		}
+	}
+	require.NoError(t, lb.addToHistory(ctx, aStackRef, backend.UpdateInfo{Kind: apitype.UpdateUpdate}, nil))
+	require.NoError(t, lb.addToHistory(ctx, aStackRef, backend.UpdateInfo{Kind: apitype.RefreshUpdate},
+		message("refreshing")))
+	lb.gzip = true
+	require.NoError(t, lb.addToHistory(ctx, aStackRef, backend.UpdateInfo{Kind: apitype.DestroyUpdate},
+		message("destroying")))
+
+	// Updates are numbered in the order in which they were made.
+	history, err := b.GetHistory(ctx, aStackRef, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Equal(t, 3, history[0].Version)
+	assert.Equal(t, apitype.DestroyUpdate, history[0].Kind)
+	assert.Equal(t, 1, history[2].Version)
+	assert.Equal(t, apitype.UpdateUpdate, history[2].Kind)
+
+	page, err := b.GetHistory(ctx, aStackRef, 1, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, 2, page[0].Version)
+
+	exporter, ok := b.(backend.UpdateEventsExporter)
+	require.True(t, ok)
+
+	events, err := exporter.ExportUpdateEvents(ctx, aStack, "2")
+	require.NoError(t, err)
+	assert.Equal(t, message("refreshing"), events)
+
+	events, err = exporter.ExportUpdateEvents(ctx, aStack, "3")
+	require.NoError(t, err)
+	assert.Equal(t, message("destroying"), events)
+
+	_, err = exporter.ExportUpdateEvents(ctx, aStack, "1")
+	assert.ErrorContains(t, err, "no events were recorded for version 1")
+
+	_, err = exporter.ExportUpdateEvents(ctx, aStack, "4")
+	assert.ErrorContains(t, err, "version 4 of stack")
+}
+
+func TestRecordEventsOptIn(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// The events of updates are only stored if the environment variable is set.
+	b, err := newLocalBackend(ctx, diagtest.LogSink(t), "file://"+filepath.ToSlash(t.TempDir()), nil, nil)
+	require.NoError(t, err)
+	assert.False(t, b.recordEvents)
+
+	b, err = newLocalBackend(ctx, diagtest.LogSink(t), "file://"+filepath.ToSlash(t.TempDir()), nil,
+		&localBackendOptions{
+			Getenv: mapGetenv(map[string]string{
+				"PULUMI_SELF_MANAGED_STATE_RECORD_EVENTS": "true",
+			}),
+		},
+	)
+	require.NoError(t, err)
+	assert.True(t, b.recordEvents)
+}
+
 func TestLoginToNonExistingFolderFails(t *testing.T) {
 	t.Parallel()
 
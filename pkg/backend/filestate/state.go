@@ -15,7 +15,10 @@
 package filestate
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -441,36 +444,11 @@ func (b *localBackend) getHistory(
 ) ([]backend.UpdateInfo, error) {
 	contract.Requiref(stack != nil, "stack", "must not be nil")
 
-	dir := stack.HistoryDir()
-	// TODO: we could consider optimizing the list operation using `page` and `pageSize`.
-	// Unfortunately, this is mildly invasive given the gocloud List API.
-	allFiles, err := listBucket(ctx, b.bucket, dir)
+	historyEntries, err := b.listHistory(ctx, stack)
 	if err != nil {
-		// History doesn't exist until a stack has been updated.
-		if gcerrors.Code(err) == gcerrors.NotFound {
-			return nil, nil
-		}
 		return nil, err
 	}
 
-	var historyEntries []*blob.ListObject
-
-	// filter down to just history entries, reversing list to be in most recent order.
-	// listBucket returns the array sorted by file name, but because of how we name files, older updates come before
-	// newer ones.
-	for i := len(allFiles) - 1; i >= 0; i-- {
-		file := allFiles[i]
-		filepath := file.Key
-
-		// ignore checkpoints
-		if !strings.HasSuffix(filepath, ".history.json") &&
-			!strings.HasSuffix(filepath, ".history.json.gz") {
-			continue
-		}
-
-		historyEntries = append(historyEntries, file)
-	}
-
 	start := 0
 	end := len(historyEntries) - 1
 	if pageSize > 0 {
@@ -503,6 +481,8 @@ func (b *localBackend) getHistory(
 		if err != nil {
 			return nil, fmt.Errorf("reading history file %s: %w", filepath, err)
 		}
+		// Updates are numbered in the order in which they were made, starting from 1.
+		update.Version = len(historyEntries) - i
 
 		updates = append(updates, update)
 	}
@@ -510,6 +490,93 @@ func (b *localBackend) getHistory(
 	return updates, nil
 }
 
+// listHistory returns the history entries of a stack, with the most recent first.
+func (b *localBackend) listHistory(ctx context.Context, stack *localBackendReference) ([]*blob.ListObject, error) {
+	dir := stack.HistoryDir()
+	// TODO: we could consider optimizing the list operation using `page` and `pageSize`.
+	// Unfortunately, this is mildly invasive given the gocloud List API.
+	allFiles, err := listBucket(ctx, b.bucket, dir)
+	if err != nil {
+		// History doesn't exist until a stack has been updated.
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var historyEntries []*blob.ListObject
+
+	// filter down to just history entries, reversing list to be in most recent order.
+	// listBucket returns the array sorted by file name, but because of how we name files, older updates come before
+	// newer ones.
+	for i := len(allFiles) - 1; i >= 0; i-- {
+		file := allFiles[i]
+		filepath := file.Key
+
+		// ignore checkpoints and events
+		if !strings.HasSuffix(filepath, ".history.json") &&
+			!strings.HasSuffix(filepath, ".history.json.gz") {
+			continue
+		}
+
+		historyEntries = append(historyEntries, file)
+	}
+
+	return historyEntries, nil
+}
+
+// getUpdateEvents returns the engine events recorded for the update with the given version in the history of a stack.
+func (b *localBackend) getUpdateEvents(
+	ctx context.Context,
+	stack *localBackendReference,
+	version int,
+) ([]apitype.EngineEvent, error) {
+	contract.Requiref(stack != nil, "stack", "must not be nil")
+
+	historyEntries, err := b.listHistory(ctx, stack)
+	if err != nil {
+		return nil, err
+	}
+	if version < 1 || version > len(historyEntries) {
+		return nil, fmt.Errorf("version %d of stack %s does not exist", version, stack)
+	}
+
+	historyFile := historyEntries[len(historyEntries)-version].Key
+	eventsFile := strings.Replace(historyFile, ".history.", ".events.", 1)
+	byts, err := b.bucket.ReadAll(ctx, eventsFile)
+	if err != nil {
+		if gcerrors.Code(err) == gcerrors.NotFound {
+			return nil, fmt.Errorf("no events were recorded for version %d of stack %s; set %s=true to record "+
+				"the events of future updates", version, stack, PulumiFilestateRecordEventsEnvVar)
+		}
+		return nil, fmt.Errorf("reading events file %s: %w", eventsFile, err)
+	}
+	var reader io.Reader = bytes.NewReader(byts)
+	if encoding.IsCompressed(byts) {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, fmt.Errorf("reading events file %s: %w", eventsFile, err)
+		}
+		defer contract.IgnoreClose(gzipReader)
+		reader = gzipReader
+	}
+
+	// The events are stored one per line, as in an event log.
+	var events []apitype.EngineEvent
+	decoder := json.NewDecoder(reader)
+	for {
+		var e apitype.EngineEvent
+		if err := decoder.Decode(&e); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("reading events file %s: %w", eventsFile, err)
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
 func (b *localBackend) renameHistory(ctx context.Context, oldName, newName *localBackendReference) error {
 	contract.Requiref(oldName != nil, "oldName", "must not be nil")
 	contract.Requiref(newName != nil, "newName", "must not be nil")
@@ -553,8 +620,11 @@ func (b *localBackend) renameHistory(ctx context.Context, oldName, newName *loca
 	return nil
 }
 
-// addToHistory saves the UpdateInfo and makes a copy of the current Checkpoint file.
-func (b *localBackend) addToHistory(ctx context.Context, ref *localBackendReference, update backend.UpdateInfo) error {
+// addToHistory saves the UpdateInfo and the engine events of the update, if any, and makes a copy of the current
+// Checkpoint file.
+func (b *localBackend) addToHistory(ctx context.Context, ref *localBackendReference, update backend.UpdateInfo,
+	events []apitype.EngineEvent,
+) error {
 	contract.Requiref(ref != nil, "ref", "must not be nil")
 
 	dir := ref.HistoryDir()
@@ -579,6 +649,34 @@ func (b *localBackend) addToHistory(ctx context.Context, ref *localBackendRefere
 		return err
 	}
 
+	// Save the events of the update, one per line as in an event log.
+	if len(events) > 0 {
+		var buf bytes.Buffer
+		var writer io.Writer = &buf
+		var gzipWriter *gzip.Writer
+		if b.gzip {
+			gzipWriter = gzip.NewWriter(&buf)
+			writer = gzipWriter
+		}
+		encoder := json.NewEncoder(writer)
+		for _, e := range events {
+			if err = encoder.Encode(e); err != nil {
+				return err
+			}
+		}
+		if gzipWriter != nil {
+			if err = gzipWriter.Close(); err != nil {
+				return err
+			}
+		}
+		byts = buf.Bytes()
+
+		eventsFile := fmt.Sprintf("%s.events.%s", pathPrefix, ext)
+		if err = b.bucket.WriteAll(ctx, eventsFile, byts, nil); err != nil {
+			return err
+		}
+	}
+
 	// Make a copy of the checkpoint file. (Assuming it already exists.)
 	checkpointFile := fmt.Sprintf("%s.checkpoint.%s", pathPrefix, ext)
 	return b.bucket.Copy(ctx, checkpointFile, b.stackPath(ctx, ref), nil)
@@ -1090,7 +1090,13 @@ func (b *cloudBackend) apply(
 ) (*deploy.Plan, sdkDisplay.ResourceChanges, result.Result) {
 	actionLabel := backend.ActionLabel(kind, opts.DryRun)
 
-	if !(op.Opts.Display.JSONDisplay || op.Opts.Display.Type == display.DisplayWatch) {
+	err := display.ValidateEventSinks(op.StackConfiguration.EventSinks, op.Opts.Display, opts.DryRun)
+	if err != nil {
+		return nil, nil, result.FromError(err)
+	}
+
+	if !(op.Opts.Display.JSONDisplay || op.Opts.Display.Type == display.DisplayWatch ||
+		op.Opts.Display.Type == display.DisplayMarkdown || op.Opts.Display.Type == display.DisplaySummary) {
 		// Print a banner so it's clear this is going to the cloud.
 		fmt.Printf(op.Opts.Display.Color.Colorize(
 			colors.SpecHeadline+"%s (%s)"+colors.Reset+"\n\n"), actionLabel, stack.Ref())
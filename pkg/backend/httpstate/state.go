@@ -127,7 +127,9 @@ func (u *cloudUpdate) RecordAndDisplayEvents(
 		close(done)
 	}()
 
-	// Start the Go-routines for displaying and persisting events.
+	// Start the Go-routines for displaying and persisting events. The display also streams the events to the event
+	// sinks declared by the project and stack.
+	opts.EventSinks = op.StackConfiguration.EventSinks
 	go display.ShowEvents(
 		label, action, stackRef.Name(), op.Proj.Name, permalink,
 		displayEvents, displayEventsDone, opts, isPreview)
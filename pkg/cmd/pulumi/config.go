@@ -32,6 +32,8 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/backend"
 	"github.com/pulumi/pulumi/pkg/v3/backend/display"
 	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
@@ -43,13 +45,22 @@ func newConfigCmd() *cobra.Command {
 	var stack string
 	var showSecrets bool
 	var jsonOut bool
+	var resolve bool
+	var showOrigin bool
 
 	cmd := &cobra.Command{
 		Use:   "config",
 		Short: "Manage configuration",
 		Long: "Lists all configuration values for a specific stack. To add a new configuration value, run\n" +
 			"`pulumi config set`. To remove and existing value run `pulumi config rm`. To get the value of\n" +
-			"for a specific configuration key, use `pulumi config get <key-name>`.",
+			"for a specific configuration key, use `pulumi config get <key-name>`.\n\n" +
+			"Values that reference an environment variable, a file, or another stack's output, e.g.\n" +
+			"`{fromEnv: NAME}`, `{fromFile: path}` or `{fromStackOutput: org/project/stack#output}`, are shown\n" +
+			"unresolved unless `--resolve` is passed. They are always resolved when the stack is deployed.\n\n" +
+			"A stack's configuration file can extend shared configuration files or the configuration of other\n" +
+			"stacks, e.g. `extends: [shared/base.yaml, stack:staging]`, which are merged in order before the\n" +
+			"stack's own values. Pass `--show-origin` to show where each value comes from. `pulumi config set`\n" +
+			"always writes to the stack's own configuration file.",
 		Args: cmdutil.NoArgs,
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			ctx := commandContext()
@@ -67,7 +78,7 @@ func newConfigCmd() *cobra.Command {
 				return err
 			}
 
-			return listConfig(ctx, project, stack, showSecrets, jsonOut)
+			return listConfig(ctx, project, stack, showSecrets, jsonOut, resolve, showOrigin)
 		}),
 	}
 
@@ -77,6 +88,12 @@ func newConfigCmd() *cobra.Command {
 	cmd.Flags().BoolVarP(
 		&jsonOut, "json", "j", false,
 		"Emit output as JSON")
+	cmd.Flags().BoolVar(
+		&resolve, "resolve", false,
+		"Resolve values that reference environment variables, files, or the outputs of other stacks")
+	cmd.Flags().BoolVar(
+		&showOrigin, "show-origin", false,
+		"Show the file that each configuration value comes from")
 	cmd.PersistentFlags().StringVarP(
 		&stack, "stack", "s", "",
 		"The name of the stack to operate on. Defaults to the current stack")
@@ -91,6 +108,8 @@ func newConfigCmd() *cobra.Command {
 	cmd.AddCommand(newConfigSetAllCmd(&stack))
 	cmd.AddCommand(newConfigRefreshCmd(&stack))
 	cmd.AddCommand(newConfigCopyCmd(&stack))
+	cmd.AddCommand(newConfigRotateCmd(&stack))
+	cmd.AddCommand(newConfigEnvCmd(&stack))
 
 	return cmd
 }
@@ -257,6 +276,7 @@ func copyEntireConfigMap(currentStack backend.Stack,
 func newConfigGetCmd(stack *string) *cobra.Command {
 	var jsonOut bool
 	var path bool
+	var resolve bool
 
 	getCmd := &cobra.Command{
 		Use:   "get <key>",
@@ -284,7 +304,7 @@ func newConfigGetCmd(stack *string) *cobra.Command {
 				return fmt.Errorf("invalid configuration key: %w", err)
 			}
 
-			return getConfig(ctx, s, key, path, jsonOut)
+			return getConfig(ctx, s, key, path, jsonOut, resolve)
 		}),
 	}
 	getCmd.Flags().BoolVarP(
@@ -293,6 +313,9 @@ func newConfigGetCmd(stack *string) *cobra.Command {
 	getCmd.PersistentFlags().BoolVar(
 		&path, "path", false,
 		"The key contains a path to a property in a map or list to get")
+	getCmd.Flags().BoolVar(
+		&resolve, "resolve", false,
+		"Resolve the value if it references an environment variable, a file, or the output of another stack")
 
 	return getCmd
 }
@@ -488,6 +511,7 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 	var plaintext bool
 	var secret bool
 	var path bool
+	outputArgs := OutputArgs{}
 
 	setCmd := &cobra.Command{
 		Use:   "set <key> [value]",
@@ -495,6 +519,8 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 		Long: "Configuration values can be accessed when a stack is being deployed and used to configure behavior. \n" +
 			"If a value is not present on the command line, pulumi will prompt for the value. Multi-line values\n" +
 			"may be set by piping a file to standard in.\n\n" +
+			"The value is always written to the stack's own configuration file, where it overrides any value\n" +
+			"for the same key in the configuration layers that the stack extends.\n\n" +
 			"The `--path` flag can be used to set a value inside a map or list:\n\n" +
 			"  - `pulumi config set --path 'names[0]' a` " +
 			"will set the value to a list with the first item `a`.\n" +
@@ -505,9 +531,14 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 		Args: cmdutil.RangeArgs(1, 2),
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			ctx := commandContext()
+			if err := outputArgs.validate(false /*jsonDisplay*/, false /*diffDisplay*/); err != nil {
+				return err
+			}
+
 			opts := display.Options{
 				Color: cmdutil.GetGlobalColorization(),
 			}
+			outputArgs.applyOptions(&opts)
 
 			project, _, err := readProject()
 			if err != nil {
@@ -520,6 +551,11 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 				return err
 			}
 
+			summary, err := newOperationSummary(ctx, apitype.ConfigSetOperation, s, opts)
+			if err != nil {
+				return err
+			}
+
 			key, err := parseConfigKey(args[0])
 			if err != nil {
 				return fmt.Errorf("invalid configuration key: %w", err)
@@ -535,7 +571,7 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 					return readerr
 				}
 				value = cmdutil.RemoveTrailingNewline(string(b))
-			case !cmdutil.Interactive():
+			case !cmdutil.Interactive() || outputArgs.json():
 				return errors.New("config value must be specified in non-interactive mode")
 			case secret:
 				value, err = cmdutil.ReadConsoleNoEcho("value")
@@ -554,6 +590,13 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 				return err
 			}
 
+			// Check the value against the type and constraints that the project declares for the key.
+			if !path {
+				if err := workspace.ValidateProjectConfigValue(project, key, value); err != nil {
+					return err
+				}
+			}
+
 			// Encrypt the config value if needed.
 			var v config.Value
 			if secret {
@@ -584,7 +627,10 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 				return err
 			}
 
-			return saveProjectStack(s, ps)
+			if err = saveProjectStack(s, ps); err != nil {
+				return err
+			}
+			return summary.print(ctx, nil, nil)
 		}),
 	}
 
@@ -597,6 +643,7 @@ func newConfigSetCmd(stack *string) *cobra.Command {
 	setCmd.PersistentFlags().BoolVar(
 		&secret, "secret", false,
 		"Encrypt the value instead of storing it in plaintext")
+	outputArgs.applyFlags(setCmd)
 
 	return setCmd
 }
@@ -729,6 +776,24 @@ func getProjectStackPath(stack backend.Stack) (string, error) {
 	return stackConfigFile, nil
 }
 
+// effectiveStackConfig returns the stack's configuration merged onto the layers of configuration that it extends,
+// along with the origin of each value.
+func effectiveStackConfig(project *workspace.Project, stack backend.Stack,
+	ps *workspace.ProjectStack,
+) (config.Map, map[config.Key]string, error) {
+	path, err := getProjectStackPath(stack)
+	if err != nil {
+		return nil, nil, err
+	}
+	layers, err := workspace.LoadConfigLayers(project, path, ps)
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg, origins := workspace.MergeConfigLayers(layers)
+	addSecretsAuditLabels(cfg, nil)
+	return cfg, origins, nil
+}
+
 func loadProjectStack(project *workspace.Project, stack backend.Stack) (*workspace.ProjectStack, error) {
 	if stackConfigFile == "" {
 		return workspace.DetectProjectStack(stack.Ref().Name().Q())
@@ -783,6 +848,8 @@ type configValueJSON struct {
 	Value       *string     `json:"value,omitempty"`
 	ObjectValue interface{} `json:"objectValue,omitempty"`
 	Secret      bool        `json:"secret"`
+	// Origin is the file that the value comes from, if --show-origin was passed.
+	Origin string `json:"origin,omitempty"`
 }
 
 func listConfig(ctx context.Context,
@@ -790,36 +857,53 @@ func listConfig(ctx context.Context,
 	stack backend.Stack,
 	showSecrets bool,
 	jsonOut bool,
+	resolve bool,
+	showOrigin bool,
 ) error {
 	ps, err := loadProjectStack(project, stack)
 	if err != nil {
 		return err
 	}
+	cfg, origins, err := effectiveStackConfig(project, stack, ps)
+	if err != nil {
+		return err
+	}
 
 	stackName := stack.Ref().Name().String()
 	// when listing configuration values
 	// also show values coming from the project
-	err = workspace.ApplyProjectConfig(stackName, project, ps.Config)
+	err = workspace.ApplyProjectConfig(stackName, project, cfg)
 	if err != nil {
 		return err
 	}
+	origin := func(key config.Key) string {
+		if o, ok := origins[key]; ok {
+			return o
+		}
+		return workspace.ProjectFile + ".yaml"
+	}
 
-	cfg := ps.Config
+	var sm secrets.Manager
+	if resolve && cfg.HasReferences() {
+		if sm, err = loadStackSecretsManager(stack, ps); err != nil {
+			return err
+		}
+		if cfg, err = resolveConfigReferences(ctx, stack, cfg, sm); err != nil {
+			return err
+		}
+	}
 
 	// By default, we will use a blinding decrypter to show "[secret]". If requested, display secrets in plaintext.
 	decrypter := config.NewBlindingDecrypter()
 	if cfg.HasSecureValue() && showSecrets {
-		stackDecrypter, needsSave, err := getStackDecrypter(stack, ps)
-		if err != nil {
-			return err
-		}
-		// This may have setup the stack's secrets provider, so save the stack if needed.
-		if needsSave {
-			if err = saveProjectStack(stack, ps); err != nil {
-				return fmt.Errorf("save stack config: %w", err)
+		if sm == nil {
+			if sm, err = loadStackSecretsManager(stack, ps); err != nil {
+				return err
 			}
 		}
-		decrypter = stackDecrypter
+		if decrypter, err = sm.Decrypter(); err != nil {
+			return err
+		}
 	}
 
 	var keys config.KeyArray
@@ -859,6 +943,10 @@ func listConfig(ctx context.Context,
 				entry.ObjectValue = nil
 			}
 
+			if showOrigin {
+				entry.Origin = origin(key)
+			}
+
 			configValues[key.String()] = entry
 		}
 		err := printJSON(configValues)
@@ -873,11 +961,19 @@ func listConfig(ctx context.Context,
 				return fmt.Errorf("could not decrypt configuration value: %w", err)
 			}
 
-			rows = append(rows, cmdutil.TableRow{Columns: []string{prettyKey(key), decrypted}})
+			columns := []string{prettyKey(key), decrypted}
+			if showOrigin {
+				columns = append(columns, origin(key))
+			}
+			rows = append(rows, cmdutil.TableRow{Columns: columns})
 		}
 
+		headers := []string{"KEY", "VALUE"}
+		if showOrigin {
+			headers = append(headers, "ORIGIN")
+		}
 		cmdutil.PrintTable(cmdutil.Table{
-			Headers: []string{"KEY", "VALUE"},
+			Headers: headers,
 			Rows:    rows,
 		})
 	}
@@ -889,7 +985,7 @@ func listConfig(ctx context.Context,
 	return nil
 }
 
-func getConfig(ctx context.Context, stack backend.Stack, key config.Key, path, jsonOut bool) error {
+func getConfig(ctx context.Context, stack backend.Stack, key config.Key, path, jsonOut, resolve bool) error {
 	project, _, err := readProject()
 	if err != nil {
 		return err
@@ -898,33 +994,45 @@ func getConfig(ctx context.Context, stack backend.Stack, key config.Key, path, j
 	if err != nil {
 		return err
 	}
+	cfg, _, err := effectiveStackConfig(project, stack, ps)
+	if err != nil {
+		return err
+	}
 
 	stackName := stack.Ref().Name().String()
 	// when asking for a configuration value, include values from the project config
-	err = workspace.ApplyProjectConfig(stackName, project, ps.Config)
+	err = workspace.ApplyProjectConfig(stackName, project, cfg)
 	if err != nil {
 		return err
 	}
-	cfg := ps.Config
 
 	v, ok, err := cfg.Get(key, path)
 	if err != nil {
 		return err
 	}
 	if ok {
+		var sm secrets.Manager
+		if _, isRef := v.Reference(); isRef && resolve {
+			if sm, err = loadStackSecretsManager(stack, ps); err != nil {
+				return err
+			}
+			resolved, err := resolveConfigReferences(ctx, stack, config.Map{key: v}, sm)
+			if err != nil {
+				return err
+			}
+			v = resolved[key]
+		}
+
 		var d config.Decrypter
 		if v.Secure() {
-			var err error
-			var needsSave bool
-			if d, needsSave, err = getStackDecrypter(stack, ps); err != nil {
-				return fmt.Errorf("could not create a decrypter: %w", err)
-			}
-			// This may have setup the stack's secrets provider, so save the stack if needed.
-			if needsSave {
-				if err = saveProjectStack(stack, ps); err != nil {
-					return fmt.Errorf("save stack config: %w", err)
+			if sm == nil {
+				if sm, err = loadStackSecretsManager(stack, ps); err != nil {
+					return fmt.Errorf("could not create a decrypter: %w", err)
 				}
 			}
+			if d, err = sm.Decrypter(); err != nil {
+				return fmt.Errorf("could not create a decrypter: %w", err)
+			}
 		} else {
 			d = config.NewPanicCrypter()
 		}
@@ -996,6 +1104,17 @@ func looksLikeSecret(k config.Key, v string) bool {
 		(info.Entropy >= (entropyThreshold/2) && entropyPerChar >= entropyPerCharThreshold)
 }
 
+// validateStackConfig validates the stack's configuration against the project's config declarations and applies the
+// project's defaults, warning about any deprecated config keys that the stack sets.
+func validateStackConfig(stackName string, project *workspace.Project, stackConfig config.Map,
+	decrypter config.Decrypter,
+) error {
+	for _, warning := range workspace.ConfigDeprecationWarnings(project, stackConfig) {
+		cmdutil.Diag().Warningf(diag.RawMessage("" /*urn*/, warning))
+	}
+	return workspace.ValidateStackConfigAndApplyProjectConfig(stackName, project, stackConfig, decrypter)
+}
+
 // getStackConfiguration loads configuration information for a given stack. If stackConfigFile is non empty,
 // it is uses instead of the default configuration file for the stack
 func getStackConfiguration(
@@ -1006,8 +1125,14 @@ func getStackConfiguration(
 ) (backend.StackConfiguration, secrets.Manager, error) {
 	defaultStackConfig := backend.StackConfiguration{}
 
+	var stackConfig config.Map
 	workspaceStack, err := loadProjectStack(project, stack)
-	if err != nil || workspaceStack == nil {
+	if err == nil && workspaceStack != nil {
+		// Merge the stack's configuration onto the layers that it extends.
+		if stackConfig, _, err = effectiveStackConfig(project, stack, workspaceStack); err != nil {
+			return defaultStackConfig, nil, err
+		}
+	} else {
 		// On first run or the latest configuration is unavailable, fallback to check the project's configuration
 		cfg, err := backend.GetLatestConfiguration(ctx, stack)
 		if err != nil {
@@ -1017,6 +1142,7 @@ func getStackConfiguration(
 		workspaceStack = &workspace.ProjectStack{
 			Config: cfg,
 		}
+		stackConfig = cfg
 	}
 
 	if sm == nil {
@@ -1032,13 +1158,35 @@ func getStackConfiguration(
 		}
 	}
 
+	// Resolve any values that reference environment variables, files, or the outputs of other stacks.
+	cfg, err := resolveConfigReferences(ctx, stack, stackConfig, sm)
+	if err != nil {
+		return defaultStackConfig, nil, err
+	}
+
+	// Load the environment variables that the stack declares for its language host and resource providers.
+	vars, err := loadStackEnvironment(project, stack, workspaceStack, sm)
+	if err != nil {
+		return defaultStackConfig, nil, fmt.Errorf("loading stack environment: %w", err)
+	}
+	environment := environmentMap(vars)
+
+	// The event sinks declared by the stack are used in addition to those of the project.
+	var eventSinks []workspace.EventSink
+	if project != nil {
+		eventSinks = append(eventSinks, project.EventSinks...)
+	}
+	eventSinks = append(eventSinks, workspaceStack.EventSinks...)
+
 	// If there are no secrets in the configuration, we should never use the decrypter, so it is safe to return
 	// one which panics if it is used. This provides for some nice UX in the common case (since, for example, building
 	// the correct decrypter for the local backend would involve prompting for a passphrase)
-	if !workspaceStack.Config.HasSecureValue() {
+	if !cfg.HasSecureValue() {
 		return backend.StackConfiguration{
-			Config:    workspaceStack.Config,
-			Decrypter: config.NewPanicCrypter(),
+			Config:      cfg,
+			Decrypter:   config.NewPanicCrypter(),
+			Environment: environment,
+			EventSinks:  eventSinks,
 		}, sm, nil
 	}
 
@@ -1048,7 +1196,9 @@ func getStackConfiguration(
 	}
 
 	return backend.StackConfiguration{
-		Config:    workspaceStack.Config,
-		Decrypter: crypter,
+		Config:      cfg,
+		Decrypter:   crypter,
+		Environment: environment,
+		EventSinks:  eventSinks,
 	}, sm, nil
 }
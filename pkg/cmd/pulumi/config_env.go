@@ -0,0 +1,299 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+func newConfigEnvCmd(stack *string) *cobra.Command {
+	var showSecrets bool
+	var jsonOut bool
+
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "Show the environment variables of a stack",
+		Long: "Show the environment variables of a stack.\n" +
+			"\n" +
+			"The `environment` section of Pulumi.yaml and of a stack's configuration file declares environment\n" +
+			"variables that are set for the language host and resource provider plugins of the stack, for example:\n" +
+			"\n" +
+			"    environment:\n" +
+			"      dotenv: [.env]\n" +
+			"      variables:\n" +
+			"        LOG_LEVEL: debug\n" +
+			"\n" +
+			"Variables override those loaded from dotenv files, and the stack's variables override the project's.\n" +
+			"Secret variables can only be declared in a stack's configuration file, with `pulumi config env set\n" +
+			"--secret`. Secret values and values loaded from dotenv files are only shown with `--show-secrets`.",
+		Args: cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			ctx := commandContext()
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			project, _, err := readProject()
+			if err != nil {
+				return err
+			}
+			s, err := requireStack(ctx, *stack, stackOfferNew|stackSetCurrent, opts)
+			if err != nil {
+				return err
+			}
+			ps, err := loadProjectStack(project, s)
+			if err != nil {
+				return err
+			}
+
+			vars, err := loadStackEnvironment(project, s, ps, nil)
+			if err != nil {
+				return err
+			}
+			return printStackEnvironment(vars, showSecrets, jsonOut)
+		}),
+	}
+
+	cmd.Flags().BoolVar(
+		&showSecrets, "show-secrets", false,
+		"Show secret values and values loaded from dotenv files")
+	cmd.Flags().BoolVarP(
+		&jsonOut, "json", "j", false,
+		"Emit output as JSON")
+
+	cmd.AddCommand(newConfigEnvSetCmd(stack))
+	cmd.AddCommand(newConfigEnvRmCmd(stack))
+
+	return cmd
+}
+
+func newConfigEnvSetCmd(stack *string) *cobra.Command {
+	var secret bool
+
+	cmd := &cobra.Command{
+		Use:   "set <name> [value]",
+		Short: "Set an environment variable of a stack",
+		Long: "Set an environment variable of a stack.\n" +
+			"\n" +
+			"If the value is not given, it is read from standard input or an interactive prompt.\n" +
+			"Use `--secret` to encrypt the value with the stack's secrets provider.",
+		Args: cmdutil.RangeArgs(1, 2),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			ctx := commandContext()
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			project, _, err := readProject()
+			if err != nil {
+				return err
+			}
+			s, err := requireStack(ctx, *stack, stackOfferNew|stackSetCurrent, opts)
+			if err != nil {
+				return err
+			}
+			ps, err := loadProjectStack(project, s)
+			if err != nil {
+				return err
+			}
+
+			name := args[0]
+			if !workspace.IsValidEnvironmentVariableName(name) {
+				return fmt.Errorf("invalid environment variable name '%s'", name)
+			}
+
+			var value string
+			switch {
+			case len(args) == 2:
+				value = args[1]
+			case !term.IsTerminal(int(os.Stdin.Fd())):
+				b, err := io.ReadAll(os.Stdin)
+				if err != nil {
+					return err
+				}
+				value = cmdutil.RemoveTrailingNewline(string(b))
+			case !cmdutil.Interactive():
+				return errors.New("value must be specified in non-interactive mode")
+			case secret:
+				if value, err = cmdutil.ReadConsoleNoEcho("value"); err != nil {
+					return err
+				}
+			default:
+				if value, err = cmdutil.ReadConsole("value"); err != nil {
+					return err
+				}
+			}
+
+			v := config.NewValue(value)
+			if secret {
+				encrypter, _, err := getStackEncrypter(s, ps)
+				if err != nil {
+					return err
+				}
+				ciphertext, err := encrypter.EncryptValue(ctx, value)
+				if err != nil {
+					return err
+				}
+				v = config.NewSecureValue(ciphertext)
+			}
+
+			if ps.Environment == nil {
+				ps.Environment = &workspace.ProjectEnvironment{}
+			}
+			if ps.Environment.Variables == nil {
+				ps.Environment.Variables = make(map[string]config.Value)
+			}
+			ps.Environment.Variables[name] = v
+			return saveProjectStack(s, ps)
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVar(
+		&secret, "secret", false,
+		"Encrypt the value instead of storing it in plaintext")
+
+	return cmd
+}
+
+func newConfigEnvRmCmd(stack *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rm <name>",
+		Short: "Remove an environment variable of a stack",
+		Args:  cmdutil.SpecificArgs([]string{"name"}),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			ctx := commandContext()
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			project, _, err := readProject()
+			if err != nil {
+				return err
+			}
+			s, err := requireStack(ctx, *stack, stackOfferNew|stackSetCurrent, opts)
+			if err != nil {
+				return err
+			}
+			ps, err := loadProjectStack(project, s)
+			if err != nil {
+				return err
+			}
+
+			if ps.Environment != nil {
+				delete(ps.Environment.Variables, args[0])
+				if len(ps.Environment.Variables) == 0 && len(ps.Environment.Dotenv) == 0 {
+					ps.Environment = nil
+				}
+			}
+			return saveProjectStack(s, ps)
+		}),
+	}
+}
+
+// loadStackEnvironment returns the effective environment variables of a stack. The stack's secrets manager is only
+// loaded if it has secret variables, and sm may be nil.
+func loadStackEnvironment(project *workspace.Project, s backend.Stack, ps *workspace.ProjectStack,
+	sm secrets.Manager,
+) ([]workspace.EnvironmentVariable, error) {
+	if project.Environment == nil && ps.Environment == nil {
+		return nil, nil
+	}
+
+	projectPath, err := workspace.DetectProjectPath()
+	if err != nil {
+		return nil, err
+	}
+	stackPath := stackConfigFile
+	if stackPath == "" {
+		if _, stackPath, err = workspace.DetectProjectStackPath(s.Ref().Name().Q()); err != nil {
+			return nil, err
+		}
+	}
+
+	var decrypter config.Decrypter
+	if ps.Environment.HasSecureValue() {
+		if sm == nil {
+			if sm, err = loadStackSecretsManager(s, ps); err != nil {
+				return nil, err
+			}
+		}
+		if decrypter, err = sm.Decrypter(); err != nil {
+			return nil, fmt.Errorf("getting environment decrypter: %w", err)
+		}
+	}
+
+	return workspace.LoadEnvironment(project, filepath.Dir(projectPath), ps, stackPath, decrypter)
+}
+
+// environmentMap returns the names and values of the given environment variables.
+func environmentMap(vars []workspace.EnvironmentVariable) map[string]string {
+	if len(vars) == 0 {
+		return nil
+	}
+	env := make(map[string]string, len(vars))
+	for _, v := range vars {
+		env[v.Name] = v.Value
+	}
+	return env
+}
+
+type environmentVariableJSON struct {
+	Value  *string `json:"value,omitempty"`
+	Secret bool    `json:"secret"`
+	Origin string  `json:"origin"`
+}
+
+func printStackEnvironment(vars []workspace.EnvironmentVariable, showSecrets, jsonOut bool) error {
+	if jsonOut {
+		out := make(map[string]environmentVariableJSON, len(vars))
+		for _, v := range vars {
+			entry := environmentVariableJSON{Secret: v.Secret, Origin: v.Origin}
+			if !v.Secret || showSecrets {
+				value := v.Value
+				entry.Value = &value
+			}
+			out[v.Name] = entry
+		}
+		return printJSON(out)
+	}
+
+	rows := make([]cmdutil.TableRow, len(vars))
+	for i, v := range vars {
+		value := v.Value
+		if v.Secret && !showSecrets {
+			value = "[secret]"
+		}
+		rows[i] = cmdutil.TableRow{Columns: []string{v.Name, value, v.Origin}}
+	}
+	cmdutil.PrintTable(cmdutil.Table{
+		Headers: []string{"NAME", "VALUE", "ORIGIN"},
+		Rows:    rows,
+	})
+	return nil
+}
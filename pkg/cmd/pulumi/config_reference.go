@@ -0,0 +1,152 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// configReferenceResolver resolves the `fromEnv`, `fromFile` and `fromStackOutput` references in a stack's
+// configuration.
+type configReferenceResolver struct {
+	// dir is the directory that file references are relative to.
+	dir string
+	// client reads the outputs of other stacks.
+	client deploy.BackendClient
+}
+
+// newConfigReferenceResolver returns a resolver that reads files relative to the current project's directory and
+// the outputs of other stacks in the given stack's backend.
+func newConfigReferenceResolver(s backend.Stack) (*configReferenceResolver, error) {
+	_, dir, err := readProject()
+	if err != nil {
+		return nil, err
+	}
+	return &configReferenceResolver{
+		dir:    dir,
+		client: backend.NewBackendClient(s.Backend(), stack.DefaultSecretsProvider),
+	}, nil
+}
+
+func (r *configReferenceResolver) ResolveReference(ctx context.Context, ref config.Reference) (string, bool, error) {
+	switch ref.Kind {
+	case config.EnvReference:
+		v, ok := os.LookupEnv(ref.Target)
+		if !ok {
+			return "", false, fmt.Errorf("environment variable %s is not set", ref.Target)
+		}
+		return v, false, nil
+	case config.FileReference:
+		path := ref.Target
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(r.dir, path)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return "", false, err
+		}
+		// Most files end with a newline that isn't part of the value.
+		return strings.TrimRight(string(b), "\r\n"), false, nil
+	case config.StackOutputReference:
+		return r.resolveStackOutput(ctx, ref.Target)
+	default:
+		return "", false, fmt.Errorf("unknown reference kind %q", ref.Kind)
+	}
+}
+
+func (r *configReferenceResolver) resolveStackOutput(ctx context.Context, target string) (string, bool, error) {
+	stackName, output, err := config.ParseStackOutputReference(target)
+	if err != nil {
+		return "", false, err
+	}
+	outputs, err := r.client.GetStackOutputs(ctx, stackName)
+	if err != nil {
+		return "", false, fmt.Errorf("reading outputs of stack %s: %w", stackName, err)
+	}
+	v, ok := outputs[resource.PropertyKey(output)]
+	if !ok {
+		return "", false, fmt.Errorf("stack %s has no output %q", stackName, output)
+	}
+
+	secret := v.ContainsSecrets()
+	if v.IsSecret() {
+		v = v.SecretValue().Element
+	}
+	if v.IsString() {
+		return v.StringValue(), secret, nil
+	}
+	b, err := json.Marshal(v.Mappable())
+	if err != nil {
+		return "", false, err
+	}
+	return string(b), secret, nil
+}
+
+// lazyEncrypter only creates the secrets manager's encrypter when a value is actually encrypted, so resolving
+// references that aren't secrets never prompts for a passphrase.
+type lazyEncrypter struct {
+	sm secrets.Manager
+}
+
+func (e lazyEncrypter) EncryptValue(ctx context.Context, plaintext string) (string, error) {
+	enc, err := e.sm.Encrypter()
+	if err != nil {
+		return "", err
+	}
+	return enc.EncryptValue(ctx, plaintext)
+}
+
+// resolveConfigReferences returns a copy of the stack's configuration with its references resolved. Resolved secrets
+// are encrypted with the given secrets manager.
+func resolveConfigReferences(ctx context.Context,
+	s backend.Stack, cfg config.Map, sm secrets.Manager,
+) (config.Map, error) {
+	if !cfg.HasReferences() {
+		return cfg, nil
+	}
+	resolver, err := newConfigReferenceResolver(s)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Resolve(ctx, resolver, lazyEncrypter{sm: sm})
+}
+
+// loadStackSecretsManager returns the stack's secrets manager, saving the stack's configuration if loading the
+// secrets manager set up its secrets provider.
+func loadStackSecretsManager(s backend.Stack, ps *workspace.ProjectStack) (secrets.Manager, error) {
+	sm, needsSave, err := getStackSecretsManager(s, ps)
+	if err != nil {
+		return nil, err
+	}
+	if needsSave {
+		if err = saveProjectStack(s, ps); err != nil {
+			return nil, fmt.Errorf("save stack config: %w", err)
+		}
+	}
+	return sm, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+)
+
+type fakeStackOutputsClient map[string]resource.PropertyMap
+
+func (c fakeStackOutputsClient) GetStackOutputs(ctx context.Context, name string) (resource.PropertyMap, error) {
+	outputs, ok := c[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown stack %q", name)
+	}
+	return outputs, nil
+}
+
+func (c fakeStackOutputsClient) GetStackResourceOutputs(
+	ctx context.Context, name string,
+) (resource.PropertyMap, error) {
+	return nil, fmt.Errorf("unknown stack %q", name)
+}
+
+//nolint:paralleltest // mutates environment variables
+func TestConfigReferenceResolver(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "token.txt"), []byte("file-token\n"), 0o600)
+	require.NoError(t, err)
+	t.Setenv("CONFIG_REFERENCE_TEST", "env-value")
+
+	resolver := &configReferenceResolver{
+		dir: dir,
+		client: fakeStackOutputsClient{
+			"org/network/dev": resource.PropertyMap{
+				"vpcId":    resource.NewStringProperty("vpc-123"),
+				"password": resource.MakeSecret(resource.NewStringProperty("hunter2")),
+				"subnets": resource.NewArrayProperty([]resource.PropertyValue{
+					resource.NewStringProperty("a"),
+					resource.NewStringProperty("b"),
+				}),
+			},
+		},
+	}
+
+	tests := []struct {
+		Ref    config.Reference
+		Value  string
+		Secret bool
+		Error  string
+	}{
+		{Ref: config.Reference{Kind: config.EnvReference, Target: "CONFIG_REFERENCE_TEST"}, Value: "env-value"},
+		{
+			Ref:   config.Reference{Kind: config.EnvReference, Target: "CONFIG_REFERENCE_TEST_UNSET"},
+			Error: "environment variable CONFIG_REFERENCE_TEST_UNSET is not set",
+		},
+		{Ref: config.Reference{Kind: config.FileReference, Target: "token.txt"}, Value: "file-token"},
+		{Ref: config.Reference{Kind: config.StackOutputReference, Target: "org/network/dev#vpcId"}, Value: "vpc-123"},
+		{
+			Ref:    config.Reference{Kind: config.StackOutputReference, Target: "org/network/dev#password"},
+			Value:  "hunter2",
+			Secret: true,
+		},
+		{Ref: config.Reference{Kind: config.StackOutputReference, Target: "org/network/dev#subnets"}, Value: `["a","b"]`},
+		{
+			Ref:   config.Reference{Kind: config.StackOutputReference, Target: "org/network/dev#missing"},
+			Error: `stack org/network/dev has no output "missing"`,
+		},
+		{
+			Ref:   config.Reference{Kind: config.StackOutputReference, Target: "org/network/prod#vpcId"},
+			Error: `reading outputs of stack org/network/prod: unknown stack "org/network/prod"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Ref.String(), func(t *testing.T) {
+			value, secret, err := resolver.ResolveReference(context.Background(), test.Ref)
+			if test.Error != "" {
+				assert.EqualError(t, err, test.Error)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.Value, value)
+			assert.Equal(t, test.Secret, secret)
+		})
+	}
+}
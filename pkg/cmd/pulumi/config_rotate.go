@@ -0,0 +1,481 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+func newConfigRotateCmd(stack *string) *cobra.Command {
+	var rotator configRotator
+	var oldValue string
+	var newValue string
+	var jsonOut bool
+
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Args:  cmdutil.NoArgs,
+		Short: "Rotate a secret configuration value across the project's stacks",
+		Long: "Rotate a secret configuration value across the project's stacks.\n" +
+			"\n" +
+			"Finds the configuration values of every stack of the current project in the current backend whose\n" +
+			"value equals `--old-value`, or whose key matches `--key-pattern`, and sets them to the new value,\n" +
+			"encrypted with each stack's own secrets provider. If both are given, a value must match both.\n" +
+			"Pass `--stack` to only rotate the values of a single stack.\n" +
+			"\n" +
+			"The new value is read from `--new-value`, from standard input, or from an interactive prompt.\n" +
+			"The key pattern is a glob that is matched against both the short and the fully qualified key,\n" +
+			"e.g. `dbPassword` or `*:apiToken`. Object values are never rotated.\n" +
+			"\n" +
+			"Only the values in each stack's own configuration file, `Pulumi.<stack-name>.yaml` in the project\n" +
+			"directory, are rotated. Stacks without such a file are skipped with a warning. The configuration\n" +
+			"layers that a stack extends may be shared with other stacks and are left unchanged, but matching\n" +
+			"values in them are reported as not rotated; rotate them by editing the layer directly.\n" +
+			"\n" +
+			"Stack configuration is stored with each project rather than in the backend, so the stacks of other\n" +
+			"projects, e.g. those in the same filestate bucket, can't be rotated. Run `pulumi config rotate` in\n" +
+			"the directory of each project instead.\n" +
+			"\n" +
+			"Use `--dry-run` to report the values that would be rotated without changing any stack's configuration.",
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			ctx := commandContext()
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			rotator.matchOldValue = cmd.Flags().Changed("old-value")
+			rotator.oldValue = oldValue
+			if !rotator.matchOldValue && rotator.keyPattern == "" {
+				return result.FromError(errors.New("one of --old-value or --key-pattern must be specified"))
+			}
+			if rotator.keyPattern != "" {
+				if _, err := path.Match(rotator.keyPattern, ""); err != nil {
+					return result.FromError(fmt.Errorf("invalid key pattern: %w", err))
+				}
+			}
+
+			if !rotator.dryRun {
+				value, err := readRotatedValue(cmd.Flags().Changed("new-value"), newValue)
+				if err != nil {
+					return result.FromError(err)
+				}
+				rotator.newValue = value
+			}
+
+			project, _, err := readProject()
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			stacks, err := configRotationStacks(ctx, project, *stack, opts)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			var rotations []configRotation
+			var failed int
+			rotated := map[string]bool{}
+			for _, s := range stacks {
+				stackRotations, err := rotator.rotateStack(ctx, s)
+				if err != nil {
+					failed++
+					cmdutil.Diag().Errorf(diag.Message("" /*urn*/, "rotating configuration of stack %s: %v"),
+						s.Ref(), err)
+					continue
+				}
+				if len(stackRotations) > 0 {
+					_, stackPath, err := workspace.DetectProjectStackPath(s.Ref().Name().Q())
+					if err == nil {
+						rotated[stackPath] = true
+					}
+				}
+				rotations = append(rotations, stackRotations...)
+			}
+
+			// Report the matching values that are left in the layers that the stacks extend, once every stack's own
+			// configuration has been rotated, since a stack can extend the configuration of another stack.
+			for _, s := range stacks {
+				layerRotations, err := rotator.checkLayers(project, s, rotated)
+				if err != nil {
+					cmdutil.Diag().Warningf(
+						diag.Message("" /*urn*/, "checking the configuration layers of stack %s: %v"), s.Ref(), err)
+					continue
+				}
+				rotations = append(rotations, layerRotations...)
+			}
+
+			if err := printConfigRotations(rotations, rotator.dryRun, jsonOut); err != nil {
+				return result.FromError(err)
+			}
+			if failed > 0 {
+				return result.Errorf("failed to rotate the configuration of %d stack(s)", failed)
+			}
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().StringVar(
+		&oldValue, "old-value", "",
+		"Rotate the configuration values that are equal to this value")
+	cmd.PersistentFlags().StringVar(
+		&rotator.keyPattern, "key-pattern", "",
+		"Rotate the configuration values whose keys match this glob pattern")
+	cmd.PersistentFlags().StringVar(
+		&newValue, "new-value", "",
+		"The new value. If not specified, it is read from standard input or an interactive prompt")
+	cmd.PersistentFlags().BoolVar(
+		&rotator.dryRun, "dry-run", false,
+		"Report the values that would be rotated without changing any configuration")
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false,
+		"Emit output as JSON")
+
+	return cmd
+}
+
+// readRotatedValue returns the new value for a rotation, which is either given by a flag, piped to standard input or
+// entered at a prompt without echoing it.
+func readRotatedValue(set bool, value string) (string, error) {
+	switch {
+	case set:
+		return value, nil
+	case !term.IsTerminal(int(os.Stdin.Fd())):
+		b, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", err
+		}
+		return cmdutil.RemoveTrailingNewline(string(b)), nil
+	case !cmdutil.Interactive():
+		return "", errors.New("--new-value must be specified in non-interactive mode")
+	default:
+		return cmdutil.ReadConsoleNoEcho("new value")
+	}
+}
+
+// configRotationStacks returns the stacks of the current project in the current backend, or just the given stack.
+func configRotationStacks(ctx context.Context, project *workspace.Project, stackName string,
+	opts display.Options,
+) ([]backend.Stack, error) {
+	if stackName != "" {
+		s, err := requireStack(ctx, stackName, stackLoadOnly, opts)
+		if err != nil {
+			return nil, err
+		}
+		return []backend.Stack{s}, nil
+	}
+
+	b, err := currentBackend(ctx, project, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	projectName := project.Name.String()
+	filter := backend.ListStacksFilter{Project: &projectName}
+	var stacks []backend.Stack
+	var token backend.ContinuationToken
+	for {
+		summaries, next, err := b.ListStacks(ctx, filter, token)
+		if err != nil {
+			return nil, err
+		}
+		for _, summary := range summaries {
+			s, err := b.GetStack(ctx, summary.Name())
+			if err != nil {
+				return nil, err
+			}
+			if s != nil {
+				stacks = append(stacks, s)
+			}
+		}
+		if next == nil {
+			break
+		}
+		token = next
+	}
+	return stacks, nil
+}
+
+// configRotation is a configuration value of a stack that was, or in a dry run would be, rotated.
+type configRotation struct {
+	Stack string `json:"stack"`
+	Key   string `json:"key"`
+	// WasSecret is false if the old value was stored in plaintext. The new value is always stored as a secret.
+	WasSecret bool `json:"wasSecret"`
+	// Layer is the configuration layer that holds a matching value that the stack extends, which is not rotated.
+	Layer string `json:"layer,omitempty"`
+}
+
+// configRotator finds and rotates the configuration values of stacks that match an old value or a key pattern.
+type configRotator struct {
+	matchOldValue bool
+	oldValue      string
+	keyPattern    string
+	newValue      string
+	dryRun        bool
+}
+
+// rotateStack rotates the matching values in the configuration file of the given stack, if it has one. The layers
+// of configuration that the stack extends are not rotated.
+func (r *configRotator) rotateStack(ctx context.Context, s backend.Stack) ([]configRotation, error) {
+	_, stackPath, err := workspace.DetectProjectStackPath(s.Ref().Name().Q())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(stackPath); os.IsNotExist(err) {
+		cmdutil.Diag().Warningf(diag.Message("" /*urn*/, "skipping stack %s, which has no configuration file at %s"),
+			s.Ref(), stackPath)
+		return nil, nil
+	}
+	ps, err := workspace.DetectProjectStack(s.Ref().Name().Q())
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := r.rotate(ctx, ps, lazySecretsManager(s, ps))
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	rotations := make([]configRotation, len(keys))
+	for i, k := range keys {
+		rotations[i] = configRotation{Stack: s.Ref().Name().String(), Key: prettyKey(k.key), WasSecret: k.wasSecret}
+	}
+	if r.dryRun {
+		return rotations, nil
+	}
+	if err := workspace.SaveProjectStack(s.Ref().Name().Q(), ps); err != nil {
+		return nil, err
+	}
+	return rotations, nil
+}
+
+// checkLayers returns the matching values in the configuration layers that the given stack extends, which are not
+// rotated. Layers that are the configuration files of rotated stacks are skipped.
+func (r *configRotator) checkLayers(project *workspace.Project, s backend.Stack,
+	rotated map[string]bool,
+) ([]configRotation, error) {
+	_, stackPath, err := workspace.DetectProjectStackPath(s.Ref().Name().Q())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(stackPath); os.IsNotExist(err) {
+		return nil, nil
+	}
+	ps, err := workspace.DetectProjectStack(s.Ref().Name().Q())
+	if err != nil {
+		return nil, err
+	}
+	if len(ps.Extends) == 0 {
+		return nil, nil
+	}
+	layers, err := workspace.LoadConfigLayers(project, stackPath, ps)
+	if err != nil {
+		return nil, err
+	}
+
+	// Base layers only hold secure values that are encrypted with the same key as the stack's own, so the stack's
+	// secrets manager can decrypt them.
+	return r.checkBaseLayers(s.Ref().Name().String(), stackPath, layers, rotated, lazySecretsManager(s, ps))
+}
+
+// checkBaseLayers returns the matching values in the given layers of the configuration file at stackPath, other than
+// the stack's own configuration, which is the last layer.
+func (r *configRotator) checkBaseLayers(stack, stackPath string, layers []workspace.ConfigLayer,
+	rotated map[string]bool, getSecretsManager func() (secrets.Manager, error),
+) ([]configRotation, error) {
+	var rotations []configRotation
+	for _, layer := range layers[:len(layers)-1] {
+		layerPath := layer.Origin
+		if !filepath.IsAbs(layerPath) {
+			layerPath = filepath.Join(filepath.Dir(stackPath), layerPath)
+		}
+		if rotated[layerPath] {
+			continue
+		}
+		keys, err := r.matchingKeys(layer.Config, getSecretsManager)
+		if err != nil {
+			return nil, fmt.Errorf("configuration layer %q: %w", layer.Origin, err)
+		}
+		for _, k := range keys {
+			rotations = append(rotations, configRotation{
+				Stack:     stack,
+				Key:       prettyKey(k.key),
+				WasSecret: k.wasSecret,
+				Layer:     layer.Origin,
+			})
+		}
+	}
+	return rotations, nil
+}
+
+// lazySecretsManager returns a function that loads the stack's secrets manager on first use, so that stacks without
+// matching values don't require access to their secrets provider.
+func lazySecretsManager(s backend.Stack, ps *workspace.ProjectStack) func() (secrets.Manager, error) {
+	var sm secrets.Manager
+	return func() (secrets.Manager, error) {
+		if sm == nil {
+			m, _, err := getStackSecretsManager(s, ps)
+			if err != nil {
+				return nil, err
+			}
+			sm = m
+		}
+		return sm, nil
+	}
+}
+
+type rotatedKey struct {
+	key       config.Key
+	wasSecret bool
+}
+
+// rotate sets the matching values of the given stack configuration to the new value, encrypted with the stack's
+// secrets manager. In a dry run, the configuration is left unchanged.
+func (r *configRotator) rotate(ctx context.Context, ps *workspace.ProjectStack,
+	getSecretsManager func() (secrets.Manager, error),
+) ([]rotatedKey, error) {
+	rotated, err := r.matchingKeys(ps.Config, getSecretsManager)
+	if err != nil || r.dryRun {
+		return rotated, err
+	}
+
+	for _, k := range rotated {
+		sm, err := getSecretsManager()
+		if err != nil {
+			return nil, err
+		}
+		encrypter, err := sm.Encrypter()
+		if err != nil {
+			return nil, err
+		}
+		enc, err := encrypter.EncryptValue(ctx, r.newValue)
+		if err != nil {
+			return nil, err
+		}
+		ps.Config[k.key] = config.NewSecureValue(enc)
+	}
+	return rotated, nil
+}
+
+// matchingKeys returns the keys of the given configuration whose values match the old value or key pattern.
+func (r *configRotator) matchingKeys(cfg config.Map,
+	getSecretsManager func() (secrets.Manager, error),
+) ([]rotatedKey, error) {
+	keys := make(config.KeyArray, 0, len(cfg))
+	for key := range cfg {
+		keys = append(keys, key)
+	}
+	sort.Sort(keys)
+
+	var matches []rotatedKey
+	for _, key := range keys {
+		v := cfg[key]
+		if v.Object() || !r.matchesKey(key) {
+			continue
+		}
+
+		if r.matchOldValue {
+			var decrypter config.Decrypter
+			if v.Secure() {
+				sm, err := getSecretsManager()
+				if err != nil {
+					return nil, err
+				}
+				if decrypter, err = sm.Decrypter(); err != nil {
+					return nil, err
+				}
+			}
+			old, err := v.Value(decrypter)
+			if err != nil {
+				return nil, fmt.Errorf("decrypting configuration value '%v': %w", prettyKey(key), err)
+			}
+			if old != r.oldValue {
+				continue
+			}
+		}
+
+		matches = append(matches, rotatedKey{key: key, wasSecret: v.Secure()})
+	}
+	return matches, nil
+}
+
+func (r *configRotator) matchesKey(key config.Key) bool {
+	if r.keyPattern == "" {
+		return true
+	}
+	for _, name := range []string{prettyKey(key), key.String()} {
+		if ok, _ := path.Match(r.keyPattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func printConfigRotations(rotations []configRotation, dryRun, jsonOut bool) error {
+	if jsonOut {
+		if rotations == nil {
+			rotations = []configRotation{}
+		}
+		return printJSON(rotations)
+	}
+
+	if len(rotations) == 0 {
+		fmt.Println("No matching configuration values found.")
+		return nil
+	}
+
+	action := "rotated"
+	if dryRun {
+		action = "would rotate"
+	}
+	rows := make([]cmdutil.TableRow, len(rotations))
+	for i, r := range rotations {
+		status := action
+		if r.Layer != "" {
+			status = "not rotated, in " + r.Layer
+		}
+		if !r.WasSecret {
+			status += " (was plaintext)"
+		}
+		rows[i] = cmdutil.TableRow{Columns: []string{r.Stack, r.Key, status}}
+	}
+	cmdutil.PrintTable(cmdutil.Table{
+		Headers: []string{"STACK", "KEY", "STATUS"},
+		Rows:    rows,
+	})
+	return nil
+}
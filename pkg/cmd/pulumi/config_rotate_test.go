@@ -0,0 +1,142 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/b64"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+func TestConfigRotator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	sm := b64.NewBase64SecretsManager()
+	enc, err := sm.Encrypter()
+	require.NoError(t, err)
+	dec, err := sm.Decrypter()
+	require.NoError(t, err)
+
+	secret := func(v string) config.Value {
+		ct, err := enc.EncryptValue(ctx, v)
+		require.NoError(t, err)
+		return config.NewSecureValue(ct)
+	}
+	newStack := func() *workspace.ProjectStack {
+		return &workspace.ProjectStack{Config: config.Map{
+			config.MustMakeKey("proj", "dbPassword"): secret("old"),
+			config.MustMakeKey("proj", "apiToken"):   secret("token"),
+			config.MustMakeKey("proj", "legacy"):     config.NewValue("old"),
+			config.MustMakeKey("aws", "region"):      config.NewValue("us-west-2"),
+			config.MustMakeKey("proj", "settings"):   config.NewObjectValue(`{"password":"old"}`),
+		}}
+	}
+	getSecretsManager := func() (secrets.Manager, error) { return sm, nil }
+
+	t.Run("old value", func(t *testing.T) {
+		t.Parallel()
+
+		ps := newStack()
+		r := &configRotator{matchOldValue: true, oldValue: "old", newValue: "new"}
+		rotated, err := r.rotate(ctx, ps, getSecretsManager)
+		require.NoError(t, err)
+		assert.Equal(t, []rotatedKey{
+			{key: config.MustMakeKey("proj", "dbPassword"), wasSecret: true},
+			{key: config.MustMakeKey("proj", "legacy"), wasSecret: false},
+		}, rotated)
+
+		for _, k := range []string{"dbPassword", "legacy"} {
+			v := ps.Config[config.MustMakeKey("proj", k)]
+			assert.True(t, v.Secure())
+			plaintext, err := v.Value(dec)
+			require.NoError(t, err)
+			assert.Equal(t, "new", plaintext)
+		}
+		assert.Equal(t, config.NewObjectValue(`{"password":"old"}`), ps.Config[config.MustMakeKey("proj", "settings")])
+	})
+
+	t.Run("key pattern", func(t *testing.T) {
+		t.Parallel()
+
+		ps := newStack()
+		r := &configRotator{keyPattern: "*:apiToken", newValue: "new"}
+		rotated, err := r.rotate(ctx, ps, getSecretsManager)
+		require.NoError(t, err)
+		assert.Equal(t, []rotatedKey{{key: config.MustMakeKey("proj", "apiToken"), wasSecret: true}}, rotated)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		t.Parallel()
+
+		ps := newStack()
+		before := ps.Config[config.MustMakeKey("proj", "dbPassword")]
+		r := &configRotator{matchOldValue: true, oldValue: "old", keyPattern: "*:db*", dryRun: true}
+		rotated, err := r.rotate(ctx, ps, getSecretsManager)
+		require.NoError(t, err)
+		assert.Equal(t, []rotatedKey{{key: config.MustMakeKey("proj", "dbPassword"), wasSecret: true}}, rotated)
+		assert.Equal(t, before, ps.Config[config.MustMakeKey("proj", "dbPassword")])
+	})
+}
+
+func TestConfigRotatorBaseLayers(t *testing.T) {
+	t.Parallel()
+
+	sm := b64.NewBase64SecretsManager()
+	enc, err := sm.Encrypter()
+	require.NoError(t, err)
+	ct, err := enc.EncryptValue(context.Background(), "old")
+	require.NoError(t, err)
+	getSecretsManager := func() (secrets.Manager, error) { return sm, nil }
+
+	dir := t.TempDir()
+	stackPath := filepath.Join(dir, "Pulumi.dev.yaml")
+	layers := []workspace.ConfigLayer{
+		{Origin: "shared.yaml", Config: config.Map{
+			config.MustMakeKey("proj", "dbPassword"): config.NewSecureValue(ct),
+			config.MustMakeKey("proj", "region"):     config.NewValue("us-west-2"),
+		}},
+		{Origin: "Pulumi.base.yaml", Config: config.Map{
+			config.MustMakeKey("proj", "legacy"): config.NewValue("old"),
+		}},
+		{Origin: "Pulumi.dev.yaml", Config: config.Map{
+			config.MustMakeKey("proj", "apiToken"): config.NewValue("old"),
+		}},
+	}
+
+	r := &configRotator{matchOldValue: true, oldValue: "old", newValue: "new"}
+	rotations, err := r.checkBaseLayers("dev", stackPath, layers, nil, getSecretsManager)
+	require.NoError(t, err)
+	assert.Equal(t, []configRotation{
+		{Stack: "dev", Key: "proj:dbPassword", WasSecret: true, Layer: "shared.yaml"},
+		{Stack: "dev", Key: "proj:legacy", WasSecret: false, Layer: "Pulumi.base.yaml"},
+	}, rotations)
+
+	// Layers that are the configuration of a rotated stack are skipped.
+	rotated := map[string]bool{filepath.Join(dir, "Pulumi.base.yaml"): true}
+	rotations, err = r.checkBaseLayers("dev", stackPath, layers, rotated, getSecretsManager)
+	require.NoError(t, err)
+	assert.Equal(t, []configRotation{
+		{Stack: "dev", Key: "proj:dbPassword", WasSecret: true, Layer: "shared.yaml"},
+	}, rotations)
+}
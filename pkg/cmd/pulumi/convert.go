@@ -15,10 +15,12 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/blang/semver"
 	"github.com/hashicorp/go-multierror"
@@ -32,6 +34,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/codegen/dotnet"
 	"github.com/pulumi/pulumi/pkg/v3/codegen/pcl"
 	"github.com/pulumi/pulumi/pkg/v3/codegen/python"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/report"
 	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
 	"github.com/pulumi/pulumi/pkg/v3/engine"
 	"github.com/pulumi/pulumi/pkg/v3/version"
@@ -58,6 +61,7 @@ func newConvertCmd() *cobra.Command {
 	var generateOnly bool
 	var mappings []string
 	var strict bool
+	var reportPath string
 
 	cmd := &cobra.Command{
 		Use:   "convert",
@@ -65,14 +69,19 @@ func newConvertCmd() *cobra.Command {
 		Short: "Convert Pulumi programs from a supported source program into other supported languages",
 		Long: "Convert Pulumi programs from a supported source program into other supported languages.\n" +
 			"\n" +
-			"The source program to convert will default to the current working directory.\n",
+			"The source program to convert will default to the current working directory.\n" +
+			"\n" +
+			"The versions of the converter plugin and of the provider plugins that map the source program are pinned\n" +
+			"by the plugins section of the Pulumi.yaml in the current working directory, if there is one. Otherwise,\n" +
+			"and for any plugins that it does not list, the latest installed versions are used. If some providers\n" +
+			"can't be mapped, the program is converted again with the provider plugins that it references.\n",
 		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
 			cwd, err := os.Getwd()
 			if err != nil {
 				return result.FromError(fmt.Errorf("get current working directory: %w", err))
 			}
 
-			return runConvert(env.Global(), cwd, mappings, from, language, outDir, generateOnly, strict)
+			return runConvert(env.Global(), cwd, mappings, from, language, outDir, generateOnly, strict, reportPath)
 		}),
 	}
 
@@ -85,7 +94,9 @@ func newConvertCmd() *cobra.Command {
 
 	cmd.PersistentFlags().StringVar(
 		//nolint:lll
-		&from, "from", "yaml", "Which converter plugin to use to read the source program")
+		&from, "from", "yaml", "Which converter plugin to use to read the source program, optionally with a version "+
+			"(e.g. terraform@1.0.0). Any installed converter plugin can be used. Versions can also be pinned in the "+
+			"plugins section of the Pulumi.yaml in the current working directory")
 
 	cmd.PersistentFlags().StringVar(
 		//nolint:lll
@@ -102,6 +113,11 @@ func newConvertCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(
 		&strict, "strict", false, "If strict is set the conversion will fail on errors such as missing variables")
 
+	cmd.PersistentFlags().StringVar(
+		&reportPath, "report", "",
+		"Write a JSON report of how much of the source program was converted to the given file. "+
+			"Coverage is reported per language and per source file")
+
 	return cmd
 }
 
@@ -193,7 +209,7 @@ func generatorWrapper(generator projectGeneratorFunc) projectGeneratorFunction {
 func runConvert(
 	e env.Env,
 	cwd string, mappings []string, from string, language string,
-	outDir string, generateOnly bool, strict bool,
+	outDir string, generateOnly bool, strict bool, reportPath string,
 ) result.Result {
 	pCtx, err := newPluginContext(cwd)
 	if err != nil {
@@ -201,12 +217,18 @@ func runConvert(
 	}
 	defer contract.IgnoreClose(pCtx.Host)
 
-	// Translate well known sources to plugins
-	switch from {
-	case "tf":
-		from = "terraform"
-	case "":
-		from = "yaml"
+	// Converter plugins can be pinned in the project of the source program.
+	projectPlugins := loadProjectPlugins(cwd)
+	from, converterVersion, err := parseConverterSource(from, projectPlugins)
+	if err != nil {
+		return result.FromError(err)
+	}
+	if converterVersion != nil && (from == "yaml" || from == "pcl") {
+		return result.FromError(fmt.Errorf("the %s converter is built in and can not be pinned to a version", from))
+	}
+	mapperPlugins, err := providerPluginSpecs(projectPlugins)
+	if err != nil {
+		return result.FromError(err)
 	}
 
 	// Translate well known languages to runtimes
@@ -283,12 +305,28 @@ func runConvert(
 		return version
 	}
 
+	var conversionReport *report.ConversionReport
+	writeReport := func() {
+		if conversionReport == nil {
+			return
+		}
+		if err := conversionReport.WriteFile(reportPath); err != nil {
+			pCtx.Diag.Warningf(diag.Message("", "failed to write conversion report: %v"), err)
+		}
+	}
+	if reportPath != "" {
+		conversionReport = report.NewConversionReport(from)
+	}
+
 	loader := schema.NewPluginLoader(pCtx.Host)
-	mapper, err := convert.NewPluginMapper(
-		convert.DefaultWorkspace(), convert.ProviderFactoryFromHost(pCtx.Host),
-		from, mappings, installProvider)
-	if err != nil {
-		return result.FromError(fmt.Errorf("create provider mapper: %w", err))
+	newMapper := func(plugins []workspace.PluginSpec) (*reportingMapper, error) {
+		mapper, err := convert.NewPluginMapper(
+			convert.DefaultWorkspace(), convert.ProviderFactoryFromHost(pCtx.Host),
+			from, mappings, plugins, installProvider)
+		if err != nil {
+			return nil, fmt.Errorf("create provider mapper: %w", err)
+		}
+		return &reportingMapper{Mapper: mapper, report: conversionReport}, nil
 	}
 
 	pclDirectory, err := os.MkdirTemp("", "pulumi-convert")
@@ -311,7 +349,7 @@ func runConvert(
 		pclDirectory = cwd
 	} else {
 		// Try and load the converter plugin for this
-		converter, err := plugin.NewConverter(pCtx, from, nil)
+		converter, err := plugin.NewConverter(pCtx, from, converterVersion)
 		if err != nil {
 			// If NewConverter returns a MissingError, we can try and install the plugin and try again.
 			var me *workspace.MissingError
@@ -321,8 +359,9 @@ func runConvert(
 			}
 
 			pluginSpec := workspace.PluginSpec{
-				Kind: workspace.ConverterPlugin,
-				Name: from,
+				Kind:    workspace.ConverterPlugin,
+				Name:    from,
+				Version: converterVersion,
 			}
 
 			_, err = pkgWorkspace.InstallPlugin(pluginSpec, log)
@@ -330,31 +369,71 @@ func runConvert(
 				return result.FromError(fmt.Errorf("install plugin source %q: %w", from, err))
 			}
 
-			converter, err = plugin.NewConverter(pCtx, from, nil)
+			converter, err = plugin.NewConverter(pCtx, from, converterVersion)
 			if err != nil {
 				return result.FromError(fmt.Errorf("load plugin source %q: %w", from, err))
 			}
 		}
 		defer contract.IgnoreClose(converter)
 
-		mapperServer := convert.NewMapperServer(mapper)
-		grpcServer, err := plugin.NewServer(pCtx, convert.MapperRegistration(mapperServer))
+		convertProgram := func(mapper convert.Mapper) (*plugin.ConvertProgramResponse, error) {
+			mapperServer := convert.NewMapperServer(mapper)
+			grpcServer, err := plugin.NewServer(pCtx, convert.MapperRegistration(mapperServer))
+			if err != nil {
+				return nil, err
+			}
+			defer contract.IgnoreClose(grpcServer)
+
+			return converter.ConvertProgram(pCtx.Request(), &plugin.ConvertProgramRequest{
+				SourceDirectory: cwd,
+				TargetDirectory: pclDirectory,
+				MapperAddress:   grpcServer.Addr(),
+			})
+		}
+
+		mapper, err := newMapper(mapperPlugins)
 		if err != nil {
 			return result.FromError(err)
 		}
-
-		resp, err := converter.ConvertProgram(pCtx.Request(), &plugin.ConvertProgramRequest{
-			SourceDirectory: cwd,
-			TargetDirectory: pclDirectory,
-			MapperAddress:   grpcServer.Addr(),
-		})
+		resp, err := convertProgram(mapper)
 		if err != nil {
 			return result.FromError(err)
 		}
+
+		// Converters can't tell us which plugins a program needs until they have converted it. If some providers
+		// couldn't be mapped, convert the program again with a mapper populated from the packages that the
+		// converted program references, which are the plugins it will be deployed with.
+		if mapper.failed && !resp.Diagnostics.HasErrors() {
+			programPlugins, err := programPluginSpecs(pclDirectory, loader, mapperPlugins)
+			if err != nil {
+				return result.FromError(err)
+			}
+			if len(programPlugins) > len(mapperPlugins) {
+				if err := resetDirectory(pclDirectory); err != nil {
+					return result.FromError(fmt.Errorf("clear intermediate directory: %w", err))
+				}
+				if conversionReport != nil {
+					conversionReport = report.NewConversionReport(from)
+				}
+				mapper, err = newMapper(programPlugins)
+				if err != nil {
+					return result.FromError(err)
+				}
+				resp, err = convertProgram(mapper)
+				if err != nil {
+					return result.FromError(err)
+				}
+			}
+		}
+
 		// These diagnostics come directly from the converter and so _should_ be user friendly. So we're just
 		// going to print them.
 		printDiagnostics(pCtx.Diag, resp.Diagnostics)
+		if conversionReport != nil {
+			conversionReport.AddConverterDiagnostics(resp.Diagnostics)
+		}
 		if resp.Diagnostics.HasErrors() {
+			writeReport()
 			// If we've got error diagnostics then program generation failed, we've printed the error above so
 			// just return a plain message here.
 			return result.FromError(fmt.Errorf("conversion failed"))
@@ -374,6 +453,13 @@ func runConvert(
 
 	pCtx.Diag.Infof(diag.Message("", "Converting to %s..."), language)
 	diagnostics, err := projectGenerator(pclDirectory, outDir, proj, loader, strict)
+	if conversionReport != nil {
+		// Bind the intermediate program again, keeping it even if it has errors, so that we can report on
+		// everything that was and wasn't converted.
+		program, bindDiagnostics, _ := pcl.BindDirectory(pclDirectory, loader, strict, pcl.PreserveProgramOnError)
+		conversionReport.AddProgram(language, program, append(bindDiagnostics, diagnostics...))
+		writeReport()
+	}
 	// If we have error diagnostics then program generation failed, print an error to the user that they
 	// should raise an issue about this
 	if diagnostics.HasErrors() {
@@ -430,6 +516,133 @@ func runConvert(
 	return nil
 }
 
+// reportingMapper records whether any provider could not be mapped, and which, in the conversion report if any.
+type reportingMapper struct {
+	convert.Mapper
+	report *report.ConversionReport
+	failed bool
+}
+
+func (m *reportingMapper) GetMapping(ctx context.Context, provider string, pulumiProvider string) ([]byte, error) {
+	data, err := m.Mapper.GetMapping(ctx, provider, pulumiProvider)
+	if err != nil || len(data) == 0 {
+		m.failed = true
+		if m.report != nil {
+			m.report.AddFailedMapping(provider)
+		}
+	}
+	return data, err
+}
+
+// converterAliases translates well known sources to the names of their converter plugins.
+var converterAliases = map[string]string{
+	"tf": "terraform",
+}
+
+// parseConverterSource parses the `--from` flag, which names the converter to use and may pin its version, e.g.
+// "terraform@1.0.0". If the flag doesn't pin a version, the version pinned by the project's converter plugins is used.
+func parseConverterSource(from string, plugins *workspace.Plugins) (string, *semver.Version, error) {
+	name, versionString, hasVersion := strings.Cut(from, "@")
+	if alias, ok := converterAliases[name]; ok {
+		name = alias
+	}
+	if name == "" {
+		name = "yaml"
+	}
+	if !hasVersion {
+		if opts, ok := plugins.GetConverter(name); ok {
+			versionString = opts.Version
+		}
+	}
+	if versionString == "" {
+		return name, nil, nil
+	}
+
+	version, err := semver.ParseTolerant(versionString)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid version for converter %q: %w", name, err)
+	}
+	return name, &version, nil
+}
+
+// loadProjectPlugins returns the plugins of the project that contains the given directory, if any.
+func loadProjectPlugins(dir string) *workspace.Plugins {
+	path, err := workspace.DetectProjectPathFrom(dir)
+	if err != nil || path == "" {
+		return nil
+	}
+	proj, err := workspace.LoadProject(path)
+	if err != nil {
+		return nil
+	}
+	return proj.Plugins
+}
+
+// providerPluginSpecs returns the resource plugins of a project, which the mapper prefers over installed plugins.
+func providerPluginSpecs(plugins *workspace.Plugins) ([]workspace.PluginSpec, error) {
+	if plugins == nil {
+		return nil, nil
+	}
+	specs := make([]workspace.PluginSpec, 0, len(plugins.Providers))
+	for _, p := range plugins.Providers {
+		spec := workspace.PluginSpec{Name: p.Name, Kind: workspace.ResourcePlugin}
+		if p.Version != "" {
+			version, err := semver.ParseTolerant(p.Version)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version for provider %q: %w", p.Name, err)
+			}
+			spec.Version = &version
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// programPluginSpecs returns the given project plugins, followed by the resource plugins that the PCL program in the
+// given directory references and the project doesn't pin, at the versions the program references them at.
+func programPluginSpecs(
+	dir string, loader schema.ReferenceLoader, projectPlugins []workspace.PluginSpec,
+) ([]workspace.PluginSpec, error) {
+	program, _, err := pcl.BindDirectory(dir, loader, false, pcl.PreserveProgramOnError)
+	if err != nil {
+		return nil, fmt.Errorf("bind converted program: %w", err)
+	}
+	if program == nil {
+		return projectPlugins, nil
+	}
+
+	specs := append([]workspace.PluginSpec{}, projectPlugins...)
+	pinned := map[string]bool{}
+	for _, spec := range projectPlugins {
+		pinned[spec.Name] = true
+	}
+	for _, ref := range program.PackageReferences() {
+		if pinned[ref.Name()] || ref.Name() == "pulumi" {
+			continue
+		}
+		specs = append(specs, workspace.PluginSpec{
+			Name:    ref.Name(),
+			Kind:    workspace.ResourcePlugin,
+			Version: ref.Version(),
+		})
+	}
+	return specs, nil
+}
+
+// resetDirectory removes the contents of the given directory.
+func resetDirectory(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func newPluginContext(cwd string) (*plugin.Context, error) {
 	sink := diag.DefaultSink(os.Stderr, os.Stderr, diag.FormatOptions{
 		Color: cmdutil.GetGlobalColorization(),
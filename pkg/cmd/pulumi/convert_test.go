@@ -1,13 +1,19 @@
 package main
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
 
+	"github.com/blang/semver"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/report"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/testing/utils"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/env"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -33,7 +39,7 @@ func TestYamlConvert(t *testing.T) {
 		t.Fatalf("Pulumi.yaml is a directory, not a file")
 	}
 
-	result := runConvert(env.Global(), "convert_testdata", []string{}, "yaml", "go", "convert_testdata/go", true, true)
+	result := runConvert(env.Global(), "convert_testdata", []string{}, "yaml", "go", "convert_testdata/go", true, true, "")
 	require.Nil(t, result, "convert failed: %v", result)
 }
 
@@ -43,7 +49,7 @@ func TestPclConvert(t *testing.T) {
 	// Check that we can run convert from PCL to PCL
 	tmp := t.TempDir()
 
-	result := runConvert(env.Global(), "pcl_convert_testdata", []string{}, "pcl", "pcl", tmp, true, true)
+	result := runConvert(env.Global(), "pcl_convert_testdata", []string{}, "pcl", "pcl", tmp, true, true, "")
 	assert.Nil(t, result)
 
 	// Check that we made one file
@@ -62,3 +68,93 @@ output result {
 }`
 	assert.Equal(t, expectedPclCode, pclCode)
 }
+
+func TestPclConvertReport(t *testing.T) {
+	t.Parallel()
+
+	tmp := t.TempDir()
+	reportPath := filepath.Join(tmp, "report.json")
+
+	result := runConvert(
+		env.Global(), "pcl_convert_testdata", []string{}, "pcl", "pcl", filepath.Join(tmp, "out"), true, true, reportPath)
+	assert.Nil(t, result)
+
+	reportBytes, err := os.ReadFile(reportPath)
+	require.NoError(t, err)
+
+	var conversionReport report.ConversionReport
+	require.NoError(t, json.Unmarshal(reportBytes, &conversionReport))
+	assert.Equal(t, "pcl", conversionReport.From)
+	assert.Equal(t, 2, conversionReport.Total)
+	assert.Equal(t, 2, conversionReport.Converted)
+	assert.Equal(t, float64(100), conversionReport.Percentage)
+	require.Contains(t, conversionReport.Languages, "pcl")
+	assert.Contains(t, conversionReport.Languages["pcl"].Files, "main.pp")
+}
+
+func TestParseConverterSource(t *testing.T) {
+	t.Parallel()
+
+	plugins := &workspace.Plugins{
+		Converters: []workspace.PluginOptions{
+			{Name: "terraform", Version: "1.2.0"},
+		},
+	}
+
+	cases := []struct {
+		from    string
+		plugins *workspace.Plugins
+		name    string
+		version string
+	}{
+		{from: "", name: "yaml"},
+		{from: "tf", name: "terraform"},
+		{from: "arm", name: "arm"},
+		{from: "terraform@1.0.0", name: "terraform", version: "1.0.0"},
+		{from: "tf", plugins: plugins, name: "terraform", version: "1.2.0"},
+		// The version given to --from takes precedence over the one pinned by the project.
+		{from: "tf@1.0.0", plugins: plugins, name: "terraform", version: "1.0.0"},
+	}
+	for _, c := range cases {
+		name, version, err := parseConverterSource(c.from, c.plugins)
+		require.NoError(t, err)
+		assert.Equal(t, c.name, name)
+		if c.version == "" {
+			assert.Nil(t, version)
+		} else {
+			require.NotNil(t, version)
+			assert.Equal(t, c.version, version.String())
+		}
+	}
+
+	_, _, err := parseConverterSource("terraform@latest", nil)
+	assert.ErrorContains(t, err, `invalid version for converter "terraform"`)
+}
+
+func TestProgramPluginSpecs(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	program := `resource pet "random:index/randomPet:RandomPet" {
+  prefix = "doggo"
+}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.pp"), []byte(program), 0o600))
+	loader := schema.NewPluginLoader(utils.NewHost(filepath.Join("..", "..", "codegen", "testing", "test", "testdata")))
+
+	// Packages that the converted program references are added after the project plugins.
+	version := semver.MustParse("5.4.0")
+	projectPlugins := []workspace.PluginSpec{{Name: "aws", Kind: workspace.ResourcePlugin, Version: &version}}
+	specs, err := programPluginSpecs(dir, loader, projectPlugins)
+	require.NoError(t, err)
+	require.Len(t, specs, 2)
+	assert.Equal(t, projectPlugins[0], specs[0])
+	assert.Equal(t, "random", specs[1].Name)
+	assert.Equal(t, workspace.ResourcePlugin, specs[1].Kind)
+
+	// Project plugins take precedence over the program's references.
+	projectPlugins = []workspace.PluginSpec{{Name: "random", Kind: workspace.ResourcePlugin, Version: &version}}
+	specs, err = programPluginSpecs(dir, loader, projectPlugins)
+	require.NoError(t, err)
+	assert.Equal(t, projectPlugins, specs)
+}
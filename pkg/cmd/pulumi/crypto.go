@@ -16,13 +16,16 @@ package main
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/pulumi/pulumi/pkg/v3/backend"
 	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
 	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/age"
 	"github.com/pulumi/pulumi/pkg/v3/secrets/cloud"
 	"github.com/pulumi/pulumi/pkg/v3/secrets/passphrase"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/secretsplugin"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/deepcopy"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
@@ -59,7 +62,13 @@ func getStackSecretsManager(s backend.Stack, ps *workspace.ProjectStack) (secret
 
 	var sm secrets.Manager
 	var err error
-	if ps.SecretsProvider != passphrase.Type && ps.SecretsProvider != "default" && ps.SecretsProvider != "" {
+	if age.IsAgeSecretsProvider(ps.SecretsProvider) {
+		sm, err = age.NewAgeSecretsManager(
+			ps, ps.SecretsProvider, false /* rotateSecretsProvider */)
+	} else if secretsplugin.IsPluginSecretsProvider(ps.SecretsProvider) {
+		sm, err = secretsplugin.NewPluginSecretsManager(
+			ps, ps.SecretsProvider, false /* rotateSecretsProvider */)
+	} else if ps.SecretsProvider != passphrase.Type && ps.SecretsProvider != "default" && ps.SecretsProvider != "" {
 		sm, err = cloud.NewCloudSecretsManager(
 			ps, ps.SecretsProvider, false /* rotateSecretsProvider */)
 	} else if ps.EncryptionSalt != "" {
@@ -74,7 +83,10 @@ func getStackSecretsManager(s backend.Stack, ps *workspace.ProjectStack) (secret
 
 	// Handle if the configuration changed any of EncryptedKey, etc
 	needsSave := needsSaveProjectStackAfterSecretManger(s, oldConfig, ps)
-	return stack.NewCachingSecretsManager(sm), needsSave, nil
+
+	addSecretsAuditLabels(ps.Config, ps.Environment)
+	sm = auditSecretsManager(s.Ref().FullyQualifiedName().String(), stack.NewCachingSecretsManager(sm))
+	return sm, needsSave, nil
 }
 
 func needsSaveProjectStackAfterSecretManger(stack backend.Stack,
@@ -87,7 +99,9 @@ func needsSaveProjectStackAfterSecretManger(stack backend.Stack,
 	// this is not the desired behaviour.
 	if old.EncryptedKey != new.EncryptedKey ||
 		old.EncryptionSalt != new.EncryptionSalt ||
-		old.SecretsProvider != new.SecretsProvider {
+		old.SecretsProvider != new.SecretsProvider ||
+		old.SecretsProviderState != new.SecretsProviderState ||
+		!reflect.DeepEqual(old.KeyWrappers, new.KeyWrappers) {
 		return true
 	}
 	return false
@@ -95,13 +109,20 @@ func needsSaveProjectStackAfterSecretManger(stack backend.Stack,
 
 func validateSecretsProvider(typ string) error {
 	kind := strings.SplitN(typ, ":", 2)[0]
-	supportedKinds := []string{"default", "passphrase", "awskms", "azurekeyvault", "gcpkms", "hashivault"}
+	supportedKinds := []string{"default", "passphrase", "age", "awskms", "azurekeyvault", "gcpkms", "hashivault"}
 	for _, supportedKind := range supportedKinds {
 		if kind == supportedKind {
 			return nil
 		}
 	}
-	return fmt.Errorf("unknown secrets provider type '%s' (supported values: %s)",
+	// Any other URL scheme names a secrets provider plugin, which must be installed.
+	if secretsplugin.IsPluginSecretsProvider(typ) {
+		if _, err := workspace.GetPluginInfo(workspace.SecretsPlugin, kind, nil, nil); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown secrets provider type '%s' (supported values: %s, "+
+		"or the name of an installed secrets provider plugin)",
 		kind,
 		strings.Join(supportedKinds, ","))
 }
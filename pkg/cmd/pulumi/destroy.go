@@ -50,6 +50,8 @@ func newDestroyCmd() *cobra.Command {
 
 	// Flags for remote operations.
 	remoteArgs := RemoteArgs{}
+	diagnosticArgs := DiagnosticArgs{}
+	outputArgs := OutputArgs{}
 
 	// Flags for engine.UpdateOptions.
 	var jsonDisplay bool
@@ -98,7 +100,15 @@ func newDestroyCmd() *cobra.Command {
 			}
 
 			yes = yes || skipPreview || skipConfirmations()
-			interactive := cmdutil.Interactive()
+
+			if err := outputArgs.validate(jsonDisplay, diffDisplay); err != nil {
+				return result.FromError(err)
+			}
+			// Messages would corrupt the JSON that is printed with either --json or --output json.
+			quiet := jsonDisplay || outputArgs.json()
+
+			// The JSON summary is meant to be read by other programs, so never prompt for it.
+			interactive := cmdutil.Interactive() && !outputArgs.json()
 			if !interactive && !yes {
 				return result.FromError(
 					errors.New("--yes or --skip-preview must be passed in to proceed when running in non-interactive mode"))
@@ -135,10 +145,18 @@ func newDestroyCmd() *cobra.Command {
 				opts.Display.SuppressPermalink = false
 			}
 
+			if err := diagnosticArgs.applyOptions(&opts.Display); err != nil {
+				return result.FromError(err)
+			}
+			outputArgs.applyOptions(&opts.Display)
+
 			if remoteArgs.remote {
 				if len(args) == 0 {
 					return result.FromError(errors.New("must specify remote URL"))
 				}
+				if outputArgs.json() {
+					return result.FromError(errors.New("--output is not supported for remote operations"))
+				}
 
 				err = validateUnsupportedRemoteFlags(false, nil, false, "", jsonDisplay, nil,
 					nil, refresh, showConfig, showReplacementSteps, showSames, false,
@@ -212,7 +230,7 @@ func newDestroyCmd() *cobra.Command {
 			}
 
 			stackName := s.Ref().Name().String()
-			configError := workspace.ValidateStackConfigAndApplyProjectConfig(stackName, proj, cfg.Config, decrypter)
+			configError := validateStackConfig(stackName, proj, cfg.Config, decrypter)
 			if configError != nil {
 				return result.FromError(fmt.Errorf("validating stack config: %w", configError))
 			}
@@ -226,6 +244,11 @@ func newDestroyCmd() *cobra.Command {
 				return result.FromError(errors.New("You cannot specify --target and --exclude-protected"))
 			}
 
+			summary, err := newOperationSummary(ctx, apitype.DestroyOperation, s, opts.Display)
+			if err != nil {
+				return result.FromError(err)
+			}
+
 			var protectedCount int
 			targetUrns := *targets
 			if excludeProtected {
@@ -234,14 +257,14 @@ func newDestroyCmd() *cobra.Command {
 				if err != nil {
 					return result.FromError(err)
 				} else if protectedCount > 0 && len(targetUrns) == 0 {
-					if !jsonDisplay {
+					if !quiet {
 						fmt.Printf("There were no unprotected resources to destroy. There are still %d"+
 							" protected resources associated with this stack.\n", protectedCount)
 					}
 					// We need to return now. Otherwise the update will conclude
 					// we tried to destroy everything and error for trying to
 					// destroy a protected resource.
-					return nil
+					return result.WrapIfNonNil(summary.print(ctx, nil, nil))
 				}
 			}
 
@@ -252,13 +275,14 @@ func newDestroyCmd() *cobra.Command {
 				Targets:                   deploy.NewUrnTargets(targetUrns),
 				TargetDependents:          targetDependents,
 				UseLegacyDiff:             useLegacyDiff(),
+				Environment:               cfg.Environment,
 				DisableProviderPreview:    disableProviderPreview(),
 				DisableResourceReferences: disableResourceReferences(),
 				DisableOutputValues:       disableOutputValues(),
 				Experimental:              hasExperimentalCommands(),
 			}
 
-			_, res := s.Destroy(ctx, backend.UpdateOperation{
+			changes, res := s.Destroy(ctx, backend.UpdateOperation{
 				Proj:               proj,
 				Root:               root,
 				M:                  m,
@@ -268,12 +292,15 @@ func newDestroyCmd() *cobra.Command {
 				SecretsProvider:    stack.DefaultSecretsProvider,
 				Scopes:             backend.CancellationScopes,
 			})
+			if err := summary.print(ctx, changes, res); err != nil {
+				return result.FromError(err)
+			}
 
-			if res == nil && protectedCount > 0 && !jsonDisplay {
+			if res == nil && protectedCount > 0 && !quiet {
 				fmt.Printf("All unprotected resources were destroyed. There are still %d protected resources"+
 					" associated with this stack.\n", protectedCount)
 			} else if res == nil && len(*targets) == 0 {
-				if !jsonDisplay && !remove {
+				if !quiet && !remove {
 					fmt.Printf("The resources in the stack have been deleted, but the history and configuration "+
 						"associated with the stack are still maintained. \nIf you want to remove the stack "+
 						"completely, run `pulumi stack rm %s`.\n", s.Ref())
@@ -286,7 +313,7 @@ func newDestroyCmd() *cobra.Command {
 					if _, path, err := workspace.DetectProjectStackPath(s.Ref().Name().Q()); err == nil {
 						if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
 							return result.FromError(err)
-						} else if !jsonDisplay {
+						} else if !quiet {
 							fmt.Printf("The resources in the stack have been deleted, and the history and " +
 								"configuration removed.\n")
 						}
@@ -367,6 +394,12 @@ func newDestroyCmd() *cobra.Command {
 	// Remote flags
 	remoteArgs.applyFlags(cmd)
 
+	// Diagnostic flags
+	diagnosticArgs.applyFlags(cmd)
+
+	// Output flags
+	outputArgs.applyFlags(cmd)
+
 	if hasDebugCommands() {
 		cmd.PersistentFlags().StringVar(
 			&eventLogPath, "event-log", "",
@@ -41,6 +41,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/providers"
 	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
 	pkgWorkspace "github.com/pulumi/pulumi/pkg/v3/workspace"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
@@ -386,6 +387,8 @@ func newImportCmd() *cobra.Command {
 
 	var from string
 
+	outputArgs := OutputArgs{}
+
 	cmd := &cobra.Command{
 		Use:   "import [type] [name] [id]",
 		Args:  cmdutil.MaximumNArgs(3),
@@ -516,7 +519,7 @@ func newImportCmd() *cobra.Command {
 
 				mapper, err := convert.NewPluginMapper(
 					convert.DefaultWorkspace(), convert.ProviderFactoryFromHost(pCtx.Host),
-					from, nil, installProvider)
+					from, nil, nil, installProvider)
 				if err != nil {
 					return result.FromError(err)
 				}
@@ -571,7 +574,17 @@ func newImportCmd() *cobra.Command {
 			}
 
 			yes = yes || skipPreview || skipConfirmations()
-			interactive := cmdutil.Interactive()
+
+			if err := outputArgs.validate(false /*jsonDisplay*/, false /*diffDisplay*/); err != nil {
+				return result.FromError(err)
+			}
+			if outputArgs.json() && generateCode && outputFilePath == "" {
+				return result.FromError(errors.New(
+					"--out or --generate-code=false must be passed in to generate code with --output json"))
+			}
+
+			// The JSON summary is meant to be read by other programs, so never prompt for it.
+			interactive := cmdutil.Interactive() && !outputArgs.json()
 			if !interactive && !yes {
 				return result.FromError(
 					errors.New("--yes or --skip-preview must be passed in to proceed when running in non-interactive mode"))
@@ -596,6 +609,7 @@ func newImportCmd() *cobra.Command {
 				EventLogPath:    eventLogPath,
 				Debug:           debug,
 			}
+			outputArgs.applyOptions(&opts.Display)
 
 			// we only suppress permalinks if the user passes true. the default is an empty string
 			// which we pass as 'false'
@@ -682,7 +696,7 @@ func newImportCmd() *cobra.Command {
 			}
 
 			stackName := s.Ref().Name().String()
-			configErr := workspace.ValidateStackConfigAndApplyProjectConfig(stackName, proj, cfg.Config, decrypter)
+			configErr := validateStackConfig(stackName, proj, cfg.Config, decrypter)
 			if configErr != nil {
 				return result.FromError(fmt.Errorf("validating stack config: %w", configErr))
 			}
@@ -691,10 +705,16 @@ func newImportCmd() *cobra.Command {
 				Parallel:      parallel,
 				Debug:         debug,
 				UseLegacyDiff: useLegacyDiff(),
+				Environment:   cfg.Environment,
 				Experimental:  hasExperimentalCommands(),
 			}
 
-			_, res := s.Import(ctx, backend.UpdateOperation{
+			summary, err := newOperationSummary(ctx, apitype.ImportOperation, s, opts.Display)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			changes, res := s.Import(ctx, backend.UpdateOperation{
 				Proj:               proj,
 				Root:               root,
 				M:                  m,
@@ -704,6 +724,9 @@ func newImportCmd() *cobra.Command {
 				SecretsProvider:    stack.DefaultSecretsProvider,
 				Scopes:             backend.CancellationScopes,
 			}, imports)
+			if err := summary.print(ctx, changes, res); err != nil {
+				return result.FromError(err)
+			}
 
 			if generateCode {
 				deployment, err := getCurrentDeploymentForStack(ctx, s)
@@ -807,6 +830,9 @@ func newImportCmd() *cobra.Command {
 		&from, "from", "",
 		"Invoke a converter to import the resources")
 
+	// Output flags
+	outputArgs.applyFlags(cmd)
+
 	if hasDebugCommands() {
 		cmd.PersistentFlags().StringVar(
 			&eventLogPath, "event-log", "",
@@ -20,8 +20,10 @@ import (
 	"runtime"
 	"runtime/debug"
 
+	"github.com/pulumi/pulumi/pkg/v3/secrets/secretsplugin"
 	"github.com/pulumi/pulumi/pkg/v3/version"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
 )
 
 func panicHandler() {
@@ -45,7 +47,12 @@ func panicHandler() {
 
 func main() {
 	defer panicHandler()
-	if err := NewPulumiCmd().Execute(); err != nil {
+	err := NewPulumiCmd().Execute()
+	// Stop any secrets provider plugins that were started while running the command.
+	if closeErr := secretsplugin.Close(); closeErr != nil {
+		logging.Warningf("could not close secrets provider plugins: %v", closeErr)
+	}
+	if err != nil {
 		_, err = fmt.Fprintf(os.Stderr, "An error occurred: %v\n", err)
 		contract.IgnoreError(err)
 		os.Exit(1)
@@ -503,7 +503,8 @@ func newNewCmd() *cobra.Command {
 		"Skip prompts and proceed with default values")
 	cmd.PersistentFlags().StringVar(
 		&args.secretsProvider, "secrets-provider", "default", "The type of the provider that should be used to encrypt and "+
-			"decrypt secrets (possible choices: default, passphrase, awskms, azurekeyvault, gcpkms, hashivault)")
+			"decrypt secrets (possible choices: default, passphrase, age, awskms, azurekeyvault, gcpkms, hashivault, "+
+			"or the name of an installed secrets provider plugin)")
 	cmd.PersistentFlags().BoolVarP(
 		&args.listTemplates, "list-templates", "l", false,
 		"List locally installed templates and exit")
@@ -55,9 +55,22 @@ Subcommands of this command are useful to package authors during development.`,
 // optional version:
 //
 //	FILE.[json|y[a]ml] | PLUGIN[@VERSION] | PATH_TO_PLUGIN
-func schemaFromSchemaSource(packageSource string) (*schema.Package, error) {
+//
+// Any overlays are applied in order to the package spec before it is bound.
+func schemaFromSchemaSource(packageSource string, overlays []string) (*schema.Package, error) {
 	var spec schema.PackageSpec
 	bind := func(spec schema.PackageSpec) (*schema.Package, error) {
+		for _, overlay := range overlays {
+			contents, err := os.ReadFile(overlay)
+			if err != nil {
+				return nil, fmt.Errorf("read schema overlay: %w", err)
+			}
+			spec, err = schema.ApplyOverlay(spec, contents)
+			if err != nil {
+				return nil, fmt.Errorf("apply schema overlay %q: %w", overlay, err)
+			}
+		}
+
 		pkg, diags, err := schema.BindSpec(spec, nil)
 		if err != nil {
 			return nil, err
@@ -34,7 +34,7 @@ func newExtractSchemaCommand() *cobra.Command {
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			source := args[0]
 
-			pkg, err := schemaFromSchemaSource(source)
+			pkg, err := schemaFromSchemaSource(source, nil)
 			if err != nil {
 				return err
 			}
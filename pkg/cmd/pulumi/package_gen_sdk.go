@@ -25,6 +25,8 @@ import (
 	javagen "github.com/pulumi/pulumi-java/pkg/codegen/java"
 
 	"github.com/pulumi/pulumi/pkg/v3/codegen/dotnet"
+	gogen "github.com/pulumi/pulumi/pkg/v3/codegen/go"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/nodejs"
 	"github.com/pulumi/pulumi/pkg/v3/codegen/python"
 	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
@@ -35,17 +37,24 @@ func newGenSdkCommand() *cobra.Command {
 	var overlays string
 	var language string
 	var out string
+	var mocks bool
+	var schemaOverlays []string
 	cmd := &cobra.Command{
 		Use:   "gen-sdk <schema_source>",
 		Args:  cobra.ExactArgs(1),
 		Short: "Generate SDK(s) from a package or schema",
 		Long: `Generate SDK(s) from a package or schema.
 
-<schema_source> can be a package name, the path to a plugin binary, or the path to a schema file.`,
+<schema_source> can be a package name, the path to a plugin binary, or the path to a schema file.
+
+The schema can be patched before the SDK is generated by passing one or more --overlay files. Each overlay is a
+YAML or JSON document containing either a list of JSON Patch (RFC 6902) operations or a JSON Merge Patch (RFC 7386)
+object. Overlays are applied in the order they are given, and fail if they refer to resources, functions or types
+that do not exist in the schema.`,
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			source := args[0]
 
-			pkg, err := schemaFromSchemaSource(source)
+			pkg, err := schemaFromSchemaSource(source, schemaOverlays)
 			if err != nil {
 				return err
 			}
@@ -59,14 +68,19 @@ func newGenSdkCommand() *cobra.Command {
 
 			if language == "all" {
 				for _, lang := range []string{"dotnet", "go", "java", "nodejs", "python"} {
-					err := genSDK(lang, out, pkg, overlays)
+					// Mocks are only generated for the languages that support them.
+					_, supportsMocks := mockGenerators[lang]
+					err := genSDK(lang, out, pkg, overlays, mocks && supportsMocks)
 					if err != nil {
 						return err
 					}
 				}
 				return nil
 			}
-			return genSDK(language, out, pkg, overlays)
+			if _, ok := mockGenerators[language]; mocks && !ok {
+				return fmt.Errorf("--mocks is not supported for %s SDKs", language)
+			}
+			return genSDK(language, out, pkg, overlays, mocks)
 		}),
 	}
 	cmd.Flags().StringVarP(&language, "language", "", "all",
@@ -75,10 +89,21 @@ func newGenSdkCommand() *cobra.Command {
 		"The directory to write the SDK to")
 	cmd.Flags().StringVar(&overlays, "overlays", "", "A folder of extra overlay files to copy to the generated SDK")
 	contract.AssertNoErrorf(cmd.Flags().MarkHidden("overlays"), `Could not mark "overlay" as hidden`)
+	cmd.Flags().StringArrayVar(&schemaOverlays, "overlay", nil,
+		"A JSON Patch or JSON Merge Patch file to apply to the schema before generating the SDK (may be repeated)")
+	cmd.Flags().BoolVar(&mocks, "mocks", false,
+		"Also generate test mocks for the package's resources and functions (Go, Node.js and Python only)")
 	return cmd
 }
 
-func genSDK(language, out string, pkg *schema.Package, overlays string) error {
+// mockGenerators maps each language that supports generated mocks to its mock generator.
+var mockGenerators = map[string]func(string, *schema.Package) (map[string][]byte, error){
+	"go":     gogen.GenerateMocks,
+	"nodejs": nodejs.GenerateMocks,
+	"python": python.GenerateMocks,
+}
+
+func genSDK(language, out string, pkg *schema.Package, overlays string, mocks bool) error {
 	cwd, err := os.Getwd()
 	if err != nil {
 		return fmt.Errorf("get current working directory: %w", err)
@@ -178,10 +203,37 @@ func genSDK(language, out string, pkg *schema.Package, overlays string) error {
 		}
 	}
 
+	var mockFiles map[string][]byte
+	if mocks {
+		mockFiles, err = mockGenerators[language]("pulumi", pkg)
+		if err != nil {
+			return fmt.Errorf("generate mocks: %w", err)
+		}
+		// The Node.js SDK only compiles the TypeScript files it knows about, so pass the mocks through as extra files.
+		if language == "nodejs" {
+			for path, contents := range mockFiles {
+				extraFiles[path] = contents
+			}
+			mockFiles = nil
+		}
+	}
+
 	root := filepath.Join(out, language)
 	err = generatePackage(root, pkg, extraFiles)
 	if err != nil {
 		return err
 	}
+
+	for k, v := range mockFiles {
+		path := filepath.Join(root, k)
+		err := os.MkdirAll(filepath.Dir(path), 0o700)
+		if err != nil {
+			return err
+		}
+		err = os.WriteFile(path, v, 0o600)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -29,11 +29,16 @@ import (
 func newPluginLsCmd() *cobra.Command {
 	var projectOnly bool
 	var jsonOut bool
+	var kind string
 	cmd := &cobra.Command{
 		Use:   "ls",
 		Short: "List plugins",
 		Args:  cmdutil.NoArgs,
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			if kind != "" && !workspace.IsPluginKind(kind) {
+				return fmt.Errorf("unrecognized plugin kind: %s", kind)
+			}
+
 			// Produce a list of plugins, sorted by name and version.
 			var plugins []workspace.PluginInfo
 			var err error
@@ -52,6 +57,10 @@ func newPluginLsCmd() *cobra.Command {
 				}
 			}
 
+			if kind != "" {
+				plugins = filterPluginsByKind(plugins, workspace.PluginKind(kind))
+			}
+
 			// Sort the plugins: by name first alphabetical ascending and version descending, so that plugins
 			// with the same name/kind sort by newest to oldest.
 			sort.Slice(plugins, func(i, j int) bool {
@@ -78,10 +87,24 @@ func newPluginLsCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVarP(
 		&jsonOut, "json", "j", false,
 		"Emit output as JSON")
+	cmd.PersistentFlags().StringVar(
+		&kind, "kind", "",
+		"List only the plugins of the given kind (analyzer, converter, language, resource or secrets)")
 
 	return cmd
 }
 
+// filterPluginsByKind returns the plugins of the given kind.
+func filterPluginsByKind(plugins []workspace.PluginInfo, kind workspace.PluginKind) []workspace.PluginInfo {
+	var filtered []workspace.PluginInfo
+	for _, p := range plugins {
+		if p.Kind == kind {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
 // pluginInfoJSON is the shape of the --json output for a configuration value.  While we can add fields to this
 // structure in the future, we should not change existing fields.
 type pluginInfoJSON struct {
@@ -31,7 +31,6 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 )
 
 func newPreviewCmd() *cobra.Command {
@@ -49,6 +48,8 @@ func newPreviewCmd() *cobra.Command {
 
 	// Flags for remote operations.
 	remoteArgs := RemoteArgs{}
+	diagnosticArgs := DiagnosticArgs{}
+	outputArgs := OutputArgs{formats: []string{"markdown"}}
 
 	// Flags for engine.UpdateOptions.
 	var jsonDisplay bool
@@ -56,6 +57,8 @@ func newPreviewCmd() *cobra.Command {
 	var policyPackConfigPaths []string
 	var diffDisplay bool
 	var eventLogPath string
+	var reportJUnitPath string
+	var reportSARIFPath string
 	var parallel int
 	var refresh string
 	var showConfig bool
@@ -97,6 +100,9 @@ func newPreviewCmd() *cobra.Command {
 			if diffDisplay {
 				displayType = display.DisplayDiff
 			}
+			if err := outputArgs.validate(jsonDisplay, diffDisplay); err != nil {
+				return result.FromError(err)
+			}
 
 			displayOpts := display.Options{
 				Color:                cmdutil.GetGlobalColorization(),
@@ -109,8 +115,11 @@ func newPreviewCmd() *cobra.Command {
 				Type:                 displayType,
 				JSONDisplay:          jsonDisplay,
 				EventLogPath:         eventLogPath,
+				ReportJUnitPath:      reportJUnitPath,
+				ReportSARIFPath:      reportSARIFPath,
 				Debug:                debug,
 			}
+			outputArgs.applyOptions(&displayOpts)
 
 			// we only suppress permalinks if the user passes true. the default is an empty string
 			// which we pass as 'false'
@@ -120,10 +129,17 @@ func newPreviewCmd() *cobra.Command {
 				displayOpts.SuppressPermalink = false
 			}
 
+			if err := diagnosticArgs.applyOptions(&displayOpts); err != nil {
+				return result.FromError(err)
+			}
+
 			if remoteArgs.remote {
 				if len(args) == 0 {
 					return result.FromError(errors.New("must specify remote URL"))
 				}
+				if outputArgs.format != "" {
+					return result.FromError(errors.New("--output is not supported for remote operations"))
+				}
 
 				err := validateUnsupportedRemoteFlags(expectNop, configArray, configPath, client, jsonDisplay,
 					policyPackPaths, policyPackConfigPaths, refresh, showConfig, showReplacementSteps, showSames,
@@ -182,7 +198,7 @@ func newPreviewCmd() *cobra.Command {
 			}
 
 			stackName := s.Ref().Name().String()
-			configErr := workspace.ValidateStackConfigAndApplyProjectConfig(stackName, proj, cfg.Config, decrypter)
+			configErr := validateStackConfig(stackName, proj, cfg.Config, decrypter)
 			if configErr != nil {
 				return result.FromError(fmt.Errorf("validating stack config: %w", configErr))
 			}
@@ -211,6 +227,7 @@ func newPreviewCmd() *cobra.Command {
 					Refresh:                   refreshOption,
 					ReplaceTargets:            deploy.NewUrnTargets(replaceURNs),
 					UseLegacyDiff:             useLegacyDiff(),
+					Environment:               cfg.Environment,
 					DisableProviderPreview:    disableProviderPreview(),
 					DisableResourceReferences: disableResourceReferences(),
 					DisableOutputValues:       disableOutputValues(),
@@ -357,10 +374,20 @@ func newPreviewCmd() *cobra.Command {
 		&suppressPermalink, "suppress-permalink", "",
 		"Suppress display of the state permalink")
 	cmd.Flag("suppress-permalink").NoOptDefVal = "false"
+	cmd.PersistentFlags().StringVar(
+		&reportJUnitPath, "report-junit", "",
+		"Write a JUnit XML report of the resources and policy violations of the update to this file")
+	cmd.PersistentFlags().StringVar(
+		&reportSARIFPath, "report-sarif", "",
+		"Write a SARIF report of the resources and policy violations of the update to this file")
 
 	// Remote flags
 	remoteArgs.applyFlags(cmd)
 
+	// Diagnostic flags
+	diagnosticArgs.applyFlags(cmd)
+	outputArgs.applyFlags(cmd)
+
 	if hasDebugCommands() {
 		cmd.PersistentFlags().StringVar(
 			&eventLogPath, "event-log", "",
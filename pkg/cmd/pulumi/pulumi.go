@@ -200,6 +200,12 @@ func NewPulumiCmd() *cobra.Command {
 				}
 			}
 
+			// Open the secrets audit log before changing directory, so that a relative path is relative to where the
+			// command was run.
+			if err := openSecretsAuditLog(cmd); err != nil {
+				return err
+			}
+
 			if cwd != "" {
 				if err := os.Chdir(cwd); err != nil {
 					return err
@@ -208,6 +214,9 @@ func NewPulumiCmd() *cobra.Command {
 
 			logging.InitLogging(logToStderr, verbose, logFlow)
 			cmdutil.InitTracing("pulumi-cli", "pulumi", tracing)
+			if cmdutil.TracingRootSpan != nil {
+				cmdutil.TracingRootSpan.SetTag("pulumi.command", cmd.CommandPath())
+			}
 			if tracingHeaderFlag != "" {
 				tracingHeader = tracingHeaderFlag
 			}
@@ -251,6 +260,7 @@ func NewPulumiCmd() *cobra.Command {
 				cmdutil.Diag().Warningf(checkVersionMsg)
 			}
 
+			closeSecretsAuditLog()
 			logging.Flush()
 			cmdutil.CloseTracing()
 
@@ -275,7 +285,8 @@ func NewPulumiCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(&cmdutil.DisableInteractive, "non-interactive", false,
 		"Disable interactive mode for all commands")
 	cmd.PersistentFlags().StringVar(&tracing, "tracing", "",
-		"Emit tracing to the specified endpoint. Use the `file:` scheme to write tracing data to a local file")
+		"Emit tracing to the specified endpoint. Use the `file:` scheme to write tracing data to a local file, or "+
+			"the `otlp+grpc:`, `otlp+http:` or `otlp+file:` schemes to export OpenTelemetry (OTLP) traces")
 	cmd.PersistentFlags().StringVar(&profiling, "profiling", "",
 		"Emit CPU and memory profiles and an execution trace to '[filename].[pid].{cpu,mem,trace}', respectively")
 	cmd.PersistentFlags().IntVar(&memProfileRate, "memprofilerate", 0,
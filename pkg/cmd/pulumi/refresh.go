@@ -35,7 +35,6 @@ import (
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 )
 
 func newRefreshCmd() *cobra.Command {
@@ -48,11 +47,15 @@ func newRefreshCmd() *cobra.Command {
 
 	// Flags for remote operations.
 	remoteArgs := RemoteArgs{}
+	diagnosticArgs := DiagnosticArgs{}
+	outputArgs := OutputArgs{}
 
 	// Flags for engine.UpdateOptions.
 	var jsonDisplay bool
 	var diffDisplay bool
 	var eventLogPath string
+	var reportJUnitPath string
+	var reportSARIFPath string
 	var parallel int
 	var showConfig bool
 	var showReplacementSteps bool
@@ -95,7 +98,13 @@ func newRefreshCmd() *cobra.Command {
 			}
 
 			yes = yes || skipPreview || skipConfirmations()
-			interactive := cmdutil.Interactive()
+
+			if err := outputArgs.validate(jsonDisplay, diffDisplay); err != nil {
+				return result.FromError(err)
+			}
+
+			// The JSON summary is meant to be read by other programs, so never prompt for it.
+			interactive := cmdutil.Interactive() && !outputArgs.json()
 			if !interactive && !yes {
 				return result.FromError(
 					errors.New("--yes or --skip-preview must be passed in to proceed when running in non-interactive mode"))
@@ -120,6 +129,8 @@ func newRefreshCmd() *cobra.Command {
 				IsInteractive:        interactive,
 				Type:                 displayType,
 				EventLogPath:         eventLogPath,
+				ReportJUnitPath:      reportJUnitPath,
+				ReportSARIFPath:      reportSARIFPath,
 				Debug:                debug,
 				JSONDisplay:          jsonDisplay,
 			}
@@ -132,10 +143,18 @@ func newRefreshCmd() *cobra.Command {
 				opts.Display.SuppressPermalink = false
 			}
 
+			if err := diagnosticArgs.applyOptions(&opts.Display); err != nil {
+				return result.FromError(err)
+			}
+			outputArgs.applyOptions(&opts.Display)
+
 			if remoteArgs.remote {
 				if len(args) == 0 {
 					return result.FromError(errors.New("must specify remote URL"))
 				}
+				if outputArgs.json() {
+					return result.FromError(errors.New("--output is not supported for remote operations"))
+				}
 
 				err = validateUnsupportedRemoteFlags(expectNop, nil, false, "", jsonDisplay, nil,
 					nil, "", showConfig, showReplacementSteps, showSames, false,
@@ -185,7 +204,7 @@ func newRefreshCmd() *cobra.Command {
 			}
 
 			stackName := s.Ref().Name().String()
-			configErr := workspace.ValidateStackConfigAndApplyProjectConfig(stackName, proj, cfg.Config, decrypter)
+			configErr := validateStackConfig(stackName, proj, cfg.Config, decrypter)
 			if configErr != nil {
 				return result.FromError(fmt.Errorf("validating stack config: %w", configErr))
 			}
@@ -247,6 +266,7 @@ func newRefreshCmd() *cobra.Command {
 				Parallel:                  parallel,
 				Debug:                     debug,
 				UseLegacyDiff:             useLegacyDiff(),
+				Environment:               cfg.Environment,
 				DisableProviderPreview:    disableProviderPreview(),
 				DisableResourceReferences: disableResourceReferences(),
 				DisableOutputValues:       disableOutputValues(),
@@ -254,6 +274,11 @@ func newRefreshCmd() *cobra.Command {
 				Experimental:              hasExperimentalCommands(),
 			}
 
+			summary, err := newOperationSummary(ctx, apitype.RefreshOperation, s, opts.Display)
+			if err != nil {
+				return result.FromError(err)
+			}
+
 			changes, res := s.Refresh(ctx, backend.UpdateOperation{
 				Proj:               proj,
 				Root:               root,
@@ -264,6 +289,9 @@ func newRefreshCmd() *cobra.Command {
 				SecretsProvider:    stack.DefaultSecretsProvider,
 				Scopes:             backend.CancellationScopes,
 			})
+			if err := summary.print(ctx, changes, res); err != nil {
+				return result.FromError(err)
+			}
 
 			switch {
 			case res != nil && res.Error() == context.Canceled:
@@ -325,6 +353,12 @@ func newRefreshCmd() *cobra.Command {
 		&suppressPermalink, "suppress-permalink", "",
 		"Suppress display of the state permalink")
 	cmd.Flag("suppress-permalink").NoOptDefVal = "false"
+	cmd.PersistentFlags().StringVar(
+		&reportJUnitPath, "report-junit", "",
+		"Write a JUnit XML report of the resources and policy violations of the update to this file")
+	cmd.PersistentFlags().StringVar(
+		&reportSARIFPath, "report-sarif", "",
+		"Write a SARIF report of the resources and policy violations of the update to this file")
 	cmd.PersistentFlags().BoolVarP(
 		&yes, "yes", "y", false,
 		"Automatically approve and perform the refresh after previewing it")
@@ -343,6 +377,12 @@ func newRefreshCmd() *cobra.Command {
 	// Remote flags
 	remoteArgs.applyFlags(cmd)
 
+	// Diagnostic flags
+	diagnosticArgs.applyFlags(cmd)
+
+	// Output flags
+	outputArgs.applyFlags(cmd)
+
 	if hasDebugCommands() {
 		cmd.PersistentFlags().StringVar(
 			&eventLogPath, "event-log", "",
@@ -159,23 +159,13 @@ func newReplayEventsCmd() *cobra.Command {
 }
 
 func loadEvents(path string) ([]engine.Event, error) {
-	f, err := os.Open(path)
+	jsonEvents, err := loadJSONEvents(path)
 	if err != nil {
-		return nil, fmt.Errorf("opening '%v': %w", path, err)
+		return nil, err
 	}
-	defer contract.IgnoreClose(f)
 
 	var events []engine.Event
-	dec := json.NewDecoder(f)
-	for {
-		var jsonEvent apitype.EngineEvent
-		if err = dec.Decode(&jsonEvent); err != nil {
-			if err == io.EOF {
-				break
-			}
-			return nil, fmt.Errorf("decoding event: %w", err)
-		}
-
+	for _, jsonEvent := range jsonEvents {
 		event, err := display.ConvertJSONEvent(jsonEvent)
 		if err != nil {
 			return nil, fmt.Errorf("decoding event: %w", err)
@@ -191,3 +181,27 @@ func loadEvents(path string) ([]engine.Event, error) {
 
 	return events, nil
 }
+
+// loadJSONEvents reads the JSON engine events of an event log, as written by --event-log.
+func loadJSONEvents(path string) ([]apitype.EngineEvent, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening '%v': %w", path, err)
+	}
+	defer contract.IgnoreClose(f)
+
+	var events []apitype.EngineEvent
+	dec := json.NewDecoder(f)
+	for {
+		var jsonEvent apitype.EngineEvent
+		if err = dec.Decode(&jsonEvent); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("decoding event: %w", err)
+		}
+		events = append(events, jsonEvent)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/audit"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/env"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// secretsAuditLog is the sink for the secrets that the current command decrypts, if PULUMI_SECRETS_AUDIT_LOG is set.
+var secretsAuditLog audit.Sink
+
+// secretsAuditOperation is the command that is recorded in audit events, e.g. `pulumi up`.
+var secretsAuditOperation string
+
+// secretsAuditLabels names the config and environment secrets that the current command has loaded, so that their
+// decryption can be recorded by key rather than by value.
+var secretsAuditLabels *audit.Labels
+
+// secretsAuditDefaultProvider is the secrets provider that was used to deserialize deployments before the secrets
+// audit log was opened.
+var secretsAuditDefaultProvider secrets.Provider
+
+// openSecretsAuditLog opens the secrets audit log for the given command, if one is configured. Every secrets manager
+// of a stack that the command uses is then audited, both those for the stack's configuration and those for its state.
+func openSecretsAuditLog(cmd *cobra.Command) error {
+	spec := env.SecretsAuditLog.Value()
+	if spec == "" {
+		return nil
+	}
+	sink, err := audit.OpenSink(spec)
+	if err != nil {
+		return err
+	}
+	secretsAuditLog = sink
+	secretsAuditOperation = cmd.CommandPath()
+	secretsAuditLabels = audit.NewLabels()
+	secretsAuditDefaultProvider = stack.DefaultSecretsProvider
+	stack.DefaultSecretsProvider = auditingSecretsProvider{provider: secretsAuditDefaultProvider}
+	return nil
+}
+
+func closeSecretsAuditLog() {
+	if secretsAuditLog == nil {
+		return
+	}
+	if err := secretsAuditLog.Close(); err != nil {
+		logging.Warningf("could not close secrets audit log: %v", err)
+	}
+	secretsAuditLog = nil
+	secretsAuditLabels = nil
+	stack.DefaultSecretsProvider = secretsAuditDefaultProvider
+}
+
+// auditingSecretsProvider audits the secrets managers that are used to deserialize deployments, so that the secrets
+// of a stack's state are recorded in the secrets audit log.
+type auditingSecretsProvider struct {
+	provider secrets.Provider
+}
+
+func (p auditingSecretsProvider) OfType(ty string, state json.RawMessage) (secrets.Manager, error) {
+	sm, err := p.provider.OfType(ty, state)
+	if err != nil {
+		return nil, err
+	}
+	// The stack is not known here, so it is recorded from the URNs of the state's secrets.
+	return auditSecretsManager("", sm), nil
+}
+
+// auditSecretsManager returns a secrets manager that records every secret that the given secrets manager of a stack
+// decrypts in the secrets audit log, if one is configured.
+func auditSecretsManager(stackName string, sm secrets.Manager) secrets.Manager {
+	if secretsAuditLog == nil {
+		return sm
+	}
+	return audit.NewManager(sm, secretsAuditLabels, func(labels []audit.Label, unlabeled int) {
+		recordSecretsDecryption(stackName, labels, unlabeled)
+	})
+}
+
+// addSecretsAuditLabels names the secret values of the given configuration and environment in the secrets audit
+// log, if one is configured.
+func addSecretsAuditLabels(cfg config.Map, environment *workspace.ProjectEnvironment) {
+	if secretsAuditLabels == nil {
+		return
+	}
+	secretsAuditLabels.AddConfig(cfg)
+	secretsAuditLabels.AddEnvironment(environment)
+}
+
+// recordSecretsDecryption records that the current command decrypted the secrets with the given labels. It never
+// fails the command: errors writing the audit log are only logged.
+func recordSecretsDecryption(stackName string, labels []audit.Label, unlabeled int) {
+	if secretsAuditLog == nil {
+		return
+	}
+
+	event := audit.Event{
+		Time:      time.Now().UTC(),
+		User:      auditUser(),
+		Stack:     stackName,
+		Operation: secretsAuditOperation,
+		Unlabeled: unlabeled,
+	}
+	seen := map[audit.Label]bool{}
+	for _, l := range labels {
+		if seen[l] {
+			continue
+		}
+		seen[l] = true
+		switch {
+		case l.ConfigKey != "":
+			event.ConfigKeys = append(event.ConfigKeys, l.ConfigKey)
+		case l.EnvironmentVariable != "":
+			event.EnvironmentVariables = append(event.EnvironmentVariables, l.EnvironmentVariable)
+		default:
+			event.StatePaths = append(event.StatePaths, l.StatePath)
+			if event.Stack == "" {
+				event.Stack = fmt.Sprintf("%s/%s", l.URN.Project(), l.URN.Stack())
+			}
+		}
+	}
+	sort.Strings(event.ConfigKeys)
+	sort.Strings(event.EnvironmentVariables)
+	sort.Strings(event.StatePaths)
+
+	if err := secretsAuditLog.Record(event); err != nil {
+		logging.Warningf("could not write to secrets audit log: %v", err)
+	}
+}
+
+func auditUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return os.Getenv("USERNAME")
+}
@@ -0,0 +1,93 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/audit"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/b64"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+)
+
+type memorySink struct {
+	events []audit.Event
+}
+
+func (s *memorySink) Record(event audit.Event) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *memorySink) Close() error {
+	return nil
+}
+
+//nolint:paralleltest // mutates the secrets audit log and default secrets provider
+func TestSecretsAuditManagers(t *testing.T) {
+	sink := &memorySink{}
+	secretsAuditLog = sink
+	secretsAuditOperation = "pulumi test"
+	secretsAuditLabels = audit.NewLabels()
+	secretsAuditDefaultProvider = stack.DefaultSecretsProvider
+	stack.DefaultSecretsProvider = auditingSecretsProvider{provider: secretsAuditDefaultProvider}
+	defer closeSecretsAuditLog()
+
+	encrypt := func(plaintext string) string {
+		return base64.StdEncoding.EncodeToString([]byte(plaintext))
+	}
+
+	// Config secrets are recorded by key, against the stack that owns the secrets manager.
+	key := config.MustMakeKey("proj", "dbPassword")
+	addSecretsAuditLabels(config.Map{key: config.NewSecureValue(encrypt("hunter2"))}, nil)
+	dec, err := auditSecretsManager("org/proj/dev", b64.NewBase64SecretsManager()).Decrypter()
+	require.NoError(t, err)
+	_, err = dec.DecryptValue(context.Background(), encrypt("hunter2"))
+	require.NoError(t, err)
+
+	// State secrets are recorded by path when a deployment is deserialized.
+	urn := resource.URN("urn:pulumi:dev::proj::db:Instance::main")
+	deployment, err := json.Marshal(apitype.DeploymentV3{
+		SecretsProviders: &apitype.SecretsProvidersV1{Type: b64.Type},
+		Resources: []apitype.ResourceV3{{
+			URN:  urn,
+			Type: "db:Instance",
+			Outputs: map[string]interface{}{
+				"password": map[string]interface{}{resource.SigKey: resource.SecretSig, "ciphertext": encrypt(`"p"`)},
+			},
+		}},
+	})
+	require.NoError(t, err)
+	_, err = stack.DeserializeUntypedDeployment(context.Background(),
+		&apitype.UntypedDeployment{Version: 3, Deployment: deployment}, stack.DefaultSecretsProvider)
+	require.NoError(t, err)
+
+	require.Len(t, sink.events, 2)
+	assert.Equal(t, "org/proj/dev", sink.events[0].Stack)
+	assert.Equal(t, "pulumi test", sink.events[0].Operation)
+	assert.Equal(t, []string{"proj:dbPassword"}, sink.events[0].ConfigKeys)
+	assert.Equal(t, "proj/dev", sink.events[1].Stack)
+	assert.Equal(t, []string{"urn:pulumi:dev::proj::db:Instance::main#outputs.password"}, sink.events[1].StatePaths)
+	assert.Zero(t, sink.events[1].Unlabeled)
+}
@@ -189,7 +189,9 @@ func newStackCmd() *cobra.Command {
 	cmd.AddCommand(newStackSelectCmd())
 	cmd.AddCommand(newStackTagCmd())
 	cmd.AddCommand(newStackRenameCmd())
+	cmd.AddCommand(newStackScanSecretsCmd())
 	cmd.AddCommand(newStackChangeSecretsProviderCmd())
+	cmd.AddCommand(newStackKeyWrapperCmd())
 	cmd.AddCommand(newStackHistoryCmd())
 	cmd.AddCommand(newStackUnselectCmd())
 
@@ -22,6 +22,7 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/backend"
 	"github.com/pulumi/pulumi/pkg/v3/backend/display"
 	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/age"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
@@ -37,7 +38,8 @@ func newStackChangeSecretsProviderCmd() *cobra.Command {
 		Args:  cmdutil.ExactArgs(1),
 		Short: "Change the secrets provider for a stack",
 		Long: "Change the secrets provider for a stack. " +
-			"Valid secret providers types are `default`, `passphrase`, `awskms`, `azurekeyvault`, `gcpkms`, `hashivault`.\n\n" +
+			"Valid secret providers types are `default`, `passphrase`, `age`, `awskms`, `azurekeyvault`, `gcpkms`, " +
+			"`hashivault`.\n\n" +
 			"To change to using the Pulumi Default Secrets Provider, use the following:\n" +
 			"\n" +
 			"pulumi stack change-secrets-provider default" +
@@ -53,7 +55,21 @@ func newStackChangeSecretsProviderCmd() *cobra.Command {
 			"\"azurekeyvault://mykeyvaultname.vault.azure.net/keys/mykeyname\"`\n" +
 			"* `pulumi stack change-secrets-provider " +
 			"\"gcpkms://projects/<p>/locations/<l>/keyRings/<r>/cryptoKeys/<k>\"`\n" +
-			"* `pulumi stack change-secrets-provider \"hashivault://mykey\"`",
+			"* `pulumi stack change-secrets-provider \"hashivault://mykey\"`" +
+			"\n" +
+			"\n" +
+			"To change the stack to encrypt its secrets for one or more age recipients, use the following:\n" +
+			"\n" +
+			"* `pulumi stack change-secrets-provider \"age://?recipient=age1...&recipient=age1...\"`\n" +
+			"\n" +
+			"The age identity file used to decrypt the stack's secrets is read from `PULUMI_AGE_IDENTITY`. " +
+			"Changing the recipients of a stack that already uses age keeps its data key, so existing secrets are " +
+			"not re-encrypted.\n" +
+			"\n" +
+			"To change the stack to use an installed secrets provider plugin, use a URL whose scheme is the name of " +
+			"the plugin:\n" +
+			"\n" +
+			"* `pulumi stack change-secrets-provider \"myplugin://...\"`",
 		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
 			ctx := commandContext()
 			opts := display.Options{
@@ -105,6 +121,14 @@ func newStackChangeSecretsProviderCmd() *cobra.Command {
 				return err
 			}
 
+			// Changing the recipients of the age secrets provider keeps the stack's data key, so only the wrapped
+			// key recorded in the checkpoint needs updating.
+			if !rotateProvider && age.IsAgeSecretsProvider(secretsProvider) &&
+				age.IsAgeSecretsProvider(currentProjectStack.SecretsProvider) {
+				fmt.Printf("Updating the recipients of the stack's data key\n")
+				return updateCheckpointSecretsProvider(ctx, project, currentStack)
+			}
+
 			// Fixup the checkpoint
 			fmt.Printf("Migrating old configuration and state to new secrets provider\n")
 			return migrateOldConfigAndCheckpointToNewSecretsProvider(ctx, project, currentStack, currentProjectStack, decrypter)
@@ -189,3 +213,48 @@ func migrateOldConfigAndCheckpointToNewSecretsProvider(ctx context.Context,
 	// Import the newly changes Deployment
 	return currentStack.ImportDeployment(ctx, &dep)
 }
+
+// updateCheckpointSecretsProvider records the state of the stack's new secrets manager in its checkpoint without
+// re-encrypting any secret values. This is only valid if the new secrets manager uses the same data key as the old.
+func updateCheckpointSecretsProvider(ctx context.Context,
+	project *workspace.Project,
+	currentStack backend.Stack,
+) error {
+	reloadedProjectStack, err := loadProjectStack(project, currentStack)
+	if err != nil {
+		return err
+	}
+	newSecretsManager, _, err := getStackSecretsManager(currentStack, reloadedProjectStack)
+	if err != nil {
+		return err
+	}
+
+	checkpoint, err := currentStack.ExportDeployment(ctx)
+	if err != nil {
+		return err
+	}
+	var deployment map[string]json.RawMessage
+	if err := json.Unmarshal(checkpoint.Deployment, &deployment); err != nil {
+		return err
+	}
+	if _, ok := deployment["secrets_providers"]; !ok {
+		// The checkpoint doesn't contain any secrets.
+		return nil
+	}
+	deployment["secrets_providers"], err = json.Marshal(apitype.SecretsProvidersV1{
+		Type:  newSecretsManager.Type(),
+		State: newSecretsManager.State(),
+	})
+	if err != nil {
+		return err
+	}
+
+	bytes, err := json.Marshal(deployment)
+	if err != nil {
+		return err
+	}
+	return currentStack.ImportDeployment(ctx, &apitype.UntypedDeployment{
+		Version:    checkpoint.Version,
+		Deployment: bytes,
+	})
+}
@@ -74,6 +74,10 @@ This command displays data about previous updates for a stack.`,
 
 			if showSecrets {
 				log3rdPartySecretsProviderDecryptionEvent(ctx, s, "", "pulumi stack history")
+				// Name the secrets of past configurations in the secrets audit log before they are decrypted.
+				for _, update := range updates {
+					addSecretsAuditLabels(update.Config, nil)
+				}
 			}
 
 			if jsonOut {
@@ -98,6 +102,8 @@ This command displays data about previous updates for a stack.`,
 		&pageSize, "page-size", 10, "Used with 'page' to control number of results returned")
 	cmd.PersistentFlags().IntVar(
 		&page, "page", 1, "Used with 'page-size' to paginate results")
+
+	cmd.AddCommand(newStackHistoryReportCmd(&stack))
 	return cmd
 }
 
@@ -0,0 +1,140 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+func newStackHistoryReportCmd(stack *string) *cobra.Command {
+	var eventLogPath string
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "report <version>",
+		Args:  cmdutil.ExactArgs(1),
+		Short: "Render an HTML report of an update",
+		Long: "Render an HTML report of an update\n" +
+			"\n" +
+			"This command renders a self-contained HTML report of an update in the stack's history, with a\n" +
+			"summary of the update, the tree of its resources and their diffs, its diagnostics, policy\n" +
+			"violations and timings, and the dependency graph of its resources.\n" +
+			"\n" +
+			"The events of the update are read from the stack's backend, if it records them, or from an\n" +
+			"event log written by `--event-log`. The report is written to standard out, or to a file if\n" +
+			"`--file` is given. Self-managed backends only record the events of updates made with\n" +
+			"PULUMI_SELF_MANAGED_STATE_RECORD_EVENTS set to true.",
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			ctx := commandContext()
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			version, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid version %q: %w", args[0], err)
+			}
+
+			s, err := requireStack(ctx, *stack, stackLoadOnly, opts)
+			if err != nil {
+				return err
+			}
+			be := s.Backend()
+
+			updates, err := be.GetHistory(ctx, s.Ref(), 0, 0)
+			if err != nil {
+				return fmt.Errorf("getting history: %w", err)
+			}
+			var update *backend.UpdateInfo
+			for i := range updates {
+				if updates[i].Version == version {
+					update = &updates[i]
+					break
+				}
+			}
+			if update == nil {
+				return fmt.Errorf("version %d of stack %s does not exist", version, s.Ref())
+			}
+
+			var events []apitype.EngineEvent
+			if eventLogPath != "" {
+				events, err = loadJSONEvents(eventLogPath)
+				if err != nil {
+					return err
+				}
+			} else {
+				// Check that the stack and its backend supports the ability to do this.
+				exporter, ok := be.(backend.UpdateEventsExporter)
+				if !ok {
+					return fmt.Errorf("the current backend (%s) does not provide the events of previous updates; "+
+						"use --event-log to render the report from an event log", be.Name())
+				}
+
+				events, err = exporter.ExportUpdateEvents(ctx, s, args[0])
+				if err != nil {
+					return err
+				}
+			}
+
+			project, _ := s.Ref().Project()
+			report := display.HTMLReport{
+				Project:   tokens.PackageName(project),
+				Stack:     s.Ref().Name(),
+				Kind:      update.Kind,
+				Version:   update.Version,
+				Message:   update.Message,
+				Result:    string(update.Result),
+				StartTime: time.Unix(update.StartTime, 0),
+				Events:    events,
+			}
+			if update.Result != backend.InProgressResult {
+				report.EndTime = time.Unix(update.EndTime, 0)
+			}
+
+			// Write to stdout or a specified file.
+			writer := os.Stdout
+			if file != "" {
+				writer, err = os.Create(file)
+				if err != nil {
+					return fmt.Errorf("could not open file: %w", err)
+				}
+				defer contract.IgnoreClose(writer)
+			}
+
+			if err = display.RenderHTMLReport(writer, report); err != nil {
+				return fmt.Errorf("could not render report: %w", err)
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(
+		&eventLogPath, "event-log", "", "A file with the events of the update, as written by --event-log")
+	cmd.Flags().StringVarP(
+		&file, "file", "", "", "A filename to write the report to")
+	return cmd
+}
@@ -30,7 +30,8 @@ import (
 
 const (
 	possibleSecretsProviderChoices = "The type of the provider that should be used to encrypt and decrypt secrets\n" +
-		"(possible choices: default, passphrase, awskms, azurekeyvault, gcpkms, hashivault)"
+		"(possible choices: default, passphrase, age, awskms, azurekeyvault, gcpkms, hashivault,\n" +
+		"or the name of an installed secrets provider plugin)"
 )
 
 func newStackInitCmd() *cobra.Command {
@@ -64,6 +65,14 @@ func newStackInitCmd() *cobra.Command {
 			"* `pulumi stack init --secrets-provider=\"gcpkms://projects/<p>/locations/<l>/keyRings/<r>/cryptoKeys/<k>\"`\n" +
 			"* `pulumi stack init --secrets-provider=\"hashivault://mykey\"\n`" +
 			"\n" +
+			"To encrypt secrets for one or more age recipients, use the following:\n" +
+			"\n" +
+			"* `pulumi stack init --secrets-provider=\"age://?recipient=age1...&recipient=age1...\"`\n" +
+			"\n" +
+			"To use an installed secrets provider plugin, use a URL whose scheme is the name of the plugin:\n" +
+			"\n" +
+			"* `pulumi stack init --secrets-provider=\"myplugin://...\"`\n" +
+			"\n" +
 			"A stack can be created based on the configuration of an existing stack by passing the\n" +
 			"`--copy-config-from` flag.\n" +
 			"* `pulumi stack init --copy-config-from dev`",
@@ -0,0 +1,191 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/age"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/cloud"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/passphrase"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/secretsplugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+func newStackKeyWrapperCmd() *cobra.Command {
+	var stack string
+
+	cmd := &cobra.Command{
+		Use:   "key-wrapper",
+		Short: "Manage the key wrappers of a stack's cloud secrets provider",
+		Long: "Manage the key wrappers of a stack's cloud secrets provider\n" +
+			"\n" +
+			"A stack that uses a cloud secrets provider (awskms, azurekeyvault, gcpkms or hashivault) encrypts\n" +
+			"its secrets with a data key, which is itself encrypted by the secrets provider. Key wrappers encrypt\n" +
+			"the same data key with other cloud secrets providers, any of which can decrypt the stack's secrets\n" +
+			"if the stack's secrets provider is unavailable, e.g. during a regional outage or after its key has\n" +
+			"been deleted. The `add`, `ls` and `rm` commands can be used to manage key wrappers.\n" +
+			"\n" +
+			"A key wrapper can also be an escrow passphrase (`passphrase`), which encrypts the data key with a\n" +
+			"key derived from the passphrase. The passphrase is read from PULUMI_CONFIG_PASSPHRASE or\n" +
+			"PULUMI_CONFIG_PASSPHRASE_FILE, or prompted for, when the key wrapper is added and whenever it is\n" +
+			"needed to decrypt or re-encrypt the data key.\n" +
+			"\n" +
+			"Adding or removing a key wrapper does not change the data key, so no secrets are re-encrypted.\n" +
+			"Removing a key wrapper does not revoke access for anyone who has already decrypted the data key;\n" +
+			"use `pulumi stack change-secrets-provider` to rotate the data key.\n",
+		Args: cmdutil.NoArgs,
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stack, "stack", "s", "", "The name of the stack to operate on. Defaults to the current stack")
+
+	cmd.AddCommand(newStackKeyWrapperAddCmd(&stack))
+	cmd.AddCommand(newStackKeyWrapperLsCmd(&stack))
+	cmd.AddCommand(newStackKeyWrapperRmCmd(&stack))
+
+	return cmd
+}
+
+// updateStackKeyWrappers applies an edit to the key wrappers of a stack, and saves the edited stack configuration
+// and the new state of its secrets manager in its checkpoint.
+func updateStackKeyWrappers(stack string, edit func(ps *workspace.ProjectStack) error) error {
+	ctx := commandContext()
+	opts := display.Options{
+		Color: cmdutil.GetGlobalColorization(),
+	}
+
+	project, _, err := readProject()
+	if err != nil {
+		return err
+	}
+	s, err := requireStack(ctx, stack, stackLoadOnly, opts)
+	if err != nil {
+		return err
+	}
+	ps, err := loadProjectStack(project, s)
+	if err != nil {
+		return err
+	}
+
+	if err := edit(ps); err != nil {
+		return err
+	}
+	if err := saveProjectStack(s, ps); err != nil {
+		return err
+	}
+	return updateCheckpointSecretsProvider(ctx, project, s)
+}
+
+// isCloudSecretsProvider returns true if the given secrets provider is handled by the cloud secrets manager.
+func isCloudSecretsProvider(secretsProvider string) bool {
+	return secretsProvider != "" && secretsProvider != "default" && secretsProvider != passphrase.Type &&
+		!age.IsAgeSecretsProvider(secretsProvider) && !secretsplugin.IsPluginSecretsProvider(secretsProvider)
+}
+
+func newStackKeyWrapperAddCmd(stack *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <secrets-provider>",
+		Short: "Encrypt the stack's data key with another cloud secrets provider or a passphrase",
+		Long: "Encrypt the stack's data key with another cloud secrets provider or a passphrase\n" +
+			"\n" +
+			"For example, to allow a stack's secrets to be decrypted by a Hashicorp Vault escrow key:\n" +
+			"\n" +
+			"* `pulumi stack key-wrapper add \"hashivault://escrow\"`\n" +
+			"\n" +
+			"Or by an escrow passphrase:\n" +
+			"\n" +
+			"* `pulumi stack key-wrapper add passphrase`",
+		Args: cmdutil.SpecificArgs([]string{"secrets-provider"}),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			url := args[0]
+			if err := validateSecretsProvider(url); err != nil {
+				return err
+			}
+			if url != passphrase.Type && !isCloudSecretsProvider(url) {
+				return fmt.Errorf("key wrappers must use a cloud secrets provider or a passphrase, got '%s'", url)
+			}
+			return updateStackKeyWrappers(*stack, func(ps *workspace.ProjectStack) error {
+				if !isCloudSecretsProvider(ps.SecretsProvider) {
+					return errors.New("key wrappers can only be added to stacks that use a cloud secrets provider")
+				}
+				return cloud.AddKeyWrapper(ps, url)
+			})
+		}),
+	}
+}
+
+func newStackKeyWrapperRmCmd(stack *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rm <secrets-provider>",
+		Short: "Remove a key wrapper from the stack",
+		Args:  cmdutil.SpecificArgs([]string{"secrets-provider"}),
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			return updateStackKeyWrappers(*stack, func(ps *workspace.ProjectStack) error {
+				return cloud.RemoveKeyWrapper(ps, args[0])
+			})
+		}),
+	}
+}
+
+func newStackKeyWrapperLsCmd(stack *string) *cobra.Command {
+	var jsonOut bool
+	cmd := &cobra.Command{
+		Use:   "ls",
+		Short: "List the key wrappers of the stack",
+		Args:  cmdutil.NoArgs,
+		Run: cmdutil.RunFunc(func(cmd *cobra.Command, args []string) error {
+			ctx := commandContext()
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			project, _, err := readProject()
+			if err != nil {
+				return err
+			}
+			s, err := requireStack(ctx, *stack, stackLoadOnly, opts)
+			if err != nil {
+				return err
+			}
+			ps, err := loadProjectStack(project, s)
+			if err != nil {
+				return err
+			}
+
+			urls := make([]string, 0, len(ps.KeyWrappers))
+			for _, w := range ps.KeyWrappers {
+				urls = append(urls, w.SecretsProvider)
+			}
+			if jsonOut {
+				return printJSON(urls)
+			}
+			for _, url := range urls {
+				fmt.Println(url)
+			}
+			return nil
+		}),
+	}
+
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false, "Emit output as JSON")
+
+	return cmd
+}
@@ -0,0 +1,191 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
+)
+
+func newStackScanSecretsCmd() *cobra.Command {
+	var stackName string
+	var markSecrets bool
+	var jsonOut bool
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "scan-secrets",
+		Args:  cmdutil.NoArgs,
+		Short: "Scan a stack's state for secrets stored in plaintext",
+		Long: "Scan a stack's state for secrets stored in plaintext.\n" +
+			"\n" +
+			"When a provider or program doesn't mark a value as secret, it is stored in plaintext in the stack's\n" +
+			"state and outputs. This command reports the resource URN and property path of every plaintext value\n" +
+			"that contains the value of one of the stack's secret configuration values, that matches the format of a\n" +
+			"well known credential, or that is a high-entropy value of a property whose name suggests a secret.\n" +
+			"The values themselves are never printed.\n" +
+			"\n" +
+			"Pass `--mark-secrets` to mark the reported values as secrets in the stack's state, so that they are\n" +
+			"encrypted with the stack's secrets provider.\n" +
+			"\n" +
+			"The command exits with an error if it finds plaintext secrets that were not marked as secrets.",
+		Run: cmdutil.RunResultFunc(func(cmd *cobra.Command, args []string) result.Result {
+			ctx := commandContext()
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+
+			s, err := requireStack(ctx, stackName, stackLoadOnly, opts)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			scanner, err := newStackSecretScanner(s)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			var found []stack.PlaintextSecret
+			if markSecrets {
+				showPrompt := !yes && !skipConfirmations()
+				res := totalStateEdit(ctx, s, showPrompt, opts, func(_ display.Options, snap *deploy.Snapshot) error {
+					found = scanner.Scan(snap)
+					for _, f := range found {
+						f.MarkSecret()
+					}
+					return nil
+				})
+				if res != nil {
+					return res
+				}
+			} else {
+				snap, err := s.Snapshot(ctx, stack.DefaultSecretsProvider)
+				if err != nil {
+					return result.FromError(err)
+				}
+				found = scanner.Scan(snap)
+			}
+
+			if err := printPlaintextSecrets(found, jsonOut); err != nil {
+				return result.FromError(err)
+			}
+
+			switch {
+			case len(found) == 0:
+				return nil
+			case markSecrets:
+				if !jsonOut {
+					fmt.Printf("Marked %d value(s) as secret in the state of stack %s.\n", len(found), s.Ref())
+				}
+				return nil
+			default:
+				return result.Errorf("found %d plaintext value(s) that look like secrets; "+
+					"rerun with --mark-secrets to mark them as secrets", len(found))
+			}
+		}),
+	}
+
+	cmd.PersistentFlags().StringVarP(
+		&stackName, "stack", "s", "",
+		"The name of the stack to operate on. Defaults to the current stack")
+	cmd.PersistentFlags().BoolVar(
+		&markSecrets, "mark-secrets", false,
+		"Mark the plaintext values that look like secrets as secrets in the stack's state")
+	cmd.PersistentFlags().BoolVarP(
+		&jsonOut, "json", "j", false,
+		"Emit output as JSON")
+	cmd.PersistentFlags().BoolVarP(
+		&yes, "yes", "y", false,
+		"Skip confirmation prompts")
+
+	return cmd
+}
+
+// newStackSecretScanner returns a scanner that matches the decrypted values of the stack's secret configuration, if
+// the stack has any.
+func newStackSecretScanner(s backend.Stack) (*stack.SecretScanner, error) {
+	scanner := &stack.SecretScanner{KnownSecrets: map[string]string{}}
+
+	project, _, err := readProject()
+	if err != nil {
+		return nil, err
+	}
+	ps, err := loadProjectStack(project, s)
+	if err != nil {
+		return nil, err
+	}
+	cfg, _, err := effectiveStackConfig(project, s, ps)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.HasSecureValue() {
+		return scanner, nil
+	}
+
+	decrypter, _, err := getStackDecrypter(s, ps)
+	if err != nil {
+		return nil, fmt.Errorf("getting stack decrypter: %w", err)
+	}
+	for _, key := range cfg.SecureKeys() {
+		values, err := cfg[key].SecureValues(decrypter)
+		if err != nil {
+			return nil, fmt.Errorf("decrypting configuration value '%v': %w", key, err)
+		}
+		for _, v := range values {
+			scanner.KnownSecrets[v] = fmt.Sprintf("the secret config key '%v'", prettyKey(key))
+		}
+	}
+	return scanner, nil
+}
+
+type plaintextSecretJSON struct {
+	URN      resource.URN `json:"urn"`
+	Location string       `json:"location"`
+	Reason   string       `json:"reason"`
+}
+
+func printPlaintextSecrets(found []stack.PlaintextSecret, jsonOut bool) error {
+	if jsonOut {
+		out := make([]plaintextSecretJSON, len(found))
+		for i, f := range found {
+			out[i] = plaintextSecretJSON{URN: f.URN, Location: f.Location(), Reason: f.Reason}
+		}
+		return printJSON(out)
+	}
+
+	if len(found) == 0 {
+		fmt.Println("No plaintext secrets found.")
+		return nil
+	}
+
+	rows := make([]cmdutil.TableRow, len(found))
+	for i, f := range found {
+		rows[i] = cmdutil.TableRow{Columns: []string{string(f.URN), f.Location(), f.Reason}}
+	}
+	cmdutil.PrintTable(cmdutil.Table{
+		Headers: []string{"URN", "LOCATION", "REASON"},
+		Rows:    rows,
+	})
+	return nil
+}
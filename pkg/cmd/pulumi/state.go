@@ -115,14 +115,22 @@ func runStateEdit(
 	ctx context.Context, stackName string, showPrompt bool,
 	urn resource.URN, operation edit.OperationFunc,
 ) result.Result {
-	return runTotalStateEdit(ctx, stackName, showPrompt, func(opts display.Options, snap *deploy.Snapshot) error {
+	return runTotalStateEdit(ctx, stackName, showPrompt, resourceStateEdit(urn, operation))
+}
+
+// resourceStateEdit returns a snapshot-mutating function that runs a resource-mutating function on the resource with
+// the given URN.
+func resourceStateEdit(
+	urn resource.URN, operation edit.OperationFunc,
+) func(opts display.Options, snap *deploy.Snapshot) error {
+	return func(opts display.Options, snap *deploy.Snapshot) error {
 		res, err := locateStackResource(opts, snap, urn)
 		if err != nil {
 			return err
 		}
 
 		return operation(snap, res)
-	})
+	}
 }
 
 // runTotalStateEdit runs a snapshot-mutating function on the entirety of the given stack's snapshot.
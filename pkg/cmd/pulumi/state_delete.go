@@ -15,10 +15,13 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v3/resource/edit"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
@@ -32,6 +35,7 @@ func newStateDeleteCommand() *cobra.Command {
 	var stack string
 	var yes bool
 	var targetDepenedents bool
+	outputArgs := OutputArgs{}
 
 	cmd := &cobra.Command{
 		Use:   "delete <resource URN>",
@@ -54,20 +58,45 @@ pulumi state delete 'urn:pulumi:stage::demo::eks:index:Cluster$pulumi:providers:
 			ctx := commandContext()
 			yes = yes || skipConfirmations()
 			urn := resource.URN(args[0])
+
+			if err := outputArgs.validate(false /*jsonDisplay*/, false /*diffDisplay*/); err != nil {
+				return result.FromError(err)
+			}
+			// The JSON summary is meant to be read by other programs, so never prompt for it.
+			if outputArgs.json() && !yes {
+				return result.FromError(errors.New("--yes must be passed in to proceed with --output json"))
+			}
+
 			// Show the confirmation prompt if the user didn't pass the --yes parameter to skip it.
 			showPrompt := !yes
 
-			res := runStateEdit(ctx, stack, showPrompt, urn, func(snap *deploy.Snapshot, res *resource.State) error {
-				var handleProtected func(*resource.State) error
-				if force {
-					handleProtected = func(res *resource.State) error {
-						cmdutil.Diag().Warningf(diag.Message(res.URN,
-							"deleting protected resource %s due to presence of --force"), res.URN)
-						return edit.UnprotectResource(nil, res)
+			opts := display.Options{
+				Color: cmdutil.GetGlobalColorization(),
+			}
+			outputArgs.applyOptions(&opts)
+
+			s, err := requireStack(ctx, stack, stackOfferNew, opts)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			summary, err := newOperationSummary(ctx, apitype.StateDeleteOperation, s, opts)
+			if err != nil {
+				return result.FromError(err)
+			}
+
+			res := totalStateEdit(ctx, s, showPrompt, opts, resourceStateEdit(urn,
+				func(snap *deploy.Snapshot, res *resource.State) error {
+					var handleProtected func(*resource.State) error
+					if force {
+						handleProtected = func(res *resource.State) error {
+							cmdutil.Diag().Warningf(diag.Message(res.URN,
+								"deleting protected resource %s due to presence of --force"), res.URN)
+							return edit.UnprotectResource(nil, res)
+						}
 					}
-				}
-				return edit.DeleteResource(snap, res, handleProtected, targetDepenedents)
-			})
+					return edit.DeleteResource(snap, res, handleProtected, targetDepenedents)
+				}))
 			if res != nil {
 				switch e := res.Error().(type) {
 				case edit.ResourceHasDependenciesError:
@@ -78,16 +107,22 @@ pulumi state delete 'urn:pulumi:stage::demo::eks:index:Cluster$pulumi:providers:
 					}
 
 					message += "\nDelete those resources first or pass --target-dependents."
-					return result.Error(message)
+					res = result.Error(message)
 				case edit.ResourceProtectedError:
-					return result.Errorf(
+					res = result.Errorf(
 						"%s can't be safely deleted because it is protected. "+
 							"Re-run this command with --force to force deletion", string(e.Condemned.URN))
-				default:
-					return res
 				}
 			}
-			fmt.Println("Resource deleted")
+			if err := summary.print(ctx, nil, res); err != nil {
+				return result.FromError(err)
+			}
+			if res != nil {
+				return res
+			}
+			if !outputArgs.json() {
+				fmt.Println("Resource deleted")
+			}
 			return nil
 		}),
 	}
@@ -98,5 +133,6 @@ pulumi state delete 'urn:pulumi:stage::demo::eks:index:Cluster$pulumi:providers:
 	cmd.Flags().BoolVar(&force, "force", false, "Force deletion of protected resources")
 	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip confirmation prompts")
 	cmd.Flags().BoolVar(&targetDepenedents, "target-dependents", false, "Delete the URN and all its dependents")
+	outputArgs.applyFlags(cmd)
 	return cmd
 }
@@ -57,6 +57,8 @@ func newUpCmd() *cobra.Command {
 
 	// Flags for remote operations.
 	remoteArgs := RemoteArgs{}
+	diagnosticArgs := DiagnosticArgs{}
+	outputArgs := OutputArgs{}
 
 	// Flags for engine.UpdateOptions.
 	var jsonDisplay bool
@@ -64,6 +66,9 @@ func newUpCmd() *cobra.Command {
 	var policyPackConfigPaths []string
 	var diffDisplay bool
 	var eventLogPath string
+	var reportJUnitPath string
+	var reportSARIFPath string
+	var htmlReportPath string
 	var parallel int
 	var refresh string
 	var showConfig bool
@@ -115,7 +120,7 @@ func newUpCmd() *cobra.Command {
 		}
 
 		stackName := s.Ref().Name().String()
-		configErr := workspace.ValidateStackConfigAndApplyProjectConfig(stackName, proj, cfg.Config, decrypter)
+		configErr := validateStackConfig(stackName, proj, cfg.Config, decrypter)
 		if configErr != nil {
 			return result.FromError(fmt.Errorf("validating stack config: %w", configErr))
 		}
@@ -140,6 +145,7 @@ func newUpCmd() *cobra.Command {
 			Refresh:                   refreshOption,
 			ReplaceTargets:            deploy.NewUrnTargets(replaceURNs),
 			UseLegacyDiff:             useLegacyDiff(),
+			Environment:               cfg.Environment,
 			DisableProviderPreview:    disableProviderPreview(),
 			DisableResourceReferences: disableResourceReferences(),
 			DisableOutputValues:       disableOutputValues(),
@@ -167,6 +173,11 @@ func newUpCmd() *cobra.Command {
 			opts.Engine.Plan = plan
 		}
 
+		summary, err := newOperationSummary(ctx, apitype.UpdateOperation, s, opts.Display)
+		if err != nil {
+			return result.FromError(err)
+		}
+
 		changes, res := s.Update(ctx, backend.UpdateOperation{
 			Proj:               proj,
 			Root:               root,
@@ -177,6 +188,9 @@ func newUpCmd() *cobra.Command {
 			SecretsProvider:    stack.DefaultSecretsProvider,
 			Scopes:             backend.CancellationScopes,
 		})
+		if err := summary.print(ctx, changes, res); err != nil {
+			return result.FromError(err)
+		}
 		switch {
 		case res != nil && res.Error() == context.Canceled:
 			return result.FromError(errors.New("update cancelled"))
@@ -336,7 +350,7 @@ func newUpCmd() *cobra.Command {
 		}
 
 		stackName := s.Ref().String()
-		configErr := workspace.ValidateStackConfigAndApplyProjectConfig(stackName, proj, cfg.Config, decrypter)
+		configErr := validateStackConfig(stackName, proj, cfg.Config, decrypter)
 		if configErr != nil {
 			return result.FromError(fmt.Errorf("validating stack config: %w", configErr))
 		}
@@ -355,6 +369,7 @@ func newUpCmd() *cobra.Command {
 			// which will be constrained to during the update phase.
 			GeneratePlan: hasExperimentalCommands(),
 			Experimental: hasExperimentalCommands(),
+			Environment:  cfg.Environment,
 		}
 
 		// TODO for the URL case:
@@ -362,6 +377,11 @@ func newUpCmd() *cobra.Command {
 		// - attempt `destroy` on any update errors.
 		// - show template.Quickstart?
 
+		summary, err := newOperationSummary(ctx, apitype.UpdateOperation, s, opts.Display)
+		if err != nil {
+			return result.FromError(err)
+		}
+
 		changes, res := s.Update(ctx, backend.UpdateOperation{
 			Proj:               proj,
 			Root:               root,
@@ -372,6 +392,9 @@ func newUpCmd() *cobra.Command {
 			SecretsProvider:    stack.DefaultSecretsProvider,
 			Scopes:             backend.CancellationScopes,
 		})
+		if err := summary.print(ctx, changes, res); err != nil {
+			return result.FromError(err)
+		}
 		switch {
 		case res != nil && res.Error() == context.Canceled:
 			return result.FromError(errors.New("update cancelled"))
@@ -411,7 +434,12 @@ func newUpCmd() *cobra.Command {
 
 			yes = yes || skipPreview || skipConfirmations()
 
-			interactive := cmdutil.Interactive()
+			if err := outputArgs.validate(jsonDisplay, diffDisplay); err != nil {
+				return result.FromError(err)
+			}
+
+			// The JSON summary is meant to be read by other programs, so never prompt for it.
+			interactive := cmdutil.Interactive() && !outputArgs.json()
 			if !interactive && !yes {
 				return result.FromError(
 					errors.New("--yes or --skip-preview must be passed in to proceed when running in non-interactive mode"))
@@ -442,6 +470,9 @@ func newUpCmd() *cobra.Command {
 				IsInteractive:        interactive,
 				Type:                 displayType,
 				EventLogPath:         eventLogPath,
+				ReportJUnitPath:      reportJUnitPath,
+				ReportSARIFPath:      reportSARIFPath,
+				HTMLReportPath:       htmlReportPath,
 				Debug:                debug,
 				JSONDisplay:          jsonDisplay,
 			}
@@ -454,10 +485,18 @@ func newUpCmd() *cobra.Command {
 				opts.Display.SuppressPermalink = false
 			}
 
+			if err := diagnosticArgs.applyOptions(&opts.Display); err != nil {
+				return result.FromError(err)
+			}
+			outputArgs.applyOptions(&opts.Display)
+
 			if remoteArgs.remote {
 				if len(args) == 0 {
 					return result.FromError(errors.New("must specify remote URL"))
 				}
+				if outputArgs.json() {
+					return result.FromError(errors.New("--output is not supported for remote operations"))
+				}
 
 				err = validateUnsupportedRemoteFlags(expectNop, configArray, path, client, jsonDisplay, policyPackPaths,
 					policyPackConfigPaths, refresh, showConfig, showReplacementSteps, showSames, showReads,
@@ -509,8 +548,9 @@ func newUpCmd() *cobra.Command {
 		"Config keys contain a path to a property in a map or list to set")
 	cmd.PersistentFlags().StringVar(
 		&secretsProvider, "secrets-provider", "default", "The type of the provider that should be used to encrypt and "+
-			"decrypt secrets (possible choices: default, passphrase, awskms, azurekeyvault, gcpkms, hashivault). Only "+
-			"used when creating a new stack from an existing template")
+			"decrypt secrets (possible choices: default, passphrase, age, awskms, azurekeyvault, gcpkms, hashivault, "+
+			"or the name of an installed secrets provider plugin). Only used when "+
+			"creating a new stack from an existing template")
 
 	cmd.PersistentFlags().StringVar(
 		&client, "client", "", "The address of an existing language runtime host to connect to")
@@ -584,6 +624,15 @@ func newUpCmd() *cobra.Command {
 		&suppressPermalink, "suppress-permalink", "",
 		"Suppress display of the state permalink")
 	cmd.Flag("suppress-permalink").NoOptDefVal = "false"
+	cmd.PersistentFlags().StringVar(
+		&reportJUnitPath, "report-junit", "",
+		"Write a JUnit XML report of the resources and policy violations of the update to this file")
+	cmd.PersistentFlags().StringVar(
+		&reportSARIFPath, "report-sarif", "",
+		"Write a SARIF report of the resources and policy violations of the update to this file")
+	cmd.PersistentFlags().StringVar(
+		&htmlReportPath, "html-report", "",
+		"Write a self-contained HTML report of the update to this file")
 	cmd.PersistentFlags().BoolVarP(
 		&yes, "yes", "y", false,
 		"Automatically approve and perform the update after previewing it")
@@ -600,6 +649,12 @@ func newUpCmd() *cobra.Command {
 	// Remote flags
 	remoteArgs.applyFlags(cmd)
 
+	// Diagnostic flags
+	diagnosticArgs.applyFlags(cmd)
+
+	// Output flags
+	outputArgs.applyFlags(cmd)
+
 	if hasDebugCommands() {
 		cmd.PersistentFlags().StringVar(
 			&eventLogPath, "event-log", "",
@@ -44,8 +44,10 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/backend/state"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/age"
 	"github.com/pulumi/pulumi/pkg/v3/secrets/cloud"
 	"github.com/pulumi/pulumi/pkg/v3/secrets/passphrase"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/secretsplugin"
 	"github.com/pulumi/pulumi/pkg/v3/util/tracing"
 	"github.com/pulumi/pulumi/pkg/v3/version"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
@@ -204,6 +206,10 @@ func createSecretsManager(
 		_, err = stack.DefaultSecretManager(ps)
 	} else if secretsProvider == passphrase.Type {
 		_, err = passphrase.NewPromptingPassphraseSecretsManager(ps, rotateSecretsProvider)
+	} else if age.IsAgeSecretsProvider(secretsProvider) {
+		_, err = age.NewAgeSecretsManager(ps, secretsProvider, rotateSecretsProvider)
+	} else if secretsplugin.IsPluginSecretsProvider(secretsProvider) {
+		_, err = secretsplugin.NewPluginSecretsManager(ps, secretsProvider, rotateSecretsProvider)
 	} else {
 		// All other non-default secrets providers are handled by the cloud secrets provider which
 		// uses a URL schema to identify the provider
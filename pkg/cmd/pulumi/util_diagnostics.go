@@ -0,0 +1,66 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+)
+
+// DiagnosticArgs are the flags that control which of the diagnostics of an update are displayed, and how.
+type DiagnosticArgs struct {
+	severity     string
+	filters      []string
+	collapse     bool
+	streamErrors bool
+}
+
+// Add flags to filter and group diagnostics
+func (d *DiagnosticArgs) applyFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(
+		&d.severity, "diagnostic-severity", "",
+		"Only display diagnostics at least this severe: one of 'debug', 'info', 'warning' or 'error'")
+	cmd.PersistentFlags().StringArrayVar(
+		&d.filters, "diagnostic-filter", nil,
+		"Only display the diagnostics of resources with this URN or type. Wildcards (*, **) are supported, "+
+			"as for --target. Multiple filters may be given")
+	cmd.PersistentFlags().BoolVar(
+		&d.collapse, "collapse-diagnostics", false,
+		"Display repeated identical diagnostics of a resource once, with the number of times they were reported")
+	cmd.PersistentFlags().BoolVar(
+		&d.streamErrors, "stream-errors", false,
+		"Display errors as soon as they are reported, as well as in the diagnostics at the end of the update")
+}
+
+// applyOptions sets the display options that correspond to the flags, returning an error if they are invalid.
+func (d *DiagnosticArgs) applyOptions(opts *display.Options) error {
+	switch severity := diag.Severity(d.severity); severity {
+	case "":
+	case diag.Debug, diag.Info, diag.Warning, diag.Error:
+		opts.DiagnosticSeverity = severity
+	default:
+		return fmt.Errorf("unsupported diagnostic severity '%s'; must be one of 'debug', 'info', 'warning' or 'error'",
+			d.severity)
+	}
+
+	opts.DiagnosticFilters = d.filters
+	opts.CollapseDiagnostics = d.collapse
+	opts.StreamErrors = d.streamErrors
+	return nil
+}
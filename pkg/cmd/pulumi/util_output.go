@@ -0,0 +1,241 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	sdkDisplay "github.com/pulumi/pulumi/sdk/v3/go/common/display"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
+)
+
+// OutputArgs is the flag that selects the output of a command that changes or previews a stack.
+type OutputArgs struct {
+	// formats are the output formats that the command supports. If empty, the command only supports `json`.
+	formats []string
+	format  string
+}
+
+// outputFormatUsage describes each output format in the usage of the --output flag.
+var outputFormatUsage = map[string]string{
+	"json":     "`json` prints a single JSON document",
+	"markdown": "`markdown` renders a summary that is suitable for a pull request comment",
+}
+
+func (o *OutputArgs) supportedFormats() []string {
+	if len(o.formats) == 0 {
+		return []string{"json"}
+	}
+	return o.formats
+}
+
+// Add the --output flag
+func (o *OutputArgs) applyFlags(cmd *cobra.Command) {
+	usage := make([]string, len(o.supportedFormats()))
+	for i, format := range o.supportedFormats() {
+		usage[i] = outputFormatUsage[format]
+	}
+	cmd.PersistentFlags().StringVar(
+		&o.format, "output", "",
+		"Print a summary of the operation in the given format once it completes, instead of displaying its "+
+			"progress. "+strings.Join(usage, "; "))
+}
+
+// validate returns an error if the output format is not supported, or if it is combined with --json or --diff.
+func (o *OutputArgs) validate(jsonDisplay, diffDisplay bool) error {
+	if o.format == "" {
+		return nil
+	}
+	formats := o.supportedFormats()
+	for _, format := range formats {
+		if format != o.format {
+			continue
+		}
+		switch {
+		case jsonDisplay:
+			return errors.New("--output cannot be combined with --json")
+		case diffDisplay && format == "markdown":
+			return errors.New("--output markdown cannot be combined with --diff")
+		}
+		return nil
+	}
+	if len(formats) == 1 {
+		return fmt.Errorf("unsupported output format '%s'; the only supported format is '%s'", o.format, formats[0])
+	}
+	return fmt.Errorf("unsupported output format '%s'; the supported formats are '%s'",
+		o.format, strings.Join(formats, "', '"))
+}
+
+// json returns true if the command should print a JSON summary.
+func (o *OutputArgs) json() bool {
+	return o.format == "json"
+}
+
+// applyOptions selects the summary display if the command should print a JSON summary, or the markdown display if
+// it should render a markdown summary.
+func (o *OutputArgs) applyOptions(opts *display.Options) {
+	switch o.format {
+	case "json":
+		opts.Type = display.DisplaySummary
+		opts.SummaryErrors = &display.SummaryErrors{}
+	case "markdown":
+		opts.Type = display.DisplayMarkdown
+	}
+}
+
+// operationSummary builds the summary that a command that changes a stack prints when it is run with `--output json`.
+type operationSummary struct {
+	kind    apitype.OperationKind
+	stack   backend.Stack
+	errors  *display.SummaryErrors
+	start   time.Time
+	version int // The latest version of the stack's history before the operation.
+
+	stdout io.Writer // defaults to os.Stdout
+}
+
+// newOperationSummary starts the summary of an operation on a stack if the display options select the summary
+// display, and returns nil otherwise.
+func newOperationSummary(ctx context.Context, kind apitype.OperationKind, s backend.Stack,
+	opts display.Options,
+) (*operationSummary, error) {
+	if opts.Type != display.DisplaySummary {
+		return nil, nil
+	}
+
+	version, err := latestStackVersion(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	return &operationSummary{
+		kind:    kind,
+		stack:   s,
+		errors:  opts.SummaryErrors,
+		start:   time.Now(),
+		version: version,
+	}, nil
+}
+
+// isUpdate returns true if the operation is an update that is run by the engine, which reports the resources that it
+// changed and may change the outputs of the stack.
+func (o *operationSummary) isUpdate() bool {
+	switch o.kind {
+	case apitype.UpdateOperation, apitype.RefreshOperation, apitype.DestroyOperation, apitype.ImportOperation:
+		return true
+	default:
+		return false
+	}
+}
+
+// print prints the summary of the completed operation, given the resources that it changed and its result. It is a
+// no-op if the summary is nil.
+func (o *operationSummary) print(ctx context.Context, changes sdkDisplay.ResourceChanges, res result.Result) error {
+	if o == nil {
+		return nil
+	}
+
+	end := time.Now()
+	summary := apitype.OperationSummary{
+		Operation:       o.kind,
+		Stack:           o.stack.Ref().FullyQualifiedName().String(),
+		Result:          apitype.SucceededResult,
+		StartTime:       o.start.UTC().Format(time.RFC3339),
+		EndTime:         end.UTC().Format(time.RFC3339),
+		DurationSeconds: end.Sub(o.start).Seconds(),
+	}
+	if project, ok := o.stack.Ref().Project(); ok {
+		summary.Project = project.String()
+	}
+
+	if o.errors != nil {
+		summary.Errors = o.errors.Errors()
+	}
+	if res != nil {
+		summary.Result = apitype.FailedResult
+
+		// Include the error that the operation failed with, unless it was already reported.
+		if err := res.Error(); err != nil {
+			reported := false
+			for _, e := range summary.Errors {
+				reported = reported || e.URN == "" && e.Message == err.Error()
+			}
+			if !reported {
+				summary.Errors = append(summary.Errors, apitype.OperationError{Message: err.Error()})
+			}
+		}
+	}
+
+	if o.isUpdate() {
+		if len(changes) > 0 {
+			summary.ResourceChanges = make(map[apitype.OpType]int)
+			for op, count := range changes {
+				summary.ResourceChanges[apitype.OpType(op)] = count
+			}
+		}
+
+		snap, err := o.stack.Snapshot(ctx, stack.DefaultSecretsProvider)
+		if err != nil {
+			return fmt.Errorf("getting snapshot: %w", err)
+		}
+		if snap != nil {
+			outputs, err := getStackOutputs(snap, false /* showSecrets */)
+			if err != nil {
+				return fmt.Errorf("getting outputs: %w", err)
+			}
+			if len(outputs) > 0 {
+				summary.Outputs = outputs
+			}
+		}
+	}
+
+	// Only report the latest version of the stack's history if the operation added it.
+	version, err := latestStackVersion(ctx, o.stack)
+	if err != nil {
+		return err
+	}
+	if version > o.version {
+		summary.Version = version
+	}
+
+	stdout := o.stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	return fprintJSON(stdout, summary)
+}
+
+// latestStackVersion returns the version of the latest update in the history of a stack, or 0 if it has none.
+func latestStackVersion(ctx context.Context, s backend.Stack) (int, error) {
+	updates, err := s.Backend().GetHistory(ctx, s.Ref(), 1 /*pageSize*/, 1 /*page*/)
+	if err != nil {
+		return 0, fmt.Errorf("getting history: %w", err)
+	}
+	if len(updates) == 0 {
+		return 0, nil
+	}
+	return updates[0].Version, nil
+}
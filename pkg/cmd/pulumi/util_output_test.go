@@ -0,0 +1,210 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/backend"
+	"github.com/pulumi/pulumi/pkg/v3/backend/display"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	sdkDisplay "github.com/pulumi/pulumi/sdk/v3/go/common/display"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
+)
+
+func TestOutputArgs(t *testing.T) {
+	t.Parallel()
+
+	args := OutputArgs{}
+	assert.NoError(t, args.validate(true, true))
+	assert.False(t, args.json())
+
+	var opts display.Options
+	args.applyOptions(&opts)
+	assert.Equal(t, display.DisplayProgress, opts.Type)
+
+	args.format = "json"
+	assert.NoError(t, args.validate(false, true))
+	assert.ErrorContains(t, args.validate(true, false), "--output cannot be combined with --json")
+	assert.True(t, args.json())
+
+	args.applyOptions(&opts)
+	assert.Equal(t, display.DisplaySummary, opts.Type)
+	assert.NotNil(t, opts.SummaryErrors)
+
+	args.format = "yaml"
+	assert.ErrorContains(t, args.validate(false, false), "unsupported output format 'yaml'")
+
+	args.format = "markdown"
+	assert.ErrorContains(t, args.validate(false, false), "the only supported format is 'json'")
+}
+
+func TestOutputArgsMarkdown(t *testing.T) {
+	t.Parallel()
+
+	args := OutputArgs{formats: []string{"markdown"}, format: "markdown"}
+	assert.NoError(t, args.validate(false, false))
+	assert.ErrorContains(t, args.validate(true, false), "--output cannot be combined with --json")
+	assert.ErrorContains(t, args.validate(false, true), "--output markdown cannot be combined with --diff")
+	assert.False(t, args.json())
+
+	var opts display.Options
+	args.applyOptions(&opts)
+	assert.Equal(t, display.DisplayMarkdown, opts.Type)
+	assert.Nil(t, opts.SummaryErrors)
+
+	args.format = "json"
+	assert.ErrorContains(t, args.validate(false, false), "the only supported format is 'markdown'")
+}
+
+// newSummaryTestStack returns a stack whose history grows by one version each time that it is read, as if an update
+// ran in between.
+func newSummaryTestStack(outputs resource.PropertyMap) backend.Stack {
+	version := 3
+	be := &backend.MockBackend{
+		GetHistoryF: func(context.Context, backend.StackReference, int, int) ([]backend.UpdateInfo, error) {
+			version++
+			return []backend.UpdateInfo{{Version: version}}, nil
+		},
+	}
+	snap := &deploy.Snapshot{
+		Resources: []*resource.State{
+			{
+				Type:    resource.RootStackType,
+				Outputs: outputs,
+			},
+		},
+	}
+	return &backend.MockStack{
+		RefF: func() backend.StackReference {
+			return &backend.MockStackReference{
+				ProjectV:            "proj",
+				FullyQualifiedNameV: "org/proj/dev",
+			}
+		},
+		SnapshotF: func(context.Context, secrets.Provider) (*deploy.Snapshot, error) {
+			return snap, nil
+		},
+		BackendF: func() backend.Backend {
+			return be
+		},
+	}
+}
+
+func TestOperationSummary(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("not requested", func(t *testing.T) {
+		t.Parallel()
+
+		summary, err := newOperationSummary(ctx, apitype.UpdateOperation, newSummaryTestStack(nil), display.Options{})
+		require.NoError(t, err)
+		assert.Nil(t, summary)
+		assert.NoError(t, summary.print(ctx, nil, nil))
+	})
+
+	t.Run("succeeded", func(t *testing.T) {
+		t.Parallel()
+
+		s := newSummaryTestStack(resource.PropertyMap{
+			"bucketName": resource.NewStringProperty("mybucket-1234"),
+			"password": resource.NewSecretProperty(&resource.Secret{
+				Element: resource.NewStringProperty("hunter2"),
+			}),
+		})
+		opts := display.Options{Type: display.DisplaySummary, SummaryErrors: &display.SummaryErrors{}}
+		summary, err := newOperationSummary(ctx, apitype.UpdateOperation, s, opts)
+		require.NoError(t, err)
+
+		var stdout bytes.Buffer
+		summary.stdout = &stdout
+		changes := sdkDisplay.ResourceChanges{
+			sdkDisplay.StepOp("create"): 2,
+			sdkDisplay.StepOp("same"):   1,
+		}
+		require.NoError(t, summary.print(ctx, changes, nil))
+		assert.NotContains(t, stdout.String(), "hunter2")
+
+		var got apitype.OperationSummary
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &got), "output is not valid JSON:\n%s", stdout.String())
+		assert.Equal(t, apitype.UpdateOperation, got.Operation)
+		assert.Equal(t, "proj", got.Project)
+		assert.Equal(t, "org/proj/dev", got.Stack)
+		assert.Equal(t, apitype.SucceededResult, got.Result)
+		assert.NotEmpty(t, got.StartTime)
+		assert.NotEmpty(t, got.EndTime)
+		assert.Equal(t, map[apitype.OpType]int{"create": 2, "same": 1}, got.ResourceChanges)
+		assert.Equal(t, map[string]interface{}{"bucketName": "mybucket-1234", "password": "[secret]"}, got.Outputs)
+		assert.Empty(t, got.Errors)
+		assert.Equal(t, 5, got.Version)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		t.Parallel()
+
+		opts := display.Options{Type: display.DisplaySummary, SummaryErrors: &display.SummaryErrors{}}
+		summary, err := newOperationSummary(ctx, apitype.UpdateOperation, newSummaryTestStack(nil), opts)
+		require.NoError(t, err)
+
+		opts.SummaryErrors.Add(apitype.OperationError{
+			URN:     "urn:pulumi:dev::proj::aws:s3/bucket:Bucket::b",
+			Message: "creating bucket: access denied",
+		})
+
+		var stdout bytes.Buffer
+		summary.stdout = &stdout
+		require.NoError(t, summary.print(ctx, nil, result.FromError(errors.New("update failed"))))
+
+		var got apitype.OperationSummary
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &got), "output is not valid JSON:\n%s", stdout.String())
+		assert.Equal(t, apitype.FailedResult, got.Result)
+		assert.Equal(t, []apitype.OperationError{
+			{URN: "urn:pulumi:dev::proj::aws:s3/bucket:Bucket::b", Message: "creating bucket: access denied"},
+			{Message: "update failed"},
+		}, got.Errors)
+	})
+
+	t.Run("config set", func(t *testing.T) {
+		t.Parallel()
+
+		opts := display.Options{Type: display.DisplaySummary}
+		summary, err := newOperationSummary(ctx, apitype.ConfigSetOperation, newSummaryTestStack(nil), opts)
+		require.NoError(t, err)
+
+		var stdout bytes.Buffer
+		summary.stdout = &stdout
+		require.NoError(t, summary.print(ctx, nil, nil))
+
+		var got map[string]interface{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &got), "output is not valid JSON:\n%s", stdout.String())
+		assert.Equal(t, "config-set", got["operation"])
+		assert.Equal(t, "succeeded", got["result"])
+		assert.NotContains(t, got, "resourceChanges")
+		assert.NotContains(t, got, "outputs")
+		assert.NotContains(t, got, "errors")
+	})
+}
@@ -27,7 +27,6 @@ import (
 	"github.com/pulumi/pulumi/pkg/v3/resource/stack"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 )
 
 // intentionally disabling here for cleaner err declaration/assignment.
@@ -120,7 +119,7 @@ func newWatchCmd() *cobra.Command {
 			}
 
 			stackName := s.Ref().Name().String()
-			configErr := workspace.ValidateStackConfigAndApplyProjectConfig(stackName, proj, cfg.Config, decrypter)
+			configErr := validateStackConfig(stackName, proj, cfg.Config, decrypter)
 			if configErr != nil {
 				return result.FromError(fmt.Errorf("validating stack config: %w", configErr))
 			}
@@ -131,6 +130,7 @@ func newWatchCmd() *cobra.Command {
 				Debug:                     debug,
 				Refresh:                   refresh,
 				UseLegacyDiff:             useLegacyDiff(),
+				Environment:               cfg.Environment,
 				DisableProviderPreview:    disableProviderPreview(),
 				DisableResourceReferences: disableResourceReferences(),
 				DisableOutputValues:       disableOutputValues(),
@@ -180,8 +180,9 @@ func newWatchCmd() *cobra.Command {
 		"Config keys contain a path to a property in a map or list to set")
 	cmd.PersistentFlags().StringVar(
 		&secretsProvider, "secrets-provider", "default", "The type of the provider that should be used to encrypt and "+
-			"decrypt secrets (possible choices: default, passphrase, awskms, azurekeyvault, gcpkms, hashivault). Only "+
-			"used when creating a new stack from an existing template")
+			"decrypt secrets (possible choices: default, passphrase, age, awskms, azurekeyvault, gcpkms, hashivault, "+
+			"or the name of an installed secrets provider plugin). Only used when "+
+			"creating a new stack from an existing template")
 
 	cmd.PersistentFlags().StringVarP(
 		&message, "message", "m", "",
@@ -91,8 +91,9 @@ func ProviderFactoryFromHost(host plugin.Host) ProviderFactory {
 }
 
 type mapperPluginSpec struct {
-	name    tokens.Package
-	version semver.Version
+	name tokens.Package
+	// version is nil for project plugins that don't pin a version.
+	version *semver.Version
 }
 
 type pluginMapper struct {
@@ -103,9 +104,13 @@ type pluginMapper struct {
 	installProvider func(tokens.Package) *semver.Version
 }
 
+// NewPluginMapper creates a mapper that asks provider plugins for mappings. The candidate plugins are the latest
+// installed version of each resource plugin, along with the given project plugins. Project plugins are the plugins a
+// program needs, e.g. those in the plugins section of Pulumi.yaml or the packages referenced by a program that a
+// converter has already converted, and take precedence over installed plugins of the same name.
 func NewPluginMapper(ws Workspace,
 	providerFactory ProviderFactory,
-	key string, mappings []string,
+	key string, mappings []string, projectPlugins []workspace.PluginSpec,
 	installProvider func(tokens.Package) *semver.Version,
 ) (Mapper, error) {
 	contract.Requiref(providerFactory != nil, "providerFactory", "must not be nil")
@@ -145,10 +150,21 @@ func NewPluginMapper(ws Workspace,
 	// We now have a list of plugin specs (i.e. a name and version), save that list because we don't want to
 	// iterate all the plugins now because the convert might not even ask for any mappings.
 	plugins := make([]mapperPluginSpec, 0)
+	for _, spec := range projectPlugins {
+		if spec.Kind != workspace.ResourcePlugin {
+			continue
+		}
+		plugins = append(plugins, mapperPluginSpec{
+			name:    tokens.Package(spec.Name),
+			version: spec.Version,
+		})
+		delete(latestVersions, spec.Name)
+	}
 	for pkg, version := range latestVersions {
+		version := version
 		plugins = append(plugins, mapperPluginSpec{
 			name:    tokens.Package(pkg),
-			version: version,
+			version: &version,
 		})
 	}
 
@@ -184,7 +200,7 @@ func NewPluginMapper(ws Workspace,
 // This is because tfbridge providers originally only replied to "tf", while new ones reply (with the same
 // answer) to both "tf" and "terraform".
 func (l *pluginMapper) getMappingForPlugin(pluginSpec mapperPluginSpec) ([]byte, string, error) {
-	providerPlugin, err := l.providerFactory(pluginSpec.name, &pluginSpec.version)
+	providerPlugin, err := l.providerFactory(pluginSpec.name, pluginSpec.version)
 	if err != nil {
 		// We should maybe be lenient here and ignore errors but for now assume it's better to fail out on
 		// things like providers failing to start.
@@ -260,7 +276,7 @@ func (l *pluginMapper) GetMapping(ctx context.Context, provider string, pulumiPr
 			i := len(l.plugins)
 			l.plugins = append(l.plugins, mapperPluginSpec{
 				name:    pulumiProviderPkg,
-				version: *version,
+				version: version,
 			})
 			l.plugins[0], l.plugins[i] = l.plugins[i], l.plugins[0]
 		}
@@ -179,7 +179,7 @@ func TestPluginMapper_InstalledPluginMatches(t *testing.T) {
 		return nil
 	}
 
-	mapper, err := NewPluginMapper(ws, providerFactory, "key", nil, installPlugin)
+	mapper, err := NewPluginMapper(ws, providerFactory, "key", nil, nil, installPlugin)
 	assert.NoError(t, err)
 	assert.NotNil(t, mapper)
 
@@ -223,7 +223,7 @@ func TestPluginMapper_MappedNameDiffersFromPulumiName(t *testing.T) {
 		return nil
 	}
 
-	mapper, err := NewPluginMapper(ws, providerFactory, "key", nil, installPlugin)
+	mapper, err := NewPluginMapper(ws, providerFactory, "key", nil, nil, installPlugin)
 	assert.NoError(t, err)
 	assert.NotNil(t, mapper)
 
@@ -268,7 +268,7 @@ func TestPluginMapper_NoPluginMatches(t *testing.T) {
 			return &ver
 		}
 
-		mapper, err := NewPluginMapper(ws, providerFactory, "key", nil, installPlugin)
+		mapper, err := NewPluginMapper(ws, providerFactory, "key", nil, nil, installPlugin)
 		assert.NoError(t, err)
 		assert.NotNil(t, mapper)
 
@@ -302,7 +302,7 @@ func TestPluginMapper_NoPluginMatches(t *testing.T) {
 			return nil
 		}
 
-		mapper, err := NewPluginMapper(ws, providerFactory, "key", nil, installPlugin)
+		mapper, err := NewPluginMapper(ws, providerFactory, "key", nil, nil, installPlugin)
 		assert.NoError(t, err)
 		assert.NotNil(t, mapper)
 
@@ -349,7 +349,7 @@ func TestPluginMapper_UseMatchingNameFirst(t *testing.T) {
 		return nil
 	}
 
-	mapper, err := NewPluginMapper(ws, providerFactory, "key", nil, installPlugin)
+	mapper, err := NewPluginMapper(ws, providerFactory, "key", nil, nil, installPlugin)
 	assert.NoError(t, err)
 	assert.NotNil(t, mapper)
 
@@ -408,7 +408,7 @@ func TestPluginMapper_MappedNamesDifferFromPulumiName(t *testing.T) {
 		return nil
 	}
 
-	mapper, err := NewPluginMapper(ws, providerFactory, "key", nil, installPlugin)
+	mapper, err := NewPluginMapper(ws, providerFactory, "key", nil, nil, installPlugin)
 	assert.NoError(t, err)
 	assert.NotNil(t, mapper)
 
@@ -460,7 +460,7 @@ func TestPluginMapper_MappedNamesDifferFromPulumiNameWithHint(t *testing.T) {
 		return nil
 	}
 
-	mapper, err := NewPluginMapper(ws, providerFactory, "key", nil, installPlugin)
+	mapper, err := NewPluginMapper(ws, providerFactory, "key", nil, nil, installPlugin)
 	assert.NoError(t, err)
 	assert.NotNil(t, mapper)
 
@@ -490,7 +490,7 @@ func TestPluginMapper_MissingProviderOnlyTriesToInstallOnce(t *testing.T) {
 		return nil
 	}
 
-	mapper, err := NewPluginMapper(ws, providerFactory, "key", nil, installPlugin)
+	mapper, err := NewPluginMapper(ws, providerFactory, "key", nil, nil, installPlugin)
 	assert.NoError(t, err)
 	assert.NotNil(t, mapper)
 
@@ -507,3 +507,46 @@ func TestPluginMapper_MissingProviderOnlyTriesToInstallOnce(t *testing.T) {
 	// Install should have only been called once
 	assert.Equal(t, 1, called)
 }
+
+func TestPluginMapper_ProjectPluginOverridesInstalled(t *testing.T) {
+	t.Parallel()
+
+	ws := &testWorkspace{
+		infos: []workspace.PluginInfo{
+			{
+				Name:    "provider",
+				Kind:    workspace.ResourcePlugin,
+				Version: semverMustParse("2.0.0"),
+			},
+		},
+	}
+	testProvider := &testProvider{
+		pkg: tokens.Package("provider"),
+		mapping: func(key string) ([]byte, string, error) {
+			return []byte("data"), "provider", nil
+		},
+	}
+
+	providerFactory := func(pkg tokens.Package, version *semver.Version) (plugin.Provider, error) {
+		assert.Equal(t, testProvider.pkg, pkg)
+		assert.Equal(t, semverMustParse("1.5.0"), version)
+		return testProvider, nil
+	}
+
+	installPlugin := func(pkg tokens.Package) *semver.Version {
+		t.Fatal("should not be called")
+		return nil
+	}
+
+	projectPlugins := []workspace.PluginSpec{
+		{Name: "provider", Kind: workspace.ResourcePlugin, Version: semverMustParse("1.5.0")},
+		// Only resource plugins are used for mappings.
+		{Name: "other", Kind: workspace.LanguagePlugin},
+	}
+	mapper, err := NewPluginMapper(ws, providerFactory, "key", nil, projectPlugins, installPlugin)
+	assert.NoError(t, err)
+
+	data, err := mapper.GetMapping(context.Background(), "provider", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+}
@@ -177,6 +177,9 @@ type pkgContext struct {
 
 	// Determines if we should emit object defaults code
 	disableObjectDefaults bool
+
+	// Determines if we should use generic input and output types instead of per-type input and output types
+	generics bool
 }
 
 func (pkg *pkgContext) detailsForType(t schema.Type) *typeDetails {
@@ -454,6 +457,20 @@ func (pkg *pkgContext) inputType(t schema.Type) (result string) {
 	panic(fmt.Errorf("unexpected type %T", t))
 }
 
+// genericInputType returns a reference to the pulumi.InputOf[T] type that corresponds to the given schema type, where
+// T is the plain Go type for the schema type.
+func (pkg *pkgContext) genericInputType(t schema.Type) string {
+	return fmt.Sprintf("pulumi.InputOf[%s]", pkg.typeString(codegen.ResolvedType(t)))
+}
+
+// stateInputType returns the type of a property in a resource's state struct.
+func (pkg *pkgContext) stateInputType(p *schema.Property) string {
+	if pkg.generics {
+		return pkg.genericInputType(codegen.OptionalType(p))
+	}
+	return pkg.inputType(p.Type)
+}
+
 func (pkg *pkgContext) argsTypeImpl(t schema.Type) (result string) {
 	switch t := codegen.SimplifyInputUnion(t).(type) {
 	case *schema.OptionalType:
@@ -737,6 +754,28 @@ func (pkg *pkgContext) contextForExternalReference(t schema.Type) (*pkgContext,
 	return extPkgCtx, *maps[mod].detailsForType(t)
 }
 
+// genGenericFunctionOutputVersion generates the output version of a function for a generic SDK. The output version
+// accepts its arguments as a pulumi.InputOf[Args] and returns a pulumi.OutputOf[Result].
+func (pkg *pkgContext) genGenericFunctionOutputVersion(w io.Writer, f *schema.Function) {
+	code := `
+func ${fn}Output(
+	ctx *pulumi.Context, args pulumi.InputOf[${fn}Args], opts ...pulumi.InvokeOption,
+) pulumi.OutputOf[${fn}Result] {
+	return pulumi.ApplyOfErr(pulumi.ToOutputOf(args), func(args ${fn}Args) (${fn}Result, error) {
+		r, err := ${fn}(ctx, &args, opts...)
+		var s ${fn}Result
+		if r != nil {
+			s = *r
+		}
+		return s, err
+	})
+}
+`
+
+	code = strings.ReplaceAll(code, "${fn}", pkg.functionName(f))
+	fmt.Fprint(w, code)
+}
+
 // outputTypeImpl does the meat of the generation of output type names from schema types. This function should only be
 // called with a fully-resolved type (e.g. the result of codegen.ResolvedType). Instead of calling this function, you
 // probably want to call pkgContext.outputType, which ensures that its argument is resolved.
@@ -825,8 +864,12 @@ func (pkg *pkgContext) outputTypeImpl(t schema.Type) string {
 // outputType returns a reference to the Go output type that corresponds to the given schema type. For example, given
 // a schema.String, outputType returns "pulumi.String", and given a *schema.ObjectType with the token pkg:mod:Name,
 // outputType returns "mod.NameOutput" or "NameOutput", depending on whether or not the object type lives in a
-// different module than the one associated with the receiver.
+// different module than the one associated with the receiver. When generating a generic SDK, outputType returns
+// "pulumi.OutputOf[T]", where T is the plain Go type that corresponds to the given schema type.
 func (pkg *pkgContext) outputType(t schema.Type) string {
+	if pkg.generics {
+		return fmt.Sprintf("pulumi.OutputOf[%s]", pkg.typeString(codegen.ResolvedType(t)))
+	}
 	return pkg.outputTypeImpl(codegen.ResolvedType(t))
 }
 
@@ -1119,6 +1162,11 @@ func (pkg *pkgContext) genEnum(w io.Writer, enumType *schema.EnumType) error {
 	}
 	fmt.Fprintln(w, ")")
 
+	// Generic SDKs use pulumi.InputOf[T] and pulumi.OutputOf[T] in place of the enum input and output types.
+	if pkg.generics {
+		return nil
+	}
+
 	details := pkg.detailsForType(enumType)
 	if details.input || details.ptrInput {
 		inputType := pkg.inputType(enumType)
@@ -1311,6 +1359,19 @@ func (pkg *pkgContext) assignProperty(w io.Writer, p *schema.Property, object, v
 	}
 }
 
+// assignGenericProperty generates a statement that assigns a prompt value to a pulumi.InputOf[T] property.
+func (pkg *pkgContext) assignGenericProperty(w io.Writer, p *schema.Property, object, value string) {
+	elementType := codegen.UnwrapType(codegen.ResolvedType(p.Type))
+	value = fmt.Sprintf("%s(%s)", pkg.typeString(elementType), value)
+
+	if _, optional := p.Type.(*schema.OptionalType); optional && !isNilType(elementType) {
+		tmpName := cgstrings.Camel(p.Name) + "_"
+		fmt.Fprintf(w, "%s := %s\n", tmpName, value)
+		value = "&" + tmpName
+	}
+	fmt.Fprintf(w, "%s.%s = pulumi.ValOf(%s)\n", object, pkg.fieldName(nil, p), value)
+}
+
 func (pkg *pkgContext) fieldName(r *schema.Resource, field *schema.Property) string {
 	contract.Assertf(field != nil, "Field must not be nil")
 	return fieldName(pkg, r, field)
@@ -1683,6 +1744,10 @@ func (pkg *pkgContext) setDefaultValue(
 func (pkg *pkgContext) genResource(w io.Writer, r *schema.Resource, generateResourceContainerTypes bool) error {
 	name := disambiguatedResourceName(r, pkg)
 
+	if pkg.generics && len(r.Methods) != 0 {
+		return fmt.Errorf("go generic SDK-gen does not implement methods for resource '%s'", r.Token)
+	}
+
 	printCommentWithDeprecationMessage(w, r.Comment, r.DeprecationMessage, false)
 	fmt.Fprintf(w, "type %s struct {\n", name)
 
@@ -1734,7 +1799,7 @@ func (pkg *pkgContext) genResource(w io.Writer, r *schema.Resource, generateReso
 
 	// Check all required inputs are present
 	for _, p := range r.InputProperties {
-		if p.IsRequired() && isNilType(p.Type) && p.DefaultValue == nil {
+		if p.IsRequired() && (isNilType(p.Type) || pkg.generics && !p.Plain) && p.DefaultValue == nil {
 			fmt.Fprintf(w, "\tif args.%s == nil {\n", pkg.fieldName(r, p))
 			fmt.Fprintf(w, "\t\treturn nil, errors.New(\"invalid value for required argument '%s'\")\n", pkg.fieldName(r, p))
 			fmt.Fprintf(w, "\t}\n")
@@ -1746,6 +1811,10 @@ func (pkg *pkgContext) genResource(w io.Writer, r *schema.Resource, generateReso
 	}
 
 	assign := func(w io.Writer, p *schema.Property, value string) {
+		if pkg.generics && !p.Plain {
+			pkg.assignGenericProperty(w, p, "args", value)
+			return
+		}
 		pkg.assignProperty(w, p, "args", value, isNilType(p.Type))
 	}
 
@@ -1757,7 +1826,7 @@ func (pkg *pkgContext) genResource(w io.Writer, r *schema.Resource, generateReso
 			}
 			assign(w, p, v)
 		} else if p.DefaultValue != nil {
-			if isNilType(p.Type) {
+			if isNilType(p.Type) || pkg.generics && !p.Plain {
 				fmt.Fprintf(w, "\tif args.%s == nil {\n", pkg.fieldName(r, p))
 			} else {
 				pkg.needsUtils = true
@@ -1785,6 +1854,10 @@ func (pkg *pkgContext) genResource(w io.Writer, r *schema.Resource, generateReso
 				originalValue, resolvedType, optionalDeref, name, outputType)
 			if p.Plain {
 				valueWithDefaults = fmt.Sprintf("args.%v.Defaults()", pkg.fieldName(r, p))
+			} else if pkg.generics {
+				valueWithDefaults = fmt.Sprintf(
+					"pulumi.ApplyOf(args.%[1]s.ToOutputOf(), func(v %[2]s) %[2]s { return %[3]sv.%[4]s() })",
+					pkg.fieldName(r, p), resolvedType, optionalDeref, name)
 			}
 
 			if !p.IsRequired() {
@@ -1823,7 +1896,11 @@ func (pkg *pkgContext) genResource(w io.Writer, r *schema.Resource, generateReso
 	for _, p := range secretInputProps {
 		fmt.Fprintf(w, "\tif args.%s != nil {\n", pkg.fieldName(r, p))
 
-		fmt.Fprintf(w, "\t\targs.%[1]s = pulumi.ToSecret(args.%[1]s).(%[2]s)\n", pkg.fieldName(r, p), pkg.typeString(p.Type))
+		if pkg.generics {
+			fmt.Fprintf(w, "\t\targs.%[1]s = pulumi.SecretOf(args.%[1]s)\n", pkg.fieldName(r, p))
+		} else {
+			fmt.Fprintf(w, "\t\targs.%[1]s = pulumi.ToSecret(args.%[1]s).(%[2]s)\n", pkg.fieldName(r, p), pkg.typeString(p.Type))
+		}
 		fmt.Fprintf(w, "\t}\n")
 	}
 	if len(secretProps) > 0 {
@@ -1898,7 +1975,7 @@ func (pkg *pkgContext) genResource(w io.Writer, r *schema.Resource, generateReso
 		if r.StateInputs != nil {
 			for _, p := range r.StateInputs.Properties {
 				printCommentWithDeprecationMessage(w, p.Comment, p.DeprecationMessage, true)
-				fmt.Fprintf(w, "\t%s %s\n", pkg.fieldName(r, p), pkg.inputType(p.Type))
+				fmt.Fprintf(w, "\t%s %s\n", pkg.fieldName(r, p), pkg.stateInputType(p))
 			}
 		}
 		fmt.Fprintf(w, "}\n\n")
@@ -1929,8 +2006,13 @@ func (pkg *pkgContext) genResource(w io.Writer, r *schema.Resource, generateReso
 			})
 		}
 
+		fieldType := pkg.typeString(typ)
+		if pkg.generics && !p.Plain {
+			fieldType = pkg.genericInputType(typ)
+		}
+
 		printCommentWithDeprecationMessage(w, p.Comment, p.DeprecationMessage, true)
-		fmt.Fprintf(w, "\t%s %s\n", pkg.fieldName(r, p), pkg.typeString(typ))
+		fmt.Fprintf(w, "\t%s %s\n", pkg.fieldName(r, p), fieldType)
 	}
 	fmt.Fprintf(w, "}\n\n")
 
@@ -1938,6 +2020,12 @@ func (pkg *pkgContext) genResource(w io.Writer, r *schema.Resource, generateReso
 	fmt.Fprintf(w, "\treturn reflect.TypeOf((*%sArgs)(nil)).Elem()\n", cgstrings.Camel(name))
 	fmt.Fprintf(w, "}\n")
 
+	// Generic SDKs use pulumi.OutputOf[*Resource] in place of the resource input and output types, and do not
+	// register any types with the runtime.
+	if pkg.generics {
+		return nil
+	}
+
 	// Emit resource methods.
 	for _, method := range r.Methods {
 		methodName := Title(method.Name)
@@ -2155,7 +2243,7 @@ func (pkg *pkgContext) genFunctionCodeFile(f *schema.Function) (string, error) {
 	buffer := &bytes.Buffer{}
 
 	var imports []string
-	if NeedsGoOutputVersion(f) {
+	if NeedsGoOutputVersion(f) && !pkg.generics {
 		imports = []string{"context", "reflect"}
 	}
 
@@ -2294,6 +2382,11 @@ func (pkg *pkgContext) genFunctionOutputVersion(w io.Writer, f *schema.Function)
 		return
 	}
 
+	if pkg.generics {
+		pkg.genGenericFunctionOutputVersion(w, f)
+		return
+	}
+
 	originalName := pkg.functionName(f)
 	name := originalName + "Output"
 	originalResultTypeName := pkg.functionResultTypeName(f)
@@ -2470,6 +2563,11 @@ func (pkg *pkgContext) genType(w io.Writer, obj *schema.ObjectType) error {
 		}
 	}
 
+	// Generic SDKs only need the plain type.
+	if pkg.generics {
+		return nil
+	}
+
 	if err := pkg.genInputTypes(w, obj.InputShape, pkg.detailsForType(obj)); err != nil {
 		return err
 	}
@@ -3195,6 +3293,7 @@ func generatePackageContextMap(tool string, pkg schema.PackageReference, goInfo
 				liftSingleValueMethodReturns:  goInfo.LiftSingleValueMethodReturns,
 				disableInputTypeRegistrations: goInfo.DisableInputTypeRegistrations,
 				disableObjectDefaults:         goInfo.DisableObjectDefaults,
+				generics:                      goInfo.Generics,
 				externalPackages:              externalPkgs,
 			}
 			packages[mod] = pack
@@ -3798,8 +3897,13 @@ func GeneratePackage(tool string, pkg *schema.Package) (map[string][]byte, error
 			pkg.getImports(r, importsAndAliases)
 			importsAndAliases["github.com/pulumi/pulumi/sdk/v3/go/pulumi"] = ""
 
+			goImports := []string{"context", "reflect"}
+			if pkg.generics {
+				goImports = []string{"reflect"}
+			}
+
 			buffer := &bytes.Buffer{}
-			pkg.genHeader(buffer, []string{"context", "reflect"}, importsAndAliases)
+			pkg.genHeader(buffer, goImports, importsAndAliases)
 
 			if err := pkg.genResource(buffer, r, goPkgInfo.GenerateResourceContainerTypes); err != nil {
 				return nil, err
@@ -3833,7 +3937,7 @@ func GeneratePackage(tool string, pkg *schema.Package) (map[string][]byte, error
 			hasOutputs, imports := false, map[string]string{}
 			for _, e := range pkg.enums {
 				pkg.getImports(e, imports)
-				hasOutputs = hasOutputs || pkg.detailsForType(e).hasOutputs()
+				hasOutputs = hasOutputs || pkg.detailsForType(e).hasOutputs() && !pkg.generics
 			}
 			var goImports []string
 			if hasOutputs {
@@ -3850,7 +3954,9 @@ func GeneratePackage(tool string, pkg *schema.Package) (map[string][]byte, error
 				}
 				delete(knownTypes, e)
 			}
-			pkg.genEnumRegistrations(buffer)
+			if !pkg.generics {
+				pkg.genEnumRegistrations(buffer)
+			}
 			setFile(path.Join(mod, "pulumiEnums.go"), buffer.String())
 		}
 
@@ -3929,6 +4035,10 @@ func GeneratePackage(tool string, pkg *schema.Package) (map[string][]byte, error
 }
 
 func generateTypes(w io.Writer, pkg *pkgContext, types []*schema.ObjectType, knownTypes []schema.Type) error {
+	if pkg.generics {
+		return generateGenericTypes(w, pkg, types)
+	}
+
 	hasOutputs, importsAndAliases := false, map[string]string{}
 	for _, t := range types {
 		pkg.getImports(t, importsAndAliases)
@@ -3965,6 +4075,55 @@ func generateTypes(w io.Writer, pkg *pkgContext, types []*schema.ObjectType, kno
 	return nil
 }
 
+// generateGenericTypes generates the types for a generic SDK. Generic SDKs only contain the plain types: there are no
+// per-type input, output or collection types to generate or register.
+func generateGenericTypes(w io.Writer, pkg *pkgContext, types []*schema.ObjectType) error {
+	importsAndAliases := map[string]string{}
+	for _, t := range types {
+		pkg.getImports(t, importsAndAliases)
+		if plainTypesNeedPulumi(t.Properties) {
+			importsAndAliases["github.com/pulumi/pulumi/sdk/v3/go/pulumi"] = ""
+		}
+	}
+
+	pkg.genHeader(w, nil, importsAndAliases)
+
+	for _, t := range types {
+		if err := pkg.genType(w, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// plainTypesNeedPulumi returns true if the plain types generated for the given properties refer to the pulumi
+// package, either because they hold assets or archives or because their defaults are read from the environment.
+func plainTypesNeedPulumi(props []*schema.Property) bool {
+	var needsPulumi func(t schema.Type) bool
+	needsPulumi = func(t schema.Type) bool {
+		switch t := codegen.UnwrapType(t).(type) {
+		case *schema.ArrayType:
+			return needsPulumi(t.ElementType)
+		case *schema.MapType:
+			return needsPulumi(t.ElementType)
+		default:
+			return t == schema.AssetType || t == schema.ArchiveType
+		}
+	}
+
+	for _, p := range props {
+		if needsPulumi(p.Type) {
+			return true
+		}
+		if p.DefaultValue != nil && len(p.DefaultValue.Environment) != 0 {
+			if _, isArray := codegen.UnwrapType(p.Type).(*schema.ArrayType); isArray {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func allResourcesAreOverlays(resources []*schema.Resource) bool {
 	for _, r := range resources {
 		if !r.IsOverlay {
@@ -0,0 +1,204 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+)
+
+// GenerateMocks generates a Go package that implements pulumi.MockResourceMonitor for the resources and functions of
+// the given package. The returned files are relative to the root of the package's SDK.
+func GenerateMocks(tool string, pkg *schema.Package) (map[string][]byte, error) {
+	if err := pkg.ImportLanguages(map[string]schema.Language{"go": Importer}); err != nil {
+		return nil, err
+	}
+	root, err := packageRoot(pkg.Reference())
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	genMocks(&buffer, tool, pkg.Name, goMockMembers(codegen.MockResources(pkg)), goMockMembers(codegen.MockFunctions(pkg)))
+
+	source, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("invalid Go source code for mocks: %w", err)
+	}
+	return map[string][]byte{path.Join(root, "mocks", "mocks.go"): source}, nil
+}
+
+// goMockMembers renames any members whose hooks would collide with the methods of pulumi.MockResourceMonitor.
+func goMockMembers(members []codegen.MockMember) []codegen.MockMember {
+	for i, m := range members {
+		switch m.Name {
+		case "Call", "NewResource":
+			members[i].Name = m.Name + "Mock"
+		}
+	}
+	return members
+}
+
+func genMocks(w io.Writer, tool, pkgName string, resources, functions []codegen.MockMember) {
+	fmt.Fprintf(w, "// Code generated by %v DO NOT EDIT.\n", tool)
+	fmt.Fprintf(w, "// *** WARNING: Do not edit by hand unless you're certain you know what you are doing! ***\n\n")
+	fmt.Fprintf(w, "// Package mocks provides a pulumi.MockResourceMonitor for the "+
+		"resources and functions of the %s package.\n", pkgName)
+	fmt.Fprintf(w, "package mocks\n\n")
+	fmt.Fprintf(w, "import (\n")
+	fmt.Fprintf(w, "\t\"github.com/pulumi/pulumi/sdk/v3/go/common/resource\"\n")
+	fmt.Fprintf(w, "\t\"github.com/pulumi/pulumi/sdk/v3/go/pulumi\"\n")
+	fmt.Fprintf(w, ")\n\n")
+
+	fmt.Fprintf(w, "// Mocks implements pulumi.MockResourceMonitor for the "+
+		"resources and functions of the %s package.\n", pkgName)
+	fmt.Fprintf(w, "//\n")
+	fmt.Fprintf(w, "// Resources are given their inputs as outputs, along with "+
+		"default values for any required outputs that are\n")
+	fmt.Fprintf(w, "// not inputs. Functions are given default values for their "+
+		"required results. Each hook may be set to compute\n")
+	fmt.Fprintf(w, "// additional outputs, which take precedence over the defaults.\n")
+	fmt.Fprintf(w, "type Mocks struct {\n")
+	for _, m := range resources {
+		fmt.Fprintf(w, "\t// %s computes the outputs of %q resources.\n", m.Name, m.Token)
+		fmt.Fprintf(w, "\t%s func(args pulumi.MockResourceArgs) (resource.PropertyMap, error)\n", m.Name)
+	}
+	for _, m := range functions {
+		fmt.Fprintf(w, "\t// %s computes the result of the %q function.\n", m.Name, m.Token)
+		fmt.Fprintf(w, "\t%s func(args pulumi.MockCallArgs) (resource.PropertyMap, error)\n", m.Name)
+	}
+	fmt.Fprintf(w, "}\n\n")
+
+	fmt.Fprintf(w, "var _ pulumi.MockResourceMonitor = (*Mocks)(nil)\n\n")
+
+	for _, m := range resources {
+		fmt.Fprintf(w, "// Default%sOutputs returns the default outputs of a %q resource.\n", m.Name, m.Token)
+		fmt.Fprintf(w, "func Default%sOutputs() resource.PropertyMap {\n", m.Name)
+		fmt.Fprintf(w, "\treturn resource.NewPropertyMapFromMap(%s)\n", goMockLiteral(m.Defaults))
+		fmt.Fprintf(w, "}\n\n")
+	}
+	for _, m := range functions {
+		fmt.Fprintf(w, "// Default%sResult returns the default result of the %q function.\n", m.Name, m.Token)
+		fmt.Fprintf(w, "func Default%sResult() resource.PropertyMap {\n", m.Name)
+		fmt.Fprintf(w, "\treturn resource.NewPropertyMapFromMap(%s)\n", goMockLiteral(m.Defaults))
+		fmt.Fprintf(w, "}\n\n")
+	}
+
+	fmt.Fprintf(w, "// NewResource implements pulumi.MockResourceMonitor.\n")
+	fmt.Fprintf(w, "func (m *Mocks) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {\n")
+	fmt.Fprintf(w, "\toutputs := resource.PropertyMap{}\n")
+	fmt.Fprintf(w, "\tvar hook func(args pulumi.MockResourceArgs) (resource.PropertyMap, error)\n")
+	if len(resources) > 0 {
+		fmt.Fprintf(w, "\tswitch args.TypeToken {\n")
+		for _, m := range resources {
+			fmt.Fprintf(w, "\tcase %q:\n", m.Token)
+			fmt.Fprintf(w, "\t\toutputs, hook = Default%sOutputs(), m.%s\n", m.Name, m.Name)
+		}
+		fmt.Fprintf(w, "\t}\n")
+	}
+	fmt.Fprintf(w, "\toutputs = mergeOutputs(outputs, args.Inputs)\n")
+	fmt.Fprintf(w, "\tif hook != nil {\n")
+	fmt.Fprintf(w, "\t\thooked, err := hook(args)\n")
+	fmt.Fprintf(w, "\t\tif err != nil {\n")
+	fmt.Fprintf(w, "\t\t\treturn \"\", nil, err\n")
+	fmt.Fprintf(w, "\t\t}\n")
+	fmt.Fprintf(w, "\t\toutputs = mergeOutputs(outputs, hooked)\n")
+	fmt.Fprintf(w, "\t}\n\n")
+	fmt.Fprintf(w, "\tid := args.ID\n")
+	fmt.Fprintf(w, "\tif id == \"\" {\n")
+	fmt.Fprintf(w, "\t\tid = args.Name + \"_id\"\n")
+	fmt.Fprintf(w, "\t}\n")
+	fmt.Fprintf(w, "\treturn id, outputs, nil\n")
+	fmt.Fprintf(w, "}\n\n")
+
+	fmt.Fprintf(w, "// Call implements pulumi.MockResourceMonitor.\n")
+	fmt.Fprintf(w, "func (m *Mocks) Call(args pulumi.MockCallArgs) (resource.PropertyMap, error) {\n")
+	fmt.Fprintf(w, "\tresult := resource.PropertyMap{}\n")
+	fmt.Fprintf(w, "\tvar hook func(args pulumi.MockCallArgs) (resource.PropertyMap, error)\n")
+	if len(functions) > 0 {
+		fmt.Fprintf(w, "\tswitch args.Token {\n")
+		for _, m := range functions {
+			fmt.Fprintf(w, "\tcase %q:\n", m.Token)
+			fmt.Fprintf(w, "\t\tresult, hook = Default%sResult(), m.%s\n", m.Name, m.Name)
+		}
+		fmt.Fprintf(w, "\t}\n")
+	}
+	fmt.Fprintf(w, "\tif hook != nil {\n")
+	fmt.Fprintf(w, "\t\thooked, err := hook(args)\n")
+	fmt.Fprintf(w, "\t\tif err != nil {\n")
+	fmt.Fprintf(w, "\t\t\treturn nil, err\n")
+	fmt.Fprintf(w, "\t\t}\n")
+	fmt.Fprintf(w, "\t\tresult = mergeOutputs(result, hooked)\n")
+	fmt.Fprintf(w, "\t}\n")
+	fmt.Fprintf(w, "\treturn result, nil\n")
+	fmt.Fprintf(w, "}\n\n")
+
+	fmt.Fprintf(w, "// mergeOutputs returns a copy of base updated with the values in overrides.\n")
+	fmt.Fprintf(w, "func mergeOutputs(base, overrides resource.PropertyMap) resource.PropertyMap {\n")
+	fmt.Fprintf(w, "\tresult := base.Copy()\n")
+	fmt.Fprintf(w, "\tfor k, v := range overrides {\n")
+	fmt.Fprintf(w, "\t\tresult[k] = v\n")
+	fmt.Fprintf(w, "\t}\n")
+	fmt.Fprintf(w, "\treturn result\n")
+	fmt.Fprintf(w, "}\n")
+}
+
+// goMockLiteral renders a value produced by codegen.MockOutputs as a Go literal.
+func goMockLiteral(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "nil"
+	case bool:
+		return strconv.FormatBool(v)
+	case string:
+		return strconv.Quote(v)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case []interface{}:
+		elements := make([]string, len(v))
+		for i, e := range v {
+			elements[i] = goMockLiteral(e)
+		}
+		return fmt.Sprintf("[]interface{}{%s}", strings.Join(elements, ", "))
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		var b strings.Builder
+		b.WriteString("map[string]interface{}{")
+		for _, k := range keys {
+			fmt.Fprintf(&b, "\n%q: %s,", k, goMockLiteral(v[k]))
+		}
+		if len(keys) > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("}")
+		return b.String()
+	default:
+		return fmt.Sprintf("%#v", v)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/testing/test"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/executable"
+)
+
+var mocksSpec = schema.PackageSpec{
+	Name:    "mocked",
+	Version: "0.0.1",
+	Language: map[string]schema.RawMessage{
+		"go": schema.RawMessage(`{"importBasePath": "mocked/mocked"}`),
+	},
+	Resources: map[string]schema.ResourceSpec{
+		"mocked:storage:Bucket": {
+			ObjectTypeSpec: schema.ObjectTypeSpec{
+				Properties: map[string]schema.PropertySpec{
+					"name":    {TypeSpec: schema.TypeSpec{Type: "string"}},
+					"arn":     {TypeSpec: schema.TypeSpec{Type: "string"}},
+					"size":    {TypeSpec: schema.TypeSpec{Type: "integer"}},
+					"website": {TypeSpec: schema.TypeSpec{Type: "string"}},
+				},
+				Required: []string{"name", "arn", "size"},
+			},
+			InputProperties: map[string]schema.PropertySpec{
+				"name": {TypeSpec: schema.TypeSpec{Type: "string"}},
+			},
+			RequiredInputs: []string{"name"},
+		},
+	},
+	Functions: map[string]schema.FunctionSpec{
+		"mocked:storage:getBucket": {
+			Inputs: &schema.ObjectTypeSpec{
+				Properties: map[string]schema.PropertySpec{
+					"name": {TypeSpec: schema.TypeSpec{Type: "string"}},
+				},
+				Required: []string{"name"},
+			},
+			Outputs: &schema.ObjectTypeSpec{
+				Properties: map[string]schema.PropertySpec{
+					"arn":  {TypeSpec: schema.TypeSpec{Type: "string"}},
+					"tags": {TypeSpec: schema.TypeSpec{Type: "array", Items: &schema.TypeSpec{Type: "string"}}},
+				},
+				Required: []string{"arn", "tags"},
+			},
+		},
+	},
+}
+
+const mocksProgramTest = `package mocks_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"mocked/mocked/mocks"
+	"mocked/mocked/storage"
+)
+
+func TestMocks(t *testing.T) {
+	m := &mocks.Mocks{
+		StorageBucket: func(args pulumi.MockResourceArgs) (resource.PropertyMap, error) {
+			return resource.PropertyMap{"arn": resource.NewStringProperty("arn:" + args.Name)}, nil
+		},
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		bucket, err := storage.NewBucket(ctx, "bucket", &storage.BucketArgs{Name: pulumi.String("my-bucket")})
+		if err != nil {
+			return err
+		}
+		pulumi.All(bucket.Name, bucket.Arn, bucket.Size, bucket.Website).ApplyT(func(all []interface{}) error {
+			defer wg.Done()
+			if all[0] != "my-bucket" || all[1] != "arn:bucket" || all[2] != 0 || all[3] != (*string)(nil) {
+				t.Errorf("unexpected bucket outputs: %v", all)
+			}
+			return nil
+		})
+
+		result, err := storage.LookupBucket(ctx, &storage.LookupBucketArgs{Name: "my-bucket"})
+		if err != nil {
+			return err
+		}
+		if result.Arn != "" || len(result.Tags) != 0 {
+			t.Errorf("unexpected function result: %v", result)
+		}
+		wg.Done()
+		return nil
+	}, pulumi.WithMocks("project", "stack", m))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wg.Wait()
+}
+`
+
+func TestGenerateMocks(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := schema.ImportSpec(mocksSpec, map[string]schema.Language{"go": Importer})
+	require.NoError(t, err)
+
+	files, err := GenerateMocks("test", pkg)
+	require.NoError(t, err)
+	require.Contains(t, files, "mocked/mocks/mocks.go")
+
+	mocks := string(files["mocked/mocks/mocks.go"])
+	assert.Contains(t, mocks, "StorageBucket func(args pulumi.MockResourceArgs) (resource.PropertyMap, error)")
+	assert.Contains(t, mocks, "StorageGetBucket func(args pulumi.MockCallArgs) (resource.PropertyMap, error)")
+	assert.Contains(t, mocks, "func DefaultStorageBucketOutputs() resource.PropertyMap {")
+	assert.Contains(t, mocks, "func DefaultStorageGetBucketResult() resource.PropertyMap {")
+
+	if testing.Short() {
+		t.Skip("skipping compilation of generated mocks in short mode")
+	}
+
+	sdk, err := GeneratePackage("test", pkg)
+	require.NoError(t, err)
+
+	dir := filepath.Join(t.TempDir(), "mocked", "go")
+	for _, fs := range []map[string][]byte{sdk, files} {
+		for name, contents := range fs {
+			path := filepath.Join(dir, name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+			require.NoError(t, os.WriteFile(path, contents, 0o600))
+		}
+	}
+	err = os.WriteFile(filepath.Join(dir, "mocked", "mocks", "mocks_test.go"), []byte(mocksProgramTest), 0o600)
+	require.NoError(t, err)
+
+	typeCheckGeneratedPackage(t, dir)
+
+	goExe, err := executable.FindExecutable("go")
+	require.NoError(t, err)
+	test.RunCommand(t, "go-test", dir, goExe, "test", "./...")
+}
@@ -4,16 +4,19 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/pulumi/pulumi/pkg/v3/codegen"
 	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
 	"github.com/pulumi/pulumi/pkg/v3/codegen/testing/test"
 	"github.com/pulumi/pulumi/pkg/v3/codegen/testing/utils"
@@ -524,3 +527,137 @@ func TestRegressTypeDuplicatesInChunking(t *testing.T) {
 		assert.NotContains(t, typedefs1, typ)
 	}
 }
+
+// TestGenerateGenericPackage generates SDKs with and without generics enabled, and compares the size of the generated
+// source, the size of a binary that links each SDK, and the time taken to rebuild that binary after the SDK changes.
+func TestGenerateGenericPackage(t *testing.T) {
+	t.Parallel()
+
+	schemas := []string{
+		"plain-and-default",
+		"output-funcs",
+		"plain-object-defaults",
+		"secrets",
+		"simple-enum-schema",
+	}
+
+	generate := func(t *testing.T, file string, generics bool) (string, int) {
+		pkg := readSchemaFile(filepath.Join(file, "schema.json"))
+		goInfo, _ := pkg.Language["go"].(GoPackageInfo)
+		goInfo.Generics = generics
+		pkg.Language["go"] = goInfo
+
+		files, err := GeneratePackage("test", pkg)
+		require.NoError(t, err)
+
+		dir := filepath.Join(t.TempDir(), file, "go")
+		size := 0
+		for name, contents := range files {
+			size += len(contents)
+			path := filepath.Join(dir, name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+			require.NoError(t, os.WriteFile(path, contents, 0o600))
+		}
+		return dir, size
+	}
+
+	for _, file := range schemas {
+		file := file
+		t.Run(file, func(t *testing.T) {
+			t.Parallel()
+
+			dir, size := generate(t, file, false)
+			genericDir, genericSize := generate(t, file, true)
+			t.Logf("generated source: %d bytes, generic generated source: %d bytes", size, genericSize)
+			assert.Less(t, genericSize, size)
+
+			if testing.Short() {
+				t.Skip("skipping compilation of generated SDKs in short mode")
+			}
+			binarySize, elapsed := buildGeneratedPackageBinary(t, dir)
+			genericBinarySize, genericElapsed := buildGeneratedPackageBinary(t, genericDir)
+			t.Logf("binary: %d bytes, rebuilt in %v; generic binary: %d bytes, rebuilt in %v",
+				binarySize, elapsed, genericBinarySize, genericElapsed)
+			assert.Less(t, genericBinarySize, binarySize)
+			// Build times are noisy, so only fail if the generic SDK is much slower to build.
+			assert.LessOrEqual(t, genericElapsed, 2*elapsed+time.Second,
+				"rebuilding the generic SDK took %v, rebuilding the SDK took %v", genericElapsed, elapsed)
+		})
+	}
+}
+
+// buildGeneratedPackageBinary builds a program that links every package of the SDK generated in codeDir. It returns
+// the size of the program, and the time taken to rebuild it after every file of the SDK changes, which excludes the
+// time taken to build the SDK's dependencies.
+func buildGeneratedPackageBinary(t *testing.T, codeDir string) (int64, time.Duration) {
+	typeCheckGeneratedPackage(t, codeDir)
+
+	var sources []string
+	packages := map[string]bool{}
+	err := filepath.WalkDir(codeDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".go" {
+			return err
+		}
+		sources = append(sources, path)
+		rel, err := filepath.Rel(codeDir, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		// Internal packages are linked through the packages that import them.
+		if rel != "." && !strings.Contains(filepath.ToSlash(rel), "internal") {
+			packages[inferModuleName(codeDir)+"/"+filepath.ToSlash(rel)] = true
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, packages)
+
+	var main strings.Builder
+	main.WriteString("package main\n\nimport (\n")
+	for _, pkg := range codegen.SortedKeys(packages) {
+		fmt.Fprintf(&main, "\t_ %q\n", pkg)
+	}
+	main.WriteString(")\n\nfunc main() {}\n")
+	mainDir := filepath.Join(codeDir, "linkcheck")
+	require.NoError(t, os.MkdirAll(mainDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(mainDir, "main.go"), []byte(main.String()), 0o600))
+
+	goExe, err := executable.FindExecutable("go")
+	require.NoError(t, err)
+	binary := filepath.Join(codeDir, "linkcheck.bin")
+	test.RunCommand(t, "go_build_binary", codeDir, goExe, "build", "-o", binary, "./linkcheck")
+
+	for _, path := range sources {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+		require.NoError(t, err)
+		_, err = f.WriteString("\n")
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+	}
+	start := time.Now()
+	test.RunCommand(t, "go_rebuild_binary", codeDir, goExe, "build", "-o", binary, "./linkcheck")
+	elapsed := time.Since(start)
+
+	info, err := os.Stat(binary)
+	require.NoError(t, err)
+	return info.Size(), elapsed
+}
+
+func TestGenerateGenericResource(t *testing.T) {
+	t.Parallel()
+
+	pkg := readSchemaFile(filepath.Join("secrets", "schema.json"))
+	goInfo, _ := pkg.Language["go"].(GoPackageInfo)
+	goInfo.Generics = true
+	pkg.Language["go"] = goInfo
+
+	files, err := GeneratePackage("test", pkg)
+	require.NoError(t, err)
+
+	resource := string(files["mypkg/resource.go"])
+	assert.Regexp(t, "Config +pulumi.OutputOf\\[Config\\] +`pulumi:\"config\"`", resource)
+	assert.Regexp(t, "Config +pulumi.InputOf\\[Config\\]\n", resource)
+	assert.Contains(t, resource, "args.Config = pulumi.SecretOf(args.Config)")
+	assert.NotContains(t, resource, "ResourceOutput")
+	assert.NotContains(t, resource, "RegisterOutputType")
+}
@@ -87,6 +87,11 @@ type GoPackageInfo struct {
 	// InternalDependencies are blank imports that are emitted in the SDK so that `go mod tidy` does not remove the
 	// associated module dependencies from the SDK's go.mod.
 	InternalDependencies []string `json:"internalDependencies,omitempty"`
+
+	// Generics determines whether to generate an SDK that uses the generic pulumi.OutputOf[T] and pulumi.InputOf[T]
+	// types in place of per-type input and output types. This is a space saving measure: generated packages no
+	// longer contain Output, ArrayOutput, MapOutput and PtrOutput types for every schema type.
+	Generics bool `json:"generics,omitempty"`
 }
 
 // Importer implements schema.Language for Go.
@@ -0,0 +1,185 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/cgstrings"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+)
+
+// MockMember describes a resource or function that generated mocks provide a hook and default outputs for.
+type MockMember struct {
+	// Token is the member's type token.
+	Token string
+	// Name is the PascalCase name of the member's hook. The module is included in the name so that members with the
+	// same name in different modules do not collide.
+	Name string
+	// Defaults are the default outputs of a resource or the default result of a function.
+	Defaults map[string]interface{}
+}
+
+// MockResources returns the resources of the given package that generated mocks provide hooks for.
+func MockResources(pkg *schema.Package) []MockMember {
+	var members []MockMember
+	for _, r := range pkg.Resources {
+		if r.IsOverlay {
+			continue
+		}
+		members = append(members, MockMember{
+			Token:    r.Token,
+			Name:     mockMemberName(r.Token),
+			Defaults: MockOutputs(r.Properties),
+		})
+	}
+	return members
+}
+
+// MockFunctions returns the functions of the given package that generated mocks provide hooks for. Resource methods
+// are not included.
+func MockFunctions(pkg *schema.Package) []MockMember {
+	var members []MockMember
+	for _, f := range pkg.Functions {
+		if f.IsOverlay || f.IsMethod {
+			continue
+		}
+		defaults := map[string]interface{}{}
+		if obj, ok := f.ReturnType.(*schema.ObjectType); ok {
+			defaults = MockOutputs(obj.Properties)
+		}
+		members = append(members, MockMember{
+			Token:    f.Token,
+			Name:     mockMemberName(f.Token),
+			Defaults: defaults,
+		})
+	}
+	return members
+}
+
+func mockMemberName(tok string) string {
+	components := strings.Split(tok, ":")
+	if len(components) != 3 {
+		return cgstrings.UppercaseFirst(cgstrings.Camel(tok))
+	}
+
+	var name strings.Builder
+	if mod := components[1]; mod != "index" {
+		for _, part := range strings.FieldsFunc(mod, func(r rune) bool { return r == '/' || r == '.' || r == '-' }) {
+			name.WriteString(cgstrings.UppercaseFirst(part))
+		}
+	}
+	name.WriteString(cgstrings.UppercaseFirst(cgstrings.Unhyphenate(components[2])))
+	return name.String()
+}
+
+// MockOutputs returns the default values that generated mocks use for the given properties. Only required properties
+// are given a value: optional properties are omitted so that programs under test observe them as unset.
+//
+// The result only contains values of type bool, float64, string, []interface{} and map[string]interface{}, so it can
+// be rendered as a literal in any language.
+func MockOutputs(props []*schema.Property) map[string]interface{} {
+	return mockObject(props, map[string]bool{})
+}
+
+// MockValue returns the default value that generated mocks use for a value of the given type, or nil if the type has
+// no sensible default (e.g. assets, archives, resource references and optional values).
+func MockValue(t schema.Type) interface{} {
+	return mockValue(t, map[string]bool{})
+}
+
+func mockObject(props []*schema.Property, seen map[string]bool) map[string]interface{} {
+	outputs := map[string]interface{}{}
+	for _, p := range props {
+		if !p.IsRequired() {
+			continue
+		}
+		if p.ConstValue != nil {
+			outputs[p.Name] = mockPrimitive(p.ConstValue)
+			continue
+		}
+		if p.DefaultValue != nil && p.DefaultValue.Value != nil {
+			outputs[p.Name] = mockPrimitive(p.DefaultValue.Value)
+			continue
+		}
+		if v := mockValue(p.Type, seen); v != nil {
+			outputs[p.Name] = v
+		}
+	}
+	return outputs
+}
+
+func mockValue(t schema.Type, seen map[string]bool) interface{} {
+	switch t := t.(type) {
+	case *schema.InputType:
+		return mockValue(t.ElementType, seen)
+	case *schema.OptionalType:
+		return nil
+	case *schema.ArrayType:
+		return []interface{}{}
+	case *schema.MapType:
+		return map[string]interface{}{}
+	case *schema.EnumType:
+		if len(t.Elements) == 0 {
+			return nil
+		}
+		return mockPrimitive(t.Elements[0].Value)
+	case *schema.ObjectType:
+		// Guard against cyclic types by only producing one level of each object type.
+		if seen[t.Token] {
+			return nil
+		}
+		seen[t.Token] = true
+		defer delete(seen, t.Token)
+		return mockObject(t.Properties, seen)
+	case *schema.TokenType:
+		if t.UnderlyingType != nil {
+			return mockValue(t.UnderlyingType, seen)
+		}
+		return nil
+	case *schema.UnionType:
+		if len(t.ElementTypes) == 0 {
+			return nil
+		}
+		return mockValue(t.ElementTypes[0], seen)
+	default:
+		switch t {
+		case schema.BoolType:
+			return false
+		case schema.IntType, schema.NumberType:
+			return float64(0)
+		case schema.StringType:
+			return ""
+		default:
+			return nil
+		}
+	}
+}
+
+// mockPrimitive normalizes a constant, default or enum value from the schema so that all numbers are float64s.
+func mockPrimitive(v interface{}) interface{} {
+	switch v := v.(type) {
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	default:
+		return v
+	}
+}
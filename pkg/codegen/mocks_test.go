@@ -0,0 +1,135 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+)
+
+func TestMockOutputs(t *testing.T) {
+	t.Parallel()
+
+	spec := schema.PackageSpec{
+		Name:    "test",
+		Version: "0.0.1",
+		Types: map[string]schema.ComplexTypeSpec{
+			"test:index:Node": {
+				ObjectTypeSpec: schema.ObjectTypeSpec{
+					Type: "object",
+					Properties: map[string]schema.PropertySpec{
+						"name": {TypeSpec: schema.TypeSpec{Type: "string"}},
+						"next": {TypeSpec: schema.TypeSpec{Ref: "#/types/test:index:Node"}},
+					},
+					Required: []string{"name", "next"},
+				},
+			},
+			"test:index:Color": {
+				ObjectTypeSpec: schema.ObjectTypeSpec{Type: "string"},
+				Enum: []schema.EnumValueSpec{
+					{Value: "red"},
+					{Value: "blue"},
+				},
+			},
+		},
+		Resources: map[string]schema.ResourceSpec{
+			"test:index:Res": {
+				ObjectTypeSpec: schema.ObjectTypeSpec{
+					Properties: map[string]schema.PropertySpec{
+						"count":    {TypeSpec: schema.TypeSpec{Type: "integer"}},
+						"enabled":  {TypeSpec: schema.TypeSpec{Type: "boolean"}},
+						"tags":     {TypeSpec: schema.TypeSpec{Type: "array", Items: &schema.TypeSpec{Type: "string"}}},
+						"color":    {TypeSpec: schema.TypeSpec{Ref: "#/types/test:index:Color"}},
+						"node":     {TypeSpec: schema.TypeSpec{Ref: "#/types/test:index:Node"}},
+						"optional": {TypeSpec: schema.TypeSpec{Type: "string"}},
+						"region": {
+							TypeSpec: schema.TypeSpec{Type: "string"},
+							Default:  "us-west-2",
+						},
+					},
+					Required: []string{"count", "enabled", "tags", "color", "node", "region"},
+				},
+			},
+		},
+	}
+	pkg, err := schema.ImportSpec(spec, nil)
+	require.NoError(t, err)
+
+	res, ok := pkg.GetResource("test:index:Res")
+	require.True(t, ok)
+
+	assert.Equal(t, map[string]interface{}{
+		"count":   float64(0),
+		"enabled": false,
+		"tags":    []interface{}{},
+		"color":   "red",
+		"node": map[string]interface{}{
+			"name": "",
+		},
+		"region": "us-west-2",
+	}, MockOutputs(res.Properties))
+
+	assert.Nil(t, MockValue(schema.AssetType))
+	assert.Equal(t, "", MockValue(&schema.InputType{ElementType: schema.StringType}))
+	assert.Nil(t, MockValue(&schema.OptionalType{ElementType: schema.StringType}))
+}
+
+func TestMockMembers(t *testing.T) {
+	t.Parallel()
+
+	spec := schema.PackageSpec{
+		Name:    "test",
+		Version: "0.0.1",
+		Resources: map[string]schema.ResourceSpec{
+			"test:index:Res":             {},
+			"test:storage/v1:Bucket":     {},
+			"test:networking:my-network": {},
+		},
+		Functions: map[string]schema.FunctionSpec{
+			"test:index:getThing": {
+				Outputs: &schema.ObjectTypeSpec{
+					Properties: map[string]schema.PropertySpec{
+						"id": {TypeSpec: schema.TypeSpec{Type: "string"}},
+					},
+					Required: []string{"id"},
+				},
+			},
+		},
+	}
+	pkg, err := schema.ImportSpec(spec, nil)
+	require.NoError(t, err)
+
+	names := map[string]string{}
+	for _, m := range MockResources(pkg) {
+		names[m.Token] = m.Name
+	}
+	assert.Equal(t, map[string]string{
+		"test:index:Res":             "Res",
+		"test:storage/v1:Bucket":     "StorageV1Bucket",
+		"test:networking:my-network": "NetworkingMyNetwork",
+	}, names)
+
+	functions := MockFunctions(pkg)
+	require.Len(t, functions, 1)
+	assert.Equal(t, MockMember{
+		Token:    "test:index:getThing",
+		Name:     "GetThing",
+		Defaults: map[string]interface{}{"id": ""},
+	}, functions[0])
+}
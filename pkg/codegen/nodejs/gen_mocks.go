@@ -0,0 +1,157 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodejs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+)
+
+// GenerateMocks generates a TypeScript module that implements pulumi.runtime.Mocks for the resources and functions of
+// the given package. The returned files are relative to the root of the package's SDK, and are suitable for passing to
+// GeneratePackage as extra files so that they are compiled along with the rest of the SDK.
+func GenerateMocks(tool string, pkg *schema.Package) (map[string][]byte, error) {
+	var buffer bytes.Buffer
+	genMocks(&buffer, tool, pkg.Name, codegen.MockResources(pkg), codegen.MockFunctions(pkg))
+	return map[string][]byte{"mocks.ts": buffer.Bytes()}, nil
+}
+
+func genMocks(w io.Writer, tool, pkgName string, resources, functions []codegen.MockMember) {
+	fmt.Fprintf(w, "// *** WARNING: this file was generated by %v. ***\n", tool)
+	fmt.Fprintf(w, "// *** Do not edit by hand unless you're certain you know what you are doing! ***\n\n")
+	fmt.Fprintf(w, "import * as pulumi from \"@pulumi/pulumi\";\n\n")
+
+	fmt.Fprintf(w, "type ResourceHook = (args: pulumi.runtime.MockResourceArgs) => Record<string, any>;\n")
+	fmt.Fprintf(w, "type CallHook = (args: pulumi.runtime.MockCallArgs) => Record<string, any>;\n\n")
+
+	for _, m := range resources {
+		fmt.Fprintf(w, "/**\n * Returns the default outputs of a \"%s\" resource.\n */\n", m.Token)
+		fmt.Fprintf(w, "export function default%sOutputs(): Record<string, any> {\n", m.Name)
+		fmt.Fprintf(w, "    return %s;\n", tsMockLiteral(m.Defaults))
+		fmt.Fprintf(w, "}\n\n")
+	}
+	for _, m := range functions {
+		fmt.Fprintf(w, "/**\n * Returns the default result of the \"%s\" function.\n */\n", m.Token)
+		fmt.Fprintf(w, "export function default%sResult(): Record<string, any> {\n", m.Name)
+		fmt.Fprintf(w, "    return %s;\n", tsMockLiteral(m.Defaults))
+		fmt.Fprintf(w, "}\n\n")
+	}
+
+	fmt.Fprintf(w, "/**\n * The hooks that compute additional outputs for the "+
+		"resources and functions of the %s package.\n */\n", pkgName)
+	fmt.Fprintf(w, "export interface MocksArgs {\n")
+	for _, m := range resources {
+		fmt.Fprintf(w, "    /**\n     * Computes the outputs of \"%s\" resources.\n     */\n", m.Token)
+		fmt.Fprintf(w, "    %s?: ResourceHook;\n", camel(m.Name))
+	}
+	for _, m := range functions {
+		fmt.Fprintf(w, "    /**\n     * Computes the result of the \"%s\" function.\n     */\n", m.Token)
+		fmt.Fprintf(w, "    %s?: CallHook;\n", camel(m.Name))
+	}
+	fmt.Fprintf(w, "}\n\n")
+
+	fmt.Fprintf(w, "/**\n")
+	fmt.Fprintf(w, " * Mocks implements pulumi.runtime.Mocks for the "+
+		"resources and functions of the %s package.\n", pkgName)
+	fmt.Fprintf(w, " *\n")
+	fmt.Fprintf(w, " * Resources are given their inputs as outputs, along with "+
+		"default values for any required outputs that are\n")
+	fmt.Fprintf(w, " * not inputs. Functions are given default values for their "+
+		"required results. Each hook may be set to compute\n")
+	fmt.Fprintf(w, " * additional outputs, which take precedence over the defaults.\n")
+	fmt.Fprintf(w, " */\n")
+	fmt.Fprintf(w, "export class Mocks implements pulumi.runtime.Mocks {\n")
+	fmt.Fprintf(w, "    constructor(private readonly hooks: MocksArgs = {}) {}\n\n")
+
+	fmt.Fprintf(w, "    newResource(args: pulumi.runtime.MockResourceArgs): pulumi.runtime.MockResourceResult {\n")
+	fmt.Fprintf(w, "        let defaults: Record<string, any> = {};\n")
+	fmt.Fprintf(w, "        let hook: ResourceHook | undefined;\n")
+	if len(resources) > 0 {
+		fmt.Fprintf(w, "        switch (args.type) {\n")
+		for _, m := range resources {
+			fmt.Fprintf(w, "            case \"%s\":\n", m.Token)
+			fmt.Fprintf(w, "                defaults = default%sOutputs();\n", m.Name)
+			fmt.Fprintf(w, "                hook = this.hooks.%s;\n", camel(m.Name))
+			fmt.Fprintf(w, "                break;\n")
+		}
+		fmt.Fprintf(w, "        }\n")
+	}
+	fmt.Fprintf(w, "        const state = { ...defaults, ...args.inputs, ...(hook ? hook(args) : {}) };\n")
+	fmt.Fprintf(w, "        return { id: args.id || `${args.name}_id`, state };\n")
+	fmt.Fprintf(w, "    }\n\n")
+
+	fmt.Fprintf(w, "    call(args: pulumi.runtime.MockCallArgs): pulumi.runtime.MockCallResult {\n")
+	fmt.Fprintf(w, "        let defaults: Record<string, any> = {};\n")
+	fmt.Fprintf(w, "        let hook: CallHook | undefined;\n")
+	if len(functions) > 0 {
+		fmt.Fprintf(w, "        switch (args.token) {\n")
+		for _, m := range functions {
+			fmt.Fprintf(w, "            case \"%s\":\n", m.Token)
+			fmt.Fprintf(w, "                defaults = default%sResult();\n", m.Name)
+			fmt.Fprintf(w, "                hook = this.hooks.%s;\n", camel(m.Name))
+			fmt.Fprintf(w, "                break;\n")
+		}
+		fmt.Fprintf(w, "        }\n")
+	}
+	fmt.Fprintf(w, "        return { ...defaults, ...(hook ? hook(args) : {}) };\n")
+	fmt.Fprintf(w, "    }\n")
+	fmt.Fprintf(w, "}\n")
+}
+
+// tsMockLiteral renders a value produced by codegen.MockOutputs as a TypeScript literal.
+func tsMockLiteral(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "undefined"
+	case bool:
+		return strconv.FormatBool(v)
+	case string:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return `""`
+		}
+		return string(b)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case []interface{}:
+		elements := make([]string, len(v))
+		for i, e := range v {
+			elements[i] = tsMockLiteral(e)
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		entries := make([]string, len(keys))
+		for i, k := range keys {
+			entries[i] = tsMockLiteral(k) + ": " + tsMockLiteral(v[k])
+		}
+		return "{" + strings.Join(entries, ", ") + "}"
+	default:
+		return "undefined"
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodejs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+)
+
+func TestGenerateMocks(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := schema.ImportSpec(schema.PackageSpec{
+		Name:    "mocked",
+		Version: "0.0.1",
+		Resources: map[string]schema.ResourceSpec{
+			"mocked:storage:Bucket": {
+				ObjectTypeSpec: schema.ObjectTypeSpec{
+					Properties: map[string]schema.PropertySpec{
+						"name":    {TypeSpec: schema.TypeSpec{Type: "string"}},
+						"size":    {TypeSpec: schema.TypeSpec{Type: "integer"}},
+						"website": {TypeSpec: schema.TypeSpec{Type: "string"}},
+					},
+					Required: []string{"name", "size"},
+				},
+			},
+		},
+		Functions: map[string]schema.FunctionSpec{
+			"mocked:index:getRegion": {
+				Outputs: &schema.ObjectTypeSpec{
+					Properties: map[string]schema.PropertySpec{
+						"region": {TypeSpec: schema.TypeSpec{Type: "string"}},
+					},
+					Required: []string{"region"},
+				},
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	files, err := GenerateMocks("test", pkg)
+	require.NoError(t, err)
+	require.Contains(t, files, "mocks.ts")
+
+	mocks := string(files["mocks.ts"])
+	assert.Contains(t, mocks, "export class Mocks implements pulumi.runtime.Mocks {")
+	assert.Contains(t, mocks, "storageBucket?: ResourceHook;")
+	assert.Contains(t, mocks, "getRegion?: CallHook;")
+	assert.Contains(t, mocks, "export function defaultStorageBucketOutputs(): Record<string, any> {\n"+
+		`    return {"name": "", "size": 0};`)
+	assert.Contains(t, mocks, "export function defaultGetRegionResult(): Record<string, any> {\n"+
+		`    return {"region": ""};`)
+}
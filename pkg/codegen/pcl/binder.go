@@ -51,6 +51,7 @@ type bindOptions struct {
 	allowMissingVariables  bool
 	allowMissingProperties bool
 	skipResourceTypecheck  bool
+	preserveOnError        bool
 	loader                 schema.Loader
 	packageCache           *PackageCache
 	// the directory path of the PCL program being bound
@@ -92,6 +93,12 @@ func SkipResourceTypechecking(options *bindOptions) {
 	options.skipResourceTypecheck = true
 }
 
+// PreserveProgramOnError returns the partially bound program even if binding produced errors. This is useful for
+// tools that want to inspect as much of a program as possible, such as conversion reports.
+func PreserveProgramOnError(options *bindOptions) {
+	options.preserveOnError = true
+}
+
 func PluginHost(host plugin.Host) BindOption {
 	return Loader(schema.NewPluginLoader(host))
 }
@@ -185,19 +192,24 @@ func BindProgram(files []*syntax.File, opts ...BindOption) (*Program, hcl.Diagno
 		diagnostics = append(diagnostics, b.bindNode(n)...)
 	}
 
-	if diagnostics.HasErrors() {
-		return nil, diagnostics, diagnostics
-	}
-
-	return &Program{
+	program := &Program{
 		Nodes:  b.nodes,
 		files:  files,
 		binder: b,
-	}, diagnostics, nil
+	}
+	if diagnostics.HasErrors() {
+		if options.preserveOnError {
+			return program, diagnostics, diagnostics
+		}
+		return nil, diagnostics, diagnostics
+	}
+	return program, diagnostics, nil
 }
 
 // Used by language plugins to bind a PCL program in the given directory.
-func BindDirectory(directory string, loader schema.ReferenceLoader, strict bool) (*Program, hcl.Diagnostics, error) {
+func BindDirectory(
+	directory string, loader schema.ReferenceLoader, strict bool, extraOptions ...BindOption,
+) (*Program, hcl.Diagnostics, error) {
 	parser := syntax.NewParser()
 	// Load all .pp files in the directory
 	files, err := os.ReadDir(directory)
@@ -244,6 +256,7 @@ func BindDirectory(directory string, loader schema.ReferenceLoader, strict bool)
 			AllowMissingProperties,
 		}...)
 	}
+	opts = append(opts, extraOptions...)
 
 	program, bindDiagnostics, err := BindProgram(parser.Files, opts...)
 
@@ -0,0 +1,187 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+)
+
+// GenerateMocks generates a Python module that implements pulumi.runtime.Mocks for the resources and functions of the
+// given package. The returned files are relative to the root of the package's SDK.
+func GenerateMocks(tool string, pkg *schema.Package) (map[string][]byte, error) {
+	if err := pkg.ImportLanguages(map[string]schema.Language{"python": Importer}); err != nil {
+		return nil, err
+	}
+	info, _ := pkg.Language["python"].(PackageInfo)
+
+	pkgName := info.PackageName
+	if pkgName == "" {
+		pkgName = pyPack(pkg.Name)
+	}
+
+	var buffer bytes.Buffer
+	genMocks(&buffer, tool, pkg.Name, codegen.MockResources(pkg), codegen.MockFunctions(pkg))
+	return map[string][]byte{path.Join(pkgName, "mocks.py"): buffer.Bytes()}, nil
+}
+
+func genMocks(w io.Writer, tool, pkgName string, resources, functions []codegen.MockMember) {
+	genStandardHeader(w, tool)
+
+	fmt.Fprintf(w, "import copy\n")
+	fmt.Fprintf(w, "from typing import Any, Callable, Dict, List, Mapping, Optional, Tuple\n\n")
+	fmt.Fprintf(w, "import pulumi\n\n")
+	fmt.Fprintf(w, "__all__ = ['Mocks']\n\n")
+
+	fmt.Fprintf(w, "_ResourceHook = Callable[[pulumi.runtime.MockResourceArgs], Mapping[str, Any]]\n")
+	fmt.Fprintf(w, "_CallHook = Callable[[pulumi.runtime.MockCallArgs], Mapping[str, Any]]\n\n")
+
+	for _, m := range resources {
+		fmt.Fprintf(w, "\ndef default_%s_outputs() -> Dict[str, Any]:\n", PyName(m.Name))
+		fmt.Fprintf(w, "    \"\"\"\n    Returns the default outputs of a '%s' resource.\n    \"\"\"\n", m.Token)
+		fmt.Fprintf(w, "    return %s\n\n", pyMockLiteral(m.Defaults))
+	}
+	for _, m := range functions {
+		fmt.Fprintf(w, "\ndef default_%s_result() -> Dict[str, Any]:\n", PyName(m.Name))
+		fmt.Fprintf(w, "    \"\"\"\n    Returns the default result of the '%s' function.\n    \"\"\"\n", m.Token)
+		fmt.Fprintf(w, "    return %s\n\n", pyMockLiteral(m.Defaults))
+	}
+
+	fmt.Fprintf(w, "\nclass Mocks(pulumi.runtime.Mocks):\n")
+	fmt.Fprintf(w, "    \"\"\"\n")
+	fmt.Fprintf(w, "    Mocks implements pulumi.runtime.Mocks for the "+
+		"resources and functions of the %s package.\n\n", pkgName)
+	fmt.Fprintf(w, "    Resources are given their inputs as outputs, along with "+
+		"default values for any required outputs that are\n")
+	fmt.Fprintf(w, "    not inputs. Functions are given default values for their "+
+		"required results. Each hook may be set to compute\n")
+	fmt.Fprintf(w, "    additional outputs, which take precedence over the defaults.\n")
+	fmt.Fprintf(w, "    \"\"\"\n\n")
+
+	fmt.Fprintf(w, "    def __init__(self")
+	if len(resources)+len(functions) > 0 {
+		fmt.Fprintf(w, ",\n                 *")
+		for _, m := range resources {
+			fmt.Fprintf(w, ",\n                 %s: Optional[_ResourceHook] = None", pyMockHookName(m))
+		}
+		for _, m := range functions {
+			fmt.Fprintf(w, ",\n                 %s: Optional[_CallHook] = None", pyMockHookName(m))
+		}
+	}
+	fmt.Fprintf(w, ") -> None:\n")
+	fmt.Fprintf(w, "        \"\"\"\n")
+	for _, m := range resources {
+		fmt.Fprintf(w, "        :param %s: Computes the outputs of '%s' resources.\n", pyMockHookName(m), m.Token)
+	}
+	for _, m := range functions {
+		fmt.Fprintf(w, "        :param %s: Computes the result of the '%s' function.\n", pyMockHookName(m), m.Token)
+	}
+	if len(resources)+len(functions) == 0 {
+		fmt.Fprintf(w, "        Creates a new set of mocks.\n")
+	}
+	fmt.Fprintf(w, "        \"\"\"\n")
+	fmt.Fprintf(w, "        self._resources: Dict[str, Tuple[Callable[[], Dict[str, Any]], Optional[_ResourceHook]]] = ")
+	genPyMockTable(w, resources, "default_%s_outputs")
+	fmt.Fprintf(w, "        self._functions: Dict[str, Tuple[Callable[[], Dict[str, Any]], Optional[_CallHook]]] = ")
+	genPyMockTable(w, functions, "default_%s_result")
+	fmt.Fprintf(w, "\n")
+
+	fmt.Fprintf(w, "    def new_resource(self, args: pulumi.runtime.MockResourceArgs) -> Tuple[Optional[str], dict]:\n")
+	fmt.Fprintf(w, "        defaults, hook = self._resources.get(args.typ, (dict, None))\n")
+	fmt.Fprintf(w, "        outputs = defaults()\n")
+	fmt.Fprintf(w, "        outputs.update(copy.deepcopy(args.inputs))\n")
+	fmt.Fprintf(w, "        if hook is not None:\n")
+	fmt.Fprintf(w, "            outputs.update(hook(args))\n")
+	fmt.Fprintf(w, "        return (args.resource_id or args.name + '_id'), outputs\n\n")
+
+	fmt.Fprintf(w, "    def call(self, args: pulumi.runtime.MockCallArgs) -> "+
+		"Tuple[dict, Optional[List[Tuple[str, str]]]]:\n")
+	fmt.Fprintf(w, "        defaults, hook = self._functions.get(args.token, (dict, None))\n")
+	fmt.Fprintf(w, "        result = defaults()\n")
+	fmt.Fprintf(w, "        if hook is not None:\n")
+	fmt.Fprintf(w, "            result.update(hook(args))\n")
+	fmt.Fprintf(w, "        return result, None\n")
+}
+
+// genPyMockTable emits a dictionary literal that maps the token of each member to its default function and hook.
+func genPyMockTable(w io.Writer, members []codegen.MockMember, defaultsFormat string) {
+	if len(members) == 0 {
+		fmt.Fprintf(w, "{}\n")
+		return
+	}
+	fmt.Fprintf(w, "{\n")
+	for _, m := range members {
+		defaults := fmt.Sprintf(defaultsFormat, PyName(m.Name))
+		fmt.Fprintf(w, "            '%s': (%s, %s),\n", m.Token, defaults, pyMockHookName(m))
+	}
+	fmt.Fprintf(w, "        }\n")
+}
+
+// pyMockHookName returns the name of the keyword argument that sets the hook of a member. The name must not be a Python
+// keyword, so it is escaped for members such as a `Lambda` resource, whose hook is set by `lambda_`.
+func pyMockHookName(m codegen.MockMember) string {
+	return EnsureKeywordSafe(PyName(m.Name))
+}
+
+// pyMockLiteral renders a value produced by codegen.MockOutputs as a Python literal.
+func pyMockLiteral(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "None"
+	case bool:
+		if v {
+			return "True"
+		}
+		return "False"
+	case string:
+		// JSON string literals are valid Python string literals.
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "''"
+		}
+		return string(b)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case []interface{}:
+		elements := make([]string, len(v))
+		for i, e := range v {
+			elements[i] = pyMockLiteral(e)
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		entries := make([]string, len(keys))
+		for i, k := range keys {
+			entries[i] = pyMockLiteral(k) + ": " + pyMockLiteral(v[k])
+		}
+		return "{" + strings.Join(entries, ", ") + "}"
+	default:
+		return "None"
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package python
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+)
+
+func TestGenerateMocks(t *testing.T) {
+	t.Parallel()
+
+	pkg, err := schema.ImportSpec(schema.PackageSpec{
+		Name:    "mocked",
+		Version: "0.0.1",
+		Resources: map[string]schema.ResourceSpec{
+			"mocked:storage:Bucket": {
+				ObjectTypeSpec: schema.ObjectTypeSpec{
+					Properties: map[string]schema.PropertySpec{
+						"name":    {TypeSpec: schema.TypeSpec{Type: "string"}},
+						"isDir":   {TypeSpec: schema.TypeSpec{Type: "boolean"}},
+						"website": {TypeSpec: schema.TypeSpec{Type: "string"}},
+					},
+					Required: []string{"name", "isDir"},
+				},
+			},
+			// Names that are Python keywords are escaped.
+			"mocked:index:Lambda": {},
+		},
+		Functions: map[string]schema.FunctionSpec{
+			"mocked:index:import": {},
+			"mocked:index:getRegion": {
+				Outputs: &schema.ObjectTypeSpec{
+					Properties: map[string]schema.PropertySpec{
+						"region": {TypeSpec: schema.TypeSpec{Type: "string"}},
+					},
+					Required: []string{"region"},
+				},
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	files, err := GenerateMocks("test", pkg)
+	require.NoError(t, err)
+	require.Contains(t, files, "pulumi_mocked/mocks.py")
+
+	mocks := string(files["pulumi_mocked/mocks.py"])
+	assert.Contains(t, mocks, "class Mocks(pulumi.runtime.Mocks):")
+	assert.Contains(t, mocks, "storage_bucket: Optional[_ResourceHook] = None")
+	assert.Contains(t, mocks, "get_region: Optional[_CallHook] = None")
+	assert.Contains(t, mocks, "lambda_: Optional[_ResourceHook] = None")
+	assert.Contains(t, mocks, "'mocked:index:Lambda': (default_lambda__outputs, lambda_),")
+	assert.Contains(t, mocks, "import_: Optional[_CallHook] = None")
+	assert.Contains(t, mocks, `return {"isDir": False, "name": ""}`)
+	assert.Contains(t, mocks, `return {"region": ""}`)
+
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 not found, skipping syntax check of generated mocks")
+	}
+	path := filepath.Join(t.TempDir(), "mocks.py")
+	require.NoError(t, os.WriteFile(path, files["pulumi_mocked/mocks.py"], 0o600))
+	out, err := exec.Command(python, "-m", "py_compile", path).CombinedOutput()
+	require.NoError(t, err, string(out))
+}
@@ -0,0 +1,402 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/hcl2/model"
+	hcl2 "github.com/pulumi/pulumi/pkg/v3/codegen/pcl"
+	"github.com/pulumi/pulumi/pkg/v3/version"
+)
+
+// pclLineOffset converts the zero-based lines used by the PCL parser to one-based lines.
+const pclLineOffset = 1
+
+// The statuses of a construct in a conversion report.
+const (
+	// StatusConverted is the status of a construct that was converted without problems.
+	StatusConverted = "converted"
+	// StatusNotImplemented is the status of a construct that contains a call to notImplemented.
+	StatusNotImplemented = "notImplemented"
+	// StatusUnresolved is the status of a resource whose type could not be found in any schema.
+	StatusUnresolved = "unresolved"
+	// StatusFailed is the status of a construct that has errors.
+	StatusFailed = "failed"
+)
+
+// ConversionReport summarizes how much of a source program was converted by `pulumi convert`, per source file and
+// per target language.
+//
+// Converters name the files of the intermediate PCL program after the source files they were converted from, so the
+// constructs of a PCL file are attributed to the source file of the same name that the converter's diagnostics refer
+// to, e.g. `main.pp` to `main.tf`. Constructs are reported under their PCL file if no such source file is known.
+type ConversionReport struct {
+	Coverage
+	ReportVersion string `json:"reportVersion"`
+	// The converter that read the source program, e.g. "terraform".
+	From string `json:"from"`
+	// The coverage of each target language, keyed by language.
+	Languages map[string]*LanguageCoverage `json:"languages"`
+	// The providers for which no mapping could be found.
+	FailedMappings []string `json:"failedMappings,omitempty"`
+	// The diagnostics reported by the converter while reading the source program.
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+
+	// sourceFiles maps the path of each known source file without its extension to its path, or to "" if more than
+	// one source file has that path.
+	sourceFiles map[string]string
+
+	m sync.Mutex
+}
+
+// Coverage counts the constructs (resources, components, config, locals and outputs) of a program by status.
+type Coverage struct {
+	Total          int `json:"total"`
+	Converted      int `json:"converted"`
+	NotImplemented int `json:"notImplemented"`
+	Unresolved     int `json:"unresolved"`
+	Failed         int `json:"failed"`
+	// The percentage of constructs that were converted without problems.
+	Percentage float64 `json:"coverage"`
+}
+
+// LanguageCoverage is the coverage of a conversion to a single language.
+type LanguageCoverage struct {
+	Coverage
+	// The coverage of each source file, keyed by file name.
+	Files map[string]*FileCoverage `json:"files"`
+}
+
+// FileCoverage is the coverage of a single source file.
+type FileCoverage struct {
+	Coverage
+	Constructs  []Construct  `json:"constructs,omitempty"`
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+}
+
+// Construct is a single construct of a converted program.
+type Construct struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// The file and line of the intermediate PCL program that declares the construct.
+	ProgramFile string `json:"programFile,omitempty"`
+	Line        int    `json:"line,omitempty"`
+	Status      string `json:"status"`
+	// The reasons the construct was not converted cleanly, if any.
+	Details []string `json:"details,omitempty"`
+}
+
+// Diagnostic is a diagnostic reported during conversion.
+type Diagnostic struct {
+	Severity string `json:"severity"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Summary  string `json:"summary"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+// NewConversionReport creates an empty report for a conversion from the given source.
+func NewConversionReport(from string) *ConversionReport {
+	return &ConversionReport{
+		ReportVersion: version.Version,
+		From:          from,
+		Languages:     map[string]*LanguageCoverage{},
+		sourceFiles:   map[string]string{},
+	}
+}
+
+// AddFailedMapping records that no mapping could be found for the given provider.
+func (r *ConversionReport) AddFailedMapping(provider string) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	for _, p := range r.FailedMappings {
+		if p == provider {
+			return
+		}
+	}
+	r.FailedMappings = append(r.FailedMappings, provider)
+	sort.Strings(r.FailedMappings)
+}
+
+// AddConverterDiagnostics records the diagnostics reported by the converter while reading the source program.
+func (r *ConversionReport) AddConverterDiagnostics(diags hcl.Diagnostics) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	for _, d := range diags {
+		r.Diagnostics = append(r.Diagnostics, newDiagnostic(d, 0))
+		if d.Subject != nil && d.Subject.Filename != "" {
+			r.addSourceFile(d.Subject.Filename)
+		}
+	}
+}
+
+func (r *ConversionReport) addSourceFile(name string) {
+	stem := trimExt(name)
+	if existing, ok := r.sourceFiles[stem]; ok && existing != name {
+		r.sourceFiles[stem] = ""
+		return
+	}
+	r.sourceFiles[stem] = name
+}
+
+// sourceFile returns the source file that the given file of the PCL program was converted from, or the PCL file
+// itself if that isn't known.
+func (r *ConversionReport) sourceFile(programFile string) string {
+	if name := r.sourceFiles[trimExt(programFile)]; name != "" {
+		return name
+	}
+	return programFile
+}
+
+func trimExt(name string) string {
+	name = filepath.ToSlash(name)
+	return strings.TrimSuffix(name, path.Ext(name))
+}
+
+// AddProgram records the coverage of the conversion of a bound program to the given language. The diagnostics are
+// those reported while binding and generating code for the program, and are attributed to the constructs they refer
+// to. A construct's status is the most severe of: unresolved, failed, notImplemented and converted.
+func (r *ConversionReport) AddProgram(language string, program *hcl2.Program, diags hcl.Diagnostics) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	lang := &LanguageCoverage{Files: map[string]*FileCoverage{}}
+	r.Languages[language] = lang
+
+	getFile := func(programFile string) *FileCoverage {
+		name := r.sourceFile(programFile)
+		f, ok := lang.Files[name]
+		if !ok {
+			f = &FileCoverage{}
+			lang.Files[name] = f
+		}
+		return f
+	}
+
+	// Binding and code generation may report the same diagnostic, so only record each one once.
+	diags = uniqueDiagnostics(diags)
+	for _, d := range diags {
+		file := ""
+		if d.Subject != nil {
+			file = d.Subject.Filename
+		}
+		f := getFile(file)
+		f.Diagnostics = append(f.Diagnostics, newDiagnostic(d, pclLineOffset))
+	}
+
+	if program != nil {
+		for _, n := range program.Nodes {
+			kind := constructKind(n)
+			if kind == "" {
+				continue
+			}
+
+			rng := n.SyntaxNode().Range()
+			c := Construct{
+				Kind:        kind,
+				Name:        n.Name(),
+				ProgramFile: rng.Filename,
+				Line:        constructLine(n),
+				Status:      StatusConverted,
+			}
+			if details := notImplementedCalls(n); len(details) > 0 {
+				c.Status = StatusNotImplemented
+				c.Details = append(c.Details, details...)
+			}
+			for _, d := range diags {
+				if d.Severity == hcl.DiagError && d.Subject != nil && rangeContains(rng, *d.Subject) {
+					c.Status = StatusFailed
+					c.Details = append(c.Details, d.Summary)
+				}
+			}
+			if res, ok := n.(*hcl2.Resource); ok && res.Schema == nil && len(res.Definition.Labels) > 1 {
+				c.Status = StatusUnresolved
+				c.Details = append(c.Details, "unknown resource type "+res.Definition.Labels[1])
+			}
+
+			f := getFile(rng.Filename)
+			f.Constructs = append(f.Constructs, c)
+			f.Coverage.add(c.Status)
+		}
+	}
+
+	for _, f := range lang.Files {
+		sort.SliceStable(f.Constructs, func(i, j int) bool {
+			ci, cj := f.Constructs[i], f.Constructs[j]
+			if ci.ProgramFile != cj.ProgramFile {
+				return ci.ProgramFile < cj.ProgramFile
+			}
+			return ci.Line < cj.Line
+		})
+		f.Coverage.updatePercentage()
+		lang.Coverage.merge(f.Coverage)
+	}
+	lang.Coverage.updatePercentage()
+
+	r.Coverage = Coverage{}
+	for _, l := range r.Languages {
+		r.Coverage.merge(l.Coverage)
+	}
+	r.Coverage.updatePercentage()
+}
+
+// WriteFile writes the report to the given path as JSON.
+func (r *ConversionReport) WriteFile(path string) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	data, err := json.MarshalIndent(r, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+func (c *Coverage) add(status string) {
+	c.Total++
+	switch status {
+	case StatusConverted:
+		c.Converted++
+	case StatusNotImplemented:
+		c.NotImplemented++
+	case StatusUnresolved:
+		c.Unresolved++
+	case StatusFailed:
+		c.Failed++
+	}
+}
+
+func (c *Coverage) merge(other Coverage) {
+	c.Total += other.Total
+	c.Converted += other.Converted
+	c.NotImplemented += other.NotImplemented
+	c.Unresolved += other.Unresolved
+	c.Failed += other.Failed
+}
+
+func (c *Coverage) updatePercentage() {
+	if c.Total == 0 {
+		c.Percentage = 100
+		return
+	}
+	c.Percentage = float64(c.Converted) * 100 / float64(c.Total)
+}
+
+func constructKind(n hcl2.Node) string {
+	switch n.(type) {
+	case *hcl2.Resource:
+		return "resource"
+	case *hcl2.Component:
+		return "component"
+	case *hcl2.ConfigVariable:
+		return "config"
+	case *hcl2.LocalVariable:
+		return "local"
+	case *hcl2.OutputVariable:
+		return "output"
+	default:
+		return ""
+	}
+}
+
+// constructLine returns the one-based line that a construct is declared on.
+func constructLine(n hcl2.Node) int {
+	var pos hcl.Pos
+	switch syntax := n.SyntaxNode().(type) {
+	case *hclsyntax.Block:
+		pos = syntax.TypeRange.Start
+	case *hclsyntax.Attribute:
+		pos = syntax.NameRange.Start
+	default:
+		pos = syntax.Range().Start
+	}
+	return pos.Line + pclLineOffset
+}
+
+// notImplementedCalls returns the arguments of the calls to notImplemented made by a node.
+func notImplementedCalls(n hcl2.Node) []string {
+	var calls []string
+	n.VisitExpressions(nil, func(x model.Expression) (model.Expression, hcl.Diagnostics) {
+		call, ok := x.(*model.FunctionCallExpression)
+		if !ok || call.Name != "notImplemented" {
+			return x, nil
+		}
+		detail := "notImplemented"
+		if len(call.Args) == 1 {
+			arg := call.Args[0]
+			if tmpl, ok := arg.(*model.TemplateExpression); ok && len(tmpl.Parts) == 1 {
+				arg = tmpl.Parts[0]
+			}
+			if lit, ok := arg.(*model.LiteralValueExpression); ok && lit.Value.Type() == cty.String {
+				detail += ": " + lit.Value.AsString()
+			}
+		}
+		calls = append(calls, detail)
+		return x, nil
+	})
+	return calls
+}
+
+func uniqueDiagnostics(diags hcl.Diagnostics) hcl.Diagnostics {
+	type key struct {
+		severity hcl.DiagnosticSeverity
+		summary  string
+		detail   string
+		subject  hcl.Range
+	}
+	seen := map[key]bool{}
+	var unique hcl.Diagnostics
+	for _, d := range diags {
+		k := key{severity: d.Severity, summary: d.Summary, detail: d.Detail}
+		if d.Subject != nil {
+			k.subject = *d.Subject
+		}
+		if !seen[k] {
+			seen[k] = true
+			unique = append(unique, d)
+		}
+	}
+	return unique
+}
+
+func rangeContains(outer, inner hcl.Range) bool {
+	return outer.Filename == inner.Filename &&
+		outer.Start.Byte <= inner.Start.Byte && inner.End.Byte <= outer.End.Byte
+}
+
+func newDiagnostic(d *hcl.Diagnostic, lineOffset int) Diagnostic {
+	severity := "warning"
+	if d.Severity == hcl.DiagError {
+		severity = "error"
+	}
+	diag := Diagnostic{Severity: severity, Summary: d.Summary, Detail: d.Detail}
+	if d.Subject != nil {
+		diag.File, diag.Line = d.Subject.Filename, d.Subject.Start.Line+lineOffset
+	}
+	return diag
+}
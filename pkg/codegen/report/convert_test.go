@@ -0,0 +1,128 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/codegen/hcl2/syntax"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/pcl"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/report"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/testing/utils"
+)
+
+func TestConversionReport(t *testing.T) {
+	t.Parallel()
+
+	parser := syntax.NewParser()
+	err := parser.ParseFile(bytes.NewReader([]byte(`config region string {
+	default = "us-west-2"
+}
+
+resource pet "random:index/randomPet:RandomPet" {
+	prefix = notImplemented("random_pet.foo.prefix")
+}
+
+resource other "random:index/randomNope:RandomNope" {
+}
+`)), "main.pp")
+	require.NoError(t, err)
+	err = parser.ParseFile(bytes.NewReader([]byte(`output petId {
+	value = pet.id
+}
+`)), "outputs.pp")
+	require.NoError(t, err)
+
+	program, bindDiags, err := pcl.BindProgram(parser.Files,
+		pcl.PluginHost(utils.NewHost(testdataPath)),
+		pcl.SkipResourceTypechecking, pcl.AllowMissingVariables, pcl.AllowMissingProperties,
+		pcl.PreserveProgramOnError)
+	require.NotNil(t, program, "bind failed: %v", err)
+
+	outputs := program.OutputVariables()
+	require.Len(t, outputs, 1)
+	genDiags := hcl.Diagnostics{{
+		Severity: hcl.DiagError,
+		Summary:  "cannot generate output",
+		Subject:  outputs[0].SyntaxNode().Range().Ptr(),
+	}}
+
+	r := report.NewConversionReport("terraform")
+	r.AddFailedMapping("google")
+	r.AddFailedMapping("google")
+	r.AddConverterDiagnostics(hcl.Diagnostics{{
+		Severity: hcl.DiagWarning,
+		Summary:  "unsupported block",
+		Subject:  &hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 3}},
+	}})
+	r.AddProgram("python", program, append(bindDiags, genDiags...))
+
+	assert.Equal(t, []string{"google"}, r.FailedMappings)
+	assert.Equal(t, []report.Diagnostic{
+		{Severity: "warning", File: "main.tf", Line: 3, Summary: "unsupported block"},
+	}, r.Diagnostics)
+
+	lang := r.Languages["python"]
+	require.NotNil(t, lang)
+
+	// The constructs of main.pp are attributed to main.tf, which the converter's diagnostics refer to, while those of
+	// outputs.pp are reported under the PCL file.
+	assert.Len(t, lang.Files, 2)
+	main := lang.Files["main.tf"]
+	require.NotNil(t, main)
+	require.Len(t, main.Constructs, 3)
+	assert.Equal(t, report.Construct{
+		Kind: "config", Name: "region", ProgramFile: "main.pp", Line: 1, Status: report.StatusConverted,
+	}, main.Constructs[0])
+	assert.Equal(t, report.Construct{
+		Kind: "resource", Name: "pet", ProgramFile: "main.pp", Line: 5, Status: report.StatusNotImplemented,
+		Details: []string{"notImplemented: random_pet.foo.prefix"},
+	}, main.Constructs[1])
+	assert.Equal(t, "other", main.Constructs[2].Name)
+	assert.Equal(t, report.StatusUnresolved, main.Constructs[2].Status)
+	assert.Contains(t, main.Constructs[2].Details, "unknown resource type random:index/randomNope:RandomNope")
+
+	outputsFile := lang.Files["outputs.pp"]
+	require.NotNil(t, outputsFile)
+	require.Len(t, outputsFile.Constructs, 1)
+	assert.Equal(t, report.StatusFailed, outputsFile.Constructs[0].Status)
+	assert.Equal(t, []string{"cannot generate output"}, outputsFile.Constructs[0].Details)
+
+	assert.Equal(t, report.Coverage{
+		Total:          4,
+		Converted:      1,
+		NotImplemented: 1,
+		Unresolved:     1,
+		Failed:         1,
+		Percentage:     25,
+	}, r.Coverage)
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, r.WriteFile(path))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "terraform", decoded["from"])
+	assert.Equal(t, float64(25), decoded["coverage"])
+}
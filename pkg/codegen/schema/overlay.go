@@ -0,0 +1,445 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// overlayMemberKinds maps the sections of a package spec that are keyed by token to the kind of member they contain.
+var overlayMemberKinds = map[string]string{
+	"resources": "resource",
+	"functions": "function",
+	"types":     "type",
+}
+
+// ApplyOverlay patches a package spec with an overlay document before it is bound. The overlay is written in YAML or
+// JSON and is either:
+//
+//   - a JSON Patch (RFC 6902): a list of operations such as
+//     `{op: replace, path: /resources/aws:s3~1bucket:Bucket/description, value: ...}`. Note that the "/" characters
+//     in a token must be escaped as "~1"; or
+//   - a JSON Merge Patch (RFC 7386): an object that is merged into the spec, where null values remove properties.
+//
+// Overlays must only refer to resources, functions and types that exist in the spec, so that overlays written against
+// an older version of a schema fail loudly rather than silently doing nothing. The only exception is a JSON Patch
+// "add", "move" or "copy" operation whose path is a token itself, which adds a new member to the spec.
+func ApplyOverlay(spec PackageSpec, overlay []byte) (PackageSpec, error) {
+	var patch interface{}
+	if err := yaml.Unmarshal(overlay, &patch); err != nil {
+		return PackageSpec{}, fmt.Errorf("parsing overlay: %w", err)
+	}
+
+	doc, err := toOverlayDocument(spec)
+	if err != nil {
+		return PackageSpec{}, err
+	}
+
+	switch patch := patch.(type) {
+	case nil:
+		return spec, nil
+	case []interface{}:
+		doc, err = applyJSONPatch(doc, patch)
+	case map[string]interface{}:
+		if err = checkMergePatchTokens(doc, patch); err == nil {
+			doc = applyMergePatch(doc, patch).(map[string]interface{})
+		}
+	default:
+		err = fmt.Errorf("an overlay must be a list of JSON Patch operations or a JSON Merge Patch object")
+	}
+	if err != nil {
+		return PackageSpec{}, err
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return PackageSpec{}, fmt.Errorf("marshaling patched spec: %w", err)
+	}
+	var result PackageSpec
+	if err := json.Unmarshal(b, &result); err != nil {
+		return PackageSpec{}, fmt.Errorf("the patched spec is not a valid package spec: %w", err)
+	}
+	return result, nil
+}
+
+// toOverlayDocument converts a package spec into its generic JSON representation.
+func toOverlayDocument(spec PackageSpec) (map[string]interface{}, error) {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling spec: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var doc map[string]interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("unmarshaling spec: %w", err)
+	}
+	// Empty sections are omitted when marshaling, but patches must be able to add members to them.
+	for section := range overlayMemberKinds {
+		if _, ok := doc[section]; !ok {
+			doc[section] = map[string]interface{}{}
+		}
+	}
+	return doc, nil
+}
+
+// checkMergePatchTokens returns an error if a merge patch refers to a member that does not exist in the document.
+func checkMergePatchTokens(doc, patch map[string]interface{}) error {
+	sections := make([]string, 0, len(overlayMemberKinds))
+	for section := range overlayMemberKinds {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections)
+
+	for _, section := range sections {
+		members, ok := patch[section].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		existing, _ := doc[section].(map[string]interface{})
+
+		tokens := make([]string, 0, len(members))
+		for token := range members {
+			tokens = append(tokens, token)
+		}
+		sort.Strings(tokens)
+		for _, token := range tokens {
+			if _, ok := existing[token]; !ok {
+				return fmt.Errorf("overlay refers to unknown %s %q", overlayMemberKinds[section], token)
+			}
+		}
+	}
+	return nil
+}
+
+// applyMergePatch applies a JSON Merge Patch to a value as described in RFC 7386.
+func applyMergePatch(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+	for k, v := range patchObject {
+		if v == nil {
+			delete(targetObject, k)
+		} else {
+			targetObject[k] = applyMergePatch(targetObject[k], v)
+		}
+	}
+	return targetObject
+}
+
+// applyJSONPatch applies a list of JSON Patch operations to a document as described in RFC 6902.
+func applyJSONPatch(doc map[string]interface{}, ops []interface{}) (map[string]interface{}, error) {
+	var root interface{} = doc
+	for i, op := range ops {
+		opObject, ok := op.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("overlay operation %d: expected an object", i)
+		}
+		name, _ := opObject["op"].(string)
+		path, ok := opObject["path"].(string)
+		if !ok {
+			return nil, fmt.Errorf("overlay operation %d: missing path", i)
+		}
+
+		var err error
+		root, err = applyJSONPatchOperation(root, name, path, opObject)
+		if err != nil {
+			return nil, fmt.Errorf("overlay operation %d (%s %s): %w", i, name, path, err)
+		}
+	}
+
+	result, ok := root.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("the patched spec must be an object")
+	}
+	return result, nil
+}
+
+func applyJSONPatchOperation(root interface{}, op, path string, args map[string]interface{}) (interface{}, error) {
+	pointer, err := parseJSONPointer(path)
+	if err != nil {
+		return nil, err
+	}
+	// Adding, moving or copying a value to a token path creates a new member.
+	if err := checkPointerToken(root, pointer, op == "add" || op == "move" || op == "copy"); err != nil {
+		return nil, err
+	}
+
+	from := func() ([]string, error) {
+		path, ok := args["from"].(string)
+		if !ok {
+			return nil, fmt.Errorf("missing from")
+		}
+		pointer, err := parseJSONPointer(path)
+		if err != nil {
+			return nil, err
+		}
+		return pointer, checkPointerToken(root, pointer, false)
+	}
+
+	switch op {
+	case "add":
+		value, ok := args["value"]
+		if !ok {
+			return nil, fmt.Errorf("missing value")
+		}
+		return addValue(root, pointer, value)
+	case "remove":
+		if _, err := getValue(root, pointer); err != nil {
+			return nil, err
+		}
+		return removeValue(root, pointer)
+	case "replace":
+		value, ok := args["value"]
+		if !ok {
+			return nil, fmt.Errorf("missing value")
+		}
+		if _, err := getValue(root, pointer); err != nil {
+			return nil, err
+		}
+		root, err = removeValue(root, pointer)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(root, pointer, value)
+	case "move", "copy":
+		fromPointer, err := from()
+		if err != nil {
+			return nil, err
+		}
+		value, err := getValue(root, fromPointer)
+		if err != nil {
+			return nil, err
+		}
+		if op == "move" {
+			if root, err = removeValue(root, fromPointer); err != nil {
+				return nil, err
+			}
+		} else {
+			value = deepCopyOverlayValue(value)
+		}
+		return addValue(root, pointer, value)
+	case "test":
+		value, err := getValue(root, pointer)
+		if err != nil {
+			return nil, err
+		}
+		if !overlayValuesEqual(value, args["value"]) {
+			return nil, fmt.Errorf("test failed")
+		}
+		return root, nil
+	default:
+		return nil, fmt.Errorf("unknown operation %q", op)
+	}
+}
+
+// parseJSONPointer parses a JSON Pointer (RFC 6901) into its unescaped reference tokens.
+func parseJSONPointer(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("invalid path %q: paths must begin with '/'", path)
+	}
+	parts := strings.Split(path[1:], "/")
+	for i, p := range parts {
+		parts[i] = strings.ReplaceAll(strings.ReplaceAll(p, "~1", "/"), "~0", "~")
+	}
+	return parts, nil
+}
+
+// checkPointerToken returns an error if a pointer refers to a member that does not exist in the document. If
+// allowNew is true, a pointer to a new member itself (rather than to a part of a new member) is allowed.
+func checkPointerToken(root interface{}, pointer []string, allowNew bool) error {
+	if len(pointer) < 2 {
+		return nil
+	}
+	kind, ok := overlayMemberKinds[pointer[0]]
+	if !ok {
+		return nil
+	}
+	if allowNew && len(pointer) == 2 {
+		return nil
+	}
+	if _, err := getValue(root, pointer[:2]); err != nil {
+		return fmt.Errorf("overlay refers to unknown %s %q", kind, pointer[1])
+	}
+	return nil
+}
+
+func getValue(root interface{}, pointer []string) (interface{}, error) {
+	v := root
+	for i, p := range pointer {
+		switch container := v.(type) {
+		case map[string]interface{}:
+			child, ok := container[p]
+			if !ok {
+				return nil, fmt.Errorf("%q does not exist", "/"+strings.Join(pointer[:i+1], "/"))
+			}
+			v = child
+		case []interface{}:
+			index, err := arrayIndex(p, len(container), false)
+			if err != nil {
+				return nil, err
+			}
+			v = container[index]
+		default:
+			return nil, fmt.Errorf("%q does not exist", "/"+strings.Join(pointer[:i+1], "/"))
+		}
+	}
+	return v, nil
+}
+
+func addValue(root interface{}, pointer []string, value interface{}) (interface{}, error) {
+	if len(pointer) == 0 {
+		return value, nil
+	}
+	parent, err := getValue(root, pointer[:len(pointer)-1])
+	if err != nil {
+		return nil, err
+	}
+	key := pointer[len(pointer)-1]
+	switch container := parent.(type) {
+	case map[string]interface{}:
+		container[key] = value
+		return root, nil
+	case []interface{}:
+		index, err := arrayIndex(key, len(container), true)
+		if err != nil {
+			return nil, err
+		}
+		updated := append(container[:index:index], value)
+		updated = append(updated, container[index:]...)
+		return setValue(root, pointer[:len(pointer)-1], updated)
+	default:
+		return nil, fmt.Errorf("cannot add a value to a %T", parent)
+	}
+}
+
+func removeValue(root interface{}, pointer []string) (interface{}, error) {
+	if len(pointer) == 0 {
+		return nil, nil
+	}
+	parent, err := getValue(root, pointer[:len(pointer)-1])
+	if err != nil {
+		return nil, err
+	}
+	key := pointer[len(pointer)-1]
+	switch container := parent.(type) {
+	case map[string]interface{}:
+		delete(container, key)
+		return root, nil
+	case []interface{}:
+		index, err := arrayIndex(key, len(container), false)
+		if err != nil {
+			return nil, err
+		}
+		updated := append(container[:index:index], container[index+1:]...)
+		return setValue(root, pointer[:len(pointer)-1], updated)
+	default:
+		return nil, fmt.Errorf("cannot remove a value from a %T", parent)
+	}
+}
+
+// setValue replaces the value at the given pointer, which must exist. This is used to replace arrays, which cannot be
+// modified in place.
+func setValue(root interface{}, pointer []string, value interface{}) (interface{}, error) {
+	if len(pointer) == 0 {
+		return value, nil
+	}
+	parent, err := getValue(root, pointer[:len(pointer)-1])
+	if err != nil {
+		return nil, err
+	}
+	key := pointer[len(pointer)-1]
+	switch container := parent.(type) {
+	case map[string]interface{}:
+		container[key] = value
+	case []interface{}:
+		index, err := arrayIndex(key, len(container), false)
+		if err != nil {
+			return nil, err
+		}
+		container[index] = value
+	}
+	return root, nil
+}
+
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if token == "-" && allowEnd {
+		return length, nil
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	limit := length - 1
+	if allowEnd {
+		limit = length
+	}
+	if index > limit {
+		return 0, fmt.Errorf("array index %d out of range", index)
+	}
+	return index, nil
+}
+
+func deepCopyOverlayValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			result[k] = deepCopyOverlayValue(e)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, e := range v {
+			result[i] = deepCopyOverlayValue(e)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+// overlayValuesEqual compares two values by their JSON encodings, which normalizes the different representations of
+// numbers used by the JSON and YAML decoders.
+func overlayValuesEqual(a, b interface{}) bool {
+	aj, aerr := json.Marshal(a)
+	bj, berr := json.Marshal(b)
+	if aerr != nil || berr != nil {
+		return reflect.DeepEqual(a, b)
+	}
+	var av, bv interface{}
+	if json.Unmarshal(aj, &av) != nil || json.Unmarshal(bj, &bv) != nil {
+		return bytes.Equal(aj, bj)
+	}
+	return reflect.DeepEqual(av, bv)
+}
@@ -0,0 +1,157 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func overlayTestSpec() PackageSpec {
+	return PackageSpec{
+		Name:    "test",
+		Version: "1.0.0",
+		Resources: map[string]ResourceSpec{
+			"test:s3/bucket:Bucket": {
+				ObjectTypeSpec: ObjectTypeSpec{
+					Description: "A bucket.",
+					Properties: map[string]PropertySpec{
+						"arn":    {TypeSpec: TypeSpec{Type: "string"}},
+						"broken": {TypeSpec: TypeSpec{Type: "string"}},
+						"key":    {TypeSpec: TypeSpec{Type: "string"}},
+					},
+					Required: []string{"arn", "broken"},
+				},
+			},
+		},
+		Functions: map[string]FunctionSpec{
+			"test:s3/getBucket:getBucket": {Description: "Gets a bucket."},
+		},
+	}
+}
+
+func TestApplyOverlayJSONPatch(t *testing.T) {
+	t.Parallel()
+
+	spec, err := ApplyOverlay(overlayTestSpec(), []byte(`
+- op: replace
+  path: /resources/test:s3~1bucket:Bucket/description
+  value: A storage bucket.
+- op: remove
+  path: /resources/test:s3~1bucket:Bucket/properties/broken
+- op: remove
+  path: /resources/test:s3~1bucket:Bucket/required/1
+- op: add
+  path: /resources/test:s3~1bucket:Bucket/properties/key/secret
+  value: true
+- op: add
+  path: /types/test:s3~1bucket:Website
+  value:
+    type: object
+    properties:
+      index: {type: string}
+- op: test
+  path: /resources/test:s3~1bucket:Bucket/required
+  value: [arn]
+`))
+	require.NoError(t, err)
+
+	bucket := spec.Resources["test:s3/bucket:Bucket"]
+	assert.Equal(t, "A storage bucket.", bucket.Description)
+	assert.NotContains(t, bucket.Properties, "broken")
+	assert.Equal(t, []string{"arn"}, bucket.Required)
+	assert.True(t, bucket.Properties["key"].Secret)
+	assert.Contains(t, spec.Types, "test:s3/bucket:Website")
+
+	_, diags, err := BindSpec(spec, nil)
+	require.NoError(t, err)
+	assert.False(t, diags.HasErrors(), diags.Error())
+}
+
+func TestApplyOverlayMergePatch(t *testing.T) {
+	t.Parallel()
+
+	spec, err := ApplyOverlay(overlayTestSpec(), []byte(`
+resources:
+  test:s3/bucket:Bucket:
+    properties:
+      broken: null
+      key:
+        secret: true
+    required: [arn]
+functions:
+  test:s3/getBucket:getBucket:
+    description: Looks up a bucket.
+`))
+	require.NoError(t, err)
+
+	bucket := spec.Resources["test:s3/bucket:Bucket"]
+	assert.Equal(t, "A bucket.", bucket.Description)
+	assert.NotContains(t, bucket.Properties, "broken")
+	assert.Equal(t, []string{"arn"}, bucket.Required)
+	assert.True(t, bucket.Properties["key"].Secret)
+	assert.Equal(t, "string", bucket.Properties["key"].Type)
+	assert.Equal(t, "Looks up a bucket.", spec.Functions["test:s3/getBucket:getBucket"].Description)
+}
+
+func TestApplyOverlayErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		overlay string
+		err     string
+	}{
+		"merge unknown resource": {
+			overlay: "resources:\n  test:s3/bucket:Buckett:\n    description: typo\n",
+			err:     `overlay refers to unknown resource "test:s3/bucket:Buckett"`,
+		},
+		"merge unknown function": {
+			overlay: "functions:\n  test:s3/getBucket:getBuckets: null\n",
+			err:     `overlay refers to unknown function "test:s3/getBucket:getBuckets"`,
+		},
+		"patch unknown resource": {
+			overlay: "- op: replace\n  path: /resources/test:s3~1bucket:Bucke/description\n  value: x\n",
+			err:     `overlay refers to unknown resource "test:s3/bucket:Bucke"`,
+		},
+		"patch missing property": {
+			overlay: "- op: remove\n  path: /resources/test:s3~1bucket:Bucket/properties/missing\n",
+			err:     `"/resources/test:s3/bucket:Bucket/properties/missing" does not exist`,
+		},
+		"patch failed test": {
+			overlay: "- op: test\n  path: /version\n  value: 2.0.0\n",
+			err:     "test failed",
+		},
+		"patch unknown op": {
+			overlay: "- op: frobnicate\n  path: /version\n",
+			err:     `unknown operation "frobnicate"`,
+		},
+		"scalar overlay": {
+			overlay: "42",
+			err:     "an overlay must be a list of JSON Patch operations or a JSON Merge Patch object",
+		},
+	}
+	for name, c := range cases {
+		c := c
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ApplyOverlay(overlayTestSpec(), []byte(c.overlay))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), c.err)
+		})
+	}
+}
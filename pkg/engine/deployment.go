@@ -181,6 +181,8 @@ func newDeployment(ctx *Context, info *deploymentContext, opts deploymentOptions
 	if err != nil {
 		return nil, err
 	}
+	// The default host shares the context, so plugins that it launches also receive these variables.
+	plugctx.Env = opts.Environment
 	plugctx = plugctx.WithCancelChannel(ctx.Cancel.Canceled())
 
 	opts.trustDependencies = proj.TrustResourceDependencies()
@@ -250,6 +252,7 @@ type runActions interface {
 
 	Changes() display.ResourceChanges
 	MaybeCorrupt() bool
+	AnnotationTotals() []AnnotationTotal
 }
 
 // run executes the deployment. It is primarily responsible for handling cancellation.
@@ -324,7 +327,8 @@ func (deployment *deployment) run(cancelCtx *Context, actions runActions, policy
 	changes := actions.Changes()
 
 	// Emit a summary event.
-	deployment.Options.Events.summaryEvent(preview, actions.MaybeCorrupt(), duration, changes, policyPacks)
+	deployment.Options.Events.summaryEvent(preview, actions.MaybeCorrupt(), duration, changes, policyPacks,
+		actions.AnnotationTotals())
 
 	return newPlan, changes, res
 }
@@ -60,6 +60,8 @@ func NewEvent(typ EventType, payload interface{}) Event {
 		_, ok = payload.(ResourceOperationFailedPayload)
 	case PolicyViolationEvent:
 		_, ok = payload.(PolicyViolationEventPayload)
+	case ResourceAnnotationEvent:
+		_, ok = payload.(ResourceAnnotationEventPayload)
 	default:
 		contract.Failf("unknown event type %v", typ)
 	}
@@ -83,6 +85,7 @@ const (
 	ResourceOutputsEvent    EventType = "resource-outputs"
 	ResourceOperationFailed EventType = "resource-operationfailed"
 	PolicyViolationEvent    EventType = "policy-violation"
+	ResourceAnnotationEvent EventType = "resource-annotation"
 )
 
 func (e Event) Payload() interface{} {
@@ -116,6 +119,23 @@ type PolicyViolationEventPayload struct {
 	Prefix            string
 }
 
+// ResourceAnnotationEventPayload is the payload for an event with type `resource-annotation`.
+type ResourceAnnotationEventPayload struct {
+	ResourceURN    resource.URN
+	Label          string   // the label of the annotation, e.g. "Monthly cost".
+	Number         *float64 // the numeric value of the annotation, if it is numeric.
+	Text           string   // the textual value of the annotation, if it is not numeric.
+	Unit           string   // the unit of a numeric value, e.g. "USD".
+	PolicyPackName string
+}
+
+// AnnotationTotal is the total of the numeric annotations with the same label and unit.
+type AnnotationTotal struct {
+	Label string
+	Unit  string
+	Total float64
+}
+
 type StdoutEventPayload struct {
 	Message string
 	Color   colors.Colorization
@@ -132,6 +152,9 @@ type SummaryEventPayload struct {
 	Duration        time.Duration           // the duration of the entire update operation (zero values for previews)
 	ResourceChanges display.ResourceChanges // count of changed resources, useful for reporting
 	PolicyPacks     map[string]string       // {policy-pack: version} for each policy pack applied
+	// AnnotationTotals are the totals of the numeric annotations of changed resources, in the order in which
+	// their labels were first seen.
+	AnnotationTotals []AnnotationTotal
 }
 
 type ResourceOperationFailedPayload struct {
@@ -405,16 +428,17 @@ func (e *eventEmitter) preludeEvent(isPreview bool, cfg config.Map) {
 }
 
 func (e *eventEmitter) summaryEvent(preview, maybeCorrupt bool, duration time.Duration,
-	resourceChanges display.ResourceChanges, policyPacks map[string]string,
+	resourceChanges display.ResourceChanges, policyPacks map[string]string, annotationTotals []AnnotationTotal,
 ) {
 	contract.Requiref(e != nil, "e", "!= nil")
 
 	e.sendEvent(NewEvent(SummaryEvent, SummaryEventPayload{
-		IsPreview:       preview,
-		MaybeCorrupt:    maybeCorrupt,
-		Duration:        duration,
-		ResourceChanges: resourceChanges,
-		PolicyPacks:     policyPacks,
+		IsPreview:        preview,
+		MaybeCorrupt:     maybeCorrupt,
+		Duration:         duration,
+		ResourceChanges:  resourceChanges,
+		PolicyPacks:      policyPacks,
+		AnnotationTotals: annotationTotals,
 	}))
 }
 
@@ -457,6 +481,19 @@ func (e *eventEmitter) policyViolationEvent(urn resource.URN, d plugin.AnalyzeDi
 	}))
 }
 
+func (e *eventEmitter) resourceAnnotationEvent(urn resource.URN, a plugin.AnalyzeAnnotation) {
+	contract.Requiref(e != nil, "e", "!= nil")
+
+	e.sendEvent(NewEvent(ResourceAnnotationEvent, ResourceAnnotationEventPayload{
+		ResourceURN:    urn,
+		Label:          logging.FilterString(a.Label),
+		Number:         a.Number,
+		Text:           logging.FilterString(a.Text),
+		Unit:           logging.FilterString(a.Unit),
+		PolicyPackName: a.PolicyPackName,
+	}))
+}
+
 func diagEvent(e *eventEmitter, d *diag.Diag, prefix, msg string, sev diag.Severity,
 	ephemeral bool,
 ) {
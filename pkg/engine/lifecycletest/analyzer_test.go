@@ -3,13 +3,19 @@ package lifecycletest
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/blang/semver"
 	. "github.com/pulumi/pulumi/pkg/v3/engine"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy/deploytest"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/result"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -157,3 +163,149 @@ func TestSimpleAnalyzeStackFailure(t *testing.T) {
 	_, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient, nil)
 	assert.NotNil(t, res)
 }
+
+func TestAnalyzerAnnotations(t *testing.T) {
+	t.Parallel()
+
+	loaders := []*deploytest.PluginLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+		deploytest.NewAnalyzerLoader("analyzerA", func(_ *plugin.PolicyAnalyzerOptions) (plugin.Analyzer, error) {
+			return &deploytest.Analyzer{
+				AnnotateF: func(changes []plugin.AnalyzerResourceChange) ([]plugin.AnalyzeAnnotation, error) {
+					var annotations []plugin.AnalyzeAnnotation
+					for _, c := range changes {
+						if c.New == nil || c.New.Type != "pkgA:m:typA" {
+							continue
+						}
+						cost := 12.5
+						annotations = append(annotations, plugin.AnalyzeAnnotation{
+							PolicyPackName: "analyzerA",
+							URN:            c.New.URN,
+							Label:          "Monthly cost",
+							Number:         &cost,
+							Unit:           "USD",
+						}, plugin.AnalyzeAnnotation{
+							PolicyPackName: "analyzerA",
+							Label:          "Complexity",
+							Text:           string(c.Op),
+						}, plugin.AnalyzeAnnotation{
+							// Annotations of resources that are not changing are dropped.
+							URN:   "urn:pulumi:test::test::pkgA:m:typA::unknown",
+							Label: "Monthly cost",
+						})
+					}
+					return annotations, nil
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.NoError(t, err)
+		_, _, _, err = monitor.RegisterResource("pkgA:m:typA", "resB", true)
+		assert.NoError(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{
+			RequiredPolicies: []RequiredPolicy{NewRequiredPolicy("analyzerA", "", nil)},
+			Host:             host,
+		},
+	}
+
+	project := p.GetProject()
+	_, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, true, p.BackendClient,
+		func(_ workspace.Project, _ deploy.Target, _ JournalEntries, events []Event, res result.Result) result.Result {
+			annotations := map[resource.URN][]ResourceAnnotationEventPayload{}
+			var summary *SummaryEventPayload
+			for _, e := range events {
+				switch e.Type {
+				case ResourceAnnotationEvent:
+					payload := e.Payload().(ResourceAnnotationEventPayload)
+					annotations[payload.ResourceURN] = append(annotations[payload.ResourceURN], payload)
+				case SummaryEvent:
+					payload := e.Payload().(SummaryEventPayload)
+					summary = &payload
+				}
+			}
+
+			assert.Len(t, annotations, 2)
+			for urn, payloads := range annotations {
+				assert.Contains(t, []string{"resA", "resB"}, urn.Name().String())
+				if assert.Len(t, payloads, 2) {
+					assert.Equal(t, "Monthly cost", payloads[0].Label)
+					assert.Equal(t, 12.5, *payloads[0].Number)
+					assert.Equal(t, "USD", payloads[0].Unit)
+					assert.Equal(t, "analyzerA", payloads[0].PolicyPackName)
+					assert.Equal(t, "Complexity", payloads[1].Label)
+					assert.Nil(t, payloads[1].Number)
+					assert.Equal(t, string(deploy.OpCreate), payloads[1].Text)
+				}
+			}
+
+			if assert.NotNil(t, summary) {
+				assert.Equal(t, []AnnotationTotal{{Label: "Monthly cost", Unit: "USD", Total: 25}}, summary.AnnotationTotals)
+			}
+			return res
+		})
+	assert.Nil(t, res)
+}
+
+func TestAnalyzerAnnotateError(t *testing.T) {
+	t.Parallel()
+
+	loaders := []*deploytest.PluginLoader{
+		deploytest.NewProviderLoader("pkgA", semver.MustParse("1.0.0"), func() (plugin.Provider, error) {
+			return &deploytest.Provider{}, nil
+		}),
+		deploytest.NewAnalyzerLoader("analyzerA", func(_ *plugin.PolicyAnalyzerOptions) (plugin.Analyzer, error) {
+			return &deploytest.Analyzer{
+				Info: plugin.AnalyzerInfo{Name: "analyzerA"},
+				AnnotateF: func(changes []plugin.AnalyzerResourceChange) ([]plugin.AnalyzeAnnotation, error) {
+					return nil, errors.New("pricing service unavailable")
+				},
+			}, nil
+		}),
+	}
+
+	program := deploytest.NewLanguageRuntime(func(_ plugin.RunInfo, monitor *deploytest.ResourceMonitor) error {
+		_, _, _, err := monitor.RegisterResource("pkgA:m:typA", "resA", true)
+		assert.NoError(t, err)
+		return nil
+	})
+	host := deploytest.NewPluginHost(nil, nil, program, loaders...)
+
+	p := &TestPlan{
+		Options: UpdateOptions{
+			RequiredPolicies: []RequiredPolicy{NewRequiredPolicy("analyzerA", "", nil)},
+			Host:             host,
+		},
+	}
+
+	// A failure to annotate the changes is reported as a warning, and doesn't stop the update.
+	project := p.GetProject()
+	snap, res := TestOp(Update).Run(project, p.GetTarget(t, nil), p.Options, false, p.BackendClient,
+		func(_ workspace.Project, _ deploy.Target, _ JournalEntries, events []Event, res result.Result) result.Result {
+			var warnings []string
+			for _, e := range events {
+				if e.Type == DiagEvent {
+					payload := e.Payload().(DiagEventPayload)
+					if payload.Severity == diag.Warning {
+						warnings = append(warnings, payload.Message)
+					}
+				}
+			}
+			assert.NotEmpty(t, warnings)
+			for _, w := range warnings {
+				assert.Contains(t, w, "analyzer analyzerA failed to annotate resource changes: pricing service unavailable")
+			}
+			return res
+		})
+	assert.Nil(t, res)
+	assert.Len(t, snap.Resources, 2)
+}
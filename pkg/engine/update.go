@@ -150,6 +150,10 @@ type UpdateOptions struct {
 
 	// Experimental is true if the engine is in experimental mode (i.e. PULUMI_EXPERIMENTAL was set)
 	Experimental bool
+
+	// Environment is additional environment variables for the language host and resource provider plugins, such as
+	// those declared by the project and stack.
+	Environment map[string]string
 }
 
 // HasChanges returns true if there are any non-same changes in the resulting summary.
@@ -495,6 +499,7 @@ type updateActions struct {
 	Update  UpdateInfo
 	Opts    deploymentOptions
 
+	annotations  annotationTotals
 	maybeCorrupt bool
 }
 
@@ -632,6 +637,15 @@ func (acts *updateActions) OnPolicyViolation(urn resource.URN, d plugin.AnalyzeD
 	acts.Opts.Events.policyViolationEvent(urn, d)
 }
 
+func (acts *updateActions) OnResourceAnnotation(urn resource.URN, a plugin.AnalyzeAnnotation) {
+	acts.annotations.add(a)
+	acts.Opts.Events.resourceAnnotationEvent(urn, a)
+}
+
+func (acts *updateActions) AnnotationTotals() []AnnotationTotal {
+	return acts.annotations.totals()
+}
+
 func (acts *updateActions) MaybeCorrupt() bool {
 	return acts.maybeCorrupt
 }
@@ -645,6 +659,36 @@ type previewActions struct {
 	Opts    deploymentOptions
 	Seen    map[resource.URN]deploy.Step
 	MapLock sync.Mutex
+
+	annotations annotationTotals
+}
+
+// annotationTotals totals the numeric annotations that analyzers make to changed resources by their label and unit.
+type annotationTotals struct {
+	lock   sync.Mutex
+	values []AnnotationTotal
+}
+
+func (t *annotationTotals) add(a plugin.AnalyzeAnnotation) {
+	if a.Number == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for i := range t.values {
+		if t.values[i].Label == a.Label && t.values[i].Unit == a.Unit {
+			t.values[i].Total += *a.Number
+			return
+		}
+	}
+	t.values = append(t.values, AnnotationTotal{Label: a.Label, Unit: a.Unit, Total: *a.Number})
+}
+
+func (t *annotationTotals) totals() []AnnotationTotal {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return append([]AnnotationTotal(nil), t.values...)
 }
 
 func shouldReportStep(step deploy.Step, opts deploymentOptions) bool {
@@ -750,6 +794,15 @@ func (acts *previewActions) OnPolicyViolation(urn resource.URN, d plugin.Analyze
 	acts.Opts.Events.policyViolationEvent(urn, d)
 }
 
+func (acts *previewActions) OnResourceAnnotation(urn resource.URN, a plugin.AnalyzeAnnotation) {
+	acts.annotations.add(a)
+	acts.Opts.Events.resourceAnnotationEvent(urn, a)
+}
+
+func (acts *previewActions) AnnotationTotals() []AnnotationTotal {
+	return acts.annotations.totals()
+}
+
 func (acts *previewActions) MaybeCorrupt() bool {
 	return false
 }
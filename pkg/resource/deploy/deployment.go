@@ -188,10 +188,16 @@ type PolicyEvents interface {
 	OnPolicyViolation(resource.URN, plugin.AnalyzeDiagnostic)
 }
 
+// AnnotationEvents is an interface that can be used to hook the annotations that analyzers make to resources.
+type AnnotationEvents interface {
+	OnResourceAnnotation(resource.URN, plugin.AnalyzeAnnotation)
+}
+
 // Events is an interface that can be used to hook interesting engine events.
 type Events interface {
 	StepExecutorEvents
 	PolicyEvents
+	AnnotationEvents
 }
 
 type goalMap struct {
@@ -355,6 +355,8 @@ func (ex *deploymentExecutor) performDeletes(
 		return res
 	}
 
+	ex.stepGen.AnnotateSteps(deleteSteps)
+
 	deletes := ex.stepGen.ScheduleDeletes(deleteSteps)
 
 	// ScheduleDeletes gives us a list of lists of steps. Each list of steps can safely be executed
@@ -408,6 +410,8 @@ func (ex *deploymentExecutor) handleSingleEvent(event SourceEvent) result.Result
 		return res
 	}
 
+	ex.stepGen.AnnotateSteps(steps)
+
 	ex.stepExec.ExecuteSerial(steps)
 	return nil
 }
@@ -27,6 +27,7 @@ type Analyzer struct {
 	AnalyzeStackF func(resources []plugin.AnalyzerStackResource) ([]plugin.AnalyzeDiagnostic, error)
 
 	ConfigureF func(policyConfig map[string]plugin.AnalyzerPolicyConfig) error
+	AnnotateF  func(changes []plugin.AnalyzerResourceChange) ([]plugin.AnalyzeAnnotation, error)
 }
 
 var _ = plugin.Analyzer((*Analyzer)(nil))
@@ -70,3 +71,10 @@ func (a *Analyzer) Configure(policyConfig map[string]plugin.AnalyzerPolicyConfig
 	}
 	return nil
 }
+
+func (a *Analyzer) Annotate(changes []plugin.AnalyzerResourceChange) ([]plugin.AnalyzeAnnotation, error) {
+	if a.AnnotateF != nil {
+		return a.AnnotateF(changes)
+	}
+	return nil, nil
+}
@@ -21,6 +21,9 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
@@ -265,6 +268,28 @@ func (se *stepExecutor) executeChain(workerID int, chain chain) {
 	}
 }
 
+// applyStep applies a step within a tracing span that carries the resource's URN, type and operation. The provider
+// calls made on behalf of the resource while the step is applied are parented within the span.
+func (se *stepExecutor) applyStep(step Step) (resource.Status, StepCompleteFunc, error) {
+	span, _ := opentracing.StartSpanFromContext(se.ctx, "pulumi-step", opentracing.Tags{
+		"resource.urn":  string(step.URN()),
+		"resource.type": string(step.Type()),
+		"resource.op":   string(step.Op()),
+		"preview":       se.preview,
+	})
+	defer span.Finish()
+
+	untrace := se.deployment.ctx.TraceResource(step.URN(), span)
+	defer untrace()
+
+	status, stepComplete, err := step.Apply(se.preview)
+	if err != nil {
+		ext.Error.Set(span, true)
+		span.LogKV("event", "error", "message", err.Error())
+	}
+	return status, stepComplete, err
+}
+
 func (se *stepExecutor) cancelDueToError() {
 	se.sawError.Store(true)
 	if !se.continueOnError {
@@ -298,7 +323,7 @@ func (se *stepExecutor) executeStep(workerID int, step Step) error {
 	}
 
 	se.log(workerID, "applying step %v on %v (preview %v)", step.Op(), step.URN(), se.preview)
-	status, stepComplete, err := step.Apply(se.preview)
+	status, stepComplete, err := se.applyStep(step)
 
 	if err == nil {
 		// If we have a state object, and this is a create or update, remember it, as we may need to update it later.
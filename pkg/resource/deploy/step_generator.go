@@ -1907,6 +1907,99 @@ func (sg *stepGenerator) calculateDependentReplacements(root *resource.State) ([
 	return toReplace, nil
 }
 
+// AnnotateSteps sends the logical changes to resources that the given steps propose off to any Analyzers, so that
+// they can annotate them with values like the estimated change in their cost. Annotations are informational, so an
+// Analyzer that fails to annotate the changes is reported as a warning rather than failing the deployment.
+func (sg *stepGenerator) AnnotateSteps(steps []Step) {
+	analyzers := sg.deployment.ctx.Host.ListAnalyzers()
+	if len(analyzers) == 0 {
+		return
+	}
+
+	var changes []plugin.AnalyzerResourceChange
+	var urns []resource.URN
+	for _, step := range steps {
+		switch step.Op() {
+		case OpCreate, OpUpdate, OpReplace, OpDelete, OpImport, OpImportReplacement:
+			urns = append(urns, step.URN())
+			changes = append(changes, plugin.AnalyzerResourceChange{
+				Op:  step.Op(),
+				Old: sg.annotatedResource(step.Old()),
+				New: sg.annotatedResource(step.New()),
+			})
+		}
+	}
+	if len(changes) == 0 {
+		return
+	}
+
+	for _, analyzer := range analyzers {
+		annotations, err := analyzer.Annotate(changes)
+		if err != nil {
+			sg.deployment.ctx.Diag.Warningf(diag.RawMessage("", fmt.Sprintf(
+				"analyzer %s failed to annotate resource changes: %v", analyzer.Name(), err)))
+			continue
+		}
+		for _, a := range annotations {
+			// Analyzers that are asked about a single change may omit the URN that they annotate.
+			urn := a.URN
+			if urn == "" && len(urns) == 1 {
+				urn = urns[0]
+			}
+
+			annotated := false
+			for _, u := range urns {
+				annotated = annotated || u == urn
+			}
+			if !annotated {
+				logging.V(7).Infof("Ignoring annotation %q of unchanged resource %q", a.Label, urn)
+				continue
+			}
+			a.URN = urn
+			sg.opts.Events.OnResourceAnnotation(urn, a)
+		}
+	}
+}
+
+// annotatedResource returns the given state of a resource as it is sent to Analyzers to annotate, or nil if there is
+// no such state.
+func (sg *stepGenerator) annotatedResource(state *resource.State) *plugin.AnalyzerResource {
+	if state == nil {
+		return nil
+	}
+
+	r := &plugin.AnalyzerResource{
+		URN:        state.URN,
+		Type:       state.Type,
+		Name:       state.URN.Name(),
+		Properties: state.Inputs,
+		Options: plugin.AnalyzerResourceOptions{
+			Protect:                 state.Protect,
+			AdditionalSecretOutputs: state.AdditionalSecretOutputs,
+			AliasURNs:               state.Aliases,
+			CustomTimeouts:          state.CustomTimeouts,
+		},
+	}
+
+	// Resources that are being deleted may use providers that the program no longer registers, so look for those
+	// amongst the old resources too.
+	if ref, err := providers.ParseReference(state.Provider); err == nil {
+		providerResource, ok := sg.providers[ref.URN()]
+		if !ok {
+			providerResource = sg.deployment.olds[ref.URN()]
+		}
+		if providerResource != nil {
+			r.Provider = &plugin.AnalyzerProviderResource{
+				URN:        providerResource.URN,
+				Type:       providerResource.Type,
+				Name:       providerResource.URN.Name(),
+				Properties: providerResource.Inputs,
+			}
+		}
+	}
+	return r
+}
+
 func (sg *stepGenerator) AnalyzeResources() result.Result {
 	var resources []plugin.AnalyzerStackResource
 	sg.deployment.news.mapRange(func(urn resource.URN, v *resource.State) bool {
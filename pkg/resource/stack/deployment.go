@@ -25,6 +25,7 @@ import (
 
 	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
 	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/audit"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype/migrate"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
@@ -243,8 +244,9 @@ func DeserializeDeploymentV3(
 			collectCiphertexts(&ciphertexts, res.Outputs)
 		}
 
-		// Decrypt the collected secrets and create a decrypter that will use the result as a cache.
-		cache, err := d.BulkDecrypt(ctx, ciphertexts)
+		// Decrypt the collected secrets and create a decrypter that will use the result as a cache. The secrets are
+		// labeled with their state paths in case the secrets manager records its decryptions in an audit log.
+		cache, err := d.BulkDecrypt(audit.WithStateLabels(ctx, deployment.Resources), ciphertexts)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,205 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nbutton23/zxcvbn-go"
+
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+// minKnownSecretLength is the minimum length of a known secret value before it is matched within larger strings.
+// Shorter secrets are only reported when a property's value is exactly the secret, to avoid spurious matches.
+const minKnownSecretLength = 8
+
+const (
+	// maxEntropyCheckLength is the maximum length of a possible secret for entropy checking.
+	maxEntropyCheckLength = 16
+	// entropyThreshold is the total entropy threshold a potential secret needs to pass before being flagged.
+	entropyThreshold = 80.0
+	// entropyPerCharThreshold is the per-char entropy threshold a potential secret needs to pass before being flagged.
+	entropyPerCharThreshold = 3.0
+)
+
+// secretPropertyPattern matches the names of properties that are likely to hold secrets.
+var secretPropertyPattern = regexp.MustCompile("(?i)passwd|pass|password|pwd|secret|token|apikey|api_key|credential")
+
+// credentialPatterns are the formats of well known credentials.
+var credentialPatterns = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"AWS access key ID", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"GitHub token", regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}`)},
+	{"Pulumi access token", regexp.MustCompile(`\bpul-[a-f0-9]{40}\b`)},
+	{"private key", regexp.MustCompile(`-----BEGIN ([A-Z]+ )?PRIVATE KEY-----`)},
+}
+
+// PlaintextSecret is a plaintext value in a deployment that looks like it should have been a secret.
+type PlaintextSecret struct {
+	// URN is the URN of the resource that holds the value.
+	URN resource.URN
+	// Outputs is true if the value is in the resource's outputs, and false if it is in its inputs.
+	Outputs bool
+	// Path is the path of the value within the resource's inputs or outputs.
+	Path resource.PropertyPath
+	// Reason describes why the value looks like a secret.
+	Reason string
+
+	state *resource.State
+}
+
+// Location returns a description of where the value is, e.g. `outputs.password`.
+func (s PlaintextSecret) Location() string {
+	field := "inputs"
+	if s.Outputs {
+		field = "outputs"
+	}
+	return fmt.Sprintf("%s.%s", field, s.Path)
+}
+
+// SecretScanner finds plaintext values in a deployment that look like they should have been secrets: values of the
+// stack's secret configuration, well known credential formats, and high-entropy values of properties whose names
+// suggest that they hold secrets.
+type SecretScanner struct {
+	// KnownSecrets maps plaintext secret values, such as the stack's decrypted secret configuration, to a description
+	// of where they come from.
+	KnownSecrets map[string]string
+}
+
+// Scan returns the plaintext values in the given snapshot that look like secrets. Values that are already marked as
+// secret are not reported.
+func (sc *SecretScanner) Scan(snap *deploy.Snapshot) []PlaintextSecret {
+	if snap == nil {
+		return nil
+	}
+
+	// Check longer secrets first, so that a secret that contains another is reported as itself.
+	known := make([]string, 0, len(sc.KnownSecrets))
+	for v := range sc.KnownSecrets {
+		if v != "" {
+			known = append(known, v)
+		}
+	}
+	sort.Slice(known, func(i, j int) bool {
+		if len(known[i]) != len(known[j]) {
+			return len(known[i]) > len(known[j])
+		}
+		return known[i] < known[j]
+	})
+
+	var results []PlaintextSecret
+	for _, res := range snap.Resources {
+		for _, outputs := range []bool{false, true} {
+			props := res.Inputs
+			if outputs {
+				props = res.Outputs
+			}
+			sc.walk(resource.NewObjectProperty(props), nil, "", known, func(path resource.PropertyPath, reason string) {
+				results = append(results, PlaintextSecret{
+					URN:     res.URN,
+					Outputs: outputs,
+					Path:    path,
+					Reason:  reason,
+					state:   res,
+				})
+			})
+		}
+	}
+	return results
+}
+
+func (sc *SecretScanner) walk(v resource.PropertyValue, path resource.PropertyPath, name string, known []string,
+	report func(path resource.PropertyPath, reason string),
+) {
+	switch {
+	case v.IsObject():
+		keys := v.ObjectValue().StableKeys()
+		for _, k := range keys {
+			sc.walk(v.ObjectValue()[k], append(copyPath(path), string(k)), string(k), known, report)
+		}
+	case v.IsArray():
+		for i, e := range v.ArrayValue() {
+			sc.walk(e, append(copyPath(path), i), name, known, report)
+		}
+	case v.IsString():
+		if reason, ok := sc.check(name, v.StringValue(), known); ok {
+			report(path, reason)
+		}
+	}
+	// Secrets, computed values and other kinds of values are never reported.
+}
+
+// check returns the reason why a string value of the property with the given name looks like a secret, if it does.
+func (sc *SecretScanner) check(name, s string, known []string) (string, bool) {
+	for _, secret := range known {
+		if s == secret || (len(secret) >= minKnownSecretLength && strings.Contains(s, secret)) {
+			return fmt.Sprintf("contains the value of %s", sc.KnownSecrets[secret]), true
+		}
+	}
+
+	for _, p := range credentialPatterns {
+		if p.pattern.MatchString(s) {
+			return fmt.Sprintf("matches the %s format", p.name), true
+		}
+	}
+
+	if name != "" && secretPropertyPattern.MatchString(name) && isHighEntropy(s) {
+		return "is a high-entropy value of a property that looks like it holds a secret", true
+	}
+
+	return "", false
+}
+
+func isHighEntropy(s string) bool {
+	if s == "" {
+		return false
+	}
+	if len(s) > maxEntropyCheckLength {
+		s = s[:maxEntropyCheckLength]
+	}
+	info := zxcvbn.PasswordStrength(s, nil)
+	entropyPerChar := info.Entropy / float64(len(s))
+	return info.Entropy >= entropyThreshold ||
+		(info.Entropy >= (entropyThreshold/2) && entropyPerChar >= entropyPerCharThreshold)
+}
+
+func copyPath(path resource.PropertyPath) resource.PropertyPath {
+	return append(make(resource.PropertyPath, 0, len(path)+1), path...)
+}
+
+// MarkSecret marks the plaintext value as a secret in the snapshot it was found in.
+func (s PlaintextSecret) MarkSecret() bool {
+	if s.state == nil {
+		return false
+	}
+	props := s.state.Inputs
+	if s.Outputs {
+		props = s.state.Outputs
+	}
+	root := resource.NewObjectProperty(props)
+	v, ok := s.Path.Get(root)
+	if !ok || v.IsSecret() {
+		return false
+	}
+	return s.Path.Set(root, resource.MakeSecret(v))
+}
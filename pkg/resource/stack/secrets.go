@@ -20,9 +20,11 @@ import (
 	"fmt"
 
 	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/age"
 	"github.com/pulumi/pulumi/pkg/v3/secrets/b64"
 	"github.com/pulumi/pulumi/pkg/v3/secrets/cloud"
 	"github.com/pulumi/pulumi/pkg/v3/secrets/passphrase"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/secretsplugin"
 	"github.com/pulumi/pulumi/pkg/v3/secrets/service"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
@@ -50,6 +52,10 @@ func (defaultSecretsProvider) OfType(ty string, state json.RawMessage) (secrets.
 		sm, err = service.NewServiceSecretsManagerFromState(state)
 	case cloud.Type:
 		sm, err = cloud.NewCloudSecretsManagerFromState(state)
+	case age.Type:
+		sm, err = age.NewAgeSecretsManagerFromState(state)
+	case secretsplugin.Type:
+		sm, err = secretsplugin.NewPluginSecretsManagerFromState(state)
 	default:
 		return nil, fmt.Errorf("no known secrets provider for type %q", ty)
 	}
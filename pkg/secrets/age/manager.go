@@ -0,0 +1,246 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package age implements a secrets manager that encrypts the stack's data key for one or more age X25519
+// recipients, so that secrets can be managed offline with key files rather than a shared passphrase or a cloud key
+// management service.
+package age
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	netUrl "net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// Type is the type of secrets managed by this secrets provider
+const Type = "age"
+
+// Scheme is the URL scheme of the age secrets provider, e.g. `age://?recipient=age1...&recipient=age1...`.
+const Scheme = "age"
+
+// IdentityEnvVar is the environment variable that lists the age identity files used to decrypt the data key,
+// separated by the OS path list separator.
+const IdentityEnvVar = "PULUMI_AGE_IDENTITY"
+
+type ageSecretsManagerState struct {
+	Recipients   []string `json:"recipients"`
+	EncryptedKey []byte   `json:"encryptedkey"`
+}
+
+// IsAgeSecretsProvider returns true if the given secrets provider URL refers to the age secrets provider.
+func IsAgeSecretsProvider(secretsProvider string) bool {
+	return strings.HasPrefix(secretsProvider, Scheme+"://")
+}
+
+// ParseRecipients returns the recipients listed by the `recipient` query parameters of an age secrets provider URL.
+func ParseRecipients(url string) ([]string, error) {
+	u, err := netUrl.Parse(url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the secrets provider URL: %w", err)
+	}
+	if u.Scheme != Scheme {
+		return nil, fmt.Errorf("expected a secrets provider URL with scheme %q, got %q", Scheme, u.Scheme)
+	}
+
+	var recipients []string
+	for _, r := range u.Query()["recipient"] {
+		for _, s := range strings.Split(r, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				recipients = append(recipients, s)
+			}
+		}
+	}
+	if len(recipients) == 0 {
+		return nil, errors.New("the age secrets provider requires at least one recipient, " +
+			"e.g. age://?recipient=age1...")
+	}
+	return recipients, nil
+}
+
+// wrapDataKey encrypts the data key for each of the given recipients. Any one of their identities can decrypt it.
+func wrapDataKey(dataKey []byte, recipients []string) ([]byte, error) {
+	rs := make([]age.Recipient, len(recipients))
+	for i, s := range recipients {
+		r, err := age.ParseX25519Recipient(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid age recipient %q: %w", s, err)
+		}
+		rs[i] = r
+	}
+
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, rs...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(dataKey); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// loadIdentities reads the identity files listed by PULUMI_AGE_IDENTITY.
+func loadIdentities() ([]age.Identity, error) {
+	paths := os.Getenv(IdentityEnvVar)
+	if paths == "" {
+		return nil, fmt.Errorf("%s must be set to the path of an age identity file to use the stack's secrets",
+			IdentityEnvVar)
+	}
+
+	var identities []age.Identity
+	for _, path := range filepath.SplitList(paths) {
+		if path == "" {
+			continue
+		}
+		ids, err := readIdentityFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading age identity file %q: %w", path, err)
+		}
+		identities = append(identities, ids...)
+	}
+	return identities, nil
+}
+
+func readIdentityFile(path string) ([]age.Identity, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return age.ParseIdentities(f)
+}
+
+// unwrapDataKey decrypts the data key with any of the identities listed by PULUMI_AGE_IDENTITY.
+func unwrapDataKey(encryptedKey []byte) ([]byte, error) {
+	identities, err := loadIdentities()
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := age.Decrypt(bytes.NewReader(encryptedKey), identities...)
+	if err != nil {
+		var noMatch *age.NoIdentityMatchError
+		if errors.As(err, &noMatch) {
+			return nil, fmt.Errorf("none of the identities in %s is a recipient of the stack's data key", IdentityEnvVar)
+		}
+		return nil, fmt.Errorf("decrypting the data key: %w", err)
+	}
+	return io.ReadAll(r)
+}
+
+// newAgeSecretsManager returns a secrets manager that uses the given plaintext data key for envelope encryption of
+// secrets values, and records the data key wrapped for the given recipients in its state.
+func newAgeSecretsManager(recipients []string, encryptedDataKey, plaintextDataKey []byte) (*Manager, error) {
+	state, err := json.Marshal(ageSecretsManagerState{
+		Recipients:   recipients,
+		EncryptedKey: encryptedDataKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshalling state: %w", err)
+	}
+	return &Manager{
+		crypter: config.NewSymmetricCrypter(plaintextDataKey),
+		state:   state,
+	}, nil
+}
+
+// Manager is the secrets.Manager implementation for age recipients.
+type Manager struct {
+	state   json.RawMessage
+	crypter config.Crypter
+}
+
+func (m *Manager) Type() string                         { return Type }
+func (m *Manager) State() json.RawMessage               { return m.state }
+func (m *Manager) Encrypter() (config.Encrypter, error) { return m.crypter, nil }
+func (m *Manager) Decrypter() (config.Decrypter, error) { return m.crypter, nil }
+
+// NewAgeSecretsManagerFromState deserializes configuration from state and returns a secrets manager that decrypts
+// the data key with the identities listed by PULUMI_AGE_IDENTITY.
+func NewAgeSecretsManagerFromState(state json.RawMessage) (secrets.Manager, error) {
+	var s ageSecretsManagerState
+	if err := json.Unmarshal(state, &s); err != nil {
+		return nil, fmt.Errorf("unmarshalling state: %w", err)
+	}
+
+	dataKey, err := unwrapDataKey(s.EncryptedKey)
+	if err != nil {
+		return nil, err
+	}
+	return newAgeSecretsManager(s.Recipients, s.EncryptedKey, dataKey)
+}
+
+// NewAgeSecretsManager returns a secrets manager for the recipients listed in the given age secrets provider URL.
+//
+// If the stack already uses the age secrets provider and isn't rotating its secrets provider, the existing data key
+// is kept and only re-wrapped for the new recipients, so that adding or removing a recipient doesn't require
+// re-encrypting any secret values. Note that a removed recipient that has already seen the data key can still
+// decrypt the stack's secrets until the data key is rotated.
+func NewAgeSecretsManager(info *workspace.ProjectStack,
+	secretsProvider string, rotateSecretsProvider bool,
+) (secrets.Manager, error) {
+	recipients, err := ParseRecipients(secretsProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only a passphrase provider has an encryption salt, and only a cloud provider has key wrappers, so they must be
+	// removed when changing to age.
+	info.EncryptionSalt = ""
+	info.KeyWrappers = nil
+	info.SecretsProviderState = ""
+
+	var dataKey, encryptedKey []byte
+	if !rotateSecretsProvider && info.EncryptedKey != "" && IsAgeSecretsProvider(info.SecretsProvider) {
+		if encryptedKey, err = base64.StdEncoding.DecodeString(info.EncryptedKey); err != nil {
+			return nil, err
+		}
+		if dataKey, err = unwrapDataKey(encryptedKey); err != nil {
+			return nil, err
+		}
+	} else {
+		dataKey = make([]byte, 32)
+		if _, err := rand.Read(dataKey); err != nil {
+			return nil, err
+		}
+	}
+
+	// Wrap the data key if it is new or its recipients have changed.
+	if encryptedKey == nil || info.SecretsProvider != secretsProvider {
+		if encryptedKey, err = wrapDataKey(dataKey, recipients); err != nil {
+			return nil, err
+		}
+		info.EncryptedKey = base64.StdEncoding.EncodeToString(encryptedKey)
+	}
+	info.SecretsProvider = secretsProvider
+
+	return newAgeSecretsManager(recipients, encryptedKey, dataKey)
+}
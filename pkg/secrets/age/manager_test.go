@@ -0,0 +1,175 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package age
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// newIdentity generates an identity and writes it to an identity file, returning the identity and the file's path.
+func newIdentity(t *testing.T) (*age.X25519Identity, string) {
+	id, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "key.txt")
+	err = os.WriteFile(path, []byte("# test identity\n"+id.String()+"\n"), 0o600)
+	require.NoError(t, err)
+	return id, path
+}
+
+func providerURL(ids ...*age.X25519Identity) string {
+	url := "age://?"
+	for i, id := range ids {
+		if i > 0 {
+			url += "&"
+		}
+		url += "recipient=" + id.Recipient().String()
+	}
+	return url
+}
+
+func encrypt(t *testing.T, sm secrets.Manager, plaintext string) string {
+	enc, err := sm.Encrypter()
+	require.NoError(t, err)
+	ciphertext, err := enc.EncryptValue(context.Background(), plaintext)
+	require.NoError(t, err)
+	return ciphertext
+}
+
+func decrypt(t *testing.T, sm secrets.Manager, ciphertext string) string {
+	dec, err := sm.Decrypter()
+	require.NoError(t, err)
+	plaintext, err := dec.DecryptValue(context.Background(), ciphertext)
+	require.NoError(t, err)
+	return plaintext
+}
+
+//nolint:paralleltest // mutates environment variables
+func TestAgeSecretsManagerRoundtrip(t *testing.T) {
+	alice, alicePath := newIdentity(t)
+	bob, bobPath := newIdentity(t)
+
+	info := &workspace.ProjectStack{
+		EncryptionSalt: "salt",
+		KeyWrappers:    []workspace.KeyWrapper{{SecretsProvider: "hashivault://escrow", EncryptedKey: "a2V5"}},
+	}
+	sm, err := NewAgeSecretsManager(info, providerURL(alice, bob), false)
+	require.NoError(t, err)
+	assert.Equal(t, Type, sm.Type())
+	assert.Equal(t, providerURL(alice, bob), info.SecretsProvider)
+	assert.NotEmpty(t, info.EncryptedKey)
+	assert.Empty(t, info.EncryptionSalt)
+	assert.Empty(t, info.KeyWrappers)
+
+	ciphertext := encrypt(t, sm, "hunter2")
+
+	// Either recipient can decrypt the data key from the manager's state.
+	for _, path := range []string{alicePath, bobPath} {
+		t.Setenv(IdentityEnvVar, path)
+		fromState, err := NewAgeSecretsManagerFromState(sm.State())
+		require.NoError(t, err)
+		assert.Equal(t, "hunter2", decrypt(t, fromState, ciphertext))
+	}
+
+	// Loading the stack again doesn't change its configuration.
+	encryptedKey := info.EncryptedKey
+	_, err = NewAgeSecretsManager(info, info.SecretsProvider, false)
+	require.NoError(t, err)
+	assert.Equal(t, encryptedKey, info.EncryptedKey)
+}
+
+//nolint:paralleltest // mutates environment variables
+func TestAgeSecretsManagerChangeRecipients(t *testing.T) {
+	alice, alicePath := newIdentity(t)
+	bob, bobPath := newIdentity(t)
+	carol, carolPath := newIdentity(t)
+
+	info := &workspace.ProjectStack{}
+	sm, err := NewAgeSecretsManager(info, providerURL(alice, bob), false)
+	require.NoError(t, err)
+	ciphertext := encrypt(t, sm, "hunter2")
+
+	// Replace bob with carol. The data key is kept, so existing ciphertexts can still be decrypted.
+	t.Setenv(IdentityEnvVar, alicePath)
+	sm, err = NewAgeSecretsManager(info, providerURL(alice, carol), false)
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", decrypt(t, sm, ciphertext))
+
+	t.Setenv(IdentityEnvVar, carolPath)
+	fromState, err := NewAgeSecretsManagerFromState(sm.State())
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", decrypt(t, fromState, ciphertext))
+
+	t.Setenv(IdentityEnvVar, bobPath)
+	_, err = NewAgeSecretsManagerFromState(sm.State())
+	assert.ErrorContains(t, err, "none of the identities in PULUMI_AGE_IDENTITY is a recipient")
+}
+
+//nolint:paralleltest // mutates environment variables
+func TestAgeSecretsManagerRotate(t *testing.T) {
+	alice, alicePath := newIdentity(t)
+	t.Setenv(IdentityEnvVar, alicePath)
+
+	info := &workspace.ProjectStack{}
+	sm, err := NewAgeSecretsManager(info, providerURL(alice), false)
+	require.NoError(t, err)
+	ciphertext := encrypt(t, sm, "hunter2")
+
+	rotated, err := NewAgeSecretsManager(info, providerURL(alice), true)
+	require.NoError(t, err)
+	dec, err := rotated.Decrypter()
+	require.NoError(t, err)
+	_, err = dec.DecryptValue(context.Background(), ciphertext)
+	assert.Error(t, err)
+}
+
+//nolint:paralleltest // mutates environment variables
+func TestAgeSecretsManagerMissingIdentity(t *testing.T) {
+	alice, _ := newIdentity(t)
+
+	info := &workspace.ProjectStack{}
+	sm, err := NewAgeSecretsManager(info, providerURL(alice), false)
+	require.NoError(t, err)
+
+	t.Setenv(IdentityEnvVar, "")
+	_, err = NewAgeSecretsManagerFromState(sm.State())
+	assert.ErrorContains(t, err, "PULUMI_AGE_IDENTITY must be set")
+}
+
+func TestParseRecipients(t *testing.T) {
+	t.Parallel()
+
+	recipients, err := ParseRecipients("age://?recipient=age1a&recipient=age1b,age1c")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"age1a", "age1b", "age1c"}, recipients)
+
+	_, err = ParseRecipients("age://")
+	assert.ErrorContains(t, err, "requires at least one recipient")
+
+	_, err = ParseRecipients("awskms://alias/test")
+	assert.ErrorContains(t, err, `expected a secrets provider URL with scheme "age"`)
+
+	_, err = NewAgeSecretsManager(&workspace.ProjectStack{}, "age://?recipient=nope", false)
+	assert.ErrorContains(t, err, `invalid age recipient "nope"`)
+}
@@ -0,0 +1,103 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit records which secrets of a stack are decrypted by which operations. The audit log never contains the
+// values of the secrets, only the names of the config keys and the paths of the state properties that hold them.
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+// SyslogSpec is the audit log destination that sends events to the local syslog daemon.
+const SyslogSpec = "syslog"
+
+// Event records that an operation decrypted some of the secrets of a stack.
+type Event struct {
+	// Time is when the secrets were decrypted.
+	Time time.Time `json:"time"`
+	// User is the name of the local user that ran the operation.
+	User string `json:"user"`
+	// Stack is the fully qualified name of the stack.
+	Stack string `json:"stack"`
+	// Operation is the command that decrypted the secrets, e.g. `pulumi up`.
+	Operation string `json:"operation"`
+	// ConfigKeys are the configuration keys whose secret values were decrypted.
+	ConfigKeys []string `json:"configKeys,omitempty"`
+	// EnvironmentVariables are the stack environment variables whose secret values were decrypted.
+	EnvironmentVariables []string `json:"environmentVariables,omitempty"`
+	// StatePaths are the paths of the secret state properties that were decrypted, e.g. `<urn>#outputs.password`.
+	StatePaths []string `json:"statePaths,omitempty"`
+	// Unlabeled is the number of decrypted secrets whose config key or state path is not known, e.g. the secrets of
+	// the pending operations of a stack.
+	Unlabeled int `json:"unlabeled,omitempty"`
+}
+
+// Sink is a destination for audit events.
+type Sink interface {
+	// Record writes an event to the audit log.
+	Record(event Event) error
+	// Close flushes and closes the audit log.
+	Close() error
+}
+
+// OpenSink opens the audit log with the given destination, which is either "syslog" for the local syslog daemon, or
+// the path of a file to which events are appended as JSON lines.
+func OpenSink(spec string) (Sink, error) {
+	if spec == SyslogSpec {
+		return newSyslogSink()
+	}
+	return NewFileSink(spec)
+}
+
+type fileSink struct {
+	m    sync.Mutex
+	file *os.File
+}
+
+// NewFileSink returns a sink that appends each event to the file at the given path as a line of JSON.
+func NewFileSink(path string) (Sink, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening secrets audit log: %w", err)
+	}
+	return &fileSink{file: f}, nil
+}
+
+func (s *fileSink) Record(event Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	_, err = s.file.Write(append(line, '\n'))
+	return err
+}
+
+func (s *fileSink) Close() error {
+	return s.file.Close()
+}
+
+// StatePath returns the audit path of a property of a resource, e.g. `<urn>#outputs.password`.
+func StatePath(urn resource.URN, field string, path resource.PropertyPath) string {
+	return fmt.Sprintf("%s#%s.%s", urn, field, path)
+}
@@ -0,0 +1,58 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSink(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	when := time.Date(2023, 6, 25, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 2; i++ {
+		sink, err := OpenSink(path)
+		require.NoError(t, err)
+		require.NoError(t, sink.Record(Event{
+			Time:       when,
+			User:       "alice",
+			Stack:      "org/proj/dev",
+			Operation:  "pulumi config get",
+			ConfigKeys: []string{"proj:dbPassword"},
+		}))
+		require.NoError(t, sink.Close())
+	}
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	require.Len(t, lines, 2)
+	assert.JSONEq(t, `{
+		"time": "2023-06-25T12:00:00Z",
+		"user": "alice",
+		"stack": "org/proj/dev",
+		"operation": "pulumi config get",
+		"configKeys": ["proj:dbPassword"]
+	}`, lines[1])
+}
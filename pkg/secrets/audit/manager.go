@@ -0,0 +1,212 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// Label names a secret in the audit log. One of ConfigKey, EnvironmentVariable or StatePath is set.
+type Label struct {
+	// ConfigKey is the configuration key that holds the secret.
+	ConfigKey string
+	// EnvironmentVariable is the name of the stack environment variable that holds the secret.
+	EnvironmentVariable string
+	// URN is the resource whose state holds the secret.
+	URN resource.URN
+	// StatePath is the path of the state property that holds the secret, e.g. `<urn>#outputs.password`.
+	StatePath string
+}
+
+// Labels maps the ciphertexts of secrets to the labels that name them in the audit log. It is safe for concurrent
+// use.
+type Labels struct {
+	m      sync.Mutex
+	labels map[string][]Label
+}
+
+// NewLabels returns an empty set of labels.
+func NewLabels() *Labels {
+	return &Labels{labels: map[string][]Label{}}
+}
+
+// AddConfig labels the secret values of the given configuration with their keys.
+func (l *Labels) AddConfig(cfg config.Map) {
+	l.m.Lock()
+	defer l.m.Unlock()
+	for k, v := range cfg {
+		if !v.Secure() || v.Object() {
+			continue
+		}
+		if ciphertext, err := v.Value(config.NopDecrypter); err == nil {
+			l.labels[ciphertext] = append(l.labels[ciphertext], Label{ConfigKey: k.String()})
+		}
+	}
+}
+
+// AddEnvironment labels the secret values of the given stack environment with the names of their variables.
+func (l *Labels) AddEnvironment(env *workspace.ProjectEnvironment) {
+	if env == nil {
+		return
+	}
+	l.m.Lock()
+	defer l.m.Unlock()
+	for name, v := range env.Variables {
+		if !v.Secure() || v.Object() {
+			continue
+		}
+		if ciphertext, err := v.Value(config.NopDecrypter); err == nil {
+			l.labels[ciphertext] = append(l.labels[ciphertext], Label{EnvironmentVariable: name})
+		}
+	}
+}
+
+func (l *Labels) get(ciphertext string) []Label {
+	l.m.Lock()
+	defer l.m.Unlock()
+	return l.labels[ciphertext]
+}
+
+type stateLabelsKey struct{}
+
+// stateLabels lazily labels the secrets in the state of some resources, so that the state is only walked if its
+// secrets are decrypted by an audited secrets manager.
+type stateLabels struct {
+	once      sync.Once
+	resources []apitype.ResourceV3
+	labels    map[string][]Label
+}
+
+func (l *stateLabels) get(ciphertext string) []Label {
+	l.once.Do(func() {
+		l.labels = map[string][]Label{}
+		for _, res := range l.resources {
+			collectStateLabels(l.labels, res.URN, "inputs", nil, res.Inputs)
+			collectStateLabels(l.labels, res.URN, "outputs", nil, res.Outputs)
+		}
+	})
+	return l.labels[ciphertext]
+}
+
+// WithStateLabels returns a context that labels the secrets in the serialized state of the given resources with
+// their state paths, for use when the state is deserialized.
+func WithStateLabels(ctx context.Context, resources []apitype.ResourceV3) context.Context {
+	return context.WithValue(ctx, stateLabelsKey{}, &stateLabels{resources: resources})
+}
+
+func collectStateLabels(labels map[string][]Label, urn resource.URN, field string, path resource.PropertyPath,
+	prop interface{},
+) {
+	switch prop := prop.(type) {
+	case []interface{}:
+		for i, v := range prop {
+			collectStateLabels(labels, urn, field, append(append(resource.PropertyPath{}, path...), i), v)
+		}
+	case map[string]interface{}:
+		if prop[resource.SigKey] == resource.SecretSig {
+			if ciphertext, ok := prop["ciphertext"].(string); ok {
+				labels[ciphertext] = append(labels[ciphertext], Label{URN: urn, StatePath: StatePath(urn, field, path)})
+			}
+			return
+		}
+		for k, v := range prop {
+			collectStateLabels(labels, urn, field, append(append(resource.PropertyPath{}, path...), k), v)
+		}
+	}
+}
+
+// Recorder is called after a secrets manager decrypts some secrets, with the labels of the decrypted secrets and the
+// number of decrypted secrets that have no label.
+type Recorder func(labels []Label, unlabeled int)
+
+// NewManager returns a secrets manager that calls record for every secret that the given secrets manager decrypts.
+// The secrets are named by the given labels, or by the state labels in the context of the decryption, never by their
+// values.
+func NewManager(sm secrets.Manager, labels *Labels, record Recorder) secrets.Manager {
+	return &manager{manager: sm, labels: labels, record: record}
+}
+
+type manager struct {
+	manager secrets.Manager
+	labels  *Labels
+	record  Recorder
+}
+
+func (m *manager) Type() string                         { return m.manager.Type() }
+func (m *manager) State() json.RawMessage               { return m.manager.State() }
+func (m *manager) Encrypter() (config.Encrypter, error) { return m.manager.Encrypter() }
+
+func (m *manager) Decrypter() (config.Decrypter, error) {
+	dec, err := m.manager.Decrypter()
+	if err != nil {
+		return nil, err
+	}
+	return &decrypter{decrypter: dec, manager: m}, nil
+}
+
+type decrypter struct {
+	decrypter config.Decrypter
+	manager   *manager
+}
+
+func (d *decrypter) DecryptValue(ctx context.Context, ciphertext string) (string, error) {
+	v, err := d.decrypter.DecryptValue(ctx, ciphertext)
+	if err != nil {
+		return "", err
+	}
+	d.decrypted(ctx, []string{ciphertext})
+	return v, nil
+}
+
+func (d *decrypter) BulkDecrypt(ctx context.Context, ciphertexts []string) (map[string]string, error) {
+	plaintexts, err := d.decrypter.BulkDecrypt(ctx, ciphertexts)
+	if err != nil {
+		return nil, err
+	}
+	d.decrypted(ctx, ciphertexts)
+	return plaintexts, nil
+}
+
+func (d *decrypter) decrypted(ctx context.Context, ciphertexts []string) {
+	if len(ciphertexts) == 0 {
+		return
+	}
+	state, _ := ctx.Value(stateLabelsKey{}).(*stateLabels)
+
+	var labels []Label
+	var unlabeled int
+	for _, ciphertext := range ciphertexts {
+		var found []Label
+		if d.manager.labels != nil {
+			found = append(found, d.manager.labels.get(ciphertext)...)
+		}
+		if state != nil {
+			found = append(found, state.get(ciphertext)...)
+		}
+		if len(found) == 0 {
+			unlabeled++
+		}
+		labels = append(labels, found...)
+	}
+	d.manager.record(labels, unlabeled)
+}
@@ -0,0 +1,112 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/secrets/b64"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+type recorded struct {
+	labels    []Label
+	unlabeled int
+}
+
+func newRecordingManager(labels *Labels) (config.Decrypter, *[]recorded, error) {
+	var events []recorded
+	sm := NewManager(b64.NewBase64SecretsManager(), labels, func(labels []Label, unlabeled int) {
+		events = append(events, recorded{labels: labels, unlabeled: unlabeled})
+	})
+	dec, err := sm.Decrypter()
+	return dec, &events, err
+}
+
+func encrypt(plaintext string) string {
+	return base64.StdEncoding.EncodeToString([]byte(plaintext))
+}
+
+func TestManagerConfigLabels(t *testing.T) {
+	t.Parallel()
+
+	labels := NewLabels()
+	labels.AddConfig(config.Map{
+		config.MustMakeKey("proj", "dbPassword"): config.NewSecureValue(encrypt("hunter2")),
+		config.MustMakeKey("proj", "region"):     config.NewValue("us-west-2"),
+	})
+	labels.AddEnvironment(&workspace.ProjectEnvironment{Variables: map[string]config.Value{
+		"API_TOKEN": config.NewSecureValue(encrypt("token")),
+	}})
+
+	dec, events, err := newRecordingManager(labels)
+	require.NoError(t, err)
+
+	v, err := dec.DecryptValue(context.Background(), encrypt("hunter2"))
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", v)
+
+	_, err = dec.BulkDecrypt(context.Background(), []string{encrypt("token"), encrypt("other")})
+	require.NoError(t, err)
+
+	assert.Equal(t, []recorded{
+		{labels: []Label{{ConfigKey: "proj:dbPassword"}}},
+		{labels: []Label{{EnvironmentVariable: "API_TOKEN"}}, unlabeled: 1},
+	}, *events)
+}
+
+func TestManagerStateLabels(t *testing.T) {
+	t.Parallel()
+
+	urn := resource.URN("urn:pulumi:dev::proj::db:Instance::main")
+	secret := func(plaintext string) map[string]interface{} {
+		return map[string]interface{}{resource.SigKey: resource.SecretSig, "ciphertext": encrypt(plaintext)}
+	}
+	ctx := WithStateLabels(context.Background(), []apitype.ResourceV3{{
+		URN: urn,
+		Inputs: map[string]interface{}{
+			"password": secret("p"),
+			"name":     "main",
+		},
+		Outputs: map[string]interface{}{
+			"users": []interface{}{
+				map[string]interface{}{"token": secret("t")},
+			},
+		},
+	}})
+
+	dec, events, err := newRecordingManager(nil)
+	require.NoError(t, err)
+	_, err = dec.BulkDecrypt(ctx, []string{encrypt("p"), encrypt("t")})
+	require.NoError(t, err)
+
+	assert.Equal(t, []recorded{{labels: []Label{
+		{URN: urn, StatePath: "urn:pulumi:dev::proj::db:Instance::main#inputs.password"},
+		{URN: urn, StatePath: "urn:pulumi:dev::proj::db:Instance::main#outputs.users[0].token"},
+	}}}, *events)
+
+	// Decryptions outside of the deserialization of the state aren't labeled with its paths.
+	_, err = dec.DecryptValue(context.Background(), encrypt("p"))
+	require.NoError(t, err)
+	assert.Equal(t, recorded{unlabeled: 1}, (*events)[1])
+}
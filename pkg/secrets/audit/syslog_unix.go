@@ -0,0 +1,49 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/syslog"
+)
+
+type syslogSink struct {
+	w *syslog.Writer
+}
+
+// newSyslogSink returns a sink that sends each event to the local syslog daemon as a JSON message, with the auth
+// facility so that it ends up with other security relevant logs.
+func newSyslogSink() (Sink, error) {
+	w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_AUTH, "pulumi")
+	if err != nil {
+		return nil, fmt.Errorf("connecting to syslog: %w", err)
+	}
+	return &syslogSink{w: w}, nil
+}
+
+func (s *syslogSink) Record(event Event) error {
+	msg, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return s.w.Info(string(msg))
+}
+
+func (s *syslogSink) Close() error {
+	return s.w.Close()
+}
@@ -20,10 +20,12 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	netUrl "net/url"
 	"os"
 
+	"github.com/hashicorp/go-multierror"
 	gosecrets "gocloud.dev/secrets"
 	_ "gocloud.dev/secrets/awskms"        // support for awskms://
 	_ "gocloud.dev/secrets/azurekeyvault" // support for azurekeyvault://
@@ -33,6 +35,7 @@ import (
 
 	"github.com/pulumi/pulumi/pkg/v3/authhelpers"
 	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/pkg/v3/secrets/passphrase"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 )
@@ -43,6 +46,16 @@ const Type = "cloud"
 type cloudSecretsManagerState struct {
 	URL          string `json:"url"`
 	EncryptedKey []byte `json:"encryptedkey"`
+	// Wrappers are the data key encrypted by other secrets providers, any of which can decrypt it if the primary
+	// secrets provider is unavailable.
+	Wrappers []cloudKeyWrapperState `json:"wrappers,omitempty"`
+}
+
+type cloudKeyWrapperState struct {
+	URL          string `json:"url"`
+	EncryptedKey []byte `json:"encryptedkey"`
+	// Salt is the passphrase state of a passphrase key wrapper.
+	Salt string `json:"salt,omitempty"`
 }
 
 // openKeeper opens the keeper, handling pulumi-specifc cases in the URL.
@@ -73,35 +86,72 @@ func openKeeper(ctx context.Context, url string) (*gosecrets.Keeper, error) {
 	}
 }
 
-// generateNewDataKey generates a new DataKey seeded by a fresh random 32-byte key and encrypted
-// using the target cloud key management service.
-func generateNewDataKey(url string) ([]byte, error) {
+// generateNewDataKey generates a fresh random 32-byte data key.
+func generateNewDataKey() ([]byte, error) {
 	plaintextDataKey := make([]byte, 32)
 	_, err := rand.Read(plaintextDataKey)
 	if err != nil {
 		return nil, err
 	}
-	keeper, err := openKeeper(context.Background(), url)
+	return plaintextDataKey, nil
+}
+
+// encryptDataKey encrypts the data key using the target cloud key management service.
+func encryptDataKey(ctx context.Context, url string, plaintextDataKey []byte) ([]byte, error) {
+	keeper, err := openKeeper(ctx, url)
 	if err != nil {
 		return nil, err
 	}
-	return keeper.Encrypt(context.Background(), plaintextDataKey)
+	return keeper.Encrypt(ctx, plaintextDataKey)
+}
+
+// decryptDataKey decrypts the data key using the target cloud key management service. If that fails, each of the
+// key's wrappers is tried in turn.
+func decryptDataKey(ctx context.Context,
+	url string, encryptedDataKey []byte, wrappers []cloudKeyWrapperState,
+) ([]byte, error) {
+	decrypt := func(url string, encryptedDataKey []byte) ([]byte, error) {
+		keeper, err := openKeeper(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		return keeper.Decrypt(ctx, encryptedDataKey)
+	}
+
+	plaintextDataKey, err := decrypt(url, encryptedDataKey)
+	if err == nil || len(wrappers) == 0 {
+		return plaintextDataKey, err
+	}
+
+	errs := multierror.Append(nil, fmt.Errorf("%s: %w", url, err))
+	for _, w := range wrappers {
+		var plaintextDataKey []byte
+		var err error
+		if w.URL == passphrase.Type {
+			plaintextDataKey, err = passphrase.UnwrapKey(ctx, w.Salt, w.EncryptedKey)
+		} else {
+			plaintextDataKey, err = decrypt(w.URL, w.EncryptedKey)
+		}
+		if err == nil {
+			return plaintextDataKey, nil
+		}
+		errs = multierror.Append(errs, fmt.Errorf("%s: %w", w.URL, err))
+	}
+	return nil, fmt.Errorf("unable to decrypt the data key with the secrets provider or any of its key wrappers: %w",
+		errs)
 }
 
 // newCloudSecretsManager returns a secrets manager that uses the target cloud key management
 // service to encrypt/decrypt a data key used for envelope encryption of secrets values.
-func newCloudSecretsManager(url string, encryptedDataKey []byte) (*Manager, error) {
-	keeper, err := openKeeper(context.Background(), url)
-	if err != nil {
-		return nil, err
-	}
-	plaintextDataKey, err := keeper.Decrypt(context.Background(), encryptedDataKey)
+func newCloudSecretsManager(url string, encryptedDataKey []byte, wrappers []cloudKeyWrapperState) (*Manager, error) {
+	plaintextDataKey, err := decryptDataKey(context.Background(), url, encryptedDataKey, wrappers)
 	if err != nil {
 		return nil, err
 	}
 	state, err := json.Marshal(cloudSecretsManagerState{
 		URL:          url,
 		EncryptedKey: encryptedDataKey,
+		Wrappers:     wrappers,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("marshalling state: %w", err)
@@ -133,7 +183,7 @@ func NewCloudSecretsManagerFromState(state json.RawMessage) (secrets.Manager, er
 		return nil, fmt.Errorf("unmarshalling state: %w", err)
 	}
 
-	return newCloudSecretsManager(s.URL, s.EncryptedKey)
+	return newCloudSecretsManager(s.URL, s.EncryptedKey, s.Wrappers)
 }
 
 func NewCloudSecretsManager(info *workspace.ProjectStack,
@@ -143,6 +193,7 @@ func NewCloudSecretsManager(info *workspace.ProjectStack,
 	// from passphrase to a cloud secrets provider should ensure that we remove the enryptionsalt
 	// as it's a legacy artifact and needs to be removed
 	info.EncryptionSalt = ""
+	info.SecretsProviderState = ""
 
 	var secretsManager *Manager
 
@@ -159,13 +210,31 @@ func NewCloudSecretsManager(info *workspace.ProjectStack,
 	}
 
 	// if there is no key OR the secrets provider is changing
-	// then we need to generate the new key based on the new secrets provider
+	// then we need to generate the new key based on the new secrets provider,
+	// and encrypt it again with each of the key wrappers
 	if info.EncryptedKey == "" || info.SecretsProvider != secretsProvider {
-		dataKey, err := generateNewDataKey(secretsProvider)
+		ctx := context.Background()
+		dataKey, err := generateNewDataKey()
 		if err != nil {
 			return nil, err
 		}
-		info.EncryptedKey = base64.StdEncoding.EncodeToString(dataKey)
+		encryptedKey, err := encryptDataKey(ctx, secretsProvider, dataKey)
+		if err != nil {
+			return nil, err
+		}
+		info.EncryptedKey = base64.StdEncoding.EncodeToString(encryptedKey)
+		for i, w := range info.KeyWrappers {
+			var encryptedKey []byte
+			if w.SecretsProvider == passphrase.Type {
+				encryptedKey, err = passphrase.RewrapKey(ctx, w.EncryptionSalt, dataKey)
+			} else {
+				encryptedKey, err = encryptDataKey(ctx, w.SecretsProvider, dataKey)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("encrypting the data key with key wrapper %s: %w", w.SecretsProvider, err)
+			}
+			info.KeyWrappers[i].EncryptedKey = base64.StdEncoding.EncodeToString(encryptedKey)
+		}
 	}
 	info.SecretsProvider = secretsProvider
 
@@ -173,10 +242,94 @@ func NewCloudSecretsManager(info *workspace.ProjectStack,
 	if err != nil {
 		return nil, err
 	}
-	secretsManager, err = newCloudSecretsManager(secretsProvider, dataKey)
+	wrappers, err := keyWrappersState(info)
+	if err != nil {
+		return nil, err
+	}
+	secretsManager, err = newCloudSecretsManager(secretsProvider, dataKey, wrappers)
 	if err != nil {
 		return nil, err
 	}
 
 	return secretsManager, nil
 }
+
+func keyWrappersState(info *workspace.ProjectStack) ([]cloudKeyWrapperState, error) {
+	var wrappers []cloudKeyWrapperState
+	for _, w := range info.KeyWrappers {
+		encryptedKey, err := base64.StdEncoding.DecodeString(w.EncryptedKey)
+		if err != nil {
+			return nil, fmt.Errorf("decoding the data key of key wrapper %s: %w", w.SecretsProvider, err)
+		}
+		wrappers = append(wrappers, cloudKeyWrapperState{
+			URL:          w.SecretsProvider,
+			EncryptedKey: encryptedKey,
+			Salt:         w.EncryptionSalt,
+		})
+	}
+	return wrappers, nil
+}
+
+// AddKeyWrapper encrypts the stack's data key with another cloud secrets provider, which can then decrypt the
+// stack's secrets if the stack's secrets provider is unavailable, e.g. during a regional outage or after its key has
+// been deleted. The data key itself is unchanged, so no secret values need to be re-encrypted. If url is
+// "passphrase", the data key is instead encrypted with a key derived from a new escrow passphrase.
+func AddKeyWrapper(info *workspace.ProjectStack, url string) error {
+	if info.EncryptedKey == "" {
+		return errors.New("key wrappers can only be added to stacks that use a cloud secrets provider")
+	}
+	if url == info.SecretsProvider {
+		return fmt.Errorf("%s is already the stack's secrets provider", url)
+	}
+	for _, w := range info.KeyWrappers {
+		if w.SecretsProvider == url {
+			return fmt.Errorf("the stack's data key is already wrapped by %s", url)
+		}
+	}
+
+	ctx := context.Background()
+	encryptedKey, err := base64.StdEncoding.DecodeString(info.EncryptedKey)
+	if err != nil {
+		return err
+	}
+	wrappers, err := keyWrappersState(info)
+	if err != nil {
+		return err
+	}
+	dataKey, err := decryptDataKey(ctx, info.SecretsProvider, encryptedKey, wrappers)
+	if err != nil {
+		return err
+	}
+
+	var salt string
+	var wrappedKey []byte
+	if url == passphrase.Type {
+		salt, wrappedKey, err = passphrase.WrapKey(ctx, dataKey)
+	} else {
+		wrappedKey, err = encryptDataKey(ctx, url, dataKey)
+	}
+	if err != nil {
+		return err
+	}
+	info.KeyWrappers = append(info.KeyWrappers, workspace.KeyWrapper{
+		SecretsProvider: url,
+		EncryptedKey:    base64.StdEncoding.EncodeToString(wrappedKey),
+		EncryptionSalt:  salt,
+	})
+	return nil
+}
+
+// RemoveKeyWrapper removes a key wrapper previously added with AddKeyWrapper. Note that this doesn't rotate the data
+// key, so anyone who has already decrypted it with the removed wrapper can still decrypt the stack's secrets.
+func RemoveKeyWrapper(info *workspace.ProjectStack, url string) error {
+	for i, w := range info.KeyWrappers {
+		if w.SecretsProvider == url {
+			info.KeyWrappers = append(info.KeyWrappers[:i], info.KeyWrappers[i+1:]...)
+			if len(info.KeyWrappers) == 0 {
+				info.KeyWrappers = nil
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("the stack's data key is not wrapped by %s", url)
+}
@@ -17,6 +17,8 @@ package cloud
 import (
 	"context"
 	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"net/url"
@@ -33,15 +35,21 @@ import (
 	"github.com/stretchr/testify/require"
 	"gocloud.dev/secrets"
 	"gocloud.dev/secrets/driver"
+	"gocloud.dev/secrets/localsecrets"
+
+	"github.com/pulumi/pulumi/pkg/v3/secrets/passphrase"
+	pconfig "github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
 )
 
 // the main testing function, takes a kms url and tries to make a new secret manager out of it and encrypt and
 // decrypt data
 func testURL(ctx context.Context, t *testing.T, url string) {
-	dataKey, err := generateNewDataKey(url)
+	dataKey, err := generateNewDataKey()
+	require.NoError(t, err)
+	encryptedKey, err := encryptDataKey(ctx, url, dataKey)
 	require.NoError(t, err)
 
-	manager, err := newCloudSecretsManager(url, dataKey)
+	manager, err := newCloudSecretsManager(url, encryptedKey, nil)
 	require.NoError(t, err)
 
 	enc, err := manager.Encrypter()
@@ -294,3 +302,169 @@ func (k dummySecretsKeeper) Decrypt(ctx context.Context, ciphertext []byte) ([]b
 func (k dummySecretsKeeper) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
 	return plaintext, nil
 }
+
+func newLocalKeyURL(t *testing.T) string {
+	key, err := localsecrets.NewRandomKey()
+	require.NoError(t, err)
+	return "base64key://" + base64.URLEncoding.EncodeToString(key[:])
+}
+
+func decryptValue(t *testing.T, manager interface {
+	Decrypter() (pconfig.Decrypter, error)
+}, ciphertext string) string {
+	dec, err := manager.Decrypter()
+	require.NoError(t, err)
+	plaintext, err := dec.DecryptValue(context.Background(), ciphertext)
+	require.NoError(t, err)
+	return plaintext
+}
+
+func TestLocalKeeper(t *testing.T) {
+	t.Parallel()
+
+	testURL(context.Background(), t, newLocalKeyURL(t))
+}
+
+func TestKeyWrappers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	primary, escrow, lost := newLocalKeyURL(t), newLocalKeyURL(t), newLocalKeyURL(t)
+
+	info := &workspace.ProjectStack{}
+	manager, err := NewCloudSecretsManager(info, primary, false)
+	require.NoError(t, err)
+	enc, err := manager.Encrypter()
+	require.NoError(t, err)
+	ciphertext, err := enc.EncryptValue(ctx, "plaintext")
+	require.NoError(t, err)
+
+	// Adding a wrapper doesn't change the data key.
+	encryptedKey := info.EncryptedKey
+	require.NoError(t, AddKeyWrapper(info, escrow))
+	assert.Equal(t, encryptedKey, info.EncryptedKey)
+	require.Len(t, info.KeyWrappers, 1)
+	assert.Equal(t, escrow, info.KeyWrappers[0].SecretsProvider)
+
+	manager, err = NewCloudSecretsManager(info, primary, false)
+	require.NoError(t, err)
+	assert.Equal(t, "plaintext", decryptValue(t, manager, ciphertext))
+
+	// If the primary key can't decrypt the data key, the wrapper is used instead.
+	var state cloudSecretsManagerState
+	require.NoError(t, json.Unmarshal(manager.State(), &state))
+	require.Len(t, state.Wrappers, 1)
+	state.URL = lost
+	lostState, err := json.Marshal(state)
+	require.NoError(t, err)
+	fromState, err := NewCloudSecretsManagerFromState(lostState)
+	require.NoError(t, err)
+	assert.Equal(t, "plaintext", decryptValue(t, fromState, ciphertext))
+
+	// Rotating the data key encrypts the new key with the wrapper too.
+	manager, err = NewCloudSecretsManager(info, primary, true)
+	require.NoError(t, err)
+	enc, err = manager.Encrypter()
+	require.NoError(t, err)
+	ciphertext, err = enc.EncryptValue(ctx, "rotated")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(manager.State(), &state))
+	state.URL = lost
+	lostState, err = json.Marshal(state)
+	require.NoError(t, err)
+	fromState, err = NewCloudSecretsManagerFromState(lostState)
+	require.NoError(t, err)
+	assert.Equal(t, "rotated", decryptValue(t, fromState, ciphertext))
+
+	// Without the wrapper the data key can't be recovered.
+	require.NoError(t, RemoveKeyWrapper(info, escrow))
+	assert.Nil(t, info.KeyWrappers)
+	manager, err = NewCloudSecretsManager(info, primary, false)
+	require.NoError(t, err)
+	state = cloudSecretsManagerState{}
+	require.NoError(t, json.Unmarshal(manager.State(), &state))
+	assert.Empty(t, state.Wrappers)
+	state.URL = lost
+	lostState, err = json.Marshal(state)
+	require.NoError(t, err)
+	_, err = NewCloudSecretsManagerFromState(lostState)
+	assert.Error(t, err)
+}
+
+func TestKeyWrapperErrors(t *testing.T) {
+	t.Parallel()
+
+	primary, escrow := newLocalKeyURL(t), newLocalKeyURL(t)
+
+	err := AddKeyWrapper(&workspace.ProjectStack{}, escrow)
+	assert.ErrorContains(t, err, "only be added to stacks that use a cloud secrets provider")
+
+	info := &workspace.ProjectStack{}
+	_, err = NewCloudSecretsManager(info, primary, false)
+	require.NoError(t, err)
+
+	err = AddKeyWrapper(info, primary)
+	assert.ErrorContains(t, err, "is already the stack's secrets provider")
+
+	require.NoError(t, AddKeyWrapper(info, escrow))
+	err = AddKeyWrapper(info, escrow)
+	assert.ErrorContains(t, err, "is already wrapped by")
+
+	err = RemoveKeyWrapper(info, primary)
+	assert.ErrorContains(t, err, "is not wrapped by")
+}
+
+//nolint:paralleltest // mutates environment variables
+func TestPassphraseKeyWrapper(t *testing.T) {
+	t.Setenv("PULUMI_CONFIG_PASSPHRASE", "escrow")
+	t.Setenv("PULUMI_CONFIG_PASSPHRASE_FILE", "")
+
+	ctx := context.Background()
+	primary, lost := newLocalKeyURL(t), newLocalKeyURL(t)
+
+	info := &workspace.ProjectStack{}
+	manager, err := NewCloudSecretsManager(info, primary, false)
+	require.NoError(t, err)
+	enc, err := manager.Encrypter()
+	require.NoError(t, err)
+	ciphertext, err := enc.EncryptValue(ctx, "plaintext")
+	require.NoError(t, err)
+
+	require.NoError(t, AddKeyWrapper(info, passphrase.Type))
+	require.Len(t, info.KeyWrappers, 1)
+	assert.Equal(t, passphrase.Type, info.KeyWrappers[0].SecretsProvider)
+	assert.NotEmpty(t, info.KeyWrappers[0].EncryptionSalt)
+
+	// If the primary key can't decrypt the data key, the passphrase is used instead.
+	manager, err = NewCloudSecretsManager(info, primary, false)
+	require.NoError(t, err)
+	var state cloudSecretsManagerState
+	require.NoError(t, json.Unmarshal(manager.State(), &state))
+	require.Len(t, state.Wrappers, 1)
+	assert.Equal(t, info.KeyWrappers[0].EncryptionSalt, state.Wrappers[0].Salt)
+	state.URL = lost
+	lostState, err := json.Marshal(state)
+	require.NoError(t, err)
+	fromState, err := NewCloudSecretsManagerFromState(lostState)
+	require.NoError(t, err)
+	assert.Equal(t, "plaintext", decryptValue(t, fromState, ciphertext))
+
+	// Rotating the data key encrypts the new key with the passphrase too.
+	manager, err = NewCloudSecretsManager(info, primary, true)
+	require.NoError(t, err)
+	enc, err = manager.Encrypter()
+	require.NoError(t, err)
+	ciphertext, err = enc.EncryptValue(ctx, "rotated")
+	require.NoError(t, err)
+	state = cloudSecretsManagerState{}
+	require.NoError(t, json.Unmarshal(manager.State(), &state))
+	state.URL = lost
+	lostState, err = json.Marshal(state)
+	require.NoError(t, err)
+	fromState, err = NewCloudSecretsManagerFromState(lostState)
+	require.NoError(t, err)
+	assert.Equal(t, "rotated", decryptValue(t, fromState, ciphertext))
+
+	err = AddKeyWrapper(info, passphrase.Type)
+	assert.ErrorContains(t, err, "is already wrapped by passphrase")
+}
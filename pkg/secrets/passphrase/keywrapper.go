@@ -0,0 +1,77 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package passphrase
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+)
+
+// WrapKey encrypts another secrets provider's data key with a key derived from a new passphrase, so that the
+// passphrase can be used to recover the data key. It returns the passphrase state, which must be kept alongside the
+// wrapped key to unwrap it, and the wrapped key. The passphrase is read from PULUMI_CONFIG_PASSPHRASE or
+// PULUMI_CONFIG_PASSPHRASE_FILE, or prompted for if interactive.
+func WrapKey(ctx context.Context, dataKey []byte) (state string, wrappedKey []byte, err error) {
+	state, sm, err := promptForNewPassphrase(false /*rotate*/)
+	if err != nil {
+		return "", nil, err
+	}
+	wrappedKey, err = wrapKey(ctx, sm, dataKey)
+	if err != nil {
+		return "", nil, err
+	}
+	return state, wrappedKey, nil
+}
+
+// RewrapKey encrypts a data key with the passphrase of an existing key wrapper, e.g. after the data key is rotated.
+func RewrapKey(ctx context.Context, state string, dataKey []byte) ([]byte, error) {
+	sm, err := newPromptingPassphraseSecretsManagerFromState(state)
+	if err != nil {
+		return nil, err
+	}
+	return wrapKey(ctx, sm, dataKey)
+}
+
+// UnwrapKey decrypts a data key wrapped by WrapKey or RewrapKey.
+func UnwrapKey(ctx context.Context, state string, wrappedKey []byte) ([]byte, error) {
+	sm, err := newPromptingPassphraseSecretsManagerFromState(state)
+	if err != nil {
+		return nil, err
+	}
+	dec, err := sm.Decrypter()
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := dec.DecryptValue(ctx, string(wrappedKey))
+	if err != nil {
+		return nil, fmt.Errorf("unwrapping the data key: %w", err)
+	}
+	return base64.StdEncoding.DecodeString(plaintext)
+}
+
+func wrapKey(ctx context.Context, sm secrets.Manager, dataKey []byte) ([]byte, error) {
+	enc, err := sm.Encrypter()
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := enc.EncryptValue(ctx, base64.StdEncoding.EncodeToString(dataKey))
+	if err != nil {
+		return nil, fmt.Errorf("wrapping the data key: %w", err)
+	}
+	return []byte(ciphertext), nil
+}
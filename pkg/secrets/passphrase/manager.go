@@ -231,9 +231,11 @@ func NewPromptingPassphraseSecretsManager(info *workspace.ProjectStack,
 	}
 
 	// If there are any other secrets providers set in the config, remove them, as the passphrase
-	// provider deals only with EncryptionSalt, not EncryptedKey or SecretsProvider.
+	// provider deals only with EncryptionSalt, not EncryptedKey, KeyWrappers or SecretsProvider.
 	info.EncryptedKey = ""
+	info.KeyWrappers = nil
 	info.SecretsProvider = ""
+	info.SecretsProviderState = ""
 
 	// If we have a salt, we can just use it.
 	if info.EncryptionSalt != "" {
@@ -1,6 +1,8 @@
 package passphrase
 
 import (
+	"context"
+	"encoding/base64"
 	"encoding/json"
 	"os"
 	"strings"
@@ -8,6 +10,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
 )
 
 const (
@@ -130,3 +134,58 @@ func TestPassphraseManagerEmptyPassfileReturnsError(t *testing.T) {
 	assert.NotNil(t, err, strings.Contains(err.Error(), "unable to find either `PULUMI_CONFIG_PASSPHRASE` nor "+
 		"`PULUMI_CONFIG_PASSPHRASE_FILE`"))
 }
+
+//nolint:paralleltest // mutates environment variables
+func TestPassphraseManagerRemovesCloudSecretsProvider(t *testing.T) {
+	resetEnv := resetPassphraseTestEnvVars()
+	defer resetEnv()
+
+	os.Setenv("PULUMI_CONFIG_PASSPHRASE", "password")
+	os.Unsetenv("PULUMI_CONFIG_PASSPHRASE_FILE")
+
+	info := &workspace.ProjectStack{
+		SecretsProvider: "awskms://alias/primary",
+		EncryptedKey:    "a2V5",
+		KeyWrappers:     []workspace.KeyWrapper{{SecretsProvider: "hashivault://escrow", EncryptedKey: "a2V5"}},
+	}
+	sm, err := NewPromptingPassphraseSecretsManager(info, false)
+	assert.NoError(t, err)
+	assert.NotNil(t, sm)
+	assert.Empty(t, info.SecretsProvider)
+	assert.Empty(t, info.EncryptedKey)
+	assert.Empty(t, info.KeyWrappers)
+	assert.NotEmpty(t, info.EncryptionSalt)
+}
+
+//nolint:paralleltest // mutates environment variables
+func TestPassphraseKeyWrapper(t *testing.T) {
+	resetEnv := resetPassphraseTestEnvVars()
+	defer resetEnv()
+
+	os.Setenv("PULUMI_CONFIG_PASSPHRASE", "escrow")
+	os.Unsetenv("PULUMI_CONFIG_PASSPHRASE_FILE")
+
+	ctx := context.Background()
+	dataKey := []byte("0123456789abcdef0123456789abcdef")
+	state, wrappedKey, err := WrapKey(ctx, dataKey)
+	require.NoError(t, err)
+	assert.NotContains(t, string(wrappedKey), base64.StdEncoding.EncodeToString(dataKey))
+
+	unwrapped, err := UnwrapKey(ctx, state, wrappedKey)
+	require.NoError(t, err)
+	assert.Equal(t, dataKey, unwrapped)
+
+	// A rotated data key is wrapped with the same passphrase.
+	rotated := []byte("fedcba9876543210fedcba9876543210")
+	rewrapped, err := RewrapKey(ctx, state, rotated)
+	require.NoError(t, err)
+	unwrapped, err = UnwrapKey(ctx, state, rewrapped)
+	require.NoError(t, err)
+	assert.Equal(t, rotated, unwrapped)
+
+	// The data key can't be unwrapped without the passphrase.
+	clearCachedSecretsManagers()
+	os.Setenv("PULUMI_CONFIG_PASSPHRASE", "not-the-escrow")
+	_, err = UnwrapKey(ctx, state, wrappedKey)
+	assert.ErrorIs(t, err, ErrIncorrectPassphrase)
+}
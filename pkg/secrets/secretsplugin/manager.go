@@ -0,0 +1,308 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secretsplugin implements a secrets manager that delegates encryption and decryption to a secrets provider
+// plugin. A stack uses a secrets provider plugin by setting its secrets provider to a URL whose scheme is the name of
+// the plugin, e.g. `myplugin://...`.
+package secretsplugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	netUrl "net/url"
+	"os"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/pulumi/pulumi/pkg/v3/secrets"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// Type is the type of secrets managed by this secrets provider
+const Type = "plugin"
+
+// builtinSchemes are the secrets provider URL schemes that are handled by the CLI itself rather than a plugin.
+var builtinSchemes = map[string]bool{
+	"awskms":        true,
+	"azurekeyvault": true,
+	"gcpkms":        true,
+	"hashivault":    true,
+	"age":           true,
+}
+
+type pluginSecretsManagerState struct {
+	Name  string          `json:"name"`
+	URL   string          `json:"url"`
+	State json.RawMessage `json:"state"`
+}
+
+// IsPluginSecretsProvider returns true if the given secrets provider URL refers to a secrets provider plugin, that
+// is, it has a scheme that isn't handled by one of the built-in secrets providers.
+func IsPluginSecretsProvider(secretsProvider string) bool {
+	name, err := PluginName(secretsProvider)
+	return err == nil && !builtinSchemes[name]
+}
+
+// PluginName returns the name of the secrets provider plugin for the given secrets provider URL, which is the URL's
+// scheme.
+func PluginName(secretsProvider string) (string, error) {
+	u, err := netUrl.Parse(secretsProvider)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse the secrets provider URL: %w", err)
+	}
+	if u.Scheme == "" {
+		return "", fmt.Errorf("secrets provider URL %q has no scheme", secretsProvider)
+	}
+	return u.Scheme, nil
+}
+
+// loadFunc loads a new instance of the named secrets provider plugin.
+type loadFunc func(name string) (plugin.SecretsProvider, error)
+
+// providerCache holds the secrets provider plugins loaded by this process, keyed by their name and state, so that a
+// plugin is only started once per configuration.
+type providerCache struct {
+	load loadFunc
+
+	m         sync.Mutex
+	providers map[string]plugin.SecretsProvider
+}
+
+func newProviderCache(load loadFunc) *providerCache {
+	return &providerCache{load: load, providers: map[string]plugin.SecretsProvider{}}
+}
+
+// defaultCache loads secrets provider plugins from the plugin cache.
+var defaultCache = newProviderCache(loadPlugin)
+
+// Close closes all the secrets provider plugins loaded by this process. It should be called before the process exits.
+func Close() error {
+	return defaultCache.Close()
+}
+
+// Close closes all the secrets provider plugins in the cache.
+func (c *providerCache) Close() error {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	var result error
+	for key, provider := range c.providers {
+		if err := provider.Close(); err != nil {
+			result = multierror.Append(result, err)
+		}
+		delete(c.providers, key)
+	}
+	return result
+}
+
+// pluginProvider is a secrets provider plugin along with the plugin context it was loaded with, which is closed
+// along with the plugin.
+type pluginProvider struct {
+	plugin.SecretsProvider
+
+	ctx *plugin.Context
+}
+
+func (p *pluginProvider) Close() error {
+	var result error
+	if err := p.SecretsProvider.Close(); err != nil {
+		result = multierror.Append(result, err)
+	}
+	if err := p.ctx.Close(); err != nil {
+		result = multierror.Append(result, err)
+	}
+	return result
+}
+
+func loadPlugin(name string) (plugin.SecretsProvider, error) {
+	pwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	sink := diag.DefaultSink(os.Stderr, os.Stderr, diag.FormatOptions{Color: colors.Never})
+	ctx, err := plugin.NewContext(sink, sink, nil, nil, pwd, nil, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := plugin.NewSecretsProvider(ctx, name, nil)
+	if err != nil {
+		contract.IgnoreClose(ctx)
+		return nil, fmt.Errorf("loading secrets provider plugin %q: %w", name, err)
+	}
+	return &pluginProvider{SecretsProvider: provider, ctx: ctx}, nil
+}
+
+func cacheKey(name string, state json.RawMessage) string {
+	return name + "\x00" + string(state)
+}
+
+// configured returns the named plugin configured with the given state, loading it if necessary.
+func (c *providerCache) configured(name string, state json.RawMessage) (plugin.SecretsProvider, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	key := cacheKey(name, state)
+	if provider, ok := c.providers[key]; ok {
+		return provider, nil
+	}
+
+	provider, err := c.load(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := provider.Configure(context.Background(), state); err != nil {
+		_ = provider.Close()
+		return nil, fmt.Errorf("configuring secrets provider plugin %q: %w", name, err)
+	}
+	c.providers[key] = provider
+	return provider, nil
+}
+
+// initialize asks the named plugin for its state for the given URL, and returns the state along with the plugin
+// configured with it.
+func (c *providerCache) initialize(name, url string,
+	previous json.RawMessage, rotate bool,
+) (json.RawMessage, plugin.SecretsProvider, error) {
+	provider, err := c.load(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	state, err := provider.Initialize(context.Background(), url, previous, rotate)
+	if err != nil {
+		_ = provider.Close()
+		return nil, nil, fmt.Errorf("initializing secrets provider plugin %q: %w", name, err)
+	}
+	if err := provider.Configure(context.Background(), state); err != nil {
+		_ = provider.Close()
+		return nil, nil, fmt.Errorf("configuring secrets provider plugin %q: %w", name, err)
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+	key := cacheKey(name, state)
+	if existing, ok := c.providers[key]; ok {
+		_ = provider.Close()
+		return state, existing, nil
+	}
+	c.providers[key] = provider
+	return state, provider, nil
+}
+
+// crypter encrypts and decrypts values with a secrets provider plugin.
+type crypter struct {
+	provider plugin.SecretsProvider
+}
+
+func (c *crypter) EncryptValue(ctx context.Context, plaintext string) (string, error) {
+	return c.provider.Encrypt(ctx, plaintext)
+}
+
+func (c *crypter) DecryptValue(ctx context.Context, ciphertext string) (string, error) {
+	return c.provider.Decrypt(ctx, ciphertext)
+}
+
+func (c *crypter) BulkDecrypt(ctx context.Context, ciphertexts []string) (map[string]string, error) {
+	return c.provider.BulkDecrypt(ctx, ciphertexts)
+}
+
+func newPluginSecretsManager(name, url string,
+	state json.RawMessage, provider plugin.SecretsProvider,
+) (*Manager, error) {
+	managerState, err := json.Marshal(pluginSecretsManagerState{
+		Name:  name,
+		URL:   url,
+		State: state,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshalling state: %w", err)
+	}
+	return &Manager{
+		crypter: &crypter{provider: provider},
+		state:   managerState,
+	}, nil
+}
+
+// Manager is the secrets.Manager implementation for secrets provider plugins.
+type Manager struct {
+	state   json.RawMessage
+	crypter config.Crypter
+}
+
+func (m *Manager) Type() string                         { return Type }
+func (m *Manager) State() json.RawMessage               { return m.state }
+func (m *Manager) Encrypter() (config.Encrypter, error) { return m.crypter, nil }
+func (m *Manager) Decrypter() (config.Decrypter, error) { return m.crypter, nil }
+
+// NewPluginSecretsManagerFromState deserializes configuration from state and returns a secrets manager that uses the
+// secrets provider plugin it names.
+func NewPluginSecretsManagerFromState(state json.RawMessage) (secrets.Manager, error) {
+	return newPluginSecretsManagerFromState(defaultCache, state)
+}
+
+func newPluginSecretsManagerFromState(cache *providerCache, state json.RawMessage) (*Manager, error) {
+	var s pluginSecretsManagerState
+	if err := json.Unmarshal(state, &s); err != nil {
+		return nil, fmt.Errorf("unmarshalling state: %w", err)
+	}
+
+	provider, err := cache.configured(s.Name, s.State)
+	if err != nil {
+		return nil, err
+	}
+	return newPluginSecretsManager(s.Name, s.URL, s.State, provider)
+}
+
+// NewPluginSecretsManager returns a secrets manager for the secrets provider plugin named by the scheme of the given
+// URL. The plugin's state is recorded in the stack's configuration. If the stack already uses this URL and isn't
+// rotating its secrets provider, the plugin is given its previous state so that it can keep using the same keys.
+func NewPluginSecretsManager(info *workspace.ProjectStack,
+	secretsProvider string, rotateSecretsProvider bool,
+) (secrets.Manager, error) {
+	return newPluginSecretsManagerForStack(defaultCache, info, secretsProvider, rotateSecretsProvider)
+}
+
+func newPluginSecretsManagerForStack(cache *providerCache, info *workspace.ProjectStack,
+	secretsProvider string, rotateSecretsProvider bool,
+) (*Manager, error) {
+	name, err := PluginName(secretsProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous json.RawMessage
+	if info.SecretsProvider == secretsProvider && info.SecretsProviderState != "" {
+		previous = json.RawMessage(info.SecretsProviderState)
+	}
+
+	state, provider, err := cache.initialize(name, secretsProvider, previous, rotateSecretsProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	// The plugin manages its own keys, so remove any state left over from the built-in secrets providers.
+	info.EncryptionSalt = ""
+	info.EncryptedKey = ""
+	info.KeyWrappers = nil
+	info.SecretsProvider = secretsProvider
+	info.SecretsProviderState = string(state)
+
+	return newPluginSecretsManager(name, secretsProvider, state, provider)
+}
@@ -0,0 +1,182 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretsplugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/plugin"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+)
+
+// fakeProvider "encrypts" values by prefixing them with the key in its state.
+type fakeProvider struct {
+	key    string
+	closed bool
+}
+
+func (p *fakeProvider) Close() error {
+	p.closed = true
+	return nil
+}
+
+func (p *fakeProvider) Initialize(ctx context.Context,
+	url string, state json.RawMessage, rotate bool,
+) (json.RawMessage, error) {
+	if state != nil && !rotate {
+		return state, nil
+	}
+	key := strings.TrimPrefix(url, "fake://")
+	if rotate {
+		key += "-rotated"
+	}
+	return json.Marshal(map[string]string{"key": key})
+}
+
+func (p *fakeProvider) Configure(ctx context.Context, state json.RawMessage) error {
+	var s map[string]string
+	if err := json.Unmarshal(state, &s); err != nil {
+		return err
+	}
+	p.key = s["key"]
+	return nil
+}
+
+func (p *fakeProvider) Encrypt(ctx context.Context, plaintext string) (string, error) {
+	return p.key + ":" + plaintext, nil
+}
+
+func (p *fakeProvider) Decrypt(ctx context.Context, ciphertext string) (string, error) {
+	plaintext, ok := strings.CutPrefix(ciphertext, p.key+":")
+	if !ok {
+		return "", errors.New("wrong key")
+	}
+	return plaintext, nil
+}
+
+func (p *fakeProvider) BulkDecrypt(ctx context.Context, ciphertexts []string) (map[string]string, error) {
+	plaintexts := map[string]string{}
+	for _, c := range ciphertexts {
+		plaintext, err := p.Decrypt(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		plaintexts[c] = plaintext
+	}
+	return plaintexts, nil
+}
+
+// newFakeCache returns a provider cache that loads fake providers, and a pointer to the number of providers loaded.
+func newFakeCache(t *testing.T) (*providerCache, *int) {
+	loads := 0
+	return newProviderCache(func(name string) (plugin.SecretsProvider, error) {
+		assert.Equal(t, "fake", name)
+		loads++
+		return &fakeProvider{}, nil
+	}), &loads
+}
+
+func TestPluginSecretsManager(t *testing.T) {
+	t.Parallel()
+
+	cache, loads := newFakeCache(t)
+	info := &workspace.ProjectStack{EncryptionSalt: "salt", EncryptedKey: "key"}
+	sm, err := newPluginSecretsManagerForStack(cache, info, "fake://alpha", false)
+	require.NoError(t, err)
+	assert.Equal(t, Type, sm.Type())
+	assert.Equal(t, "fake://alpha", info.SecretsProvider)
+	assert.JSONEq(t, `{"key":"alpha"}`, info.SecretsProviderState)
+	assert.Empty(t, info.EncryptionSalt)
+	assert.Empty(t, info.EncryptedKey)
+
+	enc, err := sm.Encrypter()
+	require.NoError(t, err)
+	ciphertext, err := enc.EncryptValue(context.Background(), "hunter2")
+	require.NoError(t, err)
+	assert.Equal(t, "alpha:hunter2", ciphertext)
+
+	// A manager loaded from the same state reuses the configured plugin.
+	fromState, err := newPluginSecretsManagerFromState(cache, sm.State())
+	require.NoError(t, err)
+	dec, err := fromState.Decrypter()
+	require.NoError(t, err)
+	plaintexts, err := dec.BulkDecrypt(context.Background(), []string{ciphertext})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{ciphertext: "hunter2"}, plaintexts)
+	assert.Equal(t, 1, *loads)
+
+	// Loading the stack again gives the plugin its previous state.
+	_, err = newPluginSecretsManagerForStack(cache, info, "fake://alpha", false)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"key":"alpha"}`, info.SecretsProviderState)
+
+	// Rotating asks the plugin for new keys.
+	rotated, err := newPluginSecretsManagerForStack(cache, info, "fake://alpha", true)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"key":"alpha-rotated"}`, info.SecretsProviderState)
+	dec, err = rotated.Decrypter()
+	require.NoError(t, err)
+	_, err = dec.DecryptValue(context.Background(), ciphertext)
+	assert.ErrorContains(t, err, "wrong key")
+}
+
+func TestIsPluginSecretsProvider(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsPluginSecretsProvider("myplugin://key"))
+	assert.True(t, IsPluginSecretsProvider("vault-transit://?key=foo"))
+	assert.False(t, IsPluginSecretsProvider("awskms://alias/test"))
+	assert.False(t, IsPluginSecretsProvider("age://?recipient=age1"))
+	assert.False(t, IsPluginSecretsProvider("passphrase"))
+	assert.False(t, IsPluginSecretsProvider("default"))
+	assert.False(t, IsPluginSecretsProvider(""))
+
+	name, err := PluginName("myplugin://key")
+	require.NoError(t, err)
+	assert.Equal(t, "myplugin", name)
+}
+
+func TestProviderCacheClose(t *testing.T) {
+	t.Parallel()
+
+	var providers []*fakeProvider
+	cache := newProviderCache(func(name string) (plugin.SecretsProvider, error) {
+		provider := &fakeProvider{}
+		providers = append(providers, provider)
+		return provider, nil
+	})
+	_, err := cache.configured("fake", json.RawMessage(`{"key":"alpha"}`))
+	require.NoError(t, err)
+	_, err = cache.configured("fake", json.RawMessage(`{"key":"beta"}`))
+	require.NoError(t, err)
+	require.Len(t, providers, 2)
+
+	require.NoError(t, cache.Close())
+	for _, provider := range providers {
+		assert.True(t, provider.closed)
+	}
+
+	// Closing the cache empties it, so the next use loads the plugin again.
+	_, err = cache.configured("fake", json.RawMessage(`{"key":"alpha"}`))
+	require.NoError(t, err)
+	assert.Len(t, providers, 3)
+}
@@ -138,6 +138,7 @@ func NewServiceSecretsManager(
 	info.EncryptionSalt = ""
 	info.SecretsProvider = ""
 	info.EncryptedKey = ""
+	info.KeyWrappers = nil
 
 	state, err := json.Marshal(serviceSecretsManagerState{
 		URL:      client.URL(),
@@ -59,6 +59,28 @@ type PolicyEvent struct {
 	EnforcementLevel string `json:"enforcementLevel"`
 }
 
+// ResourceAnnotationEvent is emitted whenever a policy pack annotates a proposed change to a resource, e.g. with the
+// estimated change in its monthly cost.
+type ResourceAnnotationEvent struct {
+	ResourceURN string `json:"resourceUrn"`
+	// Label is the label of the annotation, e.g. "Monthly cost".
+	Label string `json:"label"`
+	// Number is the numeric value of the annotation, if it is numeric.
+	Number *float64 `json:"number,omitempty"`
+	// Text is the textual value of the annotation, if it is not numeric.
+	Text string `json:"text,omitempty"`
+	// Unit is the unit of a numeric value, e.g. "USD".
+	Unit           string `json:"unit,omitempty"`
+	PolicyPackName string `json:"policyPackName,omitempty"`
+}
+
+// AnnotationTotal is the total of the numeric annotations of changed resources with the same label and unit.
+type AnnotationTotal struct {
+	Label string  `json:"label"`
+	Unit  string  `json:"unit,omitempty"`
+	Total float64 `json:"total"`
+}
+
 // PreludeEvent is emitted at the start of an update.
 type PreludeEvent struct {
 	// Config contains the keys and values for the update.
@@ -80,6 +102,8 @@ type SummaryEvent struct {
 	// compatibility. For older clients this will map to the version, while for newer ones
 	// it will be the version tag prepended with "v".
 	PolicyPacks map[string]string `json:"PolicyPacks"`
+	// AnnotationTotals contains the totals of the numeric annotations of changed resources.
+	AnnotationTotals []AnnotationTotal `json:"annotationTotals,omitempty"`
 }
 
 // DiffKind describes the kind of a particular property diff.
@@ -108,6 +132,40 @@ type PropertyDiff struct {
 	InputDiff bool `json:"inputDiff"`
 }
 
+// PropertyPatch describes the changes to a string property whose values span multiple lines or hold JSON or YAML
+// documents.
+type PropertyPatch struct {
+	// Format is "json" or "yaml" if both values are documents of that format, in which case Operations holds their
+	// structural differences. Otherwise it is "text", and Hunks holds their line-level differences.
+	Format string `json:"format"`
+	// Hunks are the hunks of a unified diff of the old and new values.
+	Hunks []PatchHunk `json:"hunks,omitempty"`
+	// Operations are the JSON Patch (RFC 6902) operations that transform the old document into the new one.
+	Operations []PatchOperation `json:"operations,omitempty"`
+}
+
+// PatchHunk is a hunk of a unified diff.
+type PatchHunk struct {
+	// OldStart is the 1-based line of the old value that the hunk starts at, and OldLines its length in old lines.
+	OldStart int `json:"oldStart"`
+	OldLines int `json:"oldLines"`
+	// NewStart is the 1-based line of the new value that the hunk starts at, and NewLines its length in new lines.
+	NewStart int `json:"newStart"`
+	NewLines int `json:"newLines"`
+	// Lines are the lines of the hunk, prefixed with " " if unchanged, "-" if deleted or "+" if added.
+	Lines []string `json:"lines"`
+}
+
+// PatchOperation is a JSON Patch (RFC 6902) operation.
+type PatchOperation struct {
+	// Op is one of "add", "remove" or "replace".
+	Op string `json:"op"`
+	// Path is the JSON pointer of the value to change.
+	Path string `json:"path"`
+	// Value is the new value for add and replace operations.
+	Value interface{} `json:"value,omitempty"`
+}
+
 // StepEventMetadata describes a "step" within the Pulumi engine, which is any concrete action
 // to migrate a set of cloud resources from one state to another.
 type StepEventMetadata struct {
@@ -128,6 +186,8 @@ type StepEventMetadata struct {
 	Diffs []string `json:"diffs,omitempty"`
 	// The diff for this step as a list of property paths and difference types.
 	DetailedDiff map[string]PropertyDiff `json:"detailedDiff,omitempty"`
+	// Patches describes the changes to multi-line and JSON or YAML string properties, keyed by property path.
+	Patches map[string]PropertyPatch `json:"patches,omitempty"`
 	// Logical is set if the step is a logical operation in the program.
 	Logical bool `json:"logical,omitempty"`
 	// Provider actually performing the step.
@@ -159,6 +219,8 @@ type StepEventStateMetadata struct {
 	Provider string `json:"provider"`
 	// InitErrors is the set of errors encountered in the process of initializing resource.
 	InitErrors []string `json:"initErrors,omitempty"`
+	// Dependencies are the URNs of the resources that this resource depends on.
+	Dependencies []string `json:"dependencies,omitempty"`
 }
 
 // ResourcePreEvent is emitted before a resource is modified.
@@ -206,6 +268,8 @@ type EngineEvent struct {
 	ResOutputsEvent  *ResOutputsEvent   `json:"resOutputsEvent,omitempty"`
 	ResOpFailedEvent *ResOpFailedEvent  `json:"resOpFailedEvent,omitempty"`
 	PolicyEvent      *PolicyEvent       `json:"policyEvent,omitempty"`
+
+	ResourceAnnotationEvent *ResourceAnnotationEvent `json:"resourceAnnotationEvent,omitempty"`
 }
 
 // EngineEventBatch is a group of engine events.
@@ -0,0 +1,69 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apitype
+
+// OperationKind is the kind of a CLI operation that changes a stack.
+type OperationKind string
+
+const (
+	// UpdateOperation is `pulumi up`.
+	UpdateOperation OperationKind = "update"
+	// RefreshOperation is `pulumi refresh`.
+	RefreshOperation OperationKind = "refresh"
+	// DestroyOperation is `pulumi destroy`.
+	DestroyOperation OperationKind = "destroy"
+	// ImportOperation is `pulumi import`.
+	ImportOperation OperationKind = "import"
+	// StateDeleteOperation is `pulumi state delete`.
+	StateDeleteOperation OperationKind = "state-delete"
+	// ConfigSetOperation is `pulumi config set`.
+	ConfigSetOperation OperationKind = "config-set"
+)
+
+// OperationSummary is the document that a CLI operation that changes a stack writes once it completes when it is run
+// with `--output json`. While fields may be added to it in the future, existing fields will not change.
+type OperationSummary struct {
+	// Operation is the kind of the operation.
+	Operation OperationKind `json:"operation"`
+	// Project is the name of the stack's project, if it is known.
+	Project string `json:"project,omitempty"`
+	// Stack is the fully qualified name of the stack.
+	Stack string `json:"stack"`
+	// Result is the result of the operation, either "succeeded" or "failed".
+	Result UpdateResult `json:"result"`
+	// StartTime and EndTime are the times at which the operation started and ended, in RFC 3339 format.
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+	// DurationSeconds is the time that the operation took.
+	DurationSeconds float64 `json:"durationSeconds"`
+	// ResourceChanges counts the resources that the operation changed, by the kind of the change, if it changes
+	// resources.
+	ResourceChanges map[OpType]int `json:"resourceChanges,omitempty"`
+	// Outputs are the outputs of the stack once the operation completed, if it may change them. Secret values are
+	// masked.
+	Outputs map[string]interface{} `json:"outputs,omitempty"`
+	// Errors are the errors that were reported by the operation.
+	Errors []OperationError `json:"errors,omitempty"`
+	// Version is the version of the stack's history that the operation added, if any.
+	Version int `json:"version,omitempty"`
+}
+
+// OperationError is an error reported by a CLI operation.
+type OperationError struct {
+	// URN is the URN of the resource that reported the error, if any.
+	URN string `json:"urn,omitempty"`
+	// Message is the text of the error.
+	Message string `json:"message"`
+}
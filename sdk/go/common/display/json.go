@@ -73,6 +73,8 @@ type PreviewStep struct {
 	ReplaceReasons []resource.PropertyKey `json:"replaceReasons,omitempty"`
 	// DetailedDiff is a structured diff that indicates precise per-property differences.
 	DetailedDiff map[string]PropertyDiff `json:"detailedDiff"`
+	// Patches describes the changes to multi-line and JSON or YAML string properties, keyed by property path.
+	Patches map[string]apitype.PropertyPatch `json:"patches,omitempty"`
 }
 
 // PreviewDiagnostic is a warning or error emitted during the execution of the preview.
@@ -69,6 +69,11 @@ fail without a --force parameter.`)
 var DebugGRPC = env.String("DEBUG_GRPC", `Enables debug tracing of Pulumi gRPC internals.
 The variable should be set to the log file to which gRPC debug traces will be sent.`)
 
+var SecretsAuditLog = env.String("SECRETS_AUDIT_LOG",
+	`Records which secrets each command decrypts, without their values.
+The variable should be set to the file to which JSON lines audit events are appended, or to "syslog" to send them to
+the local syslog daemon.`)
+
 // Environment variables that affect the self-managed backend.
 var (
 	SelfManagedStateNoLegacyWarning = env.Bool("SELF_MANAGED_STATE_NO_LEGACY_WARNING",
@@ -76,4 +81,7 @@ var (
 
 	SelfManagedStateLegacyLayout = env.Bool("SELF_MANAGED_STATE_LEGACY_LAYOUT",
 		"Uses the legacy layout for new buckets, which currently default to project-scoped stacks.")
+
+	SelfManagedStateRecordEvents = env.Bool("SELF_MANAGED_STATE_RECORD_EVENTS",
+		"Stores the engine events of each update in the stack's history, for `pulumi stack history report`.")
 )
@@ -0,0 +1,158 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// ReferenceKind is the kind of external value that a config reference resolves to.
+type ReferenceKind string
+
+const (
+	// EnvReference resolves to the value of an environment variable, e.g. `{fromEnv: NAME}`.
+	EnvReference ReferenceKind = "fromEnv"
+	// FileReference resolves to the contents of a file, relative to the project directory, e.g. `{fromFile: path}`.
+	FileReference ReferenceKind = "fromFile"
+	// StackOutputReference resolves to an output of another stack, e.g. `{fromStackOutput: org/proj/stack#key}`.
+	StackOutputReference ReferenceKind = "fromStackOutput"
+)
+
+// referenceSecretKey is the optional key that marks the resolved value of a reference as a secret.
+const referenceSecretKey = "secret"
+
+// Reference is a config value that is resolved from an external source when the configuration for a deployment is
+// built, rather than being stored in the stack's settings file.
+type Reference struct {
+	// Kind is the kind of the external source.
+	Kind ReferenceKind
+	// Target names the value within the external source, e.g. the name of an environment variable.
+	Target string
+	// Secret is true if the resolved value must be treated as a secret, regardless of its source.
+	Secret bool
+}
+
+func (r Reference) String() string {
+	if r.Secret {
+		return fmt.Sprintf("{%s: %s, secret: true}", r.Kind, r.Target)
+	}
+	return fmt.Sprintf("{%s: %s}", r.Kind, r.Target)
+}
+
+// NewReferenceValue returns a config value that holds the given reference.
+func NewReferenceValue(ref Reference) Value {
+	obj := map[string]interface{}{string(ref.Kind): ref.Target}
+	if ref.Secret {
+		obj[referenceSecretKey] = true
+	}
+	b, err := json.Marshal(obj)
+	contract.AssertNoErrorf(err, "marshalling config reference")
+	return NewObjectValue(string(b))
+}
+
+// Reference returns the reference held by this value, if any. A reference is an object with exactly one of the
+// `fromEnv`, `fromFile` or `fromStackOutput` keys set to a string, and optionally a boolean `secret` key.
+func (c Value) Reference() (Reference, bool) {
+	if !c.object || c.secure {
+		return Reference{}, false
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(c.value), &obj); err != nil {
+		return Reference{}, false
+	}
+
+	var ref Reference
+	for k, v := range obj {
+		switch kind := ReferenceKind(k); kind {
+		case EnvReference, FileReference, StackOutputReference:
+			target, ok := v.(string)
+			if !ok || ref.Kind != "" {
+				return Reference{}, false
+			}
+			ref.Kind, ref.Target = kind, target
+		case referenceSecretKey:
+			secret, ok := v.(bool)
+			if !ok {
+				return Reference{}, false
+			}
+			ref.Secret = secret
+		default:
+			return Reference{}, false
+		}
+	}
+	if ref.Kind == "" {
+		return Reference{}, false
+	}
+	return ref, true
+}
+
+// ParseStackOutputReference splits the target of a stack output reference into the name of the stack and the name of
+// its output, e.g. `org/proj/stack#key`.
+func ParseStackOutputReference(target string) (string, string, error) {
+	stack, output, ok := strings.Cut(target, "#")
+	if !ok || stack == "" || output == "" {
+		return "", "", fmt.Errorf("invalid stack output reference %q: expected <stack>#<output>", target)
+	}
+	return stack, output, nil
+}
+
+// ReferenceResolver resolves config references to their values.
+type ReferenceResolver interface {
+	// ResolveReference returns the value of the given reference, and whether that value is a secret.
+	ResolveReference(ctx context.Context, ref Reference) (string, bool, error)
+}
+
+// HasReferences returns true if the config map contains a reference.
+func (m Map) HasReferences() bool {
+	for _, v := range m {
+		if _, ok := v.Reference(); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve returns a copy of the config map in which every reference is replaced by its resolved value. Values that are
+// secret, either because the reference is marked as a secret or because its source is, are encrypted with encrypter.
+func (m Map) Resolve(ctx context.Context, resolver ReferenceResolver, encrypter Encrypter) (Map, error) {
+	resolved := make(Map, len(m))
+	for k, v := range m {
+		ref, ok := v.Reference()
+		if !ok {
+			resolved[k] = v
+			continue
+		}
+
+		value, secret, err := resolver.ResolveReference(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("resolving %s for configuration key '%s': %w", ref, k, err)
+		}
+		if secret || ref.Secret {
+			ciphertext, err := encrypter.EncryptValue(ctx, value)
+			if err != nil {
+				return nil, fmt.Errorf("encrypting configuration key '%s': %w", k, err)
+			}
+			resolved[k] = NewSecureValue(ciphertext)
+		} else {
+			resolved[k] = NewValue(value)
+		}
+	}
+	return resolved, nil
+}
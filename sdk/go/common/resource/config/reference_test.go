@@ -0,0 +1,133 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestValueReference(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		YAML     string
+		Expected Reference
+		Ok       bool
+	}{
+		{YAML: "{fromEnv: NAME}", Expected: Reference{Kind: EnvReference, Target: "NAME"}, Ok: true},
+		{
+			YAML:     "{fromFile: ./key.pem, secret: true}",
+			Expected: Reference{Kind: FileReference, Target: "./key.pem", Secret: true},
+			Ok:       true,
+		},
+		{
+			YAML:     "{fromStackOutput: org/proj/dev#url}",
+			Expected: Reference{Kind: StackOutputReference, Target: "org/proj/dev#url"},
+			Ok:       true,
+		},
+		{YAML: "{fromEnv: NAME, other: value}"},
+		{YAML: "{fromEnv: NAME, fromFile: path}"},
+		{YAML: "{fromEnv: [NAME]}"},
+		{YAML: "{fromEnv: NAME, secret: yes please}"},
+		{YAML: "{secret: true}"},
+		{YAML: "plain"},
+		{YAML: "{secure: ciphertext}"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.YAML, func(t *testing.T) {
+			t.Parallel()
+
+			var v Value
+			require.NoError(t, yaml.Unmarshal([]byte(test.YAML), &v))
+			ref, ok := v.Reference()
+			assert.Equal(t, test.Ok, ok)
+			assert.Equal(t, test.Expected, ref)
+			if ok {
+				roundtrip, ok := NewReferenceValue(ref).Reference()
+				assert.True(t, ok)
+				assert.Equal(t, ref, roundtrip)
+			}
+		})
+	}
+}
+
+type testResolver map[Reference]string
+
+func (r testResolver) ResolveReference(ctx context.Context, ref Reference) (string, bool, error) {
+	if ref.Kind == StackOutputReference {
+		return "from-stack", true, nil
+	}
+	v, ok := r[Reference{Kind: ref.Kind, Target: ref.Target}]
+	if !ok {
+		return "", false, errors.New("not found")
+	}
+	return v, false, nil
+}
+
+func TestMapResolve(t *testing.T) {
+	t.Parallel()
+
+	m := Map{
+		MustMakeKey("my", "plain"):  NewValue("value"),
+		MustMakeKey("my", "env"):    NewReferenceValue(Reference{Kind: EnvReference, Target: "NAME"}),
+		MustMakeKey("my", "file"):   NewReferenceValue(Reference{Kind: FileReference, Target: "key", Secret: true}),
+		MustMakeKey("my", "output"): NewReferenceValue(Reference{Kind: StackOutputReference, Target: "dev#url"}),
+	}
+	assert.True(t, m.HasReferences())
+
+	resolver := testResolver{
+		{Kind: EnvReference, Target: "NAME"}: "from-env",
+		{Kind: FileReference, Target: "key"}: "from-file",
+	}
+	crypter := NewSymmetricCrypter(make([]byte, SymmetricCrypterKeyBytes))
+	resolved, err := m.Resolve(context.Background(), resolver, crypter)
+	require.NoError(t, err)
+	assert.False(t, resolved.HasReferences())
+	assert.Equal(t, NewValue("value"), resolved[MustMakeKey("my", "plain")])
+	assert.Equal(t, NewValue("from-env"), resolved[MustMakeKey("my", "env")])
+	assert.ElementsMatch(t, []Key{MustMakeKey("my", "file"), MustMakeKey("my", "output")}, resolved.SecureKeys())
+
+	decrypted, err := resolved.Decrypt(crypter)
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", decrypted[MustMakeKey("my", "file")])
+	assert.Equal(t, "from-stack", decrypted[MustMakeKey("my", "output")])
+
+	// The original map still holds the references.
+	assert.True(t, m.HasReferences())
+
+	delete(resolver, Reference{Kind: EnvReference, Target: "NAME"})
+	_, err = m.Resolve(context.Background(), resolver, crypter)
+	assert.ErrorContains(t, err, "resolving {fromEnv: NAME} for configuration key 'my:env': not found")
+}
+
+func TestParseStackOutputReference(t *testing.T) {
+	t.Parallel()
+
+	stack, output, err := ParseStackOutputReference("org/proj/dev#url")
+	require.NoError(t, err)
+	assert.Equal(t, "org/proj/dev", stack)
+	assert.Equal(t, "url", output)
+
+	_, _, err = ParseStackOutputReference("org/proj/dev")
+	assert.ErrorContains(t, err, "expected <stack>#<output>")
+}
@@ -18,6 +18,7 @@ import (
 	"io"
 
 	"github.com/pulumi/pulumi/sdk/v3/go/common/apitype"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/display"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
@@ -43,6 +44,9 @@ type Analyzer interface {
 	GetPluginInfo() (workspace.PluginInfo, error)
 	// Configure configures the analyzer, passing configuration properties for each policy.
 	Configure(policyConfig map[string]AnalyzerPolicyConfig) error
+	// Annotate annotates proposed changes to resources with values that are displayed alongside them, e.g. the
+	// estimated change in their monthly cost. Is called before the resources are modified.
+	Annotate(changes []AnalyzerResourceChange) ([]AnalyzeAnnotation, error)
 }
 
 // AnalyzerResource mirrors a resource that is passed to `Analyze`.
@@ -82,6 +86,23 @@ type AnalyzerProviderResource struct {
 	Properties resource.PropertyMap
 }
 
+// AnalyzerResourceChange mirrors a proposed change to a resource that is passed to `Annotate`.
+type AnalyzerResourceChange struct {
+	Op  display.StepOp    // the operation that will be performed.
+	Old *AnalyzerResource // the current state of the resource, unless it will be created.
+	New *AnalyzerResource // the proposed state of the resource, unless it will be deleted.
+}
+
+// AnalyzeAnnotation annotates a proposed change to a resource with a numeric or textual value.
+type AnalyzeAnnotation struct {
+	PolicyPackName string
+	URN            resource.URN
+	Label          string   // the label of the annotation, e.g. "Monthly cost".
+	Number         *float64 // a numeric value, e.g. the change in cost, if the annotation is numeric.
+	Text           string   // a textual value, if the annotation is not numeric.
+	Unit           string   // the unit of a numeric value, e.g. "USD".
+}
+
 // AnalyzeDiagnostic indicates that resource analysis failed; it contains the property and reason
 // for the failure.
 type AnalyzeDiagnostic struct {
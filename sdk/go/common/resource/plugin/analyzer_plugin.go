@@ -415,6 +415,63 @@ func (a *analyzer) Configure(policyConfig map[string]AnalyzerPolicyConfig) error
 	return nil
 }
 
+// Annotate annotates proposed changes to resources with values that are displayed alongside them.
+func (a *analyzer) Annotate(changes []AnalyzerResourceChange) ([]AnalyzeAnnotation, error) {
+	label := fmt.Sprintf("%s.Annotate(#changes=%d)", a.label(), len(changes))
+	logging.V(7).Infof("%s executing", label)
+
+	protoChanges := make([]*pulumirpc.AnnotateResourceChange, len(changes))
+	for idx, change := range changes {
+		oldResource, err := marshalAnalyzerResource(change.Old)
+		if err != nil {
+			return nil, err
+		}
+		newResource, err := marshalAnalyzerResource(change.New)
+		if err != nil {
+			return nil, err
+		}
+		protoChanges[idx] = &pulumirpc.AnnotateResourceChange{
+			Op:  string(change.Op),
+			Old: oldResource,
+			New: newResource,
+		}
+	}
+
+	resp, err := a.client.Annotate(a.ctx.Request(), &pulumirpc.AnnotateRequest{
+		Changes: protoChanges,
+	})
+	if err != nil {
+		rpcError := rpcerror.Convert(err)
+		// Policy packs that predate annotations don't implement this method, which just means that they have nothing
+		// to annotate.
+		if rpcError.Code() == codes.Unimplemented {
+			logging.V(7).Infof("%s is unimplemented, skipping: err=%v", label, rpcError)
+			return nil, nil
+		}
+
+		logging.V(7).Infof("%s failed: err=%v", label, rpcError)
+		return nil, rpcError
+	}
+
+	protoAnnotations := resp.GetAnnotations()
+	logging.V(7).Infof("%s success: annotations=#%d", label, len(protoAnnotations))
+
+	annotations := make([]AnalyzeAnnotation, len(protoAnnotations))
+	for idx, protoA := range protoAnnotations {
+		annotations[idx] = AnalyzeAnnotation{
+			PolicyPackName: protoA.GetPolicyPackName(),
+			URN:            resource.URN(protoA.GetUrn()),
+			Label:          protoA.GetLabel(),
+			Text:           protoA.GetText(),
+			Unit:           protoA.GetUnit(),
+		}
+		if number, ok := protoA.GetValue().(*pulumirpc.ResourceAnnotation_Number); ok {
+			annotations[idx].Number = &number.Number
+		}
+	}
+	return annotations, nil
+}
+
 // Close tears down the underlying plugin RPC connection and process.
 func (a *analyzer) Close() error {
 	return a.plug.Close()
@@ -487,6 +544,32 @@ func marshalProvider(provider *AnalyzerProviderResource) (*pulumirpc.AnalyzerPro
 	}, nil
 }
 
+func marshalAnalyzerResource(r *AnalyzerResource) (*pulumirpc.AnalyzerResource, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	props, err := MarshalProperties(r.Properties,
+		MarshalOptions{KeepUnknowns: true, KeepSecrets: true, SkipInternalKeys: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling properties")
+	}
+
+	provider, err := marshalProvider(r.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pulumirpc.AnalyzerResource{
+		Urn:        string(r.URN),
+		Type:       string(r.Type),
+		Name:       string(r.Name),
+		Properties: props,
+		Options:    marshalResourceOptions(r.Options),
+		Provider:   provider,
+	}, nil
+}
+
 func marshalEnforcementLevel(el apitype.EnforcementLevel) pulumirpc.EnforcementLevel {
 	switch el {
 	case apitype.Advisory:
@@ -24,6 +24,7 @@ import (
 
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/diag/colors"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
@@ -39,13 +40,18 @@ type Context struct {
 	Pwd        string    // the working directory to spawn all plugins in.
 	Root       string    // the root directory of the project.
 
+	// Env is additional environment variables for the language host and resource provider plugins launched with this
+	// context, such as those declared by the project and stack.
+	Env map[string]string
+
 	// If non-nil, configures custom gRPC client options. Receives pluginInfo which is a JSON-serializable bit of
 	// metadata describing the plugin.
 	DialOptions func(pluginInfo interface{}) []grpc.DialOption
 
 	DebugTraceMutex *sync.Mutex // used internally to syncronize debug tracing
 
-	tracingSpan opentracing.Span // the OpenTracing span to parent requests within.
+	tracingSpan   opentracing.Span // the OpenTracing span to parent requests within.
+	resourceSpans *sync.Map        // the spans to parent requests for particular resources within, keyed by URN.
 
 	cancelFuncs []context.CancelFunc
 	cancelLock  *sync.Mutex // Guards cancelFuncs.
@@ -95,6 +101,7 @@ func NewContextWithRoot(d, statusD diag.Sink, host Host,
 		Host:            host,
 		Pwd:             pwd,
 		tracingSpan:     parentSpan,
+		resourceSpans:   &sync.Map{},
 		DebugTraceMutex: &sync.Mutex{},
 		cancelLock:      &sync.Mutex{},
 	}
@@ -110,11 +117,36 @@ func NewContextWithRoot(d, statusD diag.Sink, host Host,
 
 // Request allocates a request sub-context.
 func (ctx *Context) Request() context.Context {
+	return ctx.request(ctx.tracingSpan)
+}
+
+// RequestFor allocates a request sub-context for an operation on the given resource. If a span has been registered for
+// the resource with TraceResource, the request is parented within that span.
+func (ctx *Context) RequestFor(urn resource.URN) context.Context {
+	if ctx.resourceSpans != nil {
+		if span, ok := ctx.resourceSpans.Load(urn); ok {
+			return ctx.request(span.(opentracing.Span))
+		}
+	}
+	return ctx.Request()
+}
+
+// TraceResource parents the requests for the given resource within the given span until the returned function is
+// called. The engine uses this so that the provider calls made while executing a step nest under the step's span.
+func (ctx *Context) TraceResource(urn resource.URN, span opentracing.Span) func() {
+	if ctx.resourceSpans == nil {
+		return func() {}
+	}
+	ctx.resourceSpans.Store(urn, span)
+	return func() { ctx.resourceSpans.Delete(urn) }
+}
+
+func (ctx *Context) request(span opentracing.Span) context.Context {
 	c := ctx.baseContext
 	if c == nil {
 		c = context.Background()
 	}
-	c = opentracing.ContextWithSpan(c, ctx.tracingSpan)
+	c = opentracing.ContextWithSpan(c, span)
 	c, cancel := context.WithCancel(c)
 	ctx.cancelLock.Lock()
 	ctx.cancelFuncs = append(ctx.cancelFuncs, cancel)
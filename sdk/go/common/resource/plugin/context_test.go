@@ -18,8 +18,11 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
 	"github.com/pulumi/pulumi/sdk/v3/go/common/testing/diagtest"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -49,3 +52,23 @@ func TestContextRequest_race(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestContextRequestFor(t *testing.T) {
+	t.Parallel()
+
+	tracer := mocktracer.New()
+	root := tracer.StartSpan("root")
+	ctx, err := NewContext(diagtest.LogSink(t), diagtest.LogSink(t), nil, nil, t.TempDir(), nil, false, root)
+	require.NoError(t, err)
+
+	urn := resource.NewURN("dev", "proj", "", "test:index:Resource", "res")
+	other := resource.NewURN("dev", "proj", "", "test:index:Resource", "other")
+	step := tracer.StartSpan("step")
+
+	untrace := ctx.TraceResource(urn, step)
+	assert.Equal(t, step, opentracing.SpanFromContext(ctx.RequestFor(urn)))
+	assert.Equal(t, root, opentracing.SpanFromContext(ctx.RequestFor(other)))
+
+	untrace()
+	assert.Equal(t, root, opentracing.SpanFromContext(ctx.RequestFor(urn)))
+}
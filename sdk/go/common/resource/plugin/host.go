@@ -122,6 +122,17 @@ func NewDefaultHost(ctx *Context, runtimeOptions map[string]interface{},
 			}
 			projectPlugins = append(projectPlugins, info)
 		}
+		for _, converterOpts := range plugins.Converters {
+			// Converters without a path only pin a version of a plugin in the plugin cache.
+			if converterOpts.Path == "" {
+				continue
+			}
+			info, err := parsePluginOpts(converterOpts, workspace.ConverterPlugin)
+			if err != nil {
+				return nil, err
+			}
+			projectPlugins = append(projectPlugins, info)
+		}
 	}
 
 	host := &defaultHost{
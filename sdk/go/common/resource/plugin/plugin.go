@@ -22,6 +22,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -169,6 +170,22 @@ func dialPlugin(portNum int, bin, prefix string, dialOptions []grpc.DialOption)
 	return conn, nil
 }
 
+// appendEnv appends the given variables to an environment, which is the environment of the current process if nil.
+func appendEnv(env []string, vars map[string]string) []string {
+	if env == nil {
+		env = os.Environ()
+	}
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		env = append(env, name+"="+vars[name])
+	}
+	return env
+}
+
 func newPlugin(ctx *Context, pwd, bin, prefix string, kind workspace.PluginKind,
 	args, env []string, dialOptions []grpc.DialOption,
 ) (*plugin, error) {
@@ -183,6 +200,11 @@ func newPlugin(ctx *Context, pwd, bin, prefix string, kind workspace.PluginKind,
 		logging.V(9).Infof("Launching plugin '%v' from '%v' with args: %v", prefix, bin, argstr)
 	}
 
+	// Language hosts and resource providers also receive the environment variables of the context.
+	if len(ctx.Env) > 0 && (kind == workspace.LanguagePlugin || kind == workspace.ResourcePlugin) {
+		env = appendEnv(env, ctx.Env)
+	}
+
 	// Try to execute the binary.
 	plug, err := execPlugin(ctx, bin, prefix, kind, args, pwd, env)
 	if err != nil {
@@ -314,7 +336,7 @@ func execPlugin(ctx *Context, bin, prefix string, kind workspace.PluginKind,
 		pluginDir := filepath.Dir(bin)
 
 		var runtimeInfo workspace.ProjectRuntimeInfo
-		if kind == workspace.ResourcePlugin || kind == workspace.ConverterPlugin {
+		if kind == workspace.ResourcePlugin || kind == workspace.ConverterPlugin || kind == workspace.SecretsPlugin {
 			proj, err := workspace.LoadPluginProject(filepath.Join(pluginDir, "PulumiPlugin.yaml"))
 			if err != nil {
 				return nil, fmt.Errorf("loading PulumiPlugin.yaml: %w", err)
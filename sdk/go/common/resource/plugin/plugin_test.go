@@ -55,3 +55,14 @@ func TestLogFlowArgumentPropagation(t *testing.T) {
 		tracingEndpoint: "127.0.0.1:6007",
 	}), []string{"--logtostderr", "-v=9", "--tracing", "127.0.0.1:6007", "127.0.0.1:12345"})
 }
+
+func TestAppendEnv(t *testing.T) {
+	t.Parallel()
+
+	env := appendEnv([]string{"A=1"}, map[string]string{"C": "3", "B": "2"})
+	assert.Equal(t, []string{"A=1", "B=2", "C=3"}, env)
+
+	env = appendEnv(nil, map[string]string{"PULUMI_TEST_APPEND_ENV": "x"})
+	assert.Greater(t, len(env), 1)
+	assert.Equal(t, "PULUMI_TEST_APPEND_ENV=x", env[len(env)-1])
+}
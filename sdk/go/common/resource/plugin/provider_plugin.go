@@ -303,6 +303,15 @@ func (p *provider) requestContext() context.Context {
 	return p.ctx.Request()
 }
 
+// resourceRequestContext is requestContext for an operation on the given resource, which nests the request within the
+// span of the step operating on the resource, if there is one.
+func (p *provider) resourceRequestContext(urn resource.URN) context.Context {
+	if p.ctx == nil {
+		return context.Background()
+	}
+	return p.ctx.RequestFor(urn)
+}
+
 // isDiffCheckConfigLogicallyUnimplemented returns true when an rpcerror.Error should be treated as if it was an error
 // due to a rpc being unimplemented. Due to past mistakes, different providers returned "Unimplemented" in a variaity of
 // different ways that don't always result in an Uimplemented error code.
@@ -706,7 +715,7 @@ func (p *provider) Check(urn resource.URN,
 		return nil, nil, err
 	}
 
-	resp, err := client.Check(p.requestContext(), &pulumirpc.CheckRequest{
+	resp, err := client.Check(p.resourceRequestContext(urn), &pulumirpc.CheckRequest{
 		Urn:        string(urn),
 		Olds:       molds,
 		News:       mnews,
@@ -800,7 +809,7 @@ func (p *provider) Diff(urn resource.URN, id resource.ID,
 		return DiffResult{}, err
 	}
 
-	resp, err := client.Diff(p.requestContext(), &pulumirpc.DiffRequest{
+	resp, err := client.Diff(p.resourceRequestContext(urn), &pulumirpc.DiffRequest{
 		Id:            string(id),
 		Urn:           string(urn),
 		Olds:          molds,
@@ -898,7 +907,7 @@ func (p *provider) Create(urn resource.URN, props resource.PropertyMap, timeout
 	var liveObject *_struct.Struct
 	var resourceError error
 	resourceStatus := resource.StatusOK
-	resp, err := client.Create(p.requestContext(), &pulumirpc.CreateRequest{
+	resp, err := client.Create(p.resourceRequestContext(urn), &pulumirpc.CreateRequest{
 		Urn:        string(urn),
 		Properties: mprops,
 		Timeout:    timeout,
@@ -1003,7 +1012,7 @@ func (p *provider) Read(urn resource.URN, id resource.ID,
 	var liveInputs *_struct.Struct
 	var resourceError error
 	resourceStatus := resource.StatusOK
-	resp, err := client.Read(p.requestContext(), &pulumirpc.ReadRequest{
+	resp, err := client.Read(p.resourceRequestContext(urn), &pulumirpc.ReadRequest{
 		Id:         string(id),
 		Urn:        string(urn),
 		Properties: mstate,
@@ -1136,7 +1145,7 @@ func (p *provider) Update(urn resource.URN, id resource.ID,
 	var liveObject *_struct.Struct
 	var resourceError error
 	resourceStatus := resource.StatusOK
-	resp, err := client.Update(p.requestContext(), &pulumirpc.UpdateRequest{
+	resp, err := client.Update(p.resourceRequestContext(urn), &pulumirpc.UpdateRequest{
 		Id:            string(id),
 		Urn:           string(urn),
 		Olds:          molds,
@@ -1212,7 +1221,7 @@ func (p *provider) Delete(urn resource.URN, id resource.ID, props resource.Prope
 	// We should only be calling {Create,Update,Delete} if the provider is fully configured.
 	contract.Assertf(pcfg.known, "Delete cannot be called if the configuration is unknown")
 
-	if _, err := client.Delete(p.requestContext(), &pulumirpc.DeleteRequest{
+	if _, err := client.Delete(p.resourceRequestContext(urn), &pulumirpc.DeleteRequest{
 		Id:         string(id),
 		Urn:        string(urn),
 		Properties: mprops,
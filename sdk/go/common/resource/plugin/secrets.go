@@ -0,0 +1,42 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// SecretsProvider is a secrets provider plugin, which encrypts and decrypts the secrets of a stack.
+type SecretsProvider interface {
+	io.Closer
+
+	// Initialize returns the state of the secrets provider for the given URL. The previous state is given if the stack
+	// already used this URL, and rotate is true if the secrets provider should rotate its keys rather than reuse it.
+	Initialize(ctx context.Context, url string, state json.RawMessage, rotate bool) (json.RawMessage, error)
+
+	// Configure loads the state of the secrets provider, as returned by Initialize.
+	Configure(ctx context.Context, state json.RawMessage) error
+
+	// Encrypt encrypts a value.
+	Encrypt(ctx context.Context, plaintext string) (string, error)
+
+	// Decrypt decrypts a value.
+	Decrypt(ctx context.Context, ciphertext string) (string, error)
+
+	// BulkDecrypt decrypts many values at once, returning the decrypted values keyed by their encrypted value.
+	BulkDecrypt(ctx context.Context, ciphertexts []string) (map[string]string, error)
+}
@@ -0,0 +1,184 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/blang/semver"
+	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/rpcutil/rpcerror"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/workspace"
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+// secretsProvider reflects a secrets provider plugin, loaded dynamically from another process over gRPC.
+type secretsProvider struct {
+	name      string
+	plug      *plugin                         // the actual plugin process wrapper.
+	clientRaw pulumirpc.SecretsProviderClient // the raw provider client; usually unsafe to use directly.
+}
+
+// NewSecretsProvider loads the secrets provider plugin with the given name and version. Like resource providers, the
+// plugin is passed the address of the engine so that it can exit when the engine does.
+func NewSecretsProvider(ctx *Context, name string, version *semver.Version) (SecretsProvider, error) {
+	prefix := fmt.Sprintf("%v (secrets)", name)
+
+	// Load the plugin's path by using the standard workspace logic.
+	path, err := workspace.GetPluginPath(workspace.SecretsPlugin, name, version, ctx.Host.GetProjectPlugins())
+	if err != nil {
+		return nil, err
+	}
+
+	contract.Assertf(path != "", "unexpected empty path for plugin %s", name)
+
+	plug, err := newPlugin(ctx, ctx.Pwd, path, prefix,
+		workspace.SecretsPlugin, []string{ctx.Host.ServerAddr()}, os.Environ(), secretsPluginDialOptions(ctx, name, ""))
+	if err != nil {
+		return nil, err
+	}
+
+	contract.Assertf(plug != nil, "unexpected nil secrets plugin for %s", name)
+
+	return &secretsProvider{
+		name:      name,
+		plug:      plug,
+		clientRaw: pulumirpc.NewSecretsProviderClient(plug.Conn),
+	}, nil
+}
+
+func secretsPluginDialOptions(ctx *Context, name string, path string) []grpc.DialOption {
+	dialOpts := append(
+		rpcutil.OpenTracingInterceptorDialOptions(otgrpc.SpanDecorator(decorateProviderSpans)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		rpcutil.GrpcChannelOptions(),
+	)
+
+	if ctx.DialOptions != nil {
+		metadata := map[string]interface{}{
+			"mode": "client",
+			"kind": "secrets",
+		}
+		if name != "" {
+			metadata["name"] = name
+		}
+		if path != "" {
+			metadata["path"] = path
+		}
+		dialOpts = append(dialOpts, ctx.DialOptions(metadata)...)
+	}
+
+	return dialOpts
+}
+
+// label returns a base label for tracing functions.
+func (p *secretsProvider) label() string {
+	return fmt.Sprintf("SecretsProvider[%s, %p]", p.name, p)
+}
+
+func (p *secretsProvider) logError(label string, err error) {
+	rpcError := rpcerror.Convert(err)
+	logging.V(8).Infof("%s secrets provider received rpc error `%s`: `%s`", label, rpcError.Code(), rpcError.Message())
+}
+
+func (p *secretsProvider) Close() error {
+	if p.plug == nil {
+		return nil
+	}
+	return p.plug.Close()
+}
+
+func (p *secretsProvider) Initialize(ctx context.Context,
+	url string, state json.RawMessage, rotate bool,
+) (json.RawMessage, error) {
+	label := fmt.Sprintf("%s.Initialize", p.label())
+	logging.V(7).Infof("%s executing", label)
+
+	resp, err := p.clientRaw.Initialize(ctx, &pulumirpc.InitializeSecretsProviderRequest{
+		Url:    url,
+		State:  string(state),
+		Rotate: rotate,
+	})
+	if err != nil {
+		p.logError(label, err)
+		return nil, err
+	}
+
+	logging.V(7).Infof("%s success", label)
+	return json.RawMessage(resp.State), nil
+}
+
+func (p *secretsProvider) Configure(ctx context.Context, state json.RawMessage) error {
+	label := fmt.Sprintf("%s.Configure", p.label())
+	logging.V(7).Infof("%s executing", label)
+
+	_, err := p.clientRaw.Configure(ctx, &pulumirpc.ConfigureSecretsProviderRequest{
+		State: string(state),
+	})
+	if err != nil {
+		p.logError(label, err)
+		return err
+	}
+
+	logging.V(7).Infof("%s success", label)
+	return nil
+}
+
+func (p *secretsProvider) Encrypt(ctx context.Context, plaintext string) (string, error) {
+	label := fmt.Sprintf("%s.Encrypt", p.label())
+	logging.V(9).Infof("%s executing", label)
+
+	resp, err := p.clientRaw.Encrypt(ctx, &pulumirpc.EncryptRequest{Plaintext: plaintext})
+	if err != nil {
+		p.logError(label, err)
+		return "", err
+	}
+	return resp.Ciphertext, nil
+}
+
+func (p *secretsProvider) Decrypt(ctx context.Context, ciphertext string) (string, error) {
+	label := fmt.Sprintf("%s.Decrypt", p.label())
+	logging.V(9).Infof("%s executing", label)
+
+	resp, err := p.clientRaw.Decrypt(ctx, &pulumirpc.DecryptRequest{Ciphertext: ciphertext})
+	if err != nil {
+		p.logError(label, err)
+		return "", err
+	}
+	return resp.Plaintext, nil
+}
+
+func (p *secretsProvider) BulkDecrypt(ctx context.Context, ciphertexts []string) (map[string]string, error) {
+	label := fmt.Sprintf("%s.BulkDecrypt", p.label())
+	logging.V(7).Infof("%s executing", label)
+
+	resp, err := p.clientRaw.BulkDecrypt(ctx, &pulumirpc.BulkDecryptRequest{Ciphertexts: ciphertexts})
+	if err != nil {
+		p.logError(label, err)
+		return nil, err
+	}
+
+	logging.V(7).Infof("%s success", label)
+	return resp.Plaintexts, nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	pbempty "github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pulumirpc "github.com/pulumi/pulumi/sdk/v3/proto/go"
+)
+
+// testSecretsProvider "encrypts" values by prefixing them with its key.
+type testSecretsProvider struct {
+	key string
+}
+
+func (p *testSecretsProvider) Close() error { return nil }
+
+func (p *testSecretsProvider) Initialize(ctx context.Context,
+	url string, state json.RawMessage, rotate bool,
+) (json.RawMessage, error) {
+	if state != nil && !rotate {
+		return state, nil
+	}
+	key := strings.TrimPrefix(url, "test://")
+	if rotate {
+		key += "-rotated"
+	}
+	return json.Marshal(map[string]string{"key": key})
+}
+
+func (p *testSecretsProvider) Configure(ctx context.Context, state json.RawMessage) error {
+	var s map[string]string
+	if err := json.Unmarshal(state, &s); err != nil {
+		return err
+	}
+	p.key = s["key"]
+	return nil
+}
+
+func (p *testSecretsProvider) Encrypt(ctx context.Context, plaintext string) (string, error) {
+	return p.key + ":" + plaintext, nil
+}
+
+func (p *testSecretsProvider) Decrypt(ctx context.Context, ciphertext string) (string, error) {
+	if !strings.HasPrefix(ciphertext, p.key+":") {
+		return "", errors.New("wrong key")
+	}
+	return strings.TrimPrefix(ciphertext, p.key+":"), nil
+}
+
+func (p *testSecretsProvider) BulkDecrypt(ctx context.Context, ciphertexts []string) (map[string]string, error) {
+	plaintexts := map[string]string{}
+	for _, c := range ciphertexts {
+		plaintext, err := p.Decrypt(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		plaintexts[c] = plaintext
+	}
+	return plaintexts, nil
+}
+
+// loopbackSecretsProviderClient calls a secrets provider server directly rather than over gRPC.
+type loopbackSecretsProviderClient struct {
+	server pulumirpc.SecretsProviderServer
+}
+
+// loopbackError converts an error returned by the server into the gRPC error a client would receive.
+func loopbackError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
+
+func (c *loopbackSecretsProviderClient) Initialize(ctx context.Context,
+	req *pulumirpc.InitializeSecretsProviderRequest, opts ...grpc.CallOption,
+) (*pulumirpc.InitializeSecretsProviderResponse, error) {
+	resp, err := c.server.Initialize(ctx, req)
+	return resp, loopbackError(err)
+}
+
+func (c *loopbackSecretsProviderClient) Configure(ctx context.Context,
+	req *pulumirpc.ConfigureSecretsProviderRequest, opts ...grpc.CallOption,
+) (*pbempty.Empty, error) {
+	resp, err := c.server.Configure(ctx, req)
+	return resp, loopbackError(err)
+}
+
+func (c *loopbackSecretsProviderClient) Encrypt(ctx context.Context,
+	req *pulumirpc.EncryptRequest, opts ...grpc.CallOption,
+) (*pulumirpc.EncryptResponse, error) {
+	resp, err := c.server.Encrypt(ctx, req)
+	return resp, loopbackError(err)
+}
+
+func (c *loopbackSecretsProviderClient) Decrypt(ctx context.Context,
+	req *pulumirpc.DecryptRequest, opts ...grpc.CallOption,
+) (*pulumirpc.DecryptResponse, error) {
+	resp, err := c.server.Decrypt(ctx, req)
+	return resp, loopbackError(err)
+}
+
+func (c *loopbackSecretsProviderClient) BulkDecrypt(ctx context.Context,
+	req *pulumirpc.BulkDecryptRequest, opts ...grpc.CallOption,
+) (*pulumirpc.BulkDecryptResponse, error) {
+	resp, err := c.server.BulkDecrypt(ctx, req)
+	return resp, loopbackError(err)
+}
+
+func TestSecretsProviderPlugin(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	plugin := &secretsProvider{
+		clientRaw: &loopbackSecretsProviderClient{
+			server: NewSecretsProviderServer(&testSecretsProvider{}),
+		},
+	}
+
+	state, err := plugin.Initialize(ctx, "test://secret", nil, false)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"key":"secret"}`, string(state))
+
+	// The previous state is kept unless rotating.
+	kept, err := plugin.Initialize(ctx, "test://other", state, false)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(state), string(kept))
+	rotated, err := plugin.Initialize(ctx, "test://secret", state, true)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"key":"secret-rotated"}`, string(rotated))
+
+	require.NoError(t, plugin.Configure(ctx, state))
+
+	ciphertext, err := plugin.Encrypt(ctx, "plaintext")
+	require.NoError(t, err)
+	assert.Equal(t, "secret:plaintext", ciphertext)
+
+	plaintext, err := plugin.Decrypt(ctx, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "plaintext", plaintext)
+
+	plaintexts, err := plugin.BulkDecrypt(ctx, []string{"secret:a", "secret:b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"secret:a": "a", "secret:b": "b"}, plaintexts)
+
+	_, err = plugin.Decrypt(ctx, "other:plaintext")
+	assert.ErrorContains(t, err, "wrong key")
+}
@@ -122,6 +122,18 @@ func InitTracing(name, rootSpanName, tracingEndpoint string) {
 		traceCloser = collector
 		tracer = appdash_opentracing.NewTracer(collector)
 
+	case isOTLPScheme(endpointURL.Scheme):
+		// Store the tracing endpoint. Plugins are passed the same endpoint and export their own spans to it.
+		TracingEndpoint = tracingEndpoint
+
+		// If the endpoint scheme is one of the otlp+ schemes, export spans using the OpenTelemetry protocol.
+		t, closer, err := newOTLPTracer(name, endpointURL)
+		if err != nil {
+			log.Fatalf("Cannot initialize OTLP exporter: %v", err)
+		}
+
+		tracer, traceCloser = t, closer
+
 	default:
 		// Store the tracing endpoint
 		TracingEndpoint = tracingEndpoint
@@ -0,0 +1,502 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	jaeger "github.com/uber/jaeger-client-go"
+	collectortracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/logging"
+)
+
+// The tracing endpoint schemes that export spans using the OpenTelemetry protocol (OTLP).
+const (
+	otlpGRPCScheme  = "otlp+grpc"  // OTLP/gRPC over plaintext, e.g. otlp+grpc://localhost:4317
+	otlpGRPCSScheme = "otlp+grpcs" // OTLP/gRPC over TLS
+	otlpHTTPScheme  = "otlp+http"  // OTLP/HTTP with protobuf payloads, e.g. otlp+http://localhost:4318
+	otlpHTTPSScheme = "otlp+https" // OTLP/HTTP over TLS
+	otlpFileScheme  = "otlp+file"  // OTLP JSON, one export request per line, e.g. otlp+file:///tmp/traces.json
+)
+
+const (
+	otlpBatchSize     = 512
+	otlpQueueSize     = 4 * otlpBatchSize
+	otlpFlushInterval = time.Second
+	otlpTimeout       = 10 * time.Second
+
+	// otlpHeadersEnvVar is the standard OpenTelemetry variable holding the headers to send with each export, as a
+	// comma-separated list of key=value pairs. It is typically used to pass an API key to a collector.
+	otlpHeadersEnvVar = "OTEL_EXPORTER_OTLP_HEADERS"
+)
+
+// isOTLPScheme returns true if the given tracing endpoint scheme exports spans using OTLP.
+func isOTLPScheme(scheme string) bool {
+	switch scheme {
+	case otlpGRPCScheme, otlpGRPCSScheme, otlpHTTPScheme, otlpHTTPSScheme, otlpFileScheme:
+		return true
+	default:
+		return false
+	}
+}
+
+// newOTLPTracer creates a tracer that exports its spans to the given OTLP endpoint. Because the endpoint is also
+// passed to plugins, every process exports its own spans, and the propagated span context nests the spans of plugins
+// under the spans of the engine.
+func newOTLPTracer(name string, endpoint *url.URL) (opentracing.Tracer, io.Closer, error) {
+	exporter, err := newOTLPExporter(endpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	tracer, closer := jaeger.NewTracer(name, jaeger.NewConstSampler(true), newOTLPReporter(name, exporter))
+	return tracer, closer, nil
+}
+
+// otlpExporter sends batches of spans to an OTLP destination.
+type otlpExporter interface {
+	export(req *collectortracepb.ExportTraceServiceRequest) error
+	Close() error
+}
+
+func newOTLPExporter(endpoint *url.URL) (otlpExporter, error) {
+	headers, err := parseOTLPHeaders(os.Getenv(otlpHeadersEnvVar))
+	if err != nil {
+		return nil, err
+	}
+
+	switch endpoint.Scheme {
+	case otlpGRPCScheme, otlpGRPCSScheme:
+		creds := insecure.NewCredentials()
+		if endpoint.Scheme == otlpGRPCSScheme {
+			creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+		}
+		conn, err := grpc.Dial(endpoint.Host, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return nil, err
+		}
+		return &otlpGRPCExporter{
+			conn:    conn,
+			client:  collectortracepb.NewTraceServiceClient(conn),
+			headers: headers,
+		}, nil
+	case otlpHTTPScheme, otlpHTTPSScheme:
+		u := *endpoint
+		u.Scheme = strings.TrimPrefix(endpoint.Scheme, "otlp+")
+		if u.Path == "" || u.Path == "/" {
+			u.Path = "/v1/traces"
+		}
+		return &otlpHTTPExporter{
+			client:  &http.Client{Timeout: otlpTimeout},
+			url:     u.String(),
+			headers: headers,
+		}, nil
+	case otlpFileScheme:
+		path := endpoint.Path
+		if path == "" {
+			path = endpoint.Opaque
+		}
+		if path == "" {
+			return nil, fmt.Errorf("missing path in %v", endpoint)
+		}
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, err
+		}
+		return &otlpFileExporter{f: f}, nil
+	default:
+		return nil, fmt.Errorf("unsupported OTLP scheme %q", endpoint.Scheme)
+	}
+}
+
+// parseOTLPHeaders parses headers in the format of OTEL_EXPORTER_OTLP_HEADERS, e.g. "api-key=abc,tenant=dev".
+func parseOTLPHeaders(s string) (map[string]string, error) {
+	headers := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid %s: expected key=value pairs", otlpHeadersEnvVar)
+		}
+		key, err := url.QueryUnescape(strings.TrimSpace(k))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", otlpHeadersEnvVar, err)
+		}
+		value, err := url.QueryUnescape(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", otlpHeadersEnvVar, err)
+		}
+		headers[strings.ToLower(key)] = value
+	}
+	return headers, nil
+}
+
+// otlpFileExporter appends each export request to a file as a single line of OTLP JSON. The file is opened for
+// appending so that the CLI and its plugins can all write to it.
+type otlpFileExporter struct {
+	f *os.File
+}
+
+func (e *otlpFileExporter) export(req *collectortracepb.ExportTraceServiceRequest) error {
+	b, err := marshalOTLPJSON(req)
+	if err != nil {
+		return err
+	}
+	// Write the line with a single call so that lines from different processes are not interleaved.
+	_, err = e.f.Write(append(b, '\n'))
+	return err
+}
+
+func (e *otlpFileExporter) Close() error {
+	return e.f.Close()
+}
+
+// otlpHTTPExporter posts protobuf-encoded export requests to an OTLP/HTTP endpoint.
+type otlpHTTPExporter struct {
+	client  *http.Client
+	url     string
+	headers map[string]string
+}
+
+func (e *otlpHTTPExporter) export(req *collectortracepb.ExportTraceServiceRequest) error {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range e.headers {
+		httpReq.Header.Set(k, v)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+
+	resp, err := e.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer contract.IgnoreClose(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%v returned %v", e.url, resp.Status)
+	}
+	return nil
+}
+
+func (e *otlpHTTPExporter) Close() error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// otlpGRPCExporter calls the OTLP trace service.
+type otlpGRPCExporter struct {
+	conn    *grpc.ClientConn
+	client  collectortracepb.TraceServiceClient
+	headers map[string]string
+}
+
+func (e *otlpGRPCExporter) export(req *collectortracepb.ExportTraceServiceRequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), otlpTimeout)
+	defer cancel()
+	if len(e.headers) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(e.headers))
+	}
+
+	_, err := e.client.Export(ctx, req)
+	return err
+}
+
+func (e *otlpGRPCExporter) Close() error {
+	return e.conn.Close()
+}
+
+// marshalOTLPJSON encodes an export request as OTLP JSON, which differs from the canonical protobuf JSON mapping in
+// that enums are integers and trace and span IDs are hex strings rather than base64.
+func marshalOTLPJSON(req *collectortracepb.ExportTraceServiceRequest) ([]byte, error) {
+	b, err := protojson.MarshalOptions{UseEnumNumbers: true}.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var doc map[string]interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	for _, resourceSpans := range jsonObjects(doc["resourceSpans"]) {
+		for _, scopeSpans := range jsonObjects(resourceSpans["scopeSpans"]) {
+			for _, span := range jsonObjects(scopeSpans["spans"]) {
+				for _, key := range []string{"traceId", "spanId", "parentSpanId"} {
+					id, ok := span[key].(string)
+					if !ok {
+						continue
+					}
+					raw, err := base64.StdEncoding.DecodeString(id)
+					if err != nil {
+						return nil, fmt.Errorf("decoding %s: %w", key, err)
+					}
+					span[key] = hex.EncodeToString(raw)
+				}
+			}
+		}
+	}
+	return json.Marshal(doc)
+}
+
+// jsonObjects returns the objects in a decoded JSON array.
+func jsonObjects(v interface{}) []map[string]interface{} {
+	array, _ := v.([]interface{})
+	objects := make([]map[string]interface{}, 0, len(array))
+	for _, item := range array {
+		if object, ok := item.(map[string]interface{}); ok {
+			objects = append(objects, object)
+		}
+	}
+	return objects
+}
+
+// otlpReporter is a jaeger.Reporter that converts finished spans to OTLP and exports them in batches from a
+// background goroutine.
+type otlpReporter struct {
+	exporter otlpExporter
+	resource *resourcepb.Resource
+
+	m       sync.Mutex
+	closed  bool
+	dropped int // The number of spans dropped because the queue was full.
+	queue   chan *tracepb.Span
+	done    chan struct{}
+}
+
+func newOTLPReporter(service string, exporter otlpExporter) *otlpReporter {
+	r := &otlpReporter{
+		exporter: exporter,
+		resource: &resourcepb.Resource{Attributes: []*commonpb.KeyValue{
+			newOTLPKeyValue("service.name", service),
+			newOTLPKeyValue("process.pid", os.Getpid()),
+		}},
+		queue: make(chan *tracepb.Span, otlpQueueSize),
+		done:  make(chan struct{}),
+	}
+	go r.run()
+	return r
+}
+
+// Report queues a finished span for export. It never blocks the traced code: if the exporter can't keep up and the
+// queue is full, the span is dropped.
+func (r *otlpReporter) Report(span *jaeger.Span) {
+	s := newOTLPSpan(span)
+
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.closed {
+		return
+	}
+	select {
+	case r.queue <- s:
+	default:
+		r.dropped++
+	}
+}
+
+func (r *otlpReporter) Close() {
+	r.m.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.queue)
+	}
+	dropped := r.dropped
+	r.m.Unlock()
+
+	<-r.done
+	if dropped > 0 {
+		logging.V(5).Infof("dropped %d spans because the OTLP exporter could not keep up", dropped)
+	}
+}
+
+func (r *otlpReporter) run() {
+	defer close(r.done)
+	defer func() {
+		if err := r.exporter.Close(); err != nil {
+			logging.V(5).Infof("closing OTLP exporter: %v", err)
+		}
+	}()
+
+	ticker := time.NewTicker(otlpFlushInterval)
+	defer ticker.Stop()
+
+	var batch []*tracepb.Span
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := r.exporter.export(r.request(batch)); err != nil {
+			logging.V(5).Infof("exporting %d spans: %v", len(batch), err)
+		}
+		batch = nil
+	}
+
+	for {
+		select {
+		case s, ok := <-r.queue:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, s)
+			if len(batch) >= otlpBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (r *otlpReporter) request(spans []*tracepb.Span) *collectortracepb.ExportTraceServiceRequest {
+	return &collectortracepb.ExportTraceServiceRequest{ResourceSpans: []*tracepb.ResourceSpans{{
+		Resource: r.resource,
+		ScopeSpans: []*tracepb.ScopeSpans{{
+			Scope: &commonpb.InstrumentationScope{Name: "github.com/pulumi/pulumi/sdk/v3/go/common/util/cmdutil"},
+			Spans: spans,
+		}},
+	}}}
+}
+
+// newOTLPSpan converts a finished jaeger span to OTLP. Tags become attributes, logs become events, and the
+// OpenTracing span.kind and error tags become the span's kind and status.
+func newOTLPSpan(span *jaeger.Span) *tracepb.Span {
+	sc := span.SpanContext()
+	start := span.StartTime()
+
+	traceID := make([]byte, 16)
+	binary.BigEndian.PutUint64(traceID[:8], sc.TraceID().High)
+	binary.BigEndian.PutUint64(traceID[8:], sc.TraceID().Low)
+	s := &tracepb.Span{
+		TraceId:           traceID,
+		SpanId:            otlpSpanID(sc.SpanID()),
+		Name:              span.OperationName(),
+		Kind:              tracepb.Span_SPAN_KIND_INTERNAL,
+		StartTimeUnixNano: uint64(start.UnixNano()),
+		EndTimeUnixNano:   uint64(start.Add(span.Duration()).UnixNano()),
+		Status:            &tracepb.Status{},
+	}
+	if parent := sc.ParentID(); parent != 0 {
+		s.ParentSpanId = otlpSpanID(parent)
+	}
+
+	for k, v := range span.Tags() {
+		switch k {
+		case string(ext.SpanKind):
+			switch fmt.Sprint(v) {
+			case string(ext.SpanKindRPCServerEnum):
+				s.Kind = tracepb.Span_SPAN_KIND_SERVER
+			case string(ext.SpanKindRPCClientEnum):
+				s.Kind = tracepb.Span_SPAN_KIND_CLIENT
+			case string(ext.SpanKindProducerEnum):
+				s.Kind = tracepb.Span_SPAN_KIND_PRODUCER
+			case string(ext.SpanKindConsumerEnum):
+				s.Kind = tracepb.Span_SPAN_KIND_CONSUMER
+			}
+		case string(ext.Error):
+			if b, ok := v.(bool); ok && b {
+				s.Status.Code = tracepb.Status_STATUS_CODE_ERROR
+			}
+		}
+		s.Attributes = append(s.Attributes, newOTLPKeyValue(k, v))
+	}
+	sort.Slice(s.Attributes, func(i, j int) bool { return s.Attributes[i].Key < s.Attributes[j].Key })
+
+	for _, record := range span.Logs() {
+		e := &tracepb.Span_Event{TimeUnixNano: uint64(record.Timestamp.UnixNano()), Name: "log"}
+		for _, f := range record.Fields {
+			if f.Key() == "event" {
+				e.Name = fmt.Sprint(f.Value())
+				continue
+			}
+			if f.Key() == "message" && s.Status.Code == tracepb.Status_STATUS_CODE_ERROR && s.Status.Message == "" {
+				s.Status.Message = fmt.Sprint(f.Value())
+			}
+			e.Attributes = append(e.Attributes, newOTLPKeyValue(f.Key(), f.Value()))
+		}
+		s.Events = append(s.Events, e)
+	}
+
+	return s
+}
+
+func otlpSpanID(id jaeger.SpanID) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(id))
+	return b
+}
+
+func newOTLPKeyValue(key string, value interface{}) *commonpb.KeyValue {
+	v := &commonpb.AnyValue{}
+	switch value := value.(type) {
+	case string:
+		v.Value = &commonpb.AnyValue_StringValue{StringValue: value}
+	case bool:
+		v.Value = &commonpb.AnyValue_BoolValue{BoolValue: value}
+	case int, int8, int16, int32, int64:
+		v.Value = &commonpb.AnyValue_IntValue{IntValue: reflect.ValueOf(value).Int()}
+	case uint, uint8, uint16, uint32, uint64:
+		if u := reflect.ValueOf(value).Uint(); u <= math.MaxInt64 {
+			v.Value = &commonpb.AnyValue_IntValue{IntValue: int64(u)}
+		} else {
+			v.Value = &commonpb.AnyValue_StringValue{StringValue: strconv.FormatUint(u, 10)}
+		}
+	case float32:
+		v.Value = &commonpb.AnyValue_DoubleValue{DoubleValue: float64(value)}
+	case float64:
+		v.Value = &commonpb.AnyValue_DoubleValue{DoubleValue: value}
+	default:
+		v.Value = &commonpb.AnyValue_StringValue{StringValue: fmt.Sprint(value)}
+	}
+	return &commonpb.KeyValue{Key: key, Value: v}
+}
@@ -0,0 +1,287 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	jaeger "github.com/uber/jaeger-client-go"
+	collectortracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// traceTestSpans records a parent span and a failed child span.
+func traceTestSpans(tracer opentracing.Tracer) {
+	parent := tracer.StartSpan("pulumi-step", opentracing.Tags{
+		"resource.urn": "urn:pulumi:dev::proj::aws:s3/bucket:Bucket::site",
+		"preview":      false,
+	})
+	child := tracer.StartSpan("/pulumirpc.ResourceProvider/Create", opentracing.ChildOf(parent.Context()),
+		ext.SpanKindRPCClient, opentracing.Tag{Key: "attempt", Value: 2})
+	ext.Error.Set(child, true)
+	child.LogKV("event", "error", "message", "access denied")
+	child.Finish()
+	parent.Finish()
+}
+
+func TestOTLPFileExport(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "traces.json")
+	endpoint, err := url.Parse("otlp+file://" + filepath.ToSlash(path))
+	require.NoError(t, err)
+
+	tracer, closer, err := newOTLPTracer("pulumi-cli", endpoint)
+	require.NoError(t, err)
+	traceTestSpans(tracer)
+	require.NoError(t, closer.Close())
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	// Each line is an export request, and the spans may be flushed in more than one batch.
+	type exportRequest struct {
+		ResourceSpans []struct {
+			Resource struct {
+				Attributes []map[string]interface{} `json:"attributes"`
+			} `json:"resource"`
+			ScopeSpans []struct {
+				Spans []map[string]interface{} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	var spans []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		var req exportRequest
+		require.NoError(t, json.Unmarshal([]byte(line), &req))
+		require.Len(t, req.ResourceSpans, 1)
+		assert.Contains(t, req.ResourceSpans[0].Resource.Attributes, map[string]interface{}{
+			"key":   "service.name",
+			"value": map[string]interface{}{"stringValue": "pulumi-cli"},
+		})
+		require.Len(t, req.ResourceSpans[0].ScopeSpans, 1)
+		spans = append(spans, req.ResourceSpans[0].ScopeSpans[0].Spans...)
+	}
+	require.Len(t, spans, 2)
+	child, parent := spans[0], spans[1]
+
+	assert.Equal(t, "pulumi-step", parent["name"])
+	assert.Len(t, parent["traceId"], 32)
+	assert.Len(t, parent["spanId"], 16)
+	assert.NotContains(t, parent, "parentSpanId")
+	assert.Equal(t, float64(tracepb.Span_SPAN_KIND_INTERNAL), parent["kind"])
+	assert.Contains(t, parent["attributes"], map[string]interface{}{
+		"key":   "resource.urn",
+		"value": map[string]interface{}{"stringValue": "urn:pulumi:dev::proj::aws:s3/bucket:Bucket::site"},
+	})
+
+	assert.Equal(t, "/pulumirpc.ResourceProvider/Create", child["name"])
+	assert.Equal(t, parent["traceId"], child["traceId"])
+	assert.Equal(t, parent["spanId"], child["parentSpanId"])
+	assert.Equal(t, float64(tracepb.Span_SPAN_KIND_CLIENT), child["kind"])
+	assert.Contains(t, child["attributes"], map[string]interface{}{
+		"key":   "attempt",
+		"value": map[string]interface{}{"intValue": "2"},
+	})
+	assert.Equal(t, map[string]interface{}{
+		"code":    float64(tracepb.Status_STATUS_CODE_ERROR),
+		"message": "access denied",
+	}, child["status"])
+	assert.Equal(t, "error", child["events"].([]interface{})[0].(map[string]interface{})["name"])
+}
+
+func TestOTLPHTTPExport(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		path, contentType, apiKey string
+		body                      []byte
+	}
+	// The spans may be flushed in more than one batch.
+	requests := make(chan request, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		requests <- request{r.URL.Path, r.Header.Get("Content-Type"), r.Header.Get("api-key"), body}
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	u.Scheme = otlpHTTPScheme
+
+	exporter, err := newOTLPExporter(u)
+	require.NoError(t, err)
+	exporter.(*otlpHTTPExporter).headers["api-key"] = "abc"
+
+	reporter := newOTLPReporter("pulumi-cli", exporter)
+	tracer, closer := jaeger.NewTracer("pulumi-cli", jaeger.NewConstSampler(true), reporter)
+	traceTestSpans(tracer)
+	require.NoError(t, closer.Close())
+	close(requests)
+
+	var names []string
+	for req := range requests {
+		assert.Equal(t, "/v1/traces", req.path)
+		assert.Equal(t, "application/x-protobuf", req.contentType)
+		assert.Equal(t, "abc", req.apiKey)
+
+		var export collectortracepb.ExportTraceServiceRequest
+		require.NoError(t, proto.Unmarshal(req.body, &export))
+		require.Len(t, export.ResourceSpans, 1)
+		require.Len(t, export.ResourceSpans[0].ScopeSpans, 1)
+		for _, span := range export.ResourceSpans[0].ScopeSpans[0].Spans {
+			assert.Len(t, span.TraceId, 16)
+			assert.Len(t, span.SpanId, 8)
+			names = append(names, span.Name)
+		}
+	}
+	assert.Equal(t, []string{"/pulumirpc.ResourceProvider/Create", "pulumi-step"}, names)
+}
+
+// traceService is an OTLP trace service that records the requests it receives.
+type traceService struct {
+	collectortracepb.UnimplementedTraceServiceServer
+
+	requests chan<- *collectortracepb.ExportTraceServiceRequest
+	apiKeys  chan<- string
+}
+
+func (s *traceService) Export(ctx context.Context,
+	req *collectortracepb.ExportTraceServiceRequest,
+) (*collectortracepb.ExportTraceServiceResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.apiKeys <- strings.Join(md.Get("api-key"), ",")
+	s.requests <- req
+	return &collectortracepb.ExportTraceServiceResponse{}, nil
+}
+
+func TestOTLPGRPCExport(t *testing.T) {
+	t.Parallel()
+
+	// The spans may be flushed in more than one batch.
+	requests := make(chan *collectortracepb.ExportTraceServiceRequest, 2)
+	apiKeys := make(chan string, 2)
+	server := grpc.NewServer()
+	collectortracepb.RegisterTraceServiceServer(server, &traceService{requests: requests, apiKeys: apiKeys})
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(l) }()
+	defer server.Stop()
+
+	exporter, err := newOTLPExporter(&url.URL{Scheme: otlpGRPCScheme, Host: l.Addr().String()})
+	require.NoError(t, err)
+	exporter.(*otlpGRPCExporter).headers["api-key"] = "abc"
+
+	reporter := newOTLPReporter("pulumi-cli", exporter)
+	tracer, closer := jaeger.NewTracer("pulumi-cli", jaeger.NewConstSampler(true), reporter)
+	traceTestSpans(tracer)
+	require.NoError(t, closer.Close())
+	close(requests)
+	close(apiKeys)
+
+	for apiKey := range apiKeys {
+		assert.Equal(t, "abc", apiKey)
+	}
+	var spans []*tracepb.Span
+	for req := range requests {
+		for _, resourceSpans := range req.ResourceSpans {
+			for _, scopeSpans := range resourceSpans.ScopeSpans {
+				spans = append(spans, scopeSpans.Spans...)
+			}
+		}
+	}
+	require.Len(t, spans, 2)
+	child, parent := spans[0], spans[1]
+	assert.Equal(t, parent.TraceId, child.TraceId)
+	assert.Equal(t, parent.SpanId, child.ParentSpanId)
+	assert.Equal(t, tracepb.Span_SPAN_KIND_CLIENT, child.Kind)
+	assert.Equal(t, tracepb.Status_STATUS_CODE_ERROR, child.Status.Code)
+	assert.Equal(t, "access denied", child.Status.Message)
+}
+
+func TestParseOTLPHeaders(t *testing.T) {
+	t.Parallel()
+
+	headers, err := parseOTLPHeaders("api-key=abc%3D, X-Tenant = dev,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"api-key": "abc=", "x-tenant": "dev"}, headers)
+
+	_, err = parseOTLPHeaders("api-key")
+	assert.Error(t, err)
+}
+
+// blockingExporter blocks each export until it is unblocked.
+type blockingExporter struct {
+	unblock chan struct{}
+}
+
+func (e *blockingExporter) export(req *collectortracepb.ExportTraceServiceRequest) error {
+	<-e.unblock
+	return nil
+}
+
+func (e *blockingExporter) Close() error {
+	return nil
+}
+
+func TestOTLPReporterDropsSpansWhenFull(t *testing.T) {
+	t.Parallel()
+
+	exporter := &blockingExporter{unblock: make(chan struct{})}
+	reporter := newOTLPReporter("pulumi-cli", exporter)
+	tracer, closer := jaeger.NewTracer("pulumi-cli", jaeger.NewConstSampler(true), reporter)
+
+	// The first batch blocks in the exporter, so the spans after it fill the queue and the rest are dropped rather
+	// than blocking the tracer.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < otlpBatchSize+otlpQueueSize+10; i++ {
+			tracer.StartSpan("span").Finish()
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("reporting spans blocked on a slow exporter")
+	}
+
+	reporter.m.Lock()
+	dropped := reporter.dropped
+	reporter.m.Unlock()
+	assert.GreaterOrEqual(t, dropped, 10)
+
+	close(exporter.unblock)
+	require.NoError(t, closer.Close())
+}
@@ -81,15 +81,8 @@ func DefaultStackConfigValidator(
 		}
 	}
 
-	if !ValidateConfigValue(*projectConfigType.Type, projectConfigType.Items, content) {
-		typeName := InferFullTypeName(*projectConfigType.Type, projectConfigType.Items)
-		validationError := fmt.Errorf(
-			"Stack '%v' with configuration key '%v' must be of type '%v'",
-			stackName,
-			projectConfigKey,
-			typeName)
-
-		return validationError
+	if err := projectConfigType.validateValue(projectConfigKey, content); err != nil {
+		return fmt.Errorf("Stack '%v' with configuration key '%v' %w", stackName, projectConfigKey, err)
 	}
 
 	return nil
@@ -0,0 +1,243 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+)
+
+// ConfigConstraintError is a config value that violates a constraint declared in the project file.
+type ConfigConstraintError struct {
+	// Pointer is a JSON pointer to the violated constraint in the project file, e.g. `#/config/replicas/maximum`.
+	Pointer string
+	// Property is the path of the property that violates the constraint within an object config value, if any.
+	Property string
+	// Message describes the violation, e.g. `must be at most 10`.
+	Message string
+}
+
+func (e *ConfigConstraintError) Error() string {
+	if e.Property != "" {
+		return fmt.Sprintf("property '%s' %s (%s%s)", e.Property, e.Message, ProjectFile+".yaml", e.Pointer)
+	}
+	return fmt.Sprintf("%s (%s%s)", e.Message, ProjectFile+".yaml", e.Pointer)
+}
+
+// ValidateConstraints checks a config value against the constraints that the project declares for the given config
+// key. The value is either a string, as read from a stack's configuration, or a structured value such as a default.
+// It returns a *ConfigConstraintError for the first constraint that the value violates.
+func (configType *ProjectConfigType) ValidateConstraints(configKey string, value interface{}) error {
+	return configType.validateConstraints("#/config/"+escapePointer(configKey), "", value)
+}
+
+func escapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+func (configType *ProjectConfigType) validateConstraints(pointer, property string, value interface{}) error {
+	fail := func(constraint, format string, args ...interface{}) error {
+		return &ConfigConstraintError{
+			Pointer:  pointer + "/" + constraint,
+			Property: property,
+			Message:  fmt.Sprintf(format, args...),
+		}
+	}
+
+	if len(configType.Enum) > 0 {
+		found := false
+		for _, e := range configType.Enum {
+			if fmt.Sprint(e) == fmt.Sprint(value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			allowed := make([]string, len(configType.Enum))
+			for i, e := range configType.Enum {
+				allowed[i] = fmt.Sprintf("'%v'", e)
+			}
+			return fail("enum", "must be one of %s", strings.Join(allowed, ", "))
+		}
+	}
+
+	if configType.Minimum != nil || configType.Maximum != nil {
+		if n, ok := configNumber(value); ok {
+			if configType.Minimum != nil && n < *configType.Minimum {
+				return fail("minimum", "must be at least %v", *configType.Minimum)
+			}
+			if configType.Maximum != nil && n > *configType.Maximum {
+				return fail("maximum", "must be at most %v", *configType.Maximum)
+			}
+		}
+	}
+
+	if s, ok := value.(string); ok {
+		length := utf8.RuneCountInString(s)
+		if configType.MinLength != nil && length < *configType.MinLength {
+			return fail("minLength", "must be at least %d characters long", *configType.MinLength)
+		}
+		if configType.MaxLength != nil && length > *configType.MaxLength {
+			return fail("maxLength", "must be at most %d characters long", *configType.MaxLength)
+		}
+		if configType.Pattern != "" {
+			re, err := regexp.Compile(configType.Pattern)
+			if err != nil {
+				return fail("pattern", "has an invalid pattern: %v", err)
+			}
+			if !re.MatchString(s) {
+				return fail("pattern", "must match the pattern '%s'", configType.Pattern)
+			}
+		}
+	}
+
+	if obj, ok := value.(map[string]interface{}); ok {
+		for _, name := range configType.Required {
+			if _, has := obj[name]; !has {
+				return fail("required", "is missing the required property '%s'", name)
+			}
+		}
+
+		names := make([]string, 0, len(configType.Properties))
+		for name := range configType.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			v, has := obj[name]
+			if !has {
+				continue
+			}
+			propType := configType.Properties[name]
+			propPointer := pointer + "/properties/" + escapePointer(name)
+			propPath := name
+			if property != "" {
+				propPath = property + "." + name
+			}
+			if propType.IsExplicitlyTyped() && !ValidateConfigValue(propType.TypeName(), propType.Items, v) {
+				return &ConfigConstraintError{
+					Pointer:  propPointer + "/type",
+					Property: propPath,
+					Message:  fmt.Sprintf("must be of type '%v'", InferFullTypeName(propType.TypeName(), propType.Items)),
+				}
+			}
+			if err := propType.validateConstraints(propPointer, propPath, v); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// configNumber returns the numeric value of a config value, which may be a number or a string that parses as one.
+func configNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// validateConstraintDeclarations checks that the constraints declared for a config key are well formed.
+func (configType *ProjectConfigType) validateConstraintDeclarations(configKey string) error {
+	if configType.Pattern != "" {
+		if _, err := regexp.Compile(configType.Pattern); err != nil {
+			return fmt.Errorf("The configuration key '%v' has an invalid pattern: %w", configKey, err)
+		}
+	}
+	if configType.Minimum != nil && configType.Maximum != nil && *configType.Minimum > *configType.Maximum {
+		return fmt.Errorf("The configuration key '%v' has a minimum greater than its maximum", configKey)
+	}
+	for _, name := range configType.Required {
+		if _, has := configType.Properties[name]; !has && len(configType.Properties) > 0 {
+			return fmt.Errorf("The configuration key '%v' requires the undeclared property '%v'", configKey, name)
+		}
+	}
+	for name, propType := range configType.Properties {
+		propType := propType
+		if err := propType.validateConstraintDeclarations(configKey + "." + name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConfigDeprecationWarnings returns a warning for each deprecated project config key that is set in the given stack
+// configuration.
+func ConfigDeprecationWarnings(project *Project, stackConfig config.Map) []string {
+	var warnings []string
+	for configKey, configType := range project.Config {
+		if configType.Deprecated == "" {
+			continue
+		}
+		key, err := projectConfigKey(project, configKey)
+		if err != nil {
+			continue
+		}
+		if _, has := stackConfig[key]; has {
+			warnings = append(warnings, fmt.Sprintf("Configuration key '%v' is deprecated: %v", configKey,
+				configType.Deprecated))
+		}
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
+// projectConfigKey returns the stack config key for a config key declared in the project, which is namespaced by the
+// project unless it already has a namespace.
+func projectConfigKey(project *Project, configKey string) (config.Key, error) {
+	if strings.Contains(configKey, ":") {
+		return config.ParseKey(configKey)
+	}
+	return config.MustMakeKey(project.Name.String(), configKey), nil
+}
+
+// ValidateProjectConfigValue validates a value for the given stack config key against the type and constraints that
+// the project declares for it, if any.
+func ValidateProjectConfigValue(project *Project, key config.Key, value interface{}) error {
+	for configKey, configType := range project.Config {
+		declared, err := projectConfigKey(project, configKey)
+		if err != nil || declared != key || !configType.IsExplicitlyTyped() {
+			continue
+		}
+		if err := configType.validateValue(configKey, value); err != nil {
+			return fmt.Errorf("Configuration key '%v' %w", configKey, err)
+		}
+		return nil
+	}
+	return nil
+}
+
+// validateValue checks a config value against both the declared type and the constraints of a config key.
+func (configType *ProjectConfigType) validateValue(configKey string, value interface{}) error {
+	if !ValidateConfigValue(configType.TypeName(), configType.Items, value) {
+		return fmt.Errorf("must be of type '%v'", InferFullTypeName(configType.TypeName(), configType.Items))
+	}
+	return configType.ValidateConstraints(configKey, value)
+}
@@ -0,0 +1,194 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+)
+
+const constrainedProject = `
+name: test
+runtime: nodejs
+config:
+  env:
+    type: string
+    enum: [dev, staging, prod]
+  replicas:
+    type: integer
+    minimum: 1
+    maximum: 10
+  bucket:
+    type: string
+    pattern: ^[a-z0-9-]+$
+    minLength: 3
+  database:
+    type: object
+    required: [host]
+    properties:
+      host:
+        type: string
+      port:
+        type: integer
+        maximum: 65535
+  oldName:
+    type: string
+    deprecated: use newName instead
+    default: old
+`
+
+func TestConfigConstraints(t *testing.T) {
+	t.Parallel()
+
+	project, err := loadProjectFromText(t, constrainedProject)
+	require.NoError(t, err)
+
+	stackConfig := func(values map[string]config.Value) config.Map {
+		m := config.Map{
+			config.MustMakeKey("test", "env"):      config.NewValue("dev"),
+			config.MustMakeKey("test", "replicas"): config.NewValue("3"),
+			config.MustMakeKey("test", "bucket"):   config.NewValue("my-bucket"),
+			config.MustMakeKey("test", "database"): config.NewObjectValue(`{"host":"db","port":5432}`),
+		}
+		for k, v := range values {
+			m[config.MustMakeKey("test", k)] = v
+		}
+		return m
+	}
+
+	tests := []struct {
+		Name   string
+		Values map[string]config.Value
+		Error  string
+	}{
+		{Name: "valid"},
+		{
+			Name:   "enum",
+			Values: map[string]config.Value{"env": config.NewValue("qa")},
+			Error: "Stack 'dev' with configuration key 'env' must be one of 'dev', 'staging', 'prod' " +
+				"(Pulumi.yaml#/config/env/enum)",
+		},
+		{
+			Name:   "maximum",
+			Values: map[string]config.Value{"replicas": config.NewValue("11")},
+			Error:  "Stack 'dev' with configuration key 'replicas' must be at most 10 (Pulumi.yaml#/config/replicas/maximum)",
+		},
+		{
+			Name:   "minimum",
+			Values: map[string]config.Value{"replicas": config.NewValue("0")},
+			Error:  "Stack 'dev' with configuration key 'replicas' must be at least 1 (Pulumi.yaml#/config/replicas/minimum)",
+		},
+		{
+			Name:   "pattern",
+			Values: map[string]config.Value{"bucket": config.NewValue("My_Bucket")},
+			Error: "Stack 'dev' with configuration key 'bucket' must match the pattern '^[a-z0-9-]+$' " +
+				"(Pulumi.yaml#/config/bucket/pattern)",
+		},
+		{
+			Name:   "minLength",
+			Values: map[string]config.Value{"bucket": config.NewValue("ab")},
+			Error: "Stack 'dev' with configuration key 'bucket' must be at least 3 characters long " +
+				"(Pulumi.yaml#/config/bucket/minLength)",
+		},
+		{
+			Name:   "required",
+			Values: map[string]config.Value{"database": config.NewObjectValue(`{"port":5432}`)},
+			Error: "Stack 'dev' with configuration key 'database' is missing the required property 'host' " +
+				"(Pulumi.yaml#/config/database/required)",
+		},
+		{
+			Name:   "nested",
+			Values: map[string]config.Value{"database": config.NewObjectValue(`{"host":"db","port":70000}`)},
+			Error: "Stack 'dev' with configuration key 'database' property 'port' must be at most 65535 " +
+				"(Pulumi.yaml#/config/database/properties/port/maximum)",
+		},
+		{
+			Name:   "nested type",
+			Values: map[string]config.Value{"database": config.NewObjectValue(`{"host":1}`)},
+			Error: "Stack 'dev' with configuration key 'database' property 'host' must be of type 'string' " +
+				"(Pulumi.yaml#/config/database/properties/host/type)",
+		},
+		{
+			Name:   "type",
+			Values: map[string]config.Value{"database": config.NewValue("db")},
+			Error:  "Stack 'dev' with configuration key 'database' must be of type 'object'",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.Name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateStackConfigAndApplyProjectConfig("dev", project, stackConfig(test.Values),
+				config.NewPanicCrypter())
+			if test.Error == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.Error)
+			}
+		})
+	}
+}
+
+func TestConfigConstraintDeclarations(t *testing.T) {
+	t.Parallel()
+
+	_, err := loadProjectFromText(t, `
+name: test
+runtime: nodejs
+config:
+  replicas:
+    type: integer
+    maximum: 3
+    default: 5
+`)
+	assert.ErrorContains(t, err, "The default value specified for configuration key 'replicas' must be at most 3 "+
+		"(Pulumi.yaml#/config/replicas/maximum)")
+
+	_, err = loadProjectFromText(t, `
+name: test
+runtime: nodejs
+config:
+  bucket:
+    type: string
+    pattern: "[a-z"
+`)
+	assert.ErrorContains(t, err, "The configuration key 'bucket' has an invalid pattern")
+}
+
+func TestValidateProjectConfigValue(t *testing.T) {
+	t.Parallel()
+
+	project, err := loadProjectFromText(t, constrainedProject)
+	require.NoError(t, err)
+
+	assert.NoError(t, ValidateProjectConfigValue(project, config.MustMakeKey("test", "replicas"), "5"))
+	assert.EqualError(t, ValidateProjectConfigValue(project, config.MustMakeKey("test", "replicas"), "five"),
+		"Configuration key 'replicas' must be of type 'integer'")
+	assert.EqualError(t, ValidateProjectConfigValue(project, config.MustMakeKey("test", "env"), "qa"),
+		"Configuration key 'env' must be one of 'dev', 'staging', 'prod' (Pulumi.yaml#/config/env/enum)")
+	assert.NoError(t, ValidateProjectConfigValue(project, config.MustMakeKey("other", "env"), "qa"))
+
+	warnings := ConfigDeprecationWarnings(project, config.Map{
+		config.MustMakeKey("test", "oldName"): config.NewValue("x"),
+	})
+	assert.Equal(t, []string{"Configuration key 'oldName' is deprecated: use newName instead"}, warnings)
+	assert.Empty(t, ConfigDeprecationWarnings(project, config.Map{}))
+}
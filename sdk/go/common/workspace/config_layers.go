@@ -0,0 +1,166 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+// stackLayerPrefix marks a base layer that refers to the configuration of another stack of the project.
+const stackLayerPrefix = "stack:"
+
+// ConfigLayer is a set of configuration values that contributes to a stack's effective configuration.
+type ConfigLayer struct {
+	// Origin is the path of the file that the layer was read from, relative to the stack's configuration file.
+	Origin string
+	// Config is the configuration values of the layer.
+	Config config.Map
+}
+
+// LoadConfigLayers returns the layers of configuration for the stack whose configuration file is at the given path, in
+// the order in which they are merged: first the layers it extends, each preceded by its own base layers, and last the
+// stack's own configuration.
+//
+// A base layer can only contain secure values if it has the same secrets provider and data key or salt as the stack,
+// since they can't otherwise be decrypted with the stack's secrets provider. Use a `{fromEnv: NAME, secret: true}`
+// reference, or set the secret in each stack, instead.
+func LoadConfigLayers(project *Project, path string, ps *ProjectStack) ([]ConfigLayer, error) {
+	root := filepath.Dir(path)
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &configLayerLoader{
+		project:  project,
+		stack:    ps,
+		root:     root,
+		ext:      filepath.Ext(path),
+		visiting: map[string]bool{abs: true},
+	}
+	if err := l.loadBases(root, ps.Extends); err != nil {
+		return nil, err
+	}
+	l.layers = append(l.layers, ConfigLayer{Origin: filepath.Base(path), Config: ps.Config})
+	return l.layers, nil
+}
+
+type configLayerLoader struct {
+	project  *Project
+	stack    *ProjectStack
+	root     string
+	ext      string
+	visiting map[string]bool
+	layers   []ConfigLayer
+}
+
+// layerPath returns the path of the file for a base layer that is referenced from a file in dir. Stack layers use the
+// same extension as the stack's own configuration file.
+func (l *configLayerLoader) layerPath(dir, layer string) string {
+	if strings.HasPrefix(layer, stackLayerPrefix) {
+		name := strings.TrimPrefix(layer, stackLayerPrefix)
+		return filepath.Join(dir, fmt.Sprintf("%s.%s%s", ProjectFile, qnameFileName(tokens.QName(name)), l.ext))
+	}
+	if filepath.IsAbs(layer) {
+		return layer
+	}
+	return filepath.Join(dir, layer)
+}
+
+func (l *configLayerLoader) loadBases(dir string, extends []string) error {
+	for _, layer := range extends {
+		path := l.layerPath(dir, layer)
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		if l.visiting[abs] {
+			return fmt.Errorf("configuration layer %q extends itself", layer)
+		}
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("loading configuration layer %q: %w", layer, err)
+		}
+
+		base, err := LoadProjectStack(l.project, path)
+		if err != nil {
+			return fmt.Errorf("loading configuration layer %q: %w", layer, err)
+		}
+		if keys := base.Config.SecureKeys(); len(keys) > 0 && !sameSecretsConfig(base, l.stack) {
+			return fmt.Errorf("configuration layer %q contains the secret value '%v', which is encrypted with a "+
+				"different key than this stack's secrets; set it in each stack or use a "+
+				"`{fromEnv: NAME, secret: true}` reference instead", layer, keys[0])
+		}
+
+		l.visiting[abs] = true
+		if err := l.loadBases(filepath.Dir(path), base.Extends); err != nil {
+			return err
+		}
+		delete(l.visiting, abs)
+
+		origin, err := filepath.Rel(l.root, path)
+		if err != nil {
+			origin = path
+		}
+		l.layers = append(l.layers, ConfigLayer{Origin: origin, Config: base.Config})
+	}
+	return nil
+}
+
+// sameSecretsConfig returns true if the secure values of both stacks are encrypted with the same key. Stacks without
+// any key material in their configuration, such as those using the service's secrets provider, are never the same,
+// since each of them is encrypted with its own per-stack key.
+func sameSecretsConfig(a, b *ProjectStack) bool {
+	if a.EncryptedKey == "" && a.EncryptionSalt == "" && a.SecretsProviderState == "" {
+		return false
+	}
+	return effectiveSecretsProvider(a) == effectiveSecretsProvider(b) &&
+		a.EncryptedKey == b.EncryptedKey &&
+		a.EncryptionSalt == b.EncryptionSalt &&
+		a.SecretsProviderState == b.SecretsProviderState
+}
+
+// effectiveSecretsProvider returns the secrets provider that encrypts the secure values of a stack. A stack that
+// doesn't name a secrets provider uses the passphrase provider if it has a salt, and otherwise the backend's default
+// secrets provider, i.e. the service.
+func effectiveSecretsProvider(ps *ProjectStack) string {
+	switch {
+	case ps.SecretsProvider != "" && ps.SecretsProvider != "default":
+		return ps.SecretsProvider
+	case ps.EncryptionSalt != "":
+		return "passphrase"
+	default:
+		return "service"
+	}
+}
+
+// MergeConfigLayers merges the given layers in order, so that a value in a later layer replaces the value for the
+// same key in an earlier one. It returns the merged configuration and the origin of each of its values.
+func MergeConfigLayers(layers []ConfigLayer) (config.Map, map[config.Key]string) {
+	merged := make(config.Map)
+	origins := make(map[config.Key]string)
+	for _, layer := range layers {
+		for k, v := range layer.Config {
+			merged[k] = v
+			origins[k] = layer.Origin
+		}
+	}
+	return merged, origins
+}
@@ -0,0 +1,127 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+)
+
+func writeLayerFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	return dir
+}
+
+func loadLayers(t *testing.T, dir, stack string) ([]ConfigLayer, error) {
+	project := &Project{Name: "test", Runtime: NewProjectRuntimeInfo("nodejs", nil)}
+	path := filepath.Join(dir, "Pulumi."+stack+".yaml")
+	ps, err := LoadProjectStack(project, path)
+	require.NoError(t, err)
+	return LoadConfigLayers(project, path, ps)
+}
+
+func TestConfigLayers(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLayerFiles(t, map[string]string{
+		"shared/base.yaml":    "config:\n  region: us-east-1\n  size: small\n  owner: platform\n",
+		"shared/prod.yaml":    "extends: [base.yaml]\nconfig:\n  size: large\n",
+		"Pulumi.staging.yaml": "config:\n  owner: staging-team\n  replicas: 2\n",
+		"Pulumi.prod.yaml": "extends:\n  - shared/prod.yaml\n  - stack:staging\n" +
+			"config:\n  replicas: 5\n",
+	})
+
+	layers, err := loadLayers(t, dir, "prod")
+	require.NoError(t, err)
+	origins := make([]string, len(layers))
+	for i, l := range layers {
+		origins[i] = l.Origin
+	}
+	assert.Equal(t, []string{
+		filepath.Join("shared", "base.yaml"),
+		filepath.Join("shared", "prod.yaml"),
+		"Pulumi.staging.yaml",
+		"Pulumi.prod.yaml",
+	}, origins)
+
+	merged, mergedOrigins := MergeConfigLayers(layers)
+	assert.Equal(t, config.Map{
+		config.MustMakeKey("test", "region"):   config.NewValue("us-east-1"),
+		config.MustMakeKey("test", "size"):     config.NewValue("large"),
+		config.MustMakeKey("test", "owner"):    config.NewValue("staging-team"),
+		config.MustMakeKey("test", "replicas"): config.NewValue("5"),
+	}, merged)
+	assert.Equal(t, filepath.Join("shared", "base.yaml"), mergedOrigins[config.MustMakeKey("test", "region")])
+	assert.Equal(t, filepath.Join("shared", "prod.yaml"), mergedOrigins[config.MustMakeKey("test", "size")])
+	assert.Equal(t, "Pulumi.staging.yaml", mergedOrigins[config.MustMakeKey("test", "owner")])
+	assert.Equal(t, "Pulumi.prod.yaml", mergedOrigins[config.MustMakeKey("test", "replicas")])
+}
+
+func TestConfigLayersErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := writeLayerFiles(t, map[string]string{
+		"Pulumi.loop.yaml":    "extends: [a.yaml]\n",
+		"a.yaml":              "extends: [b.yaml]\n",
+		"b.yaml":              "extends: [a.yaml]\n",
+		"Pulumi.missing.yaml": "extends: [nope.yaml]\n",
+		"Pulumi.secret.yaml":  "encryptionsalt: salt1\nextends: ['stack:other']\n",
+		"Pulumi.other.yaml":   "encryptionsalt: salt2\nconfig:\n  password:\n    secure: ciphertext\n",
+		"Pulumi.shared.yaml":  "encryptionsalt: salt2\nextends: ['stack:other']\n",
+	})
+
+	_, err := loadLayers(t, dir, "loop")
+	assert.ErrorContains(t, err, `configuration layer "a.yaml" extends itself`)
+
+	_, err = loadLayers(t, dir, "missing")
+	assert.ErrorContains(t, err, `loading configuration layer "nope.yaml"`)
+
+	_, err = loadLayers(t, dir, "secret")
+	assert.ErrorContains(t, err, `configuration layer "stack:other" contains the secret value 'test:password'`)
+
+	// A stack with the same salt can decrypt the layer's secrets.
+	layers, err := loadLayers(t, dir, "shared")
+	require.NoError(t, err)
+	assert.Len(t, layers, 2)
+}
+
+func TestConfigLayersServiceSecrets(t *testing.T) {
+	t.Parallel()
+
+	// Stacks using the service's secrets provider have no key material in their configuration files.
+	dir := writeLayerFiles(t, map[string]string{
+		"shared.yaml":          "config:\n  password:\n    secure: ciphertext\n",
+		"Pulumi.service.yaml":  "extends: [shared.yaml]\n",
+		"Pulumi.explicit.yaml": "secretsprovider: default\nextends: [shared.yaml]\n",
+		"Pulumi.salted.yaml":   "encryptionsalt: salt\nextends: [shared.yaml]\n",
+	})
+
+	// The service encrypts each stack's secrets with its own key, so a layer's secrets can't be shared between them.
+	for _, stack := range []string{"service", "explicit", "salted"} {
+		_, err := loadLayers(t, dir, stack)
+		assert.ErrorContains(t, err, `configuration layer "shared.yaml" contains the secret value 'test:password'`)
+	}
+}
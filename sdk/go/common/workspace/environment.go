@@ -0,0 +1,218 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// ProjectEnvironment declares the environment variables that are set for the language host and resource provider
+// plugins of a project or stack.
+type ProjectEnvironment struct {
+	// Dotenv lists dotenv files to load variables from, relative to the file that declares them. Later files
+	// override earlier ones.
+	Dotenv []string `json:"dotenv,omitempty" yaml:"dotenv,omitempty"`
+	// Variables are the variables to set, which override those loaded from dotenv files. In a stack's configuration
+	// file, variables can be secure values that are encrypted with the stack's secrets provider.
+	Variables map[string]config.Value `json:"variables,omitempty" yaml:"variables,omitempty"`
+}
+
+// HasSecureValue returns true if any of the environment's variables is a secure value.
+func (e *ProjectEnvironment) HasSecureValue() bool {
+	if e == nil {
+		return false
+	}
+	for _, v := range e.Variables {
+		if v.Secure() {
+			return true
+		}
+	}
+	return false
+}
+
+// EnvironmentVariable is a variable of a stack's effective environment.
+type EnvironmentVariable struct {
+	// Name is the name of the variable.
+	Name string
+	// Value is the plaintext value of the variable.
+	Value string
+	// Secret is true if the value was declared as a secure value or loaded from a dotenv file, which should not be
+	// displayed.
+	Secret bool
+	// Origin is the file that declared the value.
+	Origin string
+}
+
+// LoadEnvironment returns the effective environment of a stack, sorted by name. Variables declared by the project
+// file are overridden by those of the stack's configuration file, and in each file variables override those loaded
+// from its dotenv files. The stack's secure values are decrypted with the given decrypter.
+func LoadEnvironment(project *Project, projectDir string, ps *ProjectStack, stackPath string,
+	decrypter config.Decrypter,
+) ([]EnvironmentVariable, error) {
+	vars := make(map[string]EnvironmentVariable)
+
+	if project != nil && project.Environment != nil {
+		if project.Environment.HasSecureValue() {
+			return nil, fmt.Errorf("%s.yaml: environment variables cannot be secure values; "+
+				"declare secret variables in a stack's configuration file instead", ProjectFile)
+		}
+		if err := loadEnvironmentLayer(vars, project.Environment, projectDir, ProjectFile+".yaml", nil); err != nil {
+			return nil, err
+		}
+	}
+	if ps != nil && ps.Environment != nil {
+		err := loadEnvironmentLayer(vars, ps.Environment, filepath.Dir(stackPath), filepath.Base(stackPath), decrypter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]EnvironmentVariable, 0, len(vars))
+	for _, v := range vars {
+		result = append(result, v)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+func loadEnvironmentLayer(vars map[string]EnvironmentVariable, env *ProjectEnvironment, dir, origin string,
+	decrypter config.Decrypter,
+) error {
+	for _, file := range env.Dotenv {
+		path := file
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		values, err := ReadDotenvFile(path)
+		if err != nil {
+			return err
+		}
+		for name, value := range values {
+			vars[name] = EnvironmentVariable{Name: name, Value: value, Secret: true, Origin: file}
+		}
+	}
+
+	for name, v := range env.Variables {
+		if !validEnvironmentVariableName.MatchString(name) {
+			return fmt.Errorf("%s: invalid environment variable name '%s'", origin, name)
+		}
+		if v.Object() {
+			return fmt.Errorf("%s: environment variable '%s' must be a string", origin, name)
+		}
+		if v.Secure() && decrypter == nil {
+			return fmt.Errorf("%s: environment variable '%s' is secure but no decrypter is available", origin, name)
+		}
+		value, err := v.Value(decrypter)
+		if err != nil {
+			return fmt.Errorf("%s: decrypting environment variable '%s': %w", origin, name, err)
+		}
+		vars[name] = EnvironmentVariable{Name: name, Value: value, Secret: v.Secure(), Origin: origin}
+	}
+	return nil
+}
+
+var validEnvironmentVariableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// IsValidEnvironmentVariableName returns true if the given name is a valid name for an environment variable.
+func IsValidEnvironmentVariableName(name string) bool {
+	return validEnvironmentVariableName.MatchString(name)
+}
+
+// ReadDotenvFile reads the variables of the dotenv file at the given path.
+func ReadDotenvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading dotenv file: %w", err)
+	}
+	defer contract.IgnoreClose(f)
+
+	values, err := ParseDotenv(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return values, nil
+}
+
+// ParseDotenv parses the variables of a dotenv file. Each non-empty line that isn't a `#` comment is an assignment
+// of the form `NAME=value`, optionally preceded by `export`. Values can be single quoted, which are taken literally,
+// or double quoted, in which `\n`, `\"` and `\\` are unescaped. Variable references are not expanded.
+func ParseDotenv(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		name, value, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok || !validEnvironmentVariableName.MatchString(name) {
+			return nil, fmt.Errorf("line %d: expected NAME=value", lineNumber)
+		}
+
+		value, err := parseDotenvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		values[name] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func parseDotenvValue(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	switch quote := value[0]; quote {
+	case '\'', '"':
+		end := strings.LastIndexByte(value, quote)
+		if end == 0 {
+			return "", fmt.Errorf("unterminated %c quoted value", quote)
+		}
+		if rest := strings.TrimSpace(value[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", errors.New("unexpected characters after quoted value")
+		}
+		value = value[1:end]
+		if quote == '"' {
+			value = strings.NewReplacer(`\n`, "\n", `\"`, `"`, `\\`, `\`).Replace(value)
+		}
+		return value, nil
+	default:
+		// Unquoted values end at a comment that is preceded by whitespace.
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+		return value, nil
+	}
+}
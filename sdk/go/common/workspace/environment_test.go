@@ -0,0 +1,139 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource/config"
+)
+
+func TestParseDotenv(t *testing.T) {
+	t.Parallel()
+
+	values, err := ParseDotenv(strings.NewReader(`
+# a comment
+PLAIN=value
+export EXPORTED=exported
+SPACED = spaced value # trailing comment
+SINGLE='literal \n $HOME'
+DOUBLE="line1\nline2 \"quoted\""
+HASH=abc#def
+EMPTY=
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"PLAIN":    "value",
+		"EXPORTED": "exported",
+		"SPACED":   "spaced value",
+		"SINGLE":   `literal \n $HOME`,
+		"DOUBLE":   "line1\nline2 \"quoted\"",
+		"HASH":     "abc#def",
+		"EMPTY":    "",
+	}, values)
+
+	_, err = ParseDotenv(strings.NewReader("OK=1\nnot an assignment\n"))
+	assert.EqualError(t, err, "line 2: expected NAME=value")
+
+	_, err = ParseDotenv(strings.NewReader(`UNTERMINATED="abc`))
+	assert.EqualError(t, err, "line 1: unterminated \" quoted value")
+}
+
+func TestLoadEnvironment(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("SHARED=dotenv\nTOKEN=from-dotenv\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env.dev"), []byte("DEV_ONLY=1\n"), 0o600))
+
+	project := &Project{
+		Name: "test",
+		Environment: &ProjectEnvironment{
+			Dotenv: []string{".env"},
+			Variables: map[string]config.Value{
+				"SHARED":    config.NewValue("project"),
+				"LOG_LEVEL": config.NewValue("info"),
+			},
+		},
+	}
+	ps := &ProjectStack{
+		Environment: &ProjectEnvironment{
+			Dotenv: []string{".env.dev"},
+			Variables: map[string]config.Value{
+				"LOG_LEVEL": config.NewValue("debug"),
+				"TOKEN":     config.NewSecureValue("c2VjcmV0"),
+			},
+		},
+	}
+
+	vars, err := LoadEnvironment(project, dir, ps, filepath.Join(dir, "Pulumi.dev.yaml"), base64Decrypter{})
+	require.NoError(t, err)
+	assert.Equal(t, []EnvironmentVariable{
+		{Name: "DEV_ONLY", Value: "1", Secret: true, Origin: ".env.dev"},
+		{Name: "LOG_LEVEL", Value: "debug", Origin: "Pulumi.dev.yaml"},
+		{Name: "SHARED", Value: "project", Origin: "Pulumi.yaml"},
+		{Name: "TOKEN", Value: "secret", Secret: true, Origin: "Pulumi.dev.yaml"},
+	}, vars)
+
+	project.Environment.Variables["BAD"] = config.NewSecureValue("c2VjcmV0")
+	_, err = LoadEnvironment(project, dir, nil, "", nil)
+	assert.ErrorContains(t, err, "environment variables cannot be secure values")
+}
+
+// base64Decrypter is a decrypter whose ciphertexts are the base64 encoding of their plaintext.
+type base64Decrypter struct{}
+
+func (base64Decrypter) DecryptValue(_ context.Context, ciphertext string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(ciphertext)
+	return string(b), err
+}
+
+func (d base64Decrypter) BulkDecrypt(ctx context.Context, ciphertexts []string) (map[string]string, error) {
+	return config.DefaultBulkDecrypt(ctx, d, ciphertexts)
+}
+
+func TestProjectEnvironmentSchema(t *testing.T) {
+	t.Parallel()
+
+	project, err := loadProjectFromText(t, `
+name: test
+runtime: nodejs
+environment:
+  dotenv: [.env]
+  variables:
+    LOG_LEVEL: debug
+`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{".env"}, project.Environment.Dotenv)
+	assert.Equal(t, config.NewValue("debug"), project.Environment.Variables["LOG_LEVEL"])
+
+	_, err = loadProjectFromText(t, `
+name: test
+runtime: nodejs
+environment:
+  variables:
+    TOKEN:
+      secure: c2VjcmV0
+`)
+	assert.Error(t, err)
+}
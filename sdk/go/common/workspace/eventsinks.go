@@ -0,0 +1,42 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workspace
+
+// EventSink configures a destination that the engine events of each update of a stack are streamed to, as the JSON
+// engine events written by `--event-log`. Exactly one of Webhook, Socket and Stdout must be set.
+type EventSink struct {
+	// Name identifies the sink in messages. Defaults to its destination.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// Webhook is the URL of an HTTP endpoint that batches of events are posted to. Environment variables in the URL,
+	// such as ${SLACK_WEBHOOK_TOKEN}, are expanded.
+	Webhook string `json:"webhook,omitempty" yaml:"webhook,omitempty"`
+	// Socket is the path of a Unix domain socket that events are written to as newline-delimited JSON.
+	Socket string `json:"socket,omitempty" yaml:"socket,omitempty"`
+	// Stdout writes events to standard output as newline-delimited JSON. It can only be used if the display doesn't
+	// write to standard output while the update runs, e.g. with `--output json`.
+	Stdout bool `json:"stdout,omitempty" yaml:"stdout,omitempty"`
+	// Headers are additional headers sent with each webhook request. Environment variables in the values are
+	// expanded, so that tokens need not be stored in the file.
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	// Events lists the types of the events to send, such as resourcePreEvent or diagnosticEvent. All events are sent
+	// if it is empty.
+	Events []string `json:"events,omitempty" yaml:"events,omitempty"`
+	// BatchSize is the maximum number of events sent at once. Defaults to 100.
+	BatchSize int `json:"batchSize,omitempty" yaml:"batchSize,omitempty"`
+	// FlushInterval is the longest that events are held before they are sent, such as "500ms". Defaults to 1s.
+	FlushInterval string `json:"flushInterval,omitempty" yaml:"flushInterval,omitempty"`
+	// Retries is the number of times that sending a batch is retried before it is dropped. Defaults to 3.
+	Retries *int `json:"retries,omitempty" yaml:"retries,omitempty"`
+}
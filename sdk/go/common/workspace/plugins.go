@@ -359,6 +359,10 @@ func newGithubSource(url *url.URL, name string, kind PluginKind) (*githubSource,
 	}
 
 	repository := "pulumi-" + name
+	if kind == SecretsPlugin {
+		// Secrets plugins are expected at their own repo path, e.g. github.com/pulumi/pulumi-secrets-sops.
+		repository = "pulumi-secrets-" + name
+	}
 	if kind == ConverterPlugin {
 		// Converter plugins are expected at a different repo path, e.g.
 		// github.com/pulumi/pulumi-converter-aws rather than github.com/pulumi/pulumi-aws which would clash
@@ -1503,12 +1507,14 @@ const (
 	ResourcePlugin PluginKind = "resource"
 	// ConverterPlugin is a plugin that can be used to convert from other ecosystems to Pulumi.
 	ConverterPlugin PluginKind = "converter"
+	// SecretsPlugin is a plugin that can be used as a secrets provider to encrypt and decrypt a stack's secrets.
+	SecretsPlugin PluginKind = "secrets"
 )
 
 // IsPluginKind returns true if k is a valid plugin kind, and false otherwise.
 func IsPluginKind(k string) bool {
 	switch PluginKind(k) {
-	case AnalyzerPlugin, LanguagePlugin, ResourcePlugin, ConverterPlugin:
+	case AnalyzerPlugin, LanguagePlugin, ResourcePlugin, ConverterPlugin, SecretsPlugin:
 		return true
 	default:
 		return false
@@ -39,6 +39,7 @@ const (
 	integerTypeName = "integer"
 	stringTypeName  = "string"
 	booleanTypeName = "boolean"
+	objectTypeName  = "object"
 )
 
 //go:embed project.json
@@ -103,6 +104,22 @@ type Plugins struct {
 	Providers []PluginOptions `json:"providers,omitempty" yaml:"providers,omitempty"`
 	Languages []PluginOptions `json:"languages,omitempty" yaml:"languages,omitempty"`
 	Analyzers []PluginOptions `json:"analyzers,omitempty" yaml:"analyzers,omitempty"`
+	// Converters pin the converter plugins used by `pulumi convert`. Unlike the other plugin kinds, the path of a
+	// converter is optional: a converter with only a version pins the version loaded from the plugin cache.
+	Converters []PluginOptions `json:"converters,omitempty" yaml:"converters,omitempty"`
+}
+
+// GetConverter returns the options for the converter plugin with the given name, if any.
+func (p *Plugins) GetConverter(name string) (PluginOptions, bool) {
+	if p == nil {
+		return PluginOptions{}, false
+	}
+	for _, c := range p.Converters {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return PluginOptions{}, false
 }
 
 type ProjectConfigItemsType struct {
@@ -117,6 +134,26 @@ type ProjectConfigType struct {
 	Default     interface{}             `json:"default,omitempty" yaml:"default,omitempty"`
 	Value       interface{}             `json:"value,omitempty" yaml:"value,omitempty"`
 	Secret      bool                    `json:"secret,omitempty" yaml:"secret,omitempty"`
+
+	// Enum lists the values that the config value may take.
+	Enum []interface{} `json:"enum,omitempty" yaml:"enum,omitempty"`
+	// Minimum is the smallest value that an integer config value may take.
+	Minimum *float64 `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	// Maximum is the largest value that an integer config value may take.
+	Maximum *float64 `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	// Pattern is a regular expression that a string config value must match.
+	Pattern string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	// MinLength is the minimum length of a string config value.
+	MinLength *int `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	// MaxLength is the maximum length of a string config value.
+	MaxLength *int `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	// Properties declares the properties of an object config value.
+	Properties map[string]ProjectConfigType `json:"properties,omitempty" yaml:"properties,omitempty"`
+	// Required lists the properties that an object config value must have.
+	Required []string `json:"required,omitempty" yaml:"required,omitempty"`
+	// Deprecated is a message explaining what to use instead of this config value. Setting a deprecated config value
+	// prints a warning.
+	Deprecated string `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
 }
 
 // IsExplicitlyTyped returns whether the project config type is explicitly typed.
@@ -176,6 +213,12 @@ type Project struct {
 
 	Plugins *Plugins `json:"plugins,omitempty" yaml:"plugins,omitempty"`
 
+	// Environment declares environment variables for the language host and resource provider plugins of all stacks.
+	Environment *ProjectEnvironment `json:"environment,omitempty" yaml:"environment,omitempty"`
+
+	// EventSinks are destinations that the engine events of each update of all stacks are streamed to.
+	EventSinks []EventSink `json:"eventSinks,omitempty" yaml:"eventSinks,omitempty"`
+
 	// Handle additional keys, albeit in a way that will remove comments and trivia.
 	AdditionalKeys map[string]interface{} `yaml:",inline"`
 
@@ -411,6 +454,11 @@ func ValidateConfigValue(typeName string, itemsType *ProjectConfigItemsType, val
 		return false
 	}
 
+	if typeName == objectTypeName {
+		_, ok := value.(map[string]interface{})
+		return ok
+	}
+
 	if typeName == booleanTypeName {
 		// check to see if the value is a literal string "true" | "false"
 		literalValue, ok := value.(string)
@@ -467,6 +515,10 @@ func (proj *Project) Validate() error {
 					"but does not specify the underlying type via the 'items' attribute", configKey)
 			}
 
+			if err := configType.validateConstraintDeclarations(configKey); err != nil {
+				return err
+			}
+
 			// when we have a config _type_ with a schema
 			if configType.IsExplicitlyTyped() && configType.Default != nil {
 				if !ValidateConfigValue(configTypeName, configType.Items, configType.Default) {
@@ -475,6 +527,9 @@ func (proj *Project) Validate() error {
 						configKey,
 						inferredTypeName)
 				}
+				if err := configType.ValidateConstraints(configKey, configType.Default); err != nil {
+					return fmt.Errorf("The default value specified for configuration key '%v' %w", configKey, err)
+				}
 			}
 
 		} else {
@@ -583,13 +638,41 @@ type ProjectStack struct {
 	// EncryptionSalt is this stack's base64 encoded encryption salt.  Only used for
 	// passphrase-based secrets providers.
 	EncryptionSalt string `json:"encryptionsalt,omitempty" yaml:"encryptionsalt,omitempty"`
+	// KeyWrappers are the data key encrypted by other cloud secrets providers or an escrow passphrase, any of which
+	// can decrypt the stack's secrets if its secrets provider is unavailable. Only used for cloud-based secrets
+	// providers.
+	KeyWrappers []KeyWrapper `json:"keywrappers,omitempty" yaml:"keywrappers,omitempty"`
+	// SecretsProviderState is the JSON encoded state returned by this stack's secrets provider plugin. Only used for
+	// secrets provider plugins.
+	SecretsProviderState string `json:"secretsproviderstate,omitempty" yaml:"secretsproviderstate,omitempty"`
+	// Extends lists the base layers of configuration that this stack's configuration is merged onto, in order. Each
+	// layer is either the path of a shared configuration file, relative to this file, or `stack:<name>` to extend the
+	// configuration of another stack of the project.
+	Extends []string `json:"extends,omitempty" yaml:"extends,omitempty"`
 	// Config is an optional config bag.
 	Config config.Map `json:"config,omitempty" yaml:"config,omitempty"`
+	// Environment declares environment variables for the language host and resource provider plugins of this stack,
+	// which override those declared by the project.
+	Environment *ProjectEnvironment `json:"environment,omitempty" yaml:"environment,omitempty"`
+	// EventSinks are destinations that the engine events of each update of this stack are streamed to, in addition
+	// to those declared by the project.
+	EventSinks []EventSink `json:"eventSinks,omitempty" yaml:"eventSinks,omitempty"`
 
 	// The original byte representation of the file, used to attempt trivia-preserving edits
 	raw []byte
 }
 
+// KeyWrapper is a stack's data key encrypted by a cloud secrets provider other than the stack's own, or by a
+// passphrase.
+type KeyWrapper struct {
+	// SecretsProvider is the URL of the cloud secrets provider that encrypted the data key, or "passphrase".
+	SecretsProvider string `json:"secretsprovider" yaml:"secretsprovider"`
+	// EncryptedKey is the base64 encoded ciphertext of the data key.
+	EncryptedKey string `json:"encryptedkey" yaml:"encryptedkey"`
+	// EncryptionSalt is the salt of the passphrase that encrypted the data key. Only used for passphrase wrappers.
+	EncryptionSalt string `json:"encryptionsalt,omitempty" yaml:"encryptionsalt,omitempty"`
+}
+
 func (ps ProjectStack) RawValue() []byte {
 	return ps.raw
 }
@@ -1032,3 +1032,82 @@ func TestProjectEditRoundtrip(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectLoadsConverterPlugins(t *testing.T) {
+	t.Parallel()
+
+	projectYaml := `
+name: test
+runtime: yaml
+plugins:
+  converters:
+    - name: terraform
+      version: 1.0.0
+    - name: arm
+      path: ./arm`
+
+	project, err := loadProjectFromText(t, projectYaml)
+	require.NoError(t, err)
+
+	terraform, ok := project.Plugins.GetConverter("terraform")
+	assert.True(t, ok)
+	assert.Equal(t, "1.0.0", terraform.Version)
+	assert.Empty(t, terraform.Path)
+
+	arm, ok := project.Plugins.GetConverter("arm")
+	assert.True(t, ok)
+	assert.Equal(t, "./arm", arm.Path)
+
+	_, ok = project.Plugins.GetConverter("yaml")
+	assert.False(t, ok)
+
+	// A converter must be named.
+	_, err = loadProjectFromText(t, `
+name: test
+runtime: yaml
+plugins:
+  converters:
+    - version: 1.0.0`)
+	assert.ErrorContains(t, err, "name")
+}
+
+func TestEventSinks(t *testing.T) {
+	t.Parallel()
+
+	project, err := loadProjectFromText(t, `
+name: test
+runtime: go
+eventSinks:
+  - webhook: https://hooks.example.com/${TOKEN}
+    headers:
+      Authorization: Bearer ${API_KEY}
+    events: [resourcePreEvent, summaryEvent]
+    batchSize: 10
+    flushInterval: 500ms
+    retries: 5`)
+	require.NoError(t, err)
+	retries := 5
+	assert.Equal(t, []EventSink{{
+		Webhook:       "https://hooks.example.com/${TOKEN}",
+		Headers:       map[string]string{"Authorization": "Bearer ${API_KEY}"},
+		Events:        []string{"resourcePreEvent", "summaryEvent"},
+		BatchSize:     10,
+		FlushInterval: "500ms",
+		Retries:       &retries,
+	}}, project.EventSinks)
+
+	stack, err := loadProjectStackFromText(t, project, `
+eventSinks:
+  - socket: /var/run/pulumi-events.sock
+  - stdout: true`)
+	require.NoError(t, err)
+	assert.Equal(t, []EventSink{{Socket: "/var/run/pulumi-events.sock"}, {Stdout: true}}, stack.EventSinks)
+
+	_, err = loadProjectFromText(t, `
+name: test
+runtime: go
+eventSinks:
+  - stdout: true
+    events: [resourceEvent]`)
+	assert.ErrorContains(t, err, "eventSinks")
+}
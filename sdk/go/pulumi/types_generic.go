@@ -0,0 +1,192 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// OutputOf is a strongly typed Output whose element type is T. Unlike the per-type outputs (StringOutput,
+// IntArrayOutput, ...), a single generic definition serves every element type, which allows code generators to emit
+// SDKs without a dedicated output type for each schema type.
+//
+// OutputOf values are created with ValOf, AsOutputOf, or by transforming another OutputOf with ApplyOf.
+type OutputOf[T any] struct{ *OutputState }
+
+// InputOf is the input counterpart of OutputOf. It is satisfied by OutputOf[T] and may be satisfied by other types
+// that can produce an OutputOf[T].
+type InputOf[T any] interface {
+	Input
+
+	ToOutputOf() OutputOf[T]
+	ToOutputOfWithContext(ctx context.Context) OutputOf[T]
+}
+
+// typeOf returns the reflect.Type of T.
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// ElementType returns the element type of this Output (T).
+func (OutputOf[T]) ElementType() reflect.Type {
+	return typeOf[T]()
+}
+
+func (o OutputOf[T]) ToOutputOf() OutputOf[T] {
+	return o
+}
+
+func (o OutputOf[T]) ToOutputOfWithContext(ctx context.Context) OutputOf[T] {
+	return o
+}
+
+// ValOf returns an OutputOf[T] that is already resolved to the given value.
+func ValOf[T any](v T) OutputOf[T] {
+	state := newOutputState(nil, typeOf[T]())
+	state.resolve(v, true, false, nil)
+	return OutputOf[T]{state}
+}
+
+// ToOutputOf converts the given input into an OutputOf[T]. A nil input produces an OutputOf[T] that resolves to the
+// zero value of T.
+func ToOutputOf[T any](in InputOf[T]) OutputOf[T] {
+	return ToOutputOfWithContext(context.Background(), in)
+}
+
+// ToOutputOfWithContext converts the given input into an OutputOf[T]. A nil input produces an OutputOf[T] that resolves
+// to the zero value of T.
+func ToOutputOfWithContext[T any](ctx context.Context, in InputOf[T]) OutputOf[T] {
+	if in == nil {
+		var zero T
+		return ValOf(zero)
+	}
+	return in.ToOutputOfWithContext(ctx)
+}
+
+// AsOutputOf converts an arbitrary Input (e.g. a pulumi.String or a pulumi.StringOutput) into an OutputOf[T]. It
+// panics if the input's element type cannot be converted to T.
+func AsOutputOf[T any](in Input) OutputOf[T] {
+	return AsOutputOfWithContext[T](context.Background(), in)
+}
+
+// AsOutputOfWithContext converts an arbitrary Input into an OutputOf[T]. It panics if the input's element type cannot
+// be converted to T.
+func AsOutputOfWithContext[T any](ctx context.Context, in Input) OutputOf[T] {
+	if typed, ok := in.(InputOf[T]); ok {
+		return typed.ToOutputOfWithContext(ctx)
+	}
+
+	elementType, target := in.ElementType(), typeOf[T]()
+	if !elementType.ConvertibleTo(target) {
+		panic(fmt.Errorf("cannot convert an input of type %v to OutputOf[%v]", elementType, target))
+	}
+
+	out := ToOutputWithContext(ctx, in)
+	if elementType == target {
+		return OutputOf[T]{out.getState()}
+	}
+	return applyOf(ctx, OutputOf[T]{out.getState()}, nil, func(_ context.Context, v T) (T, error) {
+		return v, nil
+	})
+}
+
+// ApplyOf transforms the value of the output using the applier func. The result is an OutputOf[U] that accumulates
+// the dependencies and secretness of the input. Unlike OutputState.ApplyT, the applier is checked at compile time.
+func ApplyOf[T, U any](o OutputOf[T], applier func(v T) U) OutputOf[U] {
+	return applyOf(context.Background(), o, nil, func(_ context.Context, v T) (U, error) {
+		return applier(v), nil
+	})
+}
+
+// ApplyOfErr is like ApplyOf, but the applier func may return an error, in which case the result is rejected.
+func ApplyOfErr[T, U any](o OutputOf[T], applier func(v T) (U, error)) OutputOf[U] {
+	return applyOf(context.Background(), o, nil, func(_ context.Context, v T) (U, error) {
+		return applier(v)
+	})
+}
+
+// ApplyOfWithContext is like ApplyOfErr, but the provided context is passed to the applier and can be used to reject
+// the output as canceled.
+func ApplyOfWithContext[T, U any](ctx context.Context, o OutputOf[T],
+	applier func(ctx context.Context, v T) (U, error),
+) OutputOf[U] {
+	return applyOf(ctx, o, nil, applier)
+}
+
+// SecretOf wraps the input in an OutputOf[T] that is marked as secret.
+func SecretOf[T any](in InputOf[T]) OutputOf[T] {
+	secret := true
+	return applyOf(context.Background(), ToOutputOf(in), &secret, func(_ context.Context, v T) (T, error) {
+		return v, nil
+	})
+}
+
+// castValue converts a resolved output value to T. Values whose dynamic type differs from T but is convertible to it
+// (for example, a named slice type) are converted by reflection.
+func castValue[T any](v interface{}) (T, error) {
+	var result T
+	if v == nil {
+		return result, nil
+	}
+	if typed, ok := v.(T); ok {
+		return typed, nil
+	}
+	rv, target := reflect.ValueOf(v), typeOf[T]()
+	if !rv.Type().ConvertibleTo(target) {
+		return result, fmt.Errorf("cannot convert a value of type %v to %v", rv.Type(), target)
+	}
+	return rv.Convert(target).Interface().(T), nil
+}
+
+func applyOf[T, U any](ctx context.Context, o OutputOf[T], forceSecret *bool,
+	applier func(ctx context.Context, v T) (U, error),
+) OutputOf[U] {
+	state := o.getState()
+	var join *workGroup
+	if state != nil {
+		join = state.join
+	}
+	result := newOutputState(join, typeOf[U](), state.dependencies()...)
+	secretness := func(secret bool) bool {
+		if forceSecret != nil {
+			return *forceSecret
+		}
+		return secret
+	}
+
+	go func() {
+		v, known, secret, deps, err := state.await(ctx)
+		if err != nil || !known {
+			result.fulfill(nil, known, secretness(secret), deps, err)
+			return
+		}
+
+		tv, err := castValue[T](v)
+		if err != nil {
+			result.reject(err)
+			return
+		}
+
+		u, err := applier(ctx, tv)
+		if err != nil {
+			result.reject(err)
+			return
+		}
+		result.fulfill(u, true, secretness(secret), deps, nil)
+	}()
+	return OutputOf[U]{result}
+}
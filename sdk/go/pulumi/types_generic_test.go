@@ -0,0 +1,142 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pulumi
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputOfBasics(t *testing.T) {
+	t.Parallel()
+
+	out := ValOf("hello")
+	assert.Equal(t, reflect.TypeOf(""), out.ElementType())
+
+	v, known, secret, deps, err := await(out)
+	require.NoError(t, err)
+	assert.True(t, known)
+	assert.False(t, secret)
+	assert.Nil(t, deps)
+	assert.Equal(t, "hello", v)
+}
+
+func TestApplyOf(t *testing.T) {
+	t.Parallel()
+
+	length := ApplyOf(ValOf("hello"), func(v string) int { return len(v) })
+	v, known, _, _, err := await(length)
+	require.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, 5, v)
+
+	failed := ApplyOfErr(length, func(v int) (string, error) { return "", errors.New("boom") })
+	_, _, _, _, err = await(failed)
+	assert.EqualError(t, err, "boom")
+}
+
+func TestApplyOfUnknown(t *testing.T) {
+	t.Parallel()
+
+	unknown := OutputOf[string]{UnsafeUnknownOutput(nil).getState()}
+	called := false
+	out := ApplyOf(unknown, func(v string) string {
+		called = true
+		return v
+	})
+	_, known, _, _, err := await(out)
+	require.NoError(t, err)
+	assert.False(t, known)
+	assert.False(t, called)
+}
+
+func TestSecretOf(t *testing.T) {
+	t.Parallel()
+
+	secret := SecretOf[string](ValOf("shh"))
+	assert.True(t, IsSecret(secret))
+
+	upper := ApplyOf(secret, func(v string) string { return v + "!" })
+	v, _, isSecret, _, err := await(upper)
+	require.NoError(t, err)
+	assert.True(t, isSecret)
+	assert.Equal(t, "shh!", v)
+}
+
+func TestAsOutputOf(t *testing.T) {
+	t.Parallel()
+
+	out := AsOutputOf[string](String("foo").ToStringOutput())
+	v, _, _, _, err := await(out)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", v)
+
+	strs := AsOutputOf[[]string](StringArray{String("a"), String("b")})
+	v, _, _, _, err = await(strs)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, v)
+
+	ids := AsOutputOf[string](ID("some-id"))
+	v, _, _, _, err = await(ids)
+	require.NoError(t, err)
+	assert.Equal(t, "some-id", v)
+
+	assert.Panics(t, func() { AsOutputOf[int](String("nope")) })
+}
+
+func TestToOutputOfNil(t *testing.T) {
+	t.Parallel()
+
+	out := ToOutputOfWithContext[*string](context.Background(), nil)
+	v, known, _, _, err := await(out)
+	require.NoError(t, err)
+	assert.True(t, known)
+	assert.Nil(t, v)
+}
+
+type genericArgs struct {
+	Name  string   `pulumi:"name"`
+	Count *int     `pulumi:"count"`
+	Tags  []string `pulumi:"tags"`
+}
+
+type GenericArgs struct {
+	Name  InputOf[string]
+	Count InputOf[*int]
+	Tags  InputOf[[]string]
+}
+
+func (GenericArgs) ElementType() reflect.Type {
+	return reflect.TypeOf((*genericArgs)(nil)).Elem()
+}
+
+func TestMarshalGenericInputs(t *testing.T) {
+	t.Parallel()
+
+	args := &GenericArgs{
+		Name: ValOf("foo"),
+		Tags: ApplyOf(ValOf("a,b"), func(v string) []string { return []string{v} }),
+	}
+	pmap, _, _, err := marshalInputs(args)
+	require.NoError(t, err)
+	assert.Equal(t, "foo", pmap["name"].StringValue())
+	assert.Equal(t, "a,b", pmap["tags"].ArrayValue()[0].StringValue())
+	assert.NotContains(t, pmap, "count")
+}
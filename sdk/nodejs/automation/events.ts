@@ -52,6 +52,28 @@ export interface PolicyEvent {
     enforcementLevel: "warning" | "mandatory";
 }
 
+// ResourceAnnotationEvent is emitted whenever a policy pack annotates a proposed change to a resource, e.g. with the
+// estimated change in its monthly cost.
+export interface ResourceAnnotationEvent {
+    resourceUrn: string;
+    // label is the label of the annotation, e.g. "Monthly cost".
+    label: string;
+    // number is the numeric value of the annotation, if it is numeric.
+    number?: number;
+    // text is the textual value of the annotation, if it is not numeric.
+    text?: string;
+    // unit is the unit of a numeric value, e.g. "USD".
+    unit?: string;
+    policyPackName?: string;
+}
+
+// AnnotationTotal is the total of the numeric annotations of changed resources with the same label and unit.
+export interface AnnotationTotal {
+    label: string;
+    unit?: string;
+    total: number;
+}
+
 // PreludeEvent is emitted at the start of an update.
 export interface PreludeEvent {
     // config contains the keys and values for the update.
@@ -74,6 +96,8 @@ export interface SummaryEvent {
     // compatibility. For older clients this will map to the version, while for newer ones
     // it will be the version tag prepended with "v".
     policyPacks: Record<string, string>;
+    // annotationTotals contains the totals of the numeric annotations of changed resources.
+    annotationTotals?: AnnotationTotal[];
 }
 
 export enum DiffKind {
@@ -196,4 +220,5 @@ export interface EngineEvent {
     resOutputsEvent?: ResOutputsEvent;
     resOpFailedEvent?: ResOpFailedEvent;
     policyEvent?: PolicyEvent;
+    resourceAnnotationEvent?: ResourceAnnotationEvent;
 }
@@ -77,6 +77,28 @@ function deserialize_pulumirpc_AnalyzerInfo(buffer_arg) {
   return pulumi_analyzer_pb.AnalyzerInfo.deserializeBinary(new Uint8Array(buffer_arg));
 }
 
+function serialize_pulumirpc_AnnotateRequest(arg) {
+  if (!(arg instanceof pulumi_analyzer_pb.AnnotateRequest)) {
+    throw new Error('Expected argument of type pulumirpc.AnnotateRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_AnnotateRequest(buffer_arg) {
+  return pulumi_analyzer_pb.AnnotateRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_AnnotateResponse(arg) {
+  if (!(arg instanceof pulumi_analyzer_pb.AnnotateResponse)) {
+    throw new Error('Expected argument of type pulumirpc.AnnotateResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_AnnotateResponse(buffer_arg) {
+  return pulumi_analyzer_pb.AnnotateResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
 function serialize_pulumirpc_ConfigureAnalyzerRequest(arg) {
   if (!(arg instanceof pulumi_analyzer_pb.ConfigureAnalyzerRequest)) {
     throw new Error('Expected argument of type pulumirpc.ConfigureAnalyzerRequest');
@@ -168,6 +190,20 @@ configure: {
     responseSerialize: serialize_google_protobuf_Empty,
     responseDeserialize: deserialize_google_protobuf_Empty,
   },
+  // Annotate annotates proposed changes to resources with values that are displayed alongside them during a
+// preview or update, e.g. the estimated change in their monthly cost. Called with the "inputs" to the
+// resources, before they are changed.
+annotate: {
+    path: '/pulumirpc.Analyzer/Annotate',
+    requestStream: false,
+    responseStream: false,
+    requestType: pulumi_analyzer_pb.AnnotateRequest,
+    responseType: pulumi_analyzer_pb.AnnotateResponse,
+    requestSerialize: serialize_pulumirpc_AnnotateRequest,
+    requestDeserialize: deserialize_pulumirpc_AnnotateRequest,
+    responseSerialize: serialize_pulumirpc_AnnotateResponse,
+    responseDeserialize: deserialize_pulumirpc_AnnotateResponse,
+  },
 };
 
 exports.AnalyzerClient = grpc.makeGenericClientConstructor(AnalyzerService);
@@ -31,11 +31,16 @@ goog.exportSymbol('proto.pulumirpc.AnalyzerProviderResource', null, global);
 goog.exportSymbol('proto.pulumirpc.AnalyzerResource', null, global);
 goog.exportSymbol('proto.pulumirpc.AnalyzerResourceOptions', null, global);
 goog.exportSymbol('proto.pulumirpc.AnalyzerResourceOptions.CustomTimeouts', null, global);
+goog.exportSymbol('proto.pulumirpc.AnnotateRequest', null, global);
+goog.exportSymbol('proto.pulumirpc.AnnotateResourceChange', null, global);
+goog.exportSymbol('proto.pulumirpc.AnnotateResponse', null, global);
 goog.exportSymbol('proto.pulumirpc.ConfigureAnalyzerRequest', null, global);
 goog.exportSymbol('proto.pulumirpc.EnforcementLevel', null, global);
 goog.exportSymbol('proto.pulumirpc.PolicyConfig', null, global);
 goog.exportSymbol('proto.pulumirpc.PolicyConfigSchema', null, global);
 goog.exportSymbol('proto.pulumirpc.PolicyInfo', null, global);
+goog.exportSymbol('proto.pulumirpc.ResourceAnnotation', null, global);
+goog.exportSymbol('proto.pulumirpc.ResourceAnnotation.ValueCase', null, global);
 /**
  * Generated by JsPbCodeGenerator.
  * @param {Array=} opt_data Optional initial data array, typically from a
@@ -330,6 +335,90 @@ if (goog.DEBUG && !COMPILED) {
    */
   proto.pulumirpc.ConfigureAnalyzerRequest.displayName = 'proto.pulumirpc.ConfigureAnalyzerRequest';
 }
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.AnnotateRequest = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.AnnotateRequest.repeatedFields_, null);
+};
+goog.inherits(proto.pulumirpc.AnnotateRequest, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.pulumirpc.AnnotateRequest.displayName = 'proto.pulumirpc.AnnotateRequest';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.AnnotateResourceChange = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, null);
+};
+goog.inherits(proto.pulumirpc.AnnotateResourceChange, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.pulumirpc.AnnotateResourceChange.displayName = 'proto.pulumirpc.AnnotateResourceChange';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.AnnotateResponse = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, proto.pulumirpc.AnnotateResponse.repeatedFields_, null);
+};
+goog.inherits(proto.pulumirpc.AnnotateResponse, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.pulumirpc.AnnotateResponse.displayName = 'proto.pulumirpc.AnnotateResponse';
+}
+/**
+ * Generated by JsPbCodeGenerator.
+ * @param {Array=} opt_data Optional initial data array, typically from a
+ * server response, or constructed directly in Javascript. The array is used
+ * in place and becomes part of the constructed object. It is not cloned.
+ * If no data is provided, the constructed object will be empty, but still
+ * valid.
+ * @extends {jspb.Message}
+ * @constructor
+ */
+proto.pulumirpc.ResourceAnnotation = function(opt_data) {
+  jspb.Message.initialize(this, opt_data, 0, -1, null, proto.pulumirpc.ResourceAnnotation.oneofGroups_);
+};
+goog.inherits(proto.pulumirpc.ResourceAnnotation, jspb.Message);
+if (goog.DEBUG && !COMPILED) {
+  /**
+   * @public
+   * @override
+   */
+  proto.pulumirpc.ResourceAnnotation.displayName = 'proto.pulumirpc.ResourceAnnotation';
+}
 
 
 
@@ -3938,6 +4027,900 @@ proto.pulumirpc.ConfigureAnalyzerRequest.prototype.clearPolicyconfigMap = functi
   return this;};
 
 
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.pulumirpc.AnnotateRequest.repeatedFields_ = [1];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.AnnotateRequest.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.AnnotateRequest.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.AnnotateRequest} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnnotateRequest.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    changesList: jspb.Message.toObjectList(msg.getChangesList(),
+    proto.pulumirpc.AnnotateResourceChange.toObject, includeInstance)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.AnnotateRequest}
+ */
+proto.pulumirpc.AnnotateRequest.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.AnnotateRequest;
+  return proto.pulumirpc.AnnotateRequest.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.AnnotateRequest} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.AnnotateRequest}
+ */
+proto.pulumirpc.AnnotateRequest.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = new proto.pulumirpc.AnnotateResourceChange;
+      reader.readMessage(value,proto.pulumirpc.AnnotateResourceChange.deserializeBinaryFromReader);
+      msg.addChanges(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.AnnotateRequest.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.AnnotateRequest.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.AnnotateRequest} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnnotateRequest.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getChangesList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      1,
+      f,
+      proto.pulumirpc.AnnotateResourceChange.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * repeated AnnotateResourceChange changes = 1;
+ * @return {!Array<!proto.pulumirpc.AnnotateResourceChange>}
+ */
+proto.pulumirpc.AnnotateRequest.prototype.getChangesList = function() {
+  return /** @type{!Array<!proto.pulumirpc.AnnotateResourceChange>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.pulumirpc.AnnotateResourceChange, 1));
+};
+
+
+/**
+ * @param {!Array<!proto.pulumirpc.AnnotateResourceChange>} value
+ * @return {!proto.pulumirpc.AnnotateRequest} returns this
+*/
+proto.pulumirpc.AnnotateRequest.prototype.setChangesList = function(value) {
+  return jspb.Message.setRepeatedWrapperField(this, 1, value);
+};
+
+
+/**
+ * @param {!proto.pulumirpc.AnnotateResourceChange=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.pulumirpc.AnnotateResourceChange}
+ */
+proto.pulumirpc.AnnotateRequest.prototype.addChanges = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 1, opt_value, proto.pulumirpc.AnnotateResourceChange, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.pulumirpc.AnnotateRequest} returns this
+ */
+proto.pulumirpc.AnnotateRequest.prototype.clearChangesList = function() {
+  return this.setChangesList([]);
+};
+
+
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.AnnotateResourceChange.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.AnnotateResourceChange.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.AnnotateResourceChange} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnnotateResourceChange.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    op: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    old: (f = msg.getOld()) && proto.pulumirpc.AnalyzerResource.toObject(includeInstance, f),
+    pb_new: (f = msg.getNew()) && proto.pulumirpc.AnalyzerResource.toObject(includeInstance, f)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.AnnotateResourceChange}
+ */
+proto.pulumirpc.AnnotateResourceChange.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.AnnotateResourceChange;
+  return proto.pulumirpc.AnnotateResourceChange.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.AnnotateResourceChange} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.AnnotateResourceChange}
+ */
+proto.pulumirpc.AnnotateResourceChange.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setOp(value);
+      break;
+    case 2:
+      var value = new proto.pulumirpc.AnalyzerResource;
+      reader.readMessage(value,proto.pulumirpc.AnalyzerResource.deserializeBinaryFromReader);
+      msg.setOld(value);
+      break;
+    case 3:
+      var value = new proto.pulumirpc.AnalyzerResource;
+      reader.readMessage(value,proto.pulumirpc.AnalyzerResource.deserializeBinaryFromReader);
+      msg.setNew(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.AnnotateResourceChange.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.AnnotateResourceChange.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.AnnotateResourceChange} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnnotateResourceChange.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getOp();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getOld();
+  if (f != null) {
+    writer.writeMessage(
+      2,
+      f,
+      proto.pulumirpc.AnalyzerResource.serializeBinaryToWriter
+    );
+  }
+  f = message.getNew();
+  if (f != null) {
+    writer.writeMessage(
+      3,
+      f,
+      proto.pulumirpc.AnalyzerResource.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * optional string op = 1;
+ * @return {string}
+ */
+proto.pulumirpc.AnnotateResourceChange.prototype.getOp = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.AnnotateResourceChange} returns this
+ */
+proto.pulumirpc.AnnotateResourceChange.prototype.setOp = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional AnalyzerResource old = 2;
+ * @return {?proto.pulumirpc.AnalyzerResource}
+ */
+proto.pulumirpc.AnnotateResourceChange.prototype.getOld = function() {
+  return /** @type{?proto.pulumirpc.AnalyzerResource} */ (
+    jspb.Message.getWrapperField(this, proto.pulumirpc.AnalyzerResource, 2));
+};
+
+
+/**
+ * @param {?proto.pulumirpc.AnalyzerResource|undefined} value
+ * @return {!proto.pulumirpc.AnnotateResourceChange} returns this
+*/
+proto.pulumirpc.AnnotateResourceChange.prototype.setOld = function(value) {
+  return jspb.Message.setWrapperField(this, 2, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.pulumirpc.AnnotateResourceChange} returns this
+ */
+proto.pulumirpc.AnnotateResourceChange.prototype.clearOld = function() {
+  return this.setOld(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.pulumirpc.AnnotateResourceChange.prototype.hasOld = function() {
+  return jspb.Message.getField(this, 2) != null;
+};
+
+
+/**
+ * optional AnalyzerResource new = 3;
+ * @return {?proto.pulumirpc.AnalyzerResource}
+ */
+proto.pulumirpc.AnnotateResourceChange.prototype.getNew = function() {
+  return /** @type{?proto.pulumirpc.AnalyzerResource} */ (
+    jspb.Message.getWrapperField(this, proto.pulumirpc.AnalyzerResource, 3));
+};
+
+
+/**
+ * @param {?proto.pulumirpc.AnalyzerResource|undefined} value
+ * @return {!proto.pulumirpc.AnnotateResourceChange} returns this
+*/
+proto.pulumirpc.AnnotateResourceChange.prototype.setNew = function(value) {
+  return jspb.Message.setWrapperField(this, 3, value);
+};
+
+
+/**
+ * Clears the message field making it undefined.
+ * @return {!proto.pulumirpc.AnnotateResourceChange} returns this
+ */
+proto.pulumirpc.AnnotateResourceChange.prototype.clearNew = function() {
+  return this.setNew(undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.pulumirpc.AnnotateResourceChange.prototype.hasNew = function() {
+  return jspb.Message.getField(this, 3) != null;
+};
+
+
+
+/**
+ * List of repeated fields within this message type.
+ * @private {!Array<number>}
+ * @const
+ */
+proto.pulumirpc.AnnotateResponse.repeatedFields_ = [1];
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.AnnotateResponse.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.AnnotateResponse.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.AnnotateResponse} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnnotateResponse.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    annotationsList: jspb.Message.toObjectList(msg.getAnnotationsList(),
+    proto.pulumirpc.ResourceAnnotation.toObject, includeInstance)
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.AnnotateResponse}
+ */
+proto.pulumirpc.AnnotateResponse.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.AnnotateResponse;
+  return proto.pulumirpc.AnnotateResponse.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.AnnotateResponse} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.AnnotateResponse}
+ */
+proto.pulumirpc.AnnotateResponse.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = new proto.pulumirpc.ResourceAnnotation;
+      reader.readMessage(value,proto.pulumirpc.ResourceAnnotation.deserializeBinaryFromReader);
+      msg.addAnnotations(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.AnnotateResponse.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.AnnotateResponse.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.AnnotateResponse} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.AnnotateResponse.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getAnnotationsList();
+  if (f.length > 0) {
+    writer.writeRepeatedMessage(
+      1,
+      f,
+      proto.pulumirpc.ResourceAnnotation.serializeBinaryToWriter
+    );
+  }
+};
+
+
+/**
+ * repeated ResourceAnnotation annotations = 1;
+ * @return {!Array<!proto.pulumirpc.ResourceAnnotation>}
+ */
+proto.pulumirpc.AnnotateResponse.prototype.getAnnotationsList = function() {
+  return /** @type{!Array<!proto.pulumirpc.ResourceAnnotation>} */ (
+    jspb.Message.getRepeatedWrapperField(this, proto.pulumirpc.ResourceAnnotation, 1));
+};
+
+
+/**
+ * @param {!Array<!proto.pulumirpc.ResourceAnnotation>} value
+ * @return {!proto.pulumirpc.AnnotateResponse} returns this
+*/
+proto.pulumirpc.AnnotateResponse.prototype.setAnnotationsList = function(value) {
+  return jspb.Message.setRepeatedWrapperField(this, 1, value);
+};
+
+
+/**
+ * @param {!proto.pulumirpc.ResourceAnnotation=} opt_value
+ * @param {number=} opt_index
+ * @return {!proto.pulumirpc.ResourceAnnotation}
+ */
+proto.pulumirpc.AnnotateResponse.prototype.addAnnotations = function(opt_value, opt_index) {
+  return jspb.Message.addToRepeatedWrapperField(this, 1, opt_value, proto.pulumirpc.ResourceAnnotation, opt_index);
+};
+
+
+/**
+ * Clears the list making it empty but non-null.
+ * @return {!proto.pulumirpc.AnnotateResponse} returns this
+ */
+proto.pulumirpc.AnnotateResponse.prototype.clearAnnotationsList = function() {
+  return this.setAnnotationsList([]);
+};
+
+
+
+/**
+ * Oneof group definitions for this message. Each group defines the field
+ * numbers belonging to that group. When of these fields' value is set, all
+ * other fields in the group are cleared. During deserialization, if multiple
+ * fields are encountered for a group, only the last value seen will be kept.
+ * @private {!Array<!Array<number>>}
+ * @const
+ */
+proto.pulumirpc.ResourceAnnotation.oneofGroups_ = [[3,4]];
+
+/**
+ * @enum {number}
+ */
+proto.pulumirpc.ResourceAnnotation.ValueCase = {
+  VALUE_NOT_SET: 0,
+  NUMBER: 3,
+  TEXT: 4
+};
+
+/**
+ * @return {proto.pulumirpc.ResourceAnnotation.ValueCase}
+ */
+proto.pulumirpc.ResourceAnnotation.prototype.getValueCase = function() {
+  return /** @type {proto.pulumirpc.ResourceAnnotation.ValueCase} */(jspb.Message.computeOneofCase(this, proto.pulumirpc.ResourceAnnotation.oneofGroups_[0]));
+};
+
+
+
+if (jspb.Message.GENERATE_TO_OBJECT) {
+/**
+ * Creates an object representation of this proto.
+ * Field names that are reserved in JavaScript and will be renamed to pb_name.
+ * Optional fields that are not set will be set to undefined.
+ * To access a reserved field use, foo.pb_<name>, eg, foo.pb_default.
+ * For the list of reserved names please see:
+ *     net/proto2/compiler/js/internal/generator.cc#kKeyword.
+ * @param {boolean=} opt_includeInstance Deprecated. whether to include the
+ *     JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @return {!Object}
+ */
+proto.pulumirpc.ResourceAnnotation.prototype.toObject = function(opt_includeInstance) {
+  return proto.pulumirpc.ResourceAnnotation.toObject(opt_includeInstance, this);
+};
+
+
+/**
+ * Static version of the {@see toObject} method.
+ * @param {boolean|undefined} includeInstance Deprecated. Whether to include
+ *     the JSPB instance for transitional soy proto support:
+ *     http://goto/soy-param-migration
+ * @param {!proto.pulumirpc.ResourceAnnotation} msg The msg instance to transform.
+ * @return {!Object}
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.ResourceAnnotation.toObject = function(includeInstance, msg) {
+  var f, obj = {
+    urn: jspb.Message.getFieldWithDefault(msg, 1, ""),
+    label: jspb.Message.getFieldWithDefault(msg, 2, ""),
+    number: jspb.Message.getFloatingPointFieldWithDefault(msg, 3, 0.0),
+    text: jspb.Message.getFieldWithDefault(msg, 4, ""),
+    unit: jspb.Message.getFieldWithDefault(msg, 5, ""),
+    policypackname: jspb.Message.getFieldWithDefault(msg, 6, "")
+  };
+
+  if (includeInstance) {
+    obj.$jspbMessageInstance = msg;
+  }
+  return obj;
+};
+}
+
+
+/**
+ * Deserializes binary data (in protobuf wire format).
+ * @param {jspb.ByteSource} bytes The bytes to deserialize.
+ * @return {!proto.pulumirpc.ResourceAnnotation}
+ */
+proto.pulumirpc.ResourceAnnotation.deserializeBinary = function(bytes) {
+  var reader = new jspb.BinaryReader(bytes);
+  var msg = new proto.pulumirpc.ResourceAnnotation;
+  return proto.pulumirpc.ResourceAnnotation.deserializeBinaryFromReader(msg, reader);
+};
+
+
+/**
+ * Deserializes binary data (in protobuf wire format) from the
+ * given reader into the given message object.
+ * @param {!proto.pulumirpc.ResourceAnnotation} msg The message object to deserialize into.
+ * @param {!jspb.BinaryReader} reader The BinaryReader to use.
+ * @return {!proto.pulumirpc.ResourceAnnotation}
+ */
+proto.pulumirpc.ResourceAnnotation.deserializeBinaryFromReader = function(msg, reader) {
+  while (reader.nextField()) {
+    if (reader.isEndGroup()) {
+      break;
+    }
+    var field = reader.getFieldNumber();
+    switch (field) {
+    case 1:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setUrn(value);
+      break;
+    case 2:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setLabel(value);
+      break;
+    case 3:
+      var value = /** @type {number} */ (reader.readDouble());
+      msg.setNumber(value);
+      break;
+    case 4:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setText(value);
+      break;
+    case 5:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setUnit(value);
+      break;
+    case 6:
+      var value = /** @type {string} */ (reader.readString());
+      msg.setPolicypackname(value);
+      break;
+    default:
+      reader.skipField();
+      break;
+    }
+  }
+  return msg;
+};
+
+
+/**
+ * Serializes the message to binary data (in protobuf wire format).
+ * @return {!Uint8Array}
+ */
+proto.pulumirpc.ResourceAnnotation.prototype.serializeBinary = function() {
+  var writer = new jspb.BinaryWriter();
+  proto.pulumirpc.ResourceAnnotation.serializeBinaryToWriter(this, writer);
+  return writer.getResultBuffer();
+};
+
+
+/**
+ * Serializes the given message to binary data (in protobuf wire
+ * format), writing to the given BinaryWriter.
+ * @param {!proto.pulumirpc.ResourceAnnotation} message
+ * @param {!jspb.BinaryWriter} writer
+ * @suppress {unusedLocalVariables} f is only used for nested messages
+ */
+proto.pulumirpc.ResourceAnnotation.serializeBinaryToWriter = function(message, writer) {
+  var f = undefined;
+  f = message.getUrn();
+  if (f.length > 0) {
+    writer.writeString(
+      1,
+      f
+    );
+  }
+  f = message.getLabel();
+  if (f.length > 0) {
+    writer.writeString(
+      2,
+      f
+    );
+  }
+  f = /** @type {number} */ (jspb.Message.getField(message, 3));
+  if (f != null) {
+    writer.writeDouble(
+      3,
+      f
+    );
+  }
+  f = /** @type {string} */ (jspb.Message.getField(message, 4));
+  if (f != null) {
+    writer.writeString(
+      4,
+      f
+    );
+  }
+  f = message.getUnit();
+  if (f.length > 0) {
+    writer.writeString(
+      5,
+      f
+    );
+  }
+  f = message.getPolicypackname();
+  if (f.length > 0) {
+    writer.writeString(
+      6,
+      f
+    );
+  }
+};
+
+
+/**
+ * optional string urn = 1;
+ * @return {string}
+ */
+proto.pulumirpc.ResourceAnnotation.prototype.getUrn = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 1, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.ResourceAnnotation} returns this
+ */
+proto.pulumirpc.ResourceAnnotation.prototype.setUrn = function(value) {
+  return jspb.Message.setProto3StringField(this, 1, value);
+};
+
+
+/**
+ * optional string label = 2;
+ * @return {string}
+ */
+proto.pulumirpc.ResourceAnnotation.prototype.getLabel = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 2, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.ResourceAnnotation} returns this
+ */
+proto.pulumirpc.ResourceAnnotation.prototype.setLabel = function(value) {
+  return jspb.Message.setProto3StringField(this, 2, value);
+};
+
+
+/**
+ * optional double number = 3;
+ * @return {number}
+ */
+proto.pulumirpc.ResourceAnnotation.prototype.getNumber = function() {
+  return /** @type {number} */ (jspb.Message.getFloatingPointFieldWithDefault(this, 3, 0.0));
+};
+
+
+/**
+ * @param {number} value
+ * @return {!proto.pulumirpc.ResourceAnnotation} returns this
+ */
+proto.pulumirpc.ResourceAnnotation.prototype.setNumber = function(value) {
+  return jspb.Message.setOneofField(this, 3, proto.pulumirpc.ResourceAnnotation.oneofGroups_[0], value);
+};
+
+
+/**
+ * Clears the field making it undefined.
+ * @return {!proto.pulumirpc.ResourceAnnotation} returns this
+ */
+proto.pulumirpc.ResourceAnnotation.prototype.clearNumber = function() {
+  return jspb.Message.setOneofField(this, 3, proto.pulumirpc.ResourceAnnotation.oneofGroups_[0], undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.pulumirpc.ResourceAnnotation.prototype.hasNumber = function() {
+  return jspb.Message.getField(this, 3) != null;
+};
+
+
+/**
+ * optional string text = 4;
+ * @return {string}
+ */
+proto.pulumirpc.ResourceAnnotation.prototype.getText = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 4, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.ResourceAnnotation} returns this
+ */
+proto.pulumirpc.ResourceAnnotation.prototype.setText = function(value) {
+  return jspb.Message.setOneofField(this, 4, proto.pulumirpc.ResourceAnnotation.oneofGroups_[0], value);
+};
+
+
+/**
+ * Clears the field making it undefined.
+ * @return {!proto.pulumirpc.ResourceAnnotation} returns this
+ */
+proto.pulumirpc.ResourceAnnotation.prototype.clearText = function() {
+  return jspb.Message.setOneofField(this, 4, proto.pulumirpc.ResourceAnnotation.oneofGroups_[0], undefined);
+};
+
+
+/**
+ * Returns whether this field is set.
+ * @return {boolean}
+ */
+proto.pulumirpc.ResourceAnnotation.prototype.hasText = function() {
+  return jspb.Message.getField(this, 4) != null;
+};
+
+
+/**
+ * optional string unit = 5;
+ * @return {string}
+ */
+proto.pulumirpc.ResourceAnnotation.prototype.getUnit = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 5, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.ResourceAnnotation} returns this
+ */
+proto.pulumirpc.ResourceAnnotation.prototype.setUnit = function(value) {
+  return jspb.Message.setProto3StringField(this, 5, value);
+};
+
+
+/**
+ * optional string policyPackName = 6;
+ * @return {string}
+ */
+proto.pulumirpc.ResourceAnnotation.prototype.getPolicypackname = function() {
+  return /** @type {string} */ (jspb.Message.getFieldWithDefault(this, 6, ""));
+};
+
+
+/**
+ * @param {string} value
+ * @return {!proto.pulumirpc.ResourceAnnotation} returns this
+ */
+proto.pulumirpc.ResourceAnnotation.prototype.setPolicypackname = function(value) {
+  return jspb.Message.setProto3StringField(this, 6, value);
+};
+
+
 /**
  * @enum {number}
  */
@@ -0,0 +1,202 @@
+// GENERATED CODE -- DO NOT EDIT!
+
+// Original file comments:
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+'use strict';
+var grpc = require('@grpc/grpc-js');
+var pulumi_secrets_pb = require('./secrets_pb.js');
+var google_protobuf_empty_pb = require('google-protobuf/google/protobuf/empty_pb.js');
+
+function serialize_google_protobuf_Empty(arg) {
+  if (!(arg instanceof google_protobuf_empty_pb.Empty)) {
+    throw new Error('Expected argument of type google.protobuf.Empty');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_google_protobuf_Empty(buffer_arg) {
+  return google_protobuf_empty_pb.Empty.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_BulkDecryptRequest(arg) {
+  if (!(arg instanceof pulumi_secrets_pb.BulkDecryptRequest)) {
+    throw new Error('Expected argument of type pulumirpc.BulkDecryptRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_BulkDecryptRequest(buffer_arg) {
+  return pulumi_secrets_pb.BulkDecryptRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_BulkDecryptResponse(arg) {
+  if (!(arg instanceof pulumi_secrets_pb.BulkDecryptResponse)) {
+    throw new Error('Expected argument of type pulumirpc.BulkDecryptResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_BulkDecryptResponse(buffer_arg) {
+  return pulumi_secrets_pb.BulkDecryptResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_ConfigureSecretsProviderRequest(arg) {
+  if (!(arg instanceof pulumi_secrets_pb.ConfigureSecretsProviderRequest)) {
+    throw new Error('Expected argument of type pulumirpc.ConfigureSecretsProviderRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_ConfigureSecretsProviderRequest(buffer_arg) {
+  return pulumi_secrets_pb.ConfigureSecretsProviderRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_DecryptRequest(arg) {
+  if (!(arg instanceof pulumi_secrets_pb.DecryptRequest)) {
+    throw new Error('Expected argument of type pulumirpc.DecryptRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_DecryptRequest(buffer_arg) {
+  return pulumi_secrets_pb.DecryptRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_DecryptResponse(arg) {
+  if (!(arg instanceof pulumi_secrets_pb.DecryptResponse)) {
+    throw new Error('Expected argument of type pulumirpc.DecryptResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_DecryptResponse(buffer_arg) {
+  return pulumi_secrets_pb.DecryptResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_EncryptRequest(arg) {
+  if (!(arg instanceof pulumi_secrets_pb.EncryptRequest)) {
+    throw new Error('Expected argument of type pulumirpc.EncryptRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_EncryptRequest(buffer_arg) {
+  return pulumi_secrets_pb.EncryptRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_EncryptResponse(arg) {
+  if (!(arg instanceof pulumi_secrets_pb.EncryptResponse)) {
+    throw new Error('Expected argument of type pulumirpc.EncryptResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_EncryptResponse(buffer_arg) {
+  return pulumi_secrets_pb.EncryptResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_InitializeSecretsProviderRequest(arg) {
+  if (!(arg instanceof pulumi_secrets_pb.InitializeSecretsProviderRequest)) {
+    throw new Error('Expected argument of type pulumirpc.InitializeSecretsProviderRequest');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_InitializeSecretsProviderRequest(buffer_arg) {
+  return pulumi_secrets_pb.InitializeSecretsProviderRequest.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+function serialize_pulumirpc_InitializeSecretsProviderResponse(arg) {
+  if (!(arg instanceof pulumi_secrets_pb.InitializeSecretsProviderResponse)) {
+    throw new Error('Expected argument of type pulumirpc.InitializeSecretsProviderResponse');
+  }
+  return Buffer.from(arg.serializeBinary());
+}
+
+function deserialize_pulumirpc_InitializeSecretsProviderResponse(buffer_arg) {
+  return pulumi_secrets_pb.InitializeSecretsProviderResponse.deserializeBinary(new Uint8Array(buffer_arg));
+}
+
+
+// SecretsProvider is a service for encrypting and decrypting the secrets of a stack, implemented by secrets provider
+// plugins. A stack uses a secrets provider plugin by setting its secrets provider to a URL whose scheme is the name of
+// the plugin, e.g. `--secrets-provider myplugin://...`.
+// This is currently unstable and experimental.
+var SecretsProviderService = exports.SecretsProviderService = {
+  // Initialize returns the state of the secrets provider for the given URL. The state is saved in the stack's
+// configuration and checkpoint, and is passed to Configure whenever the stack's secrets are used.
+initialize: {
+    path: '/pulumirpc.SecretsProvider/Initialize',
+    requestStream: false,
+    responseStream: false,
+    requestType: pulumi_secrets_pb.InitializeSecretsProviderRequest,
+    responseType: pulumi_secrets_pb.InitializeSecretsProviderResponse,
+    requestSerialize: serialize_pulumirpc_InitializeSecretsProviderRequest,
+    requestDeserialize: deserialize_pulumirpc_InitializeSecretsProviderRequest,
+    responseSerialize: serialize_pulumirpc_InitializeSecretsProviderResponse,
+    responseDeserialize: deserialize_pulumirpc_InitializeSecretsProviderResponse,
+  },
+  // Configure loads the state of the secrets provider. It is called before any values are encrypted or decrypted.
+configure: {
+    path: '/pulumirpc.SecretsProvider/Configure',
+    requestStream: false,
+    responseStream: false,
+    requestType: pulumi_secrets_pb.ConfigureSecretsProviderRequest,
+    responseType: google_protobuf_empty_pb.Empty,
+    requestSerialize: serialize_pulumirpc_ConfigureSecretsProviderRequest,
+    requestDeserialize: deserialize_pulumirpc_ConfigureSecretsProviderRequest,
+    responseSerialize: serialize_google_protobuf_Empty,
+    responseDeserialize: deserialize_google_protobuf_Empty,
+  },
+  // Encrypt encrypts a value.
+encrypt: {
+    path: '/pulumirpc.SecretsProvider/Encrypt',
+    requestStream: false,
+    responseStream: false,
+    requestType: pulumi_secrets_pb.EncryptRequest,
+    responseType: pulumi_secrets_pb.EncryptResponse,
+    requestSerialize: serialize_pulumirpc_EncryptRequest,
+    requestDeserialize: deserialize_pulumirpc_EncryptRequest,
+    responseSerialize: serialize_pulumirpc_EncryptResponse,
+    responseDeserialize: deserialize_pulumirpc_EncryptResponse,
+  },
+  // Decrypt decrypts a value.
+decrypt: {
+    path: '/pulumirpc.SecretsProvider/Decrypt',
+    requestStream: false,
+    responseStream: false,
+    requestType: pulumi_secrets_pb.DecryptRequest,
+    responseType: pulumi_secrets_pb.DecryptResponse,
+    requestSerialize: serialize_pulumirpc_DecryptRequest,
+    requestDeserialize: deserialize_pulumirpc_DecryptRequest,
+    responseSerialize: serialize_pulumirpc_DecryptResponse,
+    responseDeserialize: deserialize_pulumirpc_DecryptResponse,
+  },
+  // BulkDecrypt decrypts many values at once.
+bulkDecrypt: {
+    path: '/pulumirpc.SecretsProvider/BulkDecrypt',
+    requestStream: false,
+    responseStream: false,
+    requestType: pulumi_secrets_pb.BulkDecryptRequest,
+    responseType: pulumi_secrets_pb.BulkDecryptResponse,
+    requestSerialize: serialize_pulumirpc_BulkDecryptRequest,
+    requestDeserialize: deserialize_pulumirpc_BulkDecryptRequest,
+    responseSerialize: serialize_pulumirpc_BulkDecryptResponse,
+    responseDeserialize: deserialize_pulumirpc_BulkDecryptResponse,
+  },
+};
+
+exports.SecretsProviderClient = grpc.makeGenericClientConstructor(SecretsProviderService);
@@ -36,6 +36,10 @@ type AnalyzerClient interface {
 	GetPluginInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PluginInfo, error)
 	// Configure configures the analyzer, passing configuration properties for each policy.
 	Configure(ctx context.Context, in *ConfigureAnalyzerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Annotate annotates proposed changes to resources with values that are displayed alongside them during a
+	// preview or update, e.g. the estimated change in their monthly cost. Called with the "inputs" to the
+	// resources, before they are changed.
+	Annotate(ctx context.Context, in *AnnotateRequest, opts ...grpc.CallOption) (*AnnotateResponse, error)
 }
 
 type analyzerClient struct {
@@ -91,6 +95,15 @@ func (c *analyzerClient) Configure(ctx context.Context, in *ConfigureAnalyzerReq
 	return out, nil
 }
 
+func (c *analyzerClient) Annotate(ctx context.Context, in *AnnotateRequest, opts ...grpc.CallOption) (*AnnotateResponse, error) {
+	out := new(AnnotateResponse)
+	err := c.cc.Invoke(ctx, "/pulumirpc.Analyzer/Annotate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AnalyzerServer is the server API for Analyzer service.
 // All implementations must embed UnimplementedAnalyzerServer
 // for forward compatibility
@@ -108,6 +121,10 @@ type AnalyzerServer interface {
 	GetPluginInfo(context.Context, *emptypb.Empty) (*PluginInfo, error)
 	// Configure configures the analyzer, passing configuration properties for each policy.
 	Configure(context.Context, *ConfigureAnalyzerRequest) (*emptypb.Empty, error)
+	// Annotate annotates proposed changes to resources with values that are displayed alongside them during a
+	// preview or update, e.g. the estimated change in their monthly cost. Called with the "inputs" to the
+	// resources, before they are changed.
+	Annotate(context.Context, *AnnotateRequest) (*AnnotateResponse, error)
 	mustEmbedUnimplementedAnalyzerServer()
 }
 
@@ -130,6 +147,9 @@ func (UnimplementedAnalyzerServer) GetPluginInfo(context.Context, *emptypb.Empty
 func (UnimplementedAnalyzerServer) Configure(context.Context, *ConfigureAnalyzerRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
 }
+func (UnimplementedAnalyzerServer) Annotate(context.Context, *AnnotateRequest) (*AnnotateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Annotate not implemented")
+}
 func (UnimplementedAnalyzerServer) mustEmbedUnimplementedAnalyzerServer() {}
 
 // UnsafeAnalyzerServer may be embedded to opt out of forward compatibility for this service.
@@ -233,6 +253,24 @@ func _Analyzer_Configure_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Analyzer_Annotate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnnotateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyzerServer).Annotate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.Analyzer/Annotate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyzerServer).Annotate(ctx, req.(*AnnotateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Analyzer_ServiceDesc is the grpc.ServiceDesc for Analyzer service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -260,6 +298,10 @@ var Analyzer_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Configure",
 			Handler:    _Analyzer_Configure_Handler,
 		},
+		{
+			MethodName: "Annotate",
+			Handler:    _Analyzer_Annotate_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pulumi/analyzer.proto",
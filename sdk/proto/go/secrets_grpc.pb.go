@@ -0,0 +1,262 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.20.1
+// source: pulumi/secrets.proto
+
+package pulumirpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SecretsProviderClient is the client API for SecretsProvider service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SecretsProviderClient interface {
+	// Initialize returns the state of the secrets provider for the given URL. The state is saved in the stack's
+	// configuration and checkpoint, and is passed to Configure whenever the stack's secrets are used.
+	Initialize(ctx context.Context, in *InitializeSecretsProviderRequest, opts ...grpc.CallOption) (*InitializeSecretsProviderResponse, error)
+	// Configure loads the state of the secrets provider. It is called before any values are encrypted or decrypted.
+	Configure(ctx context.Context, in *ConfigureSecretsProviderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Encrypt encrypts a value.
+	Encrypt(ctx context.Context, in *EncryptRequest, opts ...grpc.CallOption) (*EncryptResponse, error)
+	// Decrypt decrypts a value.
+	Decrypt(ctx context.Context, in *DecryptRequest, opts ...grpc.CallOption) (*DecryptResponse, error)
+	// BulkDecrypt decrypts many values at once.
+	BulkDecrypt(ctx context.Context, in *BulkDecryptRequest, opts ...grpc.CallOption) (*BulkDecryptResponse, error)
+}
+
+type secretsProviderClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSecretsProviderClient(cc grpc.ClientConnInterface) SecretsProviderClient {
+	return &secretsProviderClient{cc}
+}
+
+func (c *secretsProviderClient) Initialize(ctx context.Context, in *InitializeSecretsProviderRequest, opts ...grpc.CallOption) (*InitializeSecretsProviderResponse, error) {
+	out := new(InitializeSecretsProviderResponse)
+	err := c.cc.Invoke(ctx, "/pulumirpc.SecretsProvider/Initialize", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretsProviderClient) Configure(ctx context.Context, in *ConfigureSecretsProviderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/pulumirpc.SecretsProvider/Configure", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretsProviderClient) Encrypt(ctx context.Context, in *EncryptRequest, opts ...grpc.CallOption) (*EncryptResponse, error) {
+	out := new(EncryptResponse)
+	err := c.cc.Invoke(ctx, "/pulumirpc.SecretsProvider/Encrypt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretsProviderClient) Decrypt(ctx context.Context, in *DecryptRequest, opts ...grpc.CallOption) (*DecryptResponse, error) {
+	out := new(DecryptResponse)
+	err := c.cc.Invoke(ctx, "/pulumirpc.SecretsProvider/Decrypt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretsProviderClient) BulkDecrypt(ctx context.Context, in *BulkDecryptRequest, opts ...grpc.CallOption) (*BulkDecryptResponse, error) {
+	out := new(BulkDecryptResponse)
+	err := c.cc.Invoke(ctx, "/pulumirpc.SecretsProvider/BulkDecrypt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SecretsProviderServer is the server API for SecretsProvider service.
+// All implementations must embed UnimplementedSecretsProviderServer
+// for forward compatibility
+type SecretsProviderServer interface {
+	// Initialize returns the state of the secrets provider for the given URL. The state is saved in the stack's
+	// configuration and checkpoint, and is passed to Configure whenever the stack's secrets are used.
+	Initialize(context.Context, *InitializeSecretsProviderRequest) (*InitializeSecretsProviderResponse, error)
+	// Configure loads the state of the secrets provider. It is called before any values are encrypted or decrypted.
+	Configure(context.Context, *ConfigureSecretsProviderRequest) (*emptypb.Empty, error)
+	// Encrypt encrypts a value.
+	Encrypt(context.Context, *EncryptRequest) (*EncryptResponse, error)
+	// Decrypt decrypts a value.
+	Decrypt(context.Context, *DecryptRequest) (*DecryptResponse, error)
+	// BulkDecrypt decrypts many values at once.
+	BulkDecrypt(context.Context, *BulkDecryptRequest) (*BulkDecryptResponse, error)
+	mustEmbedUnimplementedSecretsProviderServer()
+}
+
+// UnimplementedSecretsProviderServer must be embedded to have forward compatible implementations.
+type UnimplementedSecretsProviderServer struct {
+}
+
+func (UnimplementedSecretsProviderServer) Initialize(context.Context, *InitializeSecretsProviderRequest) (*InitializeSecretsProviderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Initialize not implemented")
+}
+func (UnimplementedSecretsProviderServer) Configure(context.Context, *ConfigureSecretsProviderRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
+}
+func (UnimplementedSecretsProviderServer) Encrypt(context.Context, *EncryptRequest) (*EncryptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Encrypt not implemented")
+}
+func (UnimplementedSecretsProviderServer) Decrypt(context.Context, *DecryptRequest) (*DecryptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Decrypt not implemented")
+}
+func (UnimplementedSecretsProviderServer) BulkDecrypt(context.Context, *BulkDecryptRequest) (*BulkDecryptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkDecrypt not implemented")
+}
+func (UnimplementedSecretsProviderServer) mustEmbedUnimplementedSecretsProviderServer() {}
+
+// UnsafeSecretsProviderServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SecretsProviderServer will
+// result in compilation errors.
+type UnsafeSecretsProviderServer interface {
+	mustEmbedUnimplementedSecretsProviderServer()
+}
+
+func RegisterSecretsProviderServer(s grpc.ServiceRegistrar, srv SecretsProviderServer) {
+	s.RegisterService(&SecretsProvider_ServiceDesc, srv)
+}
+
+func _SecretsProvider_Initialize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitializeSecretsProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretsProviderServer).Initialize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.SecretsProvider/Initialize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretsProviderServer).Initialize(ctx, req.(*InitializeSecretsProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretsProvider_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigureSecretsProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretsProviderServer).Configure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.SecretsProvider/Configure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretsProviderServer).Configure(ctx, req.(*ConfigureSecretsProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretsProvider_Encrypt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EncryptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretsProviderServer).Encrypt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.SecretsProvider/Encrypt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretsProviderServer).Encrypt(ctx, req.(*EncryptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretsProvider_Decrypt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecryptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretsProviderServer).Decrypt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.SecretsProvider/Decrypt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretsProviderServer).Decrypt(ctx, req.(*DecryptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretsProvider_BulkDecrypt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkDecryptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretsProviderServer).BulkDecrypt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulumirpc.SecretsProvider/BulkDecrypt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretsProviderServer).BulkDecrypt(ctx, req.(*BulkDecryptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SecretsProvider_ServiceDesc is the grpc.ServiceDesc for SecretsProvider service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SecretsProvider_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pulumirpc.SecretsProvider",
+	HandlerType: (*SecretsProviderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Initialize",
+			Handler:    _SecretsProvider_Initialize_Handler,
+		},
+		{
+			MethodName: "Configure",
+			Handler:    _SecretsProvider_Configure_Handler,
+		},
+		{
+			MethodName: "Encrypt",
+			Handler:    _SecretsProvider_Encrypt_Handler,
+		},
+		{
+			MethodName: "Decrypt",
+			Handler:    _SecretsProvider_Decrypt_Handler,
+		},
+		{
+			MethodName: "BulkDecrypt",
+			Handler:    _SecretsProvider_BulkDecrypt_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pulumi/secrets.proto",
+}
@@ -115,6 +115,7 @@ from .errors import (
 )
 
 from .events import (
+    AnnotationTotal,
     CancelEvent,
     DiagnosticEvent,
     DiffKind,
@@ -123,6 +124,7 @@ from .events import (
     PreludeEvent,
     PropertyDiff,
     ResOutputsEvent,
+    ResourceAnnotationEvent,
     ResourcePreEvent,
     ResOpFailedEvent,
     StdoutEngineEvent,
@@ -189,6 +191,7 @@ __all__ = [
     "CompilationError",
     "InvalidVersionError",
     # events
+    "AnnotationTotal",
     "CancelEvent",
     "DiagnosticEvent",
     "DiffKind",
@@ -197,6 +200,7 @@ __all__ = [
     "PreludeEvent",
     "PropertyDiff",
     "ResOutputsEvent",
+    "ResourceAnnotationEvent",
     "ResourcePreEvent",
     "ResOpFailedEvent",
     "StdoutEngineEvent",
@@ -197,6 +197,83 @@ class PolicyEvent(BaseEvent):
         )
 
 
+class ResourceAnnotationEvent(BaseEvent):
+    """
+    ResourceAnnotationEvent is emitted whenever a policy pack annotates a proposed change to a resource, e.g. with
+    the estimated change in its monthly cost.
+
+    Attributes
+    ----------
+    resource_urn: str
+        The urn of the annotated resource
+    label: str
+        The label of the annotation, e.g. "Monthly cost"
+    number: Optional[float]
+        The numeric value of the annotation, if it is numeric
+    text: Optional[str]
+        The textual value of the annotation, if it is not numeric
+    unit: Optional[str]
+        The unit of a numeric value, e.g. "USD"
+    policy_pack_name: Optional[str]
+        The name of the policy pack that made the annotation
+    """
+
+    def __init__(
+        self,
+        resource_urn: str,
+        label: str,
+        number: Optional[float] = None,
+        text: Optional[str] = None,
+        unit: Optional[str] = None,
+        policy_pack_name: Optional[str] = None,
+    ) -> None:
+        self.resource_urn = resource_urn
+        self.label = label
+        self.number = number
+        self.text = text
+        self.unit = unit
+        self.policy_pack_name = policy_pack_name
+
+    @classmethod
+    def from_json(cls, data: dict) -> "ResourceAnnotationEvent":
+        return cls(
+            resource_urn=data.get("resourceUrn", ""),
+            label=data.get("label", ""),
+            number=data.get("number"),
+            text=data.get("text"),
+            unit=data.get("unit"),
+            policy_pack_name=data.get("policyPackName"),
+        )
+
+
+class AnnotationTotal(BaseEvent):
+    """
+    AnnotationTotal is the total of the numeric annotations of changed resources with the same label and unit.
+
+    Attributes
+    ----------
+    label: str
+        The label of the annotations
+    total: float
+        The total of their values
+    unit: Optional[str]
+        The unit of their values
+    """
+
+    def __init__(self, label: str, total: float, unit: Optional[str] = None) -> None:
+        self.label = label
+        self.total = total
+        self.unit = unit
+
+    @classmethod
+    def from_json(cls, data: dict) -> "AnnotationTotal":
+        return cls(
+            label=data.get("label", ""),
+            total=data.get("total", 0),
+            unit=data.get("unit"),
+        )
+
+
 class PreludeEvent(BaseEvent):
     """
     PreludeEvent is emitted at the start of an update.
@@ -235,6 +312,8 @@ class SummaryEvent(BaseEvent):
         and are now locked into using PascalCase for this field to maintain backwards
         compatibility. For older clients this will map to the version, while for newer ones
         it will be the version tag prepended with "v".
+    annotation_totals: Optional[List[AnnotationTotal]]
+        annotationTotals contains the totals of the numeric annotations of changed resources.
     """
 
     def __init__(
@@ -243,11 +322,13 @@ class SummaryEvent(BaseEvent):
         duration_seconds: int,
         resource_changes: OpMap,
         policy_packs: Mapping[str, str],
+        annotation_totals: Optional[List[AnnotationTotal]] = None,
     ) -> None:
         self.maybe_corrupt = maybe_corrupt
         self.duration_seconds = duration_seconds
         self.resource_changes = resource_changes
         self.policy_packs = policy_packs
+        self.annotation_totals = annotation_totals
 
     @classmethod
     def from_json(cls, data: dict) -> "SummaryEvent":
@@ -256,6 +337,9 @@ class SummaryEvent(BaseEvent):
             duration_seconds=data.get("durationSeconds", 0),
             resource_changes=data.get("resourceChanges", {}),
             policy_packs=data.get("PolicyPacks", {}),
+            annotation_totals=[
+                AnnotationTotal.from_json(t) for t in data.get("annotationTotals", [])
+            ],
         )
 
 
@@ -541,6 +625,7 @@ class EngineEvent(BaseEvent):
         res_outputs_event: Optional[ResOutputsEvent] = None,
         res_op_failed_event: Optional[ResOpFailedEvent] = None,
         policy_event: Optional[PolicyEvent] = None,
+        resource_annotation_event: Optional[ResourceAnnotationEvent] = None,
     ):
         self.sequence = sequence
         self.timestamp = timestamp
@@ -553,6 +638,7 @@ class EngineEvent(BaseEvent):
         self.res_outputs_event = res_outputs_event
         self.res_op_failed_event = res_op_failed_event
         self.policy_event = policy_event
+        self.resource_annotation_event = resource_annotation_event
 
     @classmethod
     def from_json(cls, data: dict) -> "EngineEvent":
@@ -564,6 +650,7 @@ class EngineEvent(BaseEvent):
         res_outputs_event = data.get("resOutputsEvent")
         res_op_failed_event = data.get("resOpFailedEvent")
         policy_event = data.get("policyEvent")
+        resource_annotation_event = data.get("resourceAnnotationEvent")
 
         return cls(
             sequence=data.get("sequence", 0),
@@ -591,4 +678,9 @@ class EngineEvent(BaseEvent):
             if res_op_failed_event
             else None,
             policy_event=PolicyEvent.from_json(policy_event) if policy_event else None,
+            resource_annotation_event=ResourceAnnotationEvent.from_json(
+                resource_annotation_event
+            )
+            if resource_annotation_event
+            else None,
         )
@@ -556,3 +556,106 @@ class ConfigureAnalyzerRequest(google.protobuf.message.Message):
     def ClearField(self, field_name: typing_extensions.Literal["policyConfig", b"policyConfig"]) -> None: ...
 
 global___ConfigureAnalyzerRequest = ConfigureAnalyzerRequest
+
+@typing_extensions.final
+class AnnotateRequest(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    CHANGES_FIELD_NUMBER: builtins.int
+    @property
+    def changes(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[global___AnnotateResourceChange]:
+        """the proposed changes to annotate."""
+    def __init__(
+        self,
+        *,
+        changes: collections.abc.Iterable[global___AnnotateResourceChange] | None = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["changes", b"changes"]) -> None: ...
+
+global___AnnotateRequest = AnnotateRequest
+
+@typing_extensions.final
+class AnnotateResourceChange(google.protobuf.message.Message):
+    """AnnotateResourceChange describes a proposed change to a resource."""
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    OP_FIELD_NUMBER: builtins.int
+    OLD_FIELD_NUMBER: builtins.int
+    NEW_FIELD_NUMBER: builtins.int
+    op: builtins.str
+    """the operation that will be performed, e.g. "create", "update", "replace" or "delete"."""
+    @property
+    def old(self) -> global___AnalyzerResource:
+        """the current state of the resource, unless it will be created."""
+    @property
+    def new(self) -> global___AnalyzerResource:
+        """the proposed state of the resource, unless it will be deleted."""
+    def __init__(
+        self,
+        *,
+        op: builtins.str = ...,
+        old: global___AnalyzerResource | None = ...,
+        new: global___AnalyzerResource | None = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["new", b"new", "old", b"old"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["new", b"new", "old", b"old", "op", b"op"]) -> None: ...
+
+global___AnnotateResourceChange = AnnotateResourceChange
+
+@typing_extensions.final
+class AnnotateResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    ANNOTATIONS_FIELD_NUMBER: builtins.int
+    @property
+    def annotations(self) -> google.protobuf.internal.containers.RepeatedCompositeFieldContainer[global___ResourceAnnotation]:
+        """the annotations of the changed resources."""
+    def __init__(
+        self,
+        *,
+        annotations: collections.abc.Iterable[global___ResourceAnnotation] | None = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["annotations", b"annotations"]) -> None: ...
+
+global___AnnotateResponse = AnnotateResponse
+
+@typing_extensions.final
+class ResourceAnnotation(google.protobuf.message.Message):
+    """ResourceAnnotation annotates a proposed change to a resource with a numeric or textual value."""
+
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    URN_FIELD_NUMBER: builtins.int
+    LABEL_FIELD_NUMBER: builtins.int
+    NUMBER_FIELD_NUMBER: builtins.int
+    TEXT_FIELD_NUMBER: builtins.int
+    UNIT_FIELD_NUMBER: builtins.int
+    POLICYPACKNAME_FIELD_NUMBER: builtins.int
+    urn: builtins.str
+    """URN of the annotated resource; may be omitted if only one change was annotated."""
+    label: builtins.str
+    """Label of the annotation, which heads its column in the display, e.g. "Monthly cost"."""
+    number: builtins.float
+    """A numeric value, e.g. the change in cost. Numbers with the same label and unit are totalled."""
+    text: builtins.str
+    """A textual value, e.g. "high"."""
+    unit: builtins.str
+    """Unit of a numeric value, e.g. "USD"."""
+    policyPackName: builtins.str
+    """Name of the policy pack that made the annotation."""
+    def __init__(
+        self,
+        *,
+        urn: builtins.str = ...,
+        label: builtins.str = ...,
+        number: builtins.float = ...,
+        text: builtins.str = ...,
+        unit: builtins.str = ...,
+        policyPackName: builtins.str = ...,
+    ) -> None: ...
+    def HasField(self, field_name: typing_extensions.Literal["number", b"number", "text", b"text", "value", b"value"]) -> builtins.bool: ...
+    def ClearField(self, field_name: typing_extensions.Literal["label", b"label", "number", b"number", "policyPackName", b"policyPackName", "text", b"text", "unit", b"unit", "urn", b"urn", "value", b"value"]) -> None: ...
+    def WhichOneof(self, oneof_group: typing_extensions.Literal["value", b"value"]) -> typing_extensions.Literal["number", "text"] | None: ...
+
+global___ResourceAnnotation = ResourceAnnotation
@@ -45,6 +45,11 @@ class AnalyzerStub(object):
                 request_serializer=pulumi_dot_analyzer__pb2.ConfigureAnalyzerRequest.SerializeToString,
                 response_deserializer=google_dot_protobuf_dot_empty__pb2.Empty.FromString,
                 )
+        self.Annotate = channel.unary_unary(
+                '/pulumirpc.Analyzer/Annotate',
+                request_serializer=pulumi_dot_analyzer__pb2.AnnotateRequest.SerializeToString,
+                response_deserializer=pulumi_dot_analyzer__pb2.AnnotateResponse.FromString,
+                )
 
 
 class AnalyzerServicer(object):
@@ -92,6 +97,15 @@ class AnalyzerServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def Annotate(self, request, context):
+        """Annotate annotates proposed changes to resources with values that are displayed alongside them during a
+        preview or update, e.g. the estimated change in their monthly cost. Called with the "inputs" to the
+        resources, before they are changed.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
 
 def add_AnalyzerServicer_to_server(servicer, server):
     rpc_method_handlers = {
@@ -120,6 +134,11 @@ def add_AnalyzerServicer_to_server(servicer, server):
                     request_deserializer=pulumi_dot_analyzer__pb2.ConfigureAnalyzerRequest.FromString,
                     response_serializer=google_dot_protobuf_dot_empty__pb2.Empty.SerializeToString,
             ),
+            'Annotate': grpc.unary_unary_rpc_method_handler(
+                    servicer.Annotate,
+                    request_deserializer=pulumi_dot_analyzer__pb2.AnnotateRequest.FromString,
+                    response_serializer=pulumi_dot_analyzer__pb2.AnnotateResponse.SerializeToString,
+            ),
     }
     generic_handler = grpc.method_handlers_generic_handler(
             'pulumirpc.Analyzer', rpc_method_handlers)
@@ -218,3 +237,20 @@ class Analyzer(object):
             google_dot_protobuf_dot_empty__pb2.Empty.FromString,
             options, channel_credentials,
             insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
+
+    @staticmethod
+    def Annotate(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/pulumirpc.Analyzer/Annotate',
+            pulumi_dot_analyzer__pb2.AnnotateRequest.SerializeToString,
+            pulumi_dot_analyzer__pb2.AnnotateResponse.FromString,
+            options, channel_credentials,
+            insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
@@ -61,6 +61,14 @@ class AnalyzerStub:
         google.protobuf.empty_pb2.Empty,
     ]
     """Configure configures the analyzer, passing configuration properties for each policy."""
+    Annotate: grpc.UnaryUnaryMultiCallable[
+        pulumi.analyzer_pb2.AnnotateRequest,
+        pulumi.analyzer_pb2.AnnotateResponse,
+    ]
+    """Annotate annotates proposed changes to resources with values that are displayed alongside them during a
+    preview or update, e.g. the estimated change in their monthly cost. Called with the "inputs" to the
+    resources, before they are changed.
+    """
 
 class AnalyzerServicer(metaclass=abc.ABCMeta):
     """Analyzer provides a pluggable interface for checking resource definitions against some number of
@@ -109,5 +117,15 @@ class AnalyzerServicer(metaclass=abc.ABCMeta):
         context: grpc.ServicerContext,
     ) -> google.protobuf.empty_pb2.Empty:
         """Configure configures the analyzer, passing configuration properties for each policy."""
+    
+    def Annotate(
+        self,
+        request: pulumi.analyzer_pb2.AnnotateRequest,
+        context: grpc.ServicerContext,
+    ) -> pulumi.analyzer_pb2.AnnotateResponse:
+        """Annotate annotates proposed changes to resources with values that are displayed alongside them during a
+        preview or update, e.g. the estimated change in their monthly cost. Called with the "inputs" to the
+        resources, before they are changed.
+        """
 
 def add_AnalyzerServicer_to_server(servicer: AnalyzerServicer, server: typing.Union[grpc.Server, grpc.aio.Server]) -> None: ...
@@ -0,0 +1,49 @@
+# -*- coding: utf-8 -*-
+# Generated by the protocol buffer compiler.  DO NOT EDIT!
+# source: pulumi/secrets.proto
+"""Generated protocol buffer code."""
+from google.protobuf.internal import builder as _builder
+from google.protobuf import descriptor as _descriptor
+from google.protobuf import descriptor_pool as _descriptor_pool
+from google.protobuf import symbol_database as _symbol_database
+# @@protoc_insertion_point(imports)
+
+_sym_db = _symbol_database.Default()
+
+
+from google.protobuf import empty_pb2 as google_dot_protobuf_dot_empty__pb2
+
+
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x14pulumi/secrets.proto\x12\tpulumirpc\x1a\x1bgoogle/protobuf/empty.proto\"N\n InitializeSecretsProviderRequest\x12\x0b\n\x03url\x18\x01 \x01(\t\x12\r\n\x05state\x18\x02 \x01(\t\x12\x0e\n\x06rotate\x18\x03 \x01(\x08\"2\n!InitializeSecretsProviderResponse\x12\r\n\x05state\x18\x01 \x01(\t\"0\n\x1f\x43onfigureSecretsProviderRequest\x12\r\n\x05state\x18\x01 \x01(\t\"#\n\x0e\x45ncryptRequest\x12\x11\n\tplaintext\x18\x01 \x01(\t\"%\n\x0f\x45ncryptResponse\x12\x12\n\nciphertext\x18\x01 \x01(\t\"$\n\x0e\x44\x65\x63ryptRequest\x12\x12\n\nciphertext\x18\x01 \x01(\t\"$\n\x0f\x44\x65\x63ryptResponse\x12\x11\n\tplaintext\x18\x01 \x01(\t\")\n\x12\x42ulkDecryptRequest\x12\x13\n\x0b\x63iphertexts\x18\x01 \x03(\t\"\x8c\x01\n\x13\x42ulkDecryptResponse\x12\x42\n\nplaintexts\x18\x01 \x03(\x0b\x32..pulumirpc.BulkDecryptResponse.PlaintextsEntry\x1a\x31\n\x0fPlaintextsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\x32\xa7\x03\n\x0fSecretsProvider\x12i\n\nInitialize\x12+.pulumirpc.InitializeSecretsProviderRequest\x1a,.pulumirpc.InitializeSecretsProviderResponse\"\x00\x12Q\n\tConfigure\x12*.pulumirpc.ConfigureSecretsProviderRequest\x1a\x16.google.protobuf.Empty\"\x00\x12\x42\n\x07\x45ncrypt\x12\x19.pulumirpc.EncryptRequest\x1a\x1a.pulumirpc.EncryptResponse\"\x00\x12\x42\n\x07\x44\x65\x63rypt\x12\x19.pulumirpc.DecryptRequest\x1a\x1a.pulumirpc.DecryptResponse\"\x00\x12N\n\x0b\x42ulkDecrypt\x12\x1d.pulumirpc.BulkDecryptRequest\x1a\x1e.pulumirpc.BulkDecryptResponse\"\x00\x42\x34Z2github.com/pulumi/pulumi/sdk/v3/proto/go;pulumirpcb\x06proto3')
+
+_builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, globals())
+_builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'pulumi.secrets_pb2', globals())
+if _descriptor._USE_C_DESCRIPTORS == False:
+
+  DESCRIPTOR._options = None
+  DESCRIPTOR._serialized_options = b'Z2github.com/pulumi/pulumi/sdk/v3/proto/go;pulumirpc'
+  _BULKDECRYPTRESPONSE_PLAINTEXTSENTRY._options = None
+  _BULKDECRYPTRESPONSE_PLAINTEXTSENTRY._serialized_options = b'8\001'
+  _INITIALIZESECRETSPROVIDERREQUEST._serialized_start=64
+  _INITIALIZESECRETSPROVIDERREQUEST._serialized_end=142
+  _INITIALIZESECRETSPROVIDERRESPONSE._serialized_start=144
+  _INITIALIZESECRETSPROVIDERRESPONSE._serialized_end=194
+  _CONFIGURESECRETSPROVIDERREQUEST._serialized_start=196
+  _CONFIGURESECRETSPROVIDERREQUEST._serialized_end=244
+  _ENCRYPTREQUEST._serialized_start=246
+  _ENCRYPTREQUEST._serialized_end=281
+  _ENCRYPTRESPONSE._serialized_start=283
+  _ENCRYPTRESPONSE._serialized_end=320
+  _DECRYPTREQUEST._serialized_start=322
+  _DECRYPTREQUEST._serialized_end=358
+  _DECRYPTRESPONSE._serialized_start=360
+  _DECRYPTRESPONSE._serialized_end=396
+  _BULKDECRYPTREQUEST._serialized_start=398
+  _BULKDECRYPTREQUEST._serialized_end=439
+  _BULKDECRYPTRESPONSE._serialized_start=442
+  _BULKDECRYPTRESPONSE._serialized_end=582
+  _BULKDECRYPTRESPONSE_PLAINTEXTSENTRY._serialized_start=533
+  _BULKDECRYPTRESPONSE_PLAINTEXTSENTRY._serialized_end=582
+  _SECRETSPROVIDER._serialized_start=585
+  _SECRETSPROVIDER._serialized_end=1008
+# @@protoc_insertion_point(module_scope)
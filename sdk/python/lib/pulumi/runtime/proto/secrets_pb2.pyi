@@ -0,0 +1,200 @@
+"""
+@generated by mypy-protobuf.  Do not edit manually!
+isort:skip_file
+Copyright 2016-2023, Pulumi Corporation.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+"""
+import builtins
+import collections.abc
+import google.protobuf.descriptor
+import google.protobuf.internal.containers
+import google.protobuf.message
+import sys
+
+if sys.version_info >= (3, 8):
+    import typing as typing_extensions
+else:
+    import typing_extensions
+
+DESCRIPTOR: google.protobuf.descriptor.FileDescriptor
+
+@typing_extensions.final
+class InitializeSecretsProviderRequest(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    URL_FIELD_NUMBER: builtins.int
+    STATE_FIELD_NUMBER: builtins.int
+    ROTATE_FIELD_NUMBER: builtins.int
+    url: builtins.str
+    """the URL the stack's secrets provider is set to, e.g. `myplugin://...`."""
+    state: builtins.str
+    """the previous state of the secrets provider as a JSON document, if the stack already used this URL."""
+    rotate: builtins.bool
+    """true if the secrets provider should rotate its keys, rather than reusing the previous state."""
+    def __init__(
+        self,
+        *,
+        url: builtins.str = ...,
+        state: builtins.str = ...,
+        rotate: builtins.bool = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["rotate", b"rotate", "state", b"state", "url", b"url"]) -> None: ...
+
+global___InitializeSecretsProviderRequest = InitializeSecretsProviderRequest
+
+@typing_extensions.final
+class InitializeSecretsProviderResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    STATE_FIELD_NUMBER: builtins.int
+    state: builtins.str
+    """the state of the secrets provider as a JSON document."""
+    def __init__(
+        self,
+        *,
+        state: builtins.str = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["state", b"state"]) -> None: ...
+
+global___InitializeSecretsProviderResponse = InitializeSecretsProviderResponse
+
+@typing_extensions.final
+class ConfigureSecretsProviderRequest(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    STATE_FIELD_NUMBER: builtins.int
+    state: builtins.str
+    """the state of the secrets provider as a JSON document, as returned by Initialize."""
+    def __init__(
+        self,
+        *,
+        state: builtins.str = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["state", b"state"]) -> None: ...
+
+global___ConfigureSecretsProviderRequest = ConfigureSecretsProviderRequest
+
+@typing_extensions.final
+class EncryptRequest(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    PLAINTEXT_FIELD_NUMBER: builtins.int
+    plaintext: builtins.str
+    """the value to encrypt."""
+    def __init__(
+        self,
+        *,
+        plaintext: builtins.str = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["plaintext", b"plaintext"]) -> None: ...
+
+global___EncryptRequest = EncryptRequest
+
+@typing_extensions.final
+class EncryptResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    CIPHERTEXT_FIELD_NUMBER: builtins.int
+    ciphertext: builtins.str
+    """the encrypted value."""
+    def __init__(
+        self,
+        *,
+        ciphertext: builtins.str = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["ciphertext", b"ciphertext"]) -> None: ...
+
+global___EncryptResponse = EncryptResponse
+
+@typing_extensions.final
+class DecryptRequest(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    CIPHERTEXT_FIELD_NUMBER: builtins.int
+    ciphertext: builtins.str
+    """the value to decrypt."""
+    def __init__(
+        self,
+        *,
+        ciphertext: builtins.str = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["ciphertext", b"ciphertext"]) -> None: ...
+
+global___DecryptRequest = DecryptRequest
+
+@typing_extensions.final
+class DecryptResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    PLAINTEXT_FIELD_NUMBER: builtins.int
+    plaintext: builtins.str
+    """the decrypted value."""
+    def __init__(
+        self,
+        *,
+        plaintext: builtins.str = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["plaintext", b"plaintext"]) -> None: ...
+
+global___DecryptResponse = DecryptResponse
+
+@typing_extensions.final
+class BulkDecryptRequest(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    CIPHERTEXTS_FIELD_NUMBER: builtins.int
+    @property
+    def ciphertexts(self) -> google.protobuf.internal.containers.RepeatedScalarFieldContainer[builtins.str]:
+        """the values to decrypt."""
+    def __init__(
+        self,
+        *,
+        ciphertexts: collections.abc.Iterable[builtins.str] | None = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["ciphertexts", b"ciphertexts"]) -> None: ...
+
+global___BulkDecryptRequest = BulkDecryptRequest
+
+@typing_extensions.final
+class BulkDecryptResponse(google.protobuf.message.Message):
+    DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+    @typing_extensions.final
+    class PlaintextsEntry(google.protobuf.message.Message):
+        DESCRIPTOR: google.protobuf.descriptor.Descriptor
+
+        KEY_FIELD_NUMBER: builtins.int
+        VALUE_FIELD_NUMBER: builtins.int
+        key: builtins.str
+        value: builtins.str
+        def __init__(
+            self,
+            *,
+            key: builtins.str = ...,
+            value: builtins.str = ...,
+        ) -> None: ...
+        def ClearField(self, field_name: typing_extensions.Literal["key", b"key", "value", b"value"]) -> None: ...
+
+    PLAINTEXTS_FIELD_NUMBER: builtins.int
+    @property
+    def plaintexts(self) -> google.protobuf.internal.containers.ScalarMap[builtins.str, builtins.str]:
+        """the decrypted values, keyed by their encrypted value."""
+    def __init__(
+        self,
+        *,
+        plaintexts: collections.abc.Mapping[builtins.str, builtins.str] | None = ...,
+    ) -> None: ...
+    def ClearField(self, field_name: typing_extensions.Literal["plaintexts", b"plaintexts"]) -> None: ...
+
+global___BulkDecryptResponse = BulkDecryptResponse
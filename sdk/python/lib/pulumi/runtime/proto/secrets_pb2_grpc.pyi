@@ -0,0 +1,106 @@
+"""
+@generated by mypy-protobuf.  Do not edit manually!
+isort:skip_file
+Copyright 2016-2023, Pulumi Corporation.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+"""
+import abc
+import google.protobuf.empty_pb2
+import grpc
+import grpc.aio
+import typing
+import pulumi.secrets_pb2
+
+class SecretsProviderStub:
+    """SecretsProvider is a service for encrypting and decrypting the secrets of a stack, implemented by secrets provider
+    plugins. A stack uses a secrets provider plugin by setting its secrets provider to a URL whose scheme is the name of
+    the plugin, e.g. `--secrets-provider myplugin://...`.
+    This is currently unstable and experimental.
+    """
+
+    def __init__(self, channel: grpc.Channel) -> None: ...
+    Initialize: grpc.UnaryUnaryMultiCallable[
+        pulumi.secrets_pb2.InitializeSecretsProviderRequest,
+        pulumi.secrets_pb2.InitializeSecretsProviderResponse,
+    ]
+    """Initialize returns the state of the secrets provider for the given URL. The state is saved in the stack's
+    configuration and checkpoint, and is passed to Configure whenever the stack's secrets are used.
+    """
+    Configure: grpc.UnaryUnaryMultiCallable[
+        pulumi.secrets_pb2.ConfigureSecretsProviderRequest,
+        google.protobuf.empty_pb2.Empty,
+    ]
+    """Configure loads the state of the secrets provider. It is called before any values are encrypted or decrypted."""
+    Encrypt: grpc.UnaryUnaryMultiCallable[
+        pulumi.secrets_pb2.EncryptRequest,
+        pulumi.secrets_pb2.EncryptResponse,
+    ]
+    """Encrypt encrypts a value."""
+    Decrypt: grpc.UnaryUnaryMultiCallable[
+        pulumi.secrets_pb2.DecryptRequest,
+        pulumi.secrets_pb2.DecryptResponse,
+    ]
+    """Decrypt decrypts a value."""
+    BulkDecrypt: grpc.UnaryUnaryMultiCallable[
+        pulumi.secrets_pb2.BulkDecryptRequest,
+        pulumi.secrets_pb2.BulkDecryptResponse,
+    ]
+    """BulkDecrypt decrypts many values at once."""
+
+class SecretsProviderServicer(metaclass=abc.ABCMeta):
+    """SecretsProvider is a service for encrypting and decrypting the secrets of a stack, implemented by secrets provider
+    plugins. A stack uses a secrets provider plugin by setting its secrets provider to a URL whose scheme is the name of
+    the plugin, e.g. `--secrets-provider myplugin://...`.
+    This is currently unstable and experimental.
+    """
+
+    
+    def Initialize(
+        self,
+        request: pulumi.secrets_pb2.InitializeSecretsProviderRequest,
+        context: grpc.ServicerContext,
+    ) -> pulumi.secrets_pb2.InitializeSecretsProviderResponse:
+        """Initialize returns the state of the secrets provider for the given URL. The state is saved in the stack's
+        configuration and checkpoint, and is passed to Configure whenever the stack's secrets are used.
+        """
+    
+    def Configure(
+        self,
+        request: pulumi.secrets_pb2.ConfigureSecretsProviderRequest,
+        context: grpc.ServicerContext,
+    ) -> google.protobuf.empty_pb2.Empty:
+        """Configure loads the state of the secrets provider. It is called before any values are encrypted or decrypted."""
+    
+    def Encrypt(
+        self,
+        request: pulumi.secrets_pb2.EncryptRequest,
+        context: grpc.ServicerContext,
+    ) -> pulumi.secrets_pb2.EncryptResponse:
+        """Encrypt encrypts a value."""
+    
+    def Decrypt(
+        self,
+        request: pulumi.secrets_pb2.DecryptRequest,
+        context: grpc.ServicerContext,
+    ) -> pulumi.secrets_pb2.DecryptResponse:
+        """Decrypt decrypts a value."""
+    
+    def BulkDecrypt(
+        self,
+        request: pulumi.secrets_pb2.BulkDecryptRequest,
+        context: grpc.ServicerContext,
+    ) -> pulumi.secrets_pb2.BulkDecryptResponse:
+        """BulkDecrypt decrypts many values at once."""
+
+def add_SecretsProviderServicer_to_server(servicer: SecretsProviderServicer, server: typing.Union[grpc.Server, grpc.aio.Server]) -> None: ...